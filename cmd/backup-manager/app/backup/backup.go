@@ -33,16 +33,15 @@ import (
 // Options contains the input arguments to the backup command
 type Options struct {
 	backupUtil.GenericOptions
+	// SubCommand is the subcommand to run for log backup
+	SubCommand string
 }
 
-// backupData generates br args and runs br binary to do the real backup work
-func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup) error {
-	clusterNamespace := backup.Spec.BR.ClusterNamespace
-	if backup.Spec.BR.ClusterNamespace == "" {
-		clusterNamespace = backup.Namespace
-	}
+// backupData generates br args and runs br binary to do the real backup work,
+// the progress of BR is reported by the tracker if it is not nil
+func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup, tracker *backupUtil.ProgressTracker) error {
 	args := make([]string, 0)
-	args = append(args, fmt.Sprintf("--pd=%s-pd.%s:2379", backup.Spec.BR.Cluster, clusterNamespace))
+	args = append(args, fmt.Sprintf("--pd=%s", bo.pdAddress(backup)))
 	if bo.TLSCluster {
 		args = append(args, fmt.Sprintf("--ca=%s", path.Join(util.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey)))
 		args = append(args, fmt.Sprintf("--cert=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSCertKey)))
@@ -66,6 +65,78 @@ func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup) erro
 		backupType,
 	}
 	fullArgs = append(fullArgs, args...)
+	if err := bo.brCommandRun(ctx, fullArgs, tracker); err != nil {
+		return err
+	}
+	klog.Infof("Backup data for cluster %s successfully", bo)
+	return nil
+}
+
+// logBackupCommandArgs returns the br args of the log backup subcommand,
+// which share the pd and tls args with the snapshot backup
+func (bo *Options) logBackupCommandArgs(backup *v1alpha1.Backup, command v1alpha1.LogSubCommandType) ([]string, error) {
+	args := []string{fmt.Sprintf("--pd=%s", bo.pdAddress(backup))}
+	if bo.TLSCluster {
+		args = append(args, fmt.Sprintf("--ca=%s", path.Join(util.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey)))
+		args = append(args, fmt.Sprintf("--cert=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSCertKey)))
+		args = append(args, fmt.Sprintf("--key=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSPrivateKeyKey)))
+	}
+	globalArgs, err := backupUtil.ConstructBRGlobalOptionsForLogBackup(backup, command)
+	if err != nil {
+		return nil, err
+	}
+	return append(args, globalArgs...), nil
+}
+
+// startLogBackup starts the log backup task, the task name is the name of the backup
+func (bo *Options) startLogBackup(ctx context.Context, backup *v1alpha1.Backup) error {
+	args, err := bo.logBackupCommandArgs(backup, v1alpha1.LogStartCommand)
+	if err != nil {
+		return err
+	}
+	args = append(args, fmt.Sprintf("--task-name=%s", backup.Name))
+	if backup.Spec.CommitTs != "" {
+		args = append(args, fmt.Sprintf("--start-ts=%s", backup.Spec.CommitTs))
+	}
+	args = append(args, backup.Spec.BR.Options...)
+	fullArgs := append([]string{"log", "start"}, args...)
+	return bo.brCommandRun(ctx, fullArgs, nil)
+}
+
+// truncateLogBackup deletes the log backup data before the given ts
+func (bo *Options) truncateLogBackup(ctx context.Context, backup *v1alpha1.Backup, until string) error {
+	args, err := bo.logBackupCommandArgs(backup, v1alpha1.LogTruncateCommand)
+	if err != nil {
+		return err
+	}
+	args = append(args, fmt.Sprintf("--until=%s", until), "--yes")
+	fullArgs := append([]string{"log", "truncate"}, args...)
+	return bo.brCommandRun(ctx, fullArgs, nil)
+}
+
+// stopLogBackup stops the log backup task
+func (bo *Options) stopLogBackup(ctx context.Context, backup *v1alpha1.Backup) error {
+	args, err := bo.logBackupCommandArgs(backup, v1alpha1.LogStopCommand)
+	if err != nil {
+		return err
+	}
+	args = append(args, fmt.Sprintf("--task-name=%s", backup.Name))
+	fullArgs := append([]string{"log", "stop"}, args...)
+	return bo.brCommandRun(ctx, fullArgs, nil)
+}
+
+// pdAddress returns the pd service address of the cluster to back up
+func (bo *Options) pdAddress(backup *v1alpha1.Backup) string {
+	clusterNamespace := backup.Spec.BR.ClusterNamespace
+	if backup.Spec.BR.ClusterNamespace == "" {
+		clusterNamespace = backup.Namespace
+	}
+	return fmt.Sprintf("%s-pd.%s:2379", backup.Spec.BR.Cluster, clusterNamespace)
+}
+
+// brCommandRun runs br binary with the given args and collects the error messages,
+// the progress in the output of br is passed to the tracker
+func (bo *Options) brCommandRun(ctx context.Context, fullArgs []string, tracker *backupUtil.ProgressTracker) error {
 	klog.Infof("Running br command with args: %v", fullArgs)
 	bin := path.Join(util.BRBinPath, "br")
 	cmd := exec.CommandContext(ctx, bin, fullArgs...)
@@ -89,6 +160,7 @@ func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup) erro
 		if strings.Contains(line, "[ERROR]") {
 			errMsg += line
 		}
+		tracker.Track(line)
 
 		klog.Info(strings.Replace(line, "\n", "", -1))
 		if err != nil || io.EOF == err {
@@ -105,7 +177,6 @@ func (bo *Options) backupData(ctx context.Context, backup *v1alpha1.Backup) erro
 		return fmt.Errorf("cluster %s, wait pipe message failed, errMsg %s, err: %v", bo, errMsg, err)
 	}
 
-	klog.Infof("Backup data for cluster %s successfully", bo)
 	return nil
 }
 
@@ -16,7 +16,9 @@ package backup
 import (
 	"context"
 	"database/sql"
+	"encoding/binary"
 	"fmt"
+	"path"
 	"strconv"
 	"time"
 
@@ -25,8 +27,10 @@ import (
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	bkconstants "github.com/pingcap/tidb-operator/pkg/backup/constants"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	pkgutil "github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -96,6 +100,16 @@ func (bm *Manager) ProcessBackup() error {
 		return fmt.Errorf("no br config in %s", bm)
 	}
 
+	if v1alpha1.IsLogBackup(backup) {
+		// log backup is done by tikv itself, there is no need to connect to tidb
+		return bm.performLogBackup(ctx, backup.DeepCopy())
+	}
+
+	if v1alpha1.IsVolumeSnapshotBackup(backup) {
+		// the volumes are snapshotted by the operator, the job only keeps TiKV prepared for them
+		return bm.performVolumeSnapshotPrepare(ctx, backup.DeepCopy())
+	}
+
 	if backup.Spec.From == nil {
 		// skip the DB initialization if spec.from is not specified
 		return bm.performBackup(ctx, backup.DeepCopy(), nil)
@@ -261,8 +275,11 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 		return err
 	}
 
-	// run br binary to do the real job
-	backupErr := bm.backupData(ctx, backup)
+	// run br binary to do the real job, the progress of br is synced into the backup status
+	tracker := util.NewProgressTracker(func(progress *v1alpha1.BRProgress) error {
+		return bm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{Progress: progress})
+	})
+	backupErr := bm.backupData(ctx, backup, tracker)
 
 	if db != nil && oldTikvGCTimeDuration < tikvGCTimeDuration {
 		// use another context to revert `tikv_gc_life_time` back.
@@ -302,7 +319,7 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 	}
 	klog.Infof("backup cluster %s data to %s success", bm, backupFullPath)
 
-	backupMeta, err := util.GetBRMetaData(ctx, backup.Spec.StorageProvider)
+	backupMeta, err := util.GetBRMetaData(ctx, backup.Spec.StorageProvider, backup.Spec.Encryption)
 	if err != nil {
 		errs = append(errs, err)
 		klog.Errorf("Get backup metadata for backup files in %s of cluster %s failed, err: %s", backupFullPath, bm, err)
@@ -320,6 +337,24 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 	commitTs := backupMeta.EndVersion
 	klog.Infof("Get size %d for backup files in %s of cluster %s success", size, backupFullPath, bm)
 	klog.Infof("Get cluster %s commitTs %d success", bm, commitTs)
+
+	if len(backup.Spec.ReplicaStorages) != 0 {
+		// the backup in the primary storage is complete even if the replication fails,
+		// so the failure is only recorded in the Replicated condition
+		replicatedCondition := &v1alpha1.BackupCondition{
+			Type:   v1alpha1.BackupReplicated,
+			Status: corev1.ConditionTrue,
+		}
+		if err := bm.replicateBackup(ctx, backup); err != nil {
+			klog.Errorf("replicate backup files in %s of cluster %s failed, err: %s", backupFullPath, bm, err)
+			replicatedCondition.Status = corev1.ConditionFalse
+			replicatedCondition.Reason = "ReplicateBackupFailed"
+			replicatedCondition.Message = err.Error()
+		}
+		if err := bm.StatusUpdater.Update(backup, replicatedCondition, nil); err != nil {
+			klog.Errorf("update replicated condition of backup %s failed, err: %s", bm, err)
+		}
+	}
 	finish := time.Now()
 
 	backupSize := int64(size)
@@ -337,3 +372,280 @@ func (bm *Manager) performBackup(ctx context.Context, backup *v1alpha1.Backup, d
 		Status: corev1.ConditionTrue,
 	}, updateStatus)
 }
+
+// performLogBackup runs the subcommand of log backup
+func (bm *Manager) performLogBackup(ctx context.Context, backup *v1alpha1.Backup) error {
+	command := v1alpha1.LogSubCommandType(bm.SubCommand)
+	switch command {
+	case v1alpha1.LogStartCommand:
+		return bm.performLogBackupStart(ctx, backup)
+	case v1alpha1.LogTruncateCommand:
+		return bm.performLogBackupTruncate(ctx, backup)
+	case v1alpha1.LogStopCommand:
+		return bm.performLogBackupStop(ctx, backup)
+	default:
+		return fmt.Errorf("log backup %s, unknown subcommand %s", bm, command)
+	}
+}
+
+// performLogBackupStart starts the log backup task and keeps syncing its checkpoint ts
+// into the backup status until the log backup is stopped
+func (bm *Manager) performLogBackupStart(ctx context.Context, backup *v1alpha1.Backup) error {
+	started := time.Now()
+	command := v1alpha1.LogStartCommand
+
+	backupFullPath, err := util.GetStoragePath(backup)
+	if err != nil {
+		return bm.failLogSubCommand(backup, command, "GetBackupRemotePathFailed", err)
+	}
+
+	if err := bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupRunning,
+		Status: corev1.ConditionTrue,
+	}, &controller.BackupUpdateStatus{
+		BackupPath: &backupFullPath,
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:     command,
+			TimeStarted: metav1.Time{Time: started},
+			Phase:       v1alpha1.BackupRunning,
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := bm.startLogBackup(ctx, backup); err != nil {
+		klog.Errorf("start log backup of cluster %s failed, err: %s", bm, err)
+		return bm.failLogSubCommand(backup, command, "StartLogBackupFailed", err)
+	}
+	klog.Infof("start log backup of cluster %s to %s success", bm, backupFullPath)
+
+	updateStatus := &controller.BackupUpdateStatus{
+		TimeStarted: &metav1.Time{Time: started},
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:       command,
+			TimeCompleted: metav1.Time{Time: time.Now()},
+			Phase:         v1alpha1.BackupComplete,
+		},
+	}
+	if backup.Spec.CommitTs != "" {
+		startTs, err := backuputil.ParseTSString(backup.Spec.CommitTs)
+		if err != nil {
+			return bm.failLogSubCommand(backup, command, "ParseCommitTsFailed", err)
+		}
+		ts := strconv.FormatUint(startTs, 10)
+		updateStatus.CommitTs = &ts
+	}
+	if err := bm.StatusUpdater.Update(backup, nil, updateStatus); err != nil {
+		return err
+	}
+
+	return bm.syncLogBackupCheckpoint(ctx, backup)
+}
+
+// syncLogBackupCheckpoint periodically syncs the checkpoint ts of the log backup task until
+// the log backup is stopped or deleted
+func (bm *Manager) syncLogBackupCheckpoint(ctx context.Context, backup *v1alpha1.Backup) error {
+	etcdCli, err := bm.GetPDEtcdClient(bm.pdAddress(backup))
+	if err != nil {
+		klog.Errorf("log backup %s, create pd etcd client failed, checkpoint ts will not be synced, err: %s", bm, err)
+		return nil
+	}
+	defer etcdCli.Close()
+
+	ticker := time.NewTicker(constants.LogBackupCheckpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			klog.Infof("log backup %s, stop syncing checkpoint ts: %v", bm, ctx.Err())
+			return nil
+		case <-ticker.C:
+		}
+
+		latest, err := bm.backupLister.Backups(bm.Namespace).Get(bm.ResourceName)
+		if err != nil {
+			klog.Warningf("log backup %s, get backup failed, err: %s", bm, err)
+			continue
+		}
+		if latest.DeletionTimestamp != nil || latest.Spec.LogStop || v1alpha1.IsBackupStopped(latest) {
+			klog.Infof("log backup %s is stopped, stop syncing checkpoint ts", bm)
+			return nil
+		}
+
+		checkpointTs, err := getLogBackupCheckpointTs(etcdCli, backup.Name)
+		if err != nil {
+			klog.Warningf("log backup %s, get checkpoint ts failed, err: %s", bm, err)
+			continue
+		}
+		ts := strconv.FormatUint(checkpointTs, 10)
+		updateStatus := &controller.BackupUpdateStatus{
+			LogCheckpointTs: &ts,
+		}
+		startTs := latest.Status.CommitTs
+		// the start ts is not specified, take the first checkpoint ts as the commit ts
+		if startTs == "" {
+			updateStatus.CommitTs = &ts
+			startTs = ts
+		}
+		// the progress is synced even if the checkpoint ts is unchanged, so that the growing lag
+		// of a stuck log backup task is visible in the status
+		progress, err := logBackupProgress(startTs, latest.Status.LogSuccessTruncateUntil, checkpointTs)
+		if err != nil {
+			klog.Warningf("log backup %s, calculate progress failed, err: %s", bm, err)
+		} else {
+			updateStatus.LogProgress = progress
+		}
+		if err := bm.StatusUpdater.Update(latest.DeepCopy(), nil, updateStatus); err != nil {
+			klog.Warningf("log backup %s, update checkpoint ts %s failed, err: %s", bm, ts, err)
+		}
+	}
+}
+
+// logBackupProgress returns the range of the backed up logs, which starts from the start ts or
+// the truncated ts of the log backup task and ends at the checkpoint ts
+func logBackupProgress(startTs, truncateUntil string, checkpointTs uint64) (*v1alpha1.LogBackupProgress, error) {
+	start, err := backuputil.ParseTSString(startTs)
+	if err != nil {
+		return nil, err
+	}
+	truncated, err := backuputil.ParseTSString(truncateUntil)
+	if err != nil {
+		return nil, err
+	}
+	if truncated > start {
+		start = truncated
+	}
+
+	now := time.Now()
+	checkpoint := backuputil.TSToGoTime(checkpointTs)
+	progress := &v1alpha1.LogBackupProgress{
+		StartTime:      metav1.Time{Time: backuputil.TSToGoTime(start)},
+		CheckpointTime: metav1.Time{Time: checkpoint},
+		LastUpdateTime: metav1.Time{Time: now},
+	}
+	if lag := now.Sub(checkpoint); lag > 0 {
+		progress.CheckpointLagSeconds = int64(lag.Seconds())
+	}
+	return progress, nil
+}
+
+// getLogBackupCheckpointTs returns the checkpoint ts of the log backup task, which is
+// the minimum of the checkpoint ts reported by all the tikv stores
+func getLogBackupCheckpointTs(etcdCli pdapi.PDEtcdClient, taskName string) (uint64, error) {
+	key := path.Join(constants.LogBackupCheckpointKeyPrefix, taskName) + "/"
+	kvs, err := etcdCli.Get(key, true)
+	if err != nil {
+		return 0, err
+	}
+	if len(kvs) == 0 {
+		return 0, fmt.Errorf("checkpoint of log backup task %s not found", taskName)
+	}
+
+	var checkpointTs uint64
+	for _, kv := range kvs {
+		if len(kv.Value) != 8 {
+			return 0, fmt.Errorf("invalid checkpoint %v of key %s", kv.Value, kv.Key)
+		}
+		ts := binary.BigEndian.Uint64(kv.Value)
+		if checkpointTs == 0 || ts < checkpointTs {
+			checkpointTs = ts
+		}
+	}
+	return checkpointTs, nil
+}
+
+// performLogBackupTruncate deletes the log backup data before the truncate ts
+func (bm *Manager) performLogBackupTruncate(ctx context.Context, backup *v1alpha1.Backup) error {
+	command := v1alpha1.LogTruncateCommand
+	until := backup.Spec.LogTruncateUntil
+	if status := v1alpha1.GetLogSubCommandStatus(backup, command); status != nil && status.LogTruncatingUntil != "" {
+		until = status.LogTruncatingUntil
+	}
+	untilTs, err := backuputil.ParseTSString(until)
+	if err != nil {
+		return bm.failLogSubCommand(backup, command, "ParseLogTruncateUntilFailed", err)
+	}
+
+	if err := bm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:            command,
+			TimeStarted:        metav1.Time{Time: time.Now()},
+			LogTruncatingUntil: until,
+			Phase:              v1alpha1.BackupRunning,
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := bm.truncateLogBackup(ctx, backup, strconv.FormatUint(untilTs, 10)); err != nil {
+		klog.Errorf("truncate log backup of cluster %s until %s failed, err: %s", bm, until, err)
+		return bm.failLogSubCommand(backup, command, "TruncateLogBackupFailed", err)
+	}
+	klog.Infof("truncate log backup of cluster %s until %s success", bm, until)
+
+	return bm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{
+		LogSuccessTruncateUntil: &until,
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:       command,
+			TimeCompleted: metav1.Time{Time: time.Now()},
+			Phase:         v1alpha1.BackupComplete,
+		},
+	})
+}
+
+// performLogBackupStop stops the log backup task
+func (bm *Manager) performLogBackupStop(ctx context.Context, backup *v1alpha1.Backup) error {
+	command := v1alpha1.LogStopCommand
+	if err := bm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:     command,
+			TimeStarted: metav1.Time{Time: time.Now()},
+			Phase:       v1alpha1.BackupRunning,
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := bm.stopLogBackup(ctx, backup); err != nil {
+		klog.Errorf("stop log backup of cluster %s failed, err: %s", bm, err)
+		return bm.failLogSubCommand(backup, command, "StopLogBackupFailed", err)
+	}
+	klog.Infof("stop log backup of cluster %s success", bm)
+
+	finish := time.Now()
+	return bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupStopped,
+		Status: corev1.ConditionTrue,
+	}, &controller.BackupUpdateStatus{
+		TimeCompleted: &metav1.Time{Time: finish},
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:       command,
+			TimeCompleted: metav1.Time{Time: finish},
+			Phase:         v1alpha1.BackupComplete,
+		},
+	})
+}
+
+// failLogSubCommand marks the subcommand of log backup as failed, the whole backup
+// is failed only if the log backup task can not be started
+func (bm *Manager) failLogSubCommand(backup *v1alpha1.Backup, command v1alpha1.LogSubCommandType, reason string, err error) error {
+	errs := []error{err}
+	var condition *v1alpha1.BackupCondition
+	if command == v1alpha1.LogStartCommand {
+		condition = &v1alpha1.BackupCondition{
+			Type:    v1alpha1.BackupFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  reason,
+			Message: err.Error(),
+		}
+	}
+	uerr := bm.StatusUpdater.Update(backup, condition, &controller.BackupUpdateStatus{
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command: command,
+			Phase:   v1alpha1.BackupFailed,
+			Message: fmt.Sprintf("%s: %s", reason, err.Error()),
+		},
+	})
+	errs = append(errs, uerr)
+	return errorutils.NewAggregate(errs)
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+)
+
+func TestLogBackupProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	startTs := backuputil.GoTimeToTS(now.Add(-2 * time.Hour))
+	truncateTs := backuputil.GoTimeToTS(now.Add(-time.Hour))
+	checkpointTs := backuputil.GoTimeToTS(now.Add(-10 * time.Minute))
+
+	// the logs start from the start ts
+	progress, err := logBackupProgress(strconv.FormatUint(startTs, 10), "", checkpointTs)
+	g.Expect(err).To(Succeed())
+	g.Expect(progress.StartTime.Time).To(Equal(backuputil.TSToGoTime(startTs)))
+	g.Expect(progress.CheckpointTime.Time).To(Equal(backuputil.TSToGoTime(checkpointTs)))
+	g.Expect(progress.CheckpointLagSeconds).To(BeNumerically("~", 600, 5))
+	g.Expect(progress.LastUpdateTime.Time).NotTo(BeTemporally("<", now))
+
+	// the logs before the truncated ts are deleted
+	progress, err = logBackupProgress(strconv.FormatUint(startTs, 10), strconv.FormatUint(truncateTs, 10), checkpointTs)
+	g.Expect(err).To(Succeed())
+	g.Expect(progress.StartTime.Time).To(Equal(backuputil.TSToGoTime(truncateTs)))
+
+	// the truncated ts before the start ts is ignored
+	progress, err = logBackupProgress(strconv.FormatUint(truncateTs, 10), strconv.FormatUint(startTs, 10), checkpointTs)
+	g.Expect(err).To(Succeed())
+	g.Expect(progress.StartTime.Time).To(Equal(backuputil.TSToGoTime(truncateTs)))
+
+	// the ts in datetime format
+	progress, err = logBackupProgress(now.Add(-2*time.Hour).Format("2006-01-02 15:04:05 -0700"), "", checkpointTs)
+	g.Expect(err).To(Succeed())
+	g.Expect(progress.StartTime.Time.Unix()).To(Equal(now.Add(-2 * time.Hour).Unix()))
+
+	// the checkpoint ts ahead of now has no lag
+	progress, err = logBackupProgress(strconv.FormatUint(startTs, 10), "", backuputil.GoTimeToTS(now.Add(time.Minute)))
+	g.Expect(err).To(Succeed())
+	g.Expect(progress.CheckpointLagSeconds).To(BeZero())
+
+	// invalid ts
+	_, err = logBackupProgress("invalid", "", checkpointTs)
+	g.Expect(err).To(HaveOccurred())
+	_, err = logBackupProgress(strconv.FormatUint(startTs, 10), "invalid", checkpointTs)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGetLogBackupCheckpointTs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	checkpointKey := func(store int) string {
+		return fmt.Sprintf("%s/%s/store/%d", constants.LogBackupCheckpointKeyPrefix, "log-backup", store)
+	}
+	checkpointValue := func(ts uint64) []byte {
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, ts)
+		return value
+	}
+
+	type testcase struct {
+		name         string
+		kvs          []*pdapi.KeyValue
+		getErr       error
+		expectTs     uint64
+		expectErrMsg string
+	}
+
+	tests := []testcase{
+		{
+			name: "the minimum checkpoint ts of the stores",
+			kvs: []*pdapi.KeyValue{
+				{Key: checkpointKey(1), Value: checkpointValue(300)},
+				{Key: checkpointKey(2), Value: checkpointValue(100)},
+				{Key: checkpointKey(3), Value: checkpointValue(200)},
+			},
+			expectTs: 100,
+		},
+		{
+			name:         "get from pd etcd failed",
+			getErr:       fmt.Errorf("context deadline exceeded"),
+			expectErrMsg: "context deadline exceeded",
+		},
+		{
+			name:         "the checkpoint is not reported by BR",
+			expectErrMsg: "checkpoint of log backup task log-backup not found",
+		},
+		{
+			name: "the checkpoint reported by BR is invalid",
+			kvs: []*pdapi.KeyValue{
+				{Key: checkpointKey(1), Value: checkpointValue(100)},
+				{Key: checkpointKey(2), Value: []byte("100")},
+			},
+			expectErrMsg: "invalid checkpoint",
+		},
+	}
+
+	for _, test := range tests {
+		t.Log(test.name)
+		etcdCli := &fakePDEtcdClient{kvs: test.kvs, err: test.getErr}
+		ts, err := getLogBackupCheckpointTs(etcdCli, "log-backup")
+		g.Expect(etcdCli.key).To(Equal(constants.LogBackupCheckpointKeyPrefix + "/log-backup/"))
+		if test.expectErrMsg != "" {
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(err.Error()).To(ContainSubstring(test.expectErrMsg))
+			continue
+		}
+		g.Expect(err).To(Succeed())
+		g.Expect(ts).To(Equal(test.expectTs))
+	}
+}
+
+// fakePDEtcdClient returns the kvs or the error for the prefix read of the checkpoint ts
+type fakePDEtcdClient struct {
+	pdapi.PDEtcdClient
+	kvs []*pdapi.KeyValue
+	err error
+	key string
+}
+
+func (c *fakePDEtcdClient) Get(key string, prefix bool) ([]*pdapi.KeyValue, error) {
+	c.key = key
+	if !prefix {
+		return nil, fmt.Errorf("key %s is not read by prefix", key)
+	}
+	return c.kvs, c.err
+}
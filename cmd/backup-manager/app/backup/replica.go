@@ -0,0 +1,114 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// replicateBackup copies the backup data to all the replica storages one by one,
+// the progress of each replica storage is synced into the backup status. A failed
+// replica storage doesn't stop copying to the others, the errors are aggregated.
+func (bm *Manager) replicateBackup(ctx context.Context, backup *v1alpha1.Backup) error {
+	if len(backup.Spec.ReplicaStorages) == 0 {
+		return nil
+	}
+
+	src, err := util.NewStorageBackend(backup.Spec.StorageProvider)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	var errs []error
+	for i := range backup.Spec.ReplicaStorages {
+		if err := bm.replicateBackupTo(ctx, backup, src, int32(i)); err != nil {
+			errs = append(errs, fmt.Errorf("replicate backup to replicaStorages[%d] failed, err: %v", i, err))
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// replicateBackupTo copies the backup data to the replica storage with the index
+func (bm *Manager) replicateBackupTo(ctx context.Context, backup *v1alpha1.Backup, src *util.StorageBackend, index int32) error {
+	provider := backup.Spec.ReplicaStorages[index]
+	status := &v1alpha1.BackupReplicaStatus{
+		Index:       index,
+		Phase:       v1alpha1.BackupRunning,
+		TimeStarted: metav1.Time{Time: time.Now()},
+	}
+	path, err := util.GetProviderStoragePath(provider)
+	if err != nil {
+		return bm.failReplica(backup, status, err)
+	}
+	status.Path = path
+	if err := bm.updateReplicaStatus(backup, status); err != nil {
+		return err
+	}
+
+	dst, err := util.NewReplicaStorageBackend(provider, int(index))
+	if err != nil {
+		return bm.failReplica(backup, status, err)
+	}
+	defer dst.Close()
+
+	klog.Infof("backup %s, start copying backup data to replica storage %s", bm, path)
+	lastUpdate := time.Now()
+	err = util.CopyObjects(ctx, src, dst, constants.ReplicaCopyConcurrency, func(progress util.CopyProgress) {
+		status.TotalObjects = progress.TotalObjects
+		status.CopiedObjects = progress.CopiedObjects
+		status.CopiedSize = progress.CopiedSize
+		if time.Since(lastUpdate) < constants.ReplicaProgressInterval {
+			return
+		}
+		lastUpdate = time.Now()
+		if err := bm.updateReplicaStatus(backup, status); err != nil {
+			klog.Warningf("backup %s, update progress of replica storage %s failed, err: %s", bm, path, err)
+		}
+	})
+	if err != nil {
+		return bm.failReplica(backup, status, err)
+	}
+	klog.Infof("backup %s, copy %d objects to replica storage %s success", bm, status.CopiedObjects, path)
+
+	status.Phase = v1alpha1.BackupComplete
+	status.TimeCompleted = metav1.Time{Time: time.Now()}
+	return bm.updateReplicaStatus(backup, status)
+}
+
+// failReplica marks the copying to the replica storage as failed and returns the cause
+func (bm *Manager) failReplica(backup *v1alpha1.Backup, status *v1alpha1.BackupReplicaStatus, err error) error {
+	status.Phase = v1alpha1.BackupFailed
+	status.Message = err.Error()
+	if uerr := bm.updateReplicaStatus(backup, status); uerr != nil {
+		klog.Errorf("backup %s, update status of replicaStorages[%d] failed, err: %s", bm, status.Index, uerr)
+	}
+	return err
+}
+
+func (bm *Manager) updateReplicaStatus(backup *v1alpha1.Backup, status *v1alpha1.BackupReplicaStatus) error {
+	return bm.StatusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{
+		ReplicaStatus: status.DeepCopy(),
+	})
+}
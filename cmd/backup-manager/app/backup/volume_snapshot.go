@@ -0,0 +1,123 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"strings"
+	"syscall"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// performVolumeSnapshotPrepare keeps the TiKV stores prepared for the volume snapshots taken by the operator.
+// BR pauses GC and the schedulers, waits for the applied raft logs and suspends the writes of all the stores,
+// the prepared condition is reported to the operator then. The job is deleted by the operator once all the
+// snapshots are cut, and BR resumes the stores when it is terminated.
+func (bm *Manager) performVolumeSnapshotPrepare(ctx context.Context, backup *v1alpha1.Backup) error {
+	err := bm.prepareForSnapshotBackup(ctx, backup, func() error {
+		return bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
+			Type:   v1alpha1.BackupVolumeSnapshotPrepared,
+			Status: corev1.ConditionTrue,
+		}, nil)
+	})
+	if ctx.Err() != nil {
+		klog.Infof("prepare for volume snapshots of cluster %s is terminated", bm)
+		return nil
+	}
+	if err != nil {
+		// the operator fails the backup and resumes the cluster once the job exits
+		klog.Errorf("prepare for volume snapshots of cluster %s failed, err: %s", bm, err)
+		return err
+	}
+	return fmt.Errorf("prepare for volume snapshots of cluster %s exited before all the snapshots are taken", bm)
+}
+
+// prepareForSnapshotBackup runs `br operator prepare-for-snapshot-backup` until the context is canceled,
+// onReady is called once BR reports that all the TiKV stores are prepared. BR is terminated by SIGTERM
+// instead of SIGKILL, so it has the chance to resume the stores before it exits.
+func (bo *Options) prepareForSnapshotBackup(ctx context.Context, backup *v1alpha1.Backup, onReady func() error) error {
+	args := []string{
+		"operator",
+		"prepare-for-snapshot-backup",
+		fmt.Sprintf("--pd=%s", bo.pdAddress(backup)),
+	}
+	if bo.TLSCluster {
+		args = append(args, fmt.Sprintf("--ca=%s", path.Join(util.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey)))
+		args = append(args, fmt.Sprintf("--cert=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSCertKey)))
+		args = append(args, fmt.Sprintf("--key=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSPrivateKeyKey)))
+	}
+	klog.Infof("Running br command with args: %v", args)
+	bin := path.Join(util.BRBinPath, "br")
+	cmd := exec.Command(bin, args...)
+
+	stdOut, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("cluster %s, create stdout pipe failed, err: %v", bo, err)
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("cluster %s, execute br command failed, args: %s, err: %v", bo, args, err)
+	}
+
+	exited := make(chan struct{})
+	defer close(exited)
+	go func() {
+		select {
+		case <-ctx.Done():
+			klog.Infof("cluster %s, terminate br to resume the TiKV stores", bo)
+			cmd.Process.Signal(syscall.SIGTERM)
+		case <-exited:
+		}
+	}()
+
+	var errMsg string
+	var readyErr error
+	ready := false
+	reader := bufio.NewReader(stdOut)
+	for {
+		line, err := reader.ReadString('\n')
+		if strings.Contains(line, "[ERROR]") {
+			errMsg += line
+		}
+		klog.Info(strings.Replace(line, "\n", "", -1))
+		if !ready && strings.Contains(line, constants.BRSnapshotBackupReadyHint) {
+			ready = true
+			if readyErr = onReady(); readyErr != nil {
+				// the operator can't know the stores are prepared, resume them
+				cmd.Process.Signal(syscall.SIGTERM)
+			}
+		}
+		if err != nil || io.EOF == err {
+			break
+		}
+	}
+	err = cmd.Wait()
+	if readyErr != nil {
+		return fmt.Errorf("cluster %s, report the prepared stores failed, err: %v", bo, readyErr)
+	}
+	if err != nil {
+		return fmt.Errorf("cluster %s, wait pipe message failed, errMsg %s, err: %v", bo, errMsg, err)
+	}
+	return nil
+}
@@ -37,14 +37,40 @@ func (bo *Options) String() string {
 	return fmt.Sprintf("%s/%s", bo.Namespace, bo.BackupName)
 }
 
-// cleanBRRemoteBackupData clean the backup data from remote
+// cleanBRRemoteBackupData clean the backup data from remote and the replica storages
 func (bo *Options) cleanBRRemoteBackupData(ctx context.Context, backup *v1alpha1.Backup) error {
 	s, err := util.NewStorageBackend(backup.Spec.StorageProvider)
 	if err != nil {
 		return err
 	}
 	defer s.Close()
+	if err := bo.cleanBRStorageData(ctx, backup, s); err != nil {
+		return err
+	}
+	for _, status := range backup.Status.ReplicaStatuses {
+		if int(status.Index) >= len(backup.Spec.ReplicaStorages) {
+			continue
+		}
+		klog.Infof("cleanning cluster %s backup data in replica storage %s", bo, status.Path)
+		if err := bo.cleanReplicaStorageData(ctx, backup, status.Index); err != nil {
+			return fmt.Errorf("clean replica storage %s failed, err: %v", status.Path, err)
+		}
+	}
+	return nil
+}
+
+// cleanReplicaStorageData clean the backup data from the replica storage with the index
+func (bo *Options) cleanReplicaStorageData(ctx context.Context, backup *v1alpha1.Backup, index int32) error {
+	s, err := util.NewReplicaStorageBackend(backup.Spec.ReplicaStorages[index], int(index))
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return bo.cleanBRStorageData(ctx, backup, s)
+}
 
+// cleanBRStorageData clean the backup data from the storage
+func (bo *Options) cleanBRStorageData(ctx context.Context, backup *v1alpha1.Backup, s *util.StorageBackend) error {
 	opt := backup.GetCleanOption()
 
 	klog.Infof("cleanning cluster %s backup data with opt: %+v", bo, opt)
@@ -22,6 +22,25 @@ const (
 	// PollInterval is the interval to check if the tidb cluster is ready
 	PollInterval = 5 * time.Second
 
+	// LogBackupCheckpointInterval is the interval to sync the checkpoint ts of log backup
+	LogBackupCheckpointInterval = 30 * time.Second
+
+	// LogBackupCheckpointKeyPrefix is the key prefix of log backup checkpoint ts in pd etcd
+	LogBackupCheckpointKeyPrefix = "/tidb/br-stream/checkpoint"
+
+	// ReplicaCopyConcurrency is the number of objects copied concurrently to a replica storage
+	ReplicaCopyConcurrency = 8
+
+	// ReplicaProgressInterval is the interval to update the progress of copying to a replica storage
+	ReplicaProgressInterval = 10 * time.Second
+
+	// BRProgressInterval is the interval to update the progress of BR into the status
+	BRProgressInterval = 10 * time.Second
+
+	// BRSnapshotBackupReadyHint is logged by `br operator prepare-for-snapshot-backup` once GC and
+	// the schedulers are paused and the writes of all the TiKV stores are suspended
+	BRSnapshotBackupReadyHint = "All ready."
+
 	// CheckTimeout is the maximum time to wait for the tidb cluster ready
 	CheckTimeout = 30 * time.Minute
 
@@ -54,6 +73,10 @@ const (
 	// RcloneConfigArg represents the config argument to rclone cmd
 	RcloneConfigArg = "--config=" + RcloneConfigFile
 
+	// BRCrypterKeyFile is the file which the data key of the encryption is written into for BR,
+	// so that the key doesn't show up in the args of the BR process
+	BRCrypterKeyFile = "/tmp/br-crypter.key"
+
 	// MetaFile is the file name for meta data of backup with BR
 	MetaFile = "backupmeta"
 
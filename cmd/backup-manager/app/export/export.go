@@ -16,6 +16,7 @@ package export
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
@@ -162,3 +163,16 @@ func archiveBackupData(backupDir, destFile string) error {
 	}
 	return nil
 }
+
+// encryptBackupData encrypts the archived backup data in place with the data key of the encryption
+func encryptBackupData(archiveFile string, encryption *v1alpha1.BackupEncryption) error {
+	key, err := backupUtil.GetEncryptionKey(encryption)
+	if err != nil {
+		return err
+	}
+	encryptedFile := archiveFile + ".enc"
+	if err := backupUtil.EncryptFile(archiveFile, encryptedFile, key); err != nil {
+		return fmt.Errorf("encrypt backup data %s failed, err: %v", archiveFile, err)
+	}
+	return os.Rename(encryptedFile, archiveFile)
+}
@@ -328,6 +328,22 @@ func (bm *BackupManager) performBackup(ctx context.Context, backup *v1alpha1.Bac
 	}
 	klog.Infof("archive cluster %s backup data %s success", bm, archiveBackupPath)
 
+	if backup.Spec.Encryption != nil {
+		if err := encryptBackupData(archiveBackupPath, backup.Spec.Encryption); err != nil {
+			errs = append(errs, err)
+			klog.Errorf("encrypt cluster %s backup data %s failed, err: %s", bm, archiveBackupPath, err)
+			uerr := bm.StatusUpdater.Update(backup, &v1alpha1.BackupCondition{
+				Type:    v1alpha1.BackupFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "EncryptBackupDataFailed",
+				Message: err.Error(),
+			}, nil)
+			errs = append(errs, uerr)
+			return errorutils.NewAggregate(errs)
+		}
+		klog.Infof("encrypt cluster %s backup data %s success", bm, archiveBackupPath)
+	}
+
 	opts := util.GetOptions(backup.Spec.StorageProvider)
 	size, err := getBackupSize(ctx, archiveBackupPath, opts)
 	if err != nil {
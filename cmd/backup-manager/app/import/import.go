@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
@@ -128,6 +129,19 @@ func (ro *Options) loadTidbClusterData(ctx context.Context, restorePath string,
 	return nil
 }
 
+// decryptBackupData decrypts the downloaded backup data in place with the data key of the encryption
+func decryptBackupData(backupFile string, encryption *v1alpha1.BackupEncryption) error {
+	key, err := backupUtil.GetEncryptionKey(encryption)
+	if err != nil {
+		return err
+	}
+	decryptedFile := backupFile + ".dec"
+	if err := backupUtil.DecryptFile(backupFile, decryptedFile, key); err != nil {
+		return fmt.Errorf("decrypt backup data %s failed, err: %v", backupFile, err)
+	}
+	return os.Rename(decryptedFile, backupFile)
+}
+
 // unarchiveBackupData unarchive backup data to dest dir
 // NOTE: no context/timeout supported for `tarGz.Unarchive`, this may cause to be KILLed when blocking.
 func unarchiveBackupData(backupFile, destDir string) (string, error) {
@@ -120,6 +120,22 @@ func (rm *RestoreManager) performRestore(ctx context.Context, restore *v1alpha1.
 	}
 	klog.Infof("download cluster %s backup %s data success", rm, rm.BackupPath)
 
+	if restore.Spec.Encryption != nil {
+		if err := decryptBackupData(restoreDataPath, restore.Spec.Encryption); err != nil {
+			errs = append(errs, err)
+			klog.Errorf("decrypt cluster %s backup %s data failed, err: %s", rm, restoreDataPath, err)
+			uerr := rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "DecryptBackupDataFailed",
+				Message: fmt.Sprintf("decrypt backup %s data failed, err: %v", restoreDataPath, err),
+			}, nil)
+			errs = append(errs, uerr)
+			return errorutils.NewAggregate(errs)
+		}
+		klog.Infof("decrypt cluster %s backup %s data success", rm, restoreDataPath)
+	}
+
 	restoreDataDir := filepath.Dir(restoreDataPath)
 	unarchiveDataPath, err := unarchiveBackupData(restoreDataPath, restoreDataDir)
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	bkconstants "github.com/pingcap/tidb-operator/pkg/backup/constants"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	pkgutil "github.com/pingcap/tidb-operator/pkg/util"
@@ -36,6 +37,7 @@ import (
 
 type Manager struct {
 	restoreLister listers.RestoreLister
+	backupLister  listers.BackupLister
 	StatusUpdater controller.RestoreConditionUpdaterInterface
 	Options
 }
@@ -43,10 +45,12 @@ type Manager struct {
 // NewManager return a RestoreManager
 func NewManager(
 	restoreLister listers.RestoreLister,
+	backupLister listers.BackupLister,
 	statusUpdater controller.RestoreConditionUpdaterInterface,
 	restoreOpts Options) *Manager {
 	return &Manager{
 		restoreLister,
+		backupLister,
 		statusUpdater,
 		restoreOpts,
 	}
@@ -149,7 +153,16 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 
 	var errs []error
 
-	commitTs, err := util.GetCommitTsFromBRMetaData(ctx, restore.Spec.StorageProvider)
+	var commitTs uint64
+	if restore.Spec.Mode == v1alpha1.RestoreModePiTR {
+		// the cluster is restored to the restored ts in pitr mode
+		commitTs, err = backuputil.ParseTSString(restore.Spec.PitrRestoredTs)
+	} else if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+		// the commit ts of the volume-snapshot backup is recorded by the operator when the volumes are provisioned
+		commitTs, err = backuputil.ParseTSString(restore.Status.CommitTs)
+	} else {
+		commitTs, err = util.GetCommitTsFromBRMetaData(ctx, restore.Spec.StorageProvider, restore.Spec.Encryption)
+	}
 	if err != nil {
 		errs = append(errs, err)
 		klog.Errorf("get cluster %s commitTs failed, err: %s", rm, err)
@@ -249,7 +262,16 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 		}
 	}
 
-	restoreErr := rm.restoreData(ctx, restore)
+	// the progress of br is synced into the restore status
+	tracker := util.NewProgressTracker(func(progress *v1alpha1.BRProgress) error {
+		return rm.StatusUpdater.Update(restore, nil, &controller.RestoreUpdateStatus{Progress: progress})
+	})
+	var restoreErr error
+	if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+		restoreErr = rm.restoreVolumeSnapshotData(ctx, restore, commitTs, tracker)
+	} else {
+		restoreErr = rm.restoreData(ctx, restore, tracker)
+	}
 
 	if db != nil && oldTikvGCTimeDuration < tikvGCTimeDuration {
 		// use another context to revert `tikv_gc_life_time` back.
@@ -289,6 +311,14 @@ func (rm *Manager) performRestore(ctx context.Context, restore *v1alpha1.Restore
 	}
 	klog.Infof("restore cluster %s from %s succeed", rm, restore.Spec.Type)
 
+	if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+		// the restore is completed by the operator after the cluster is switched out of recovery mode
+		return rm.StatusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+			Type:   v1alpha1.RestoreDataComplete,
+			Status: corev1.ConditionTrue,
+		}, nil)
+	}
+
 	finish := time.Now()
 	ts := strconv.FormatUint(commitTs, 10)
 	updateStatus := &controller.RestoreUpdateStatus{
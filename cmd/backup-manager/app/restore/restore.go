@@ -25,6 +25,7 @@ import (
 
 	backupUtil "github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -34,7 +35,29 @@ type Options struct {
 	backupUtil.GenericOptions
 }
 
-func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore) error {
+// restoreData generates br args and runs br binary to do the real restore work,
+// the progress of BR is reported by the tracker
+func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore, tracker *backupUtil.ProgressTracker) error {
+	// `options` in spec are put to the last because we want them to have higher priority than generated arguments
+	dataArgs, err := constructBROptions(restore)
+	if err != nil {
+		return err
+	}
+
+	var restoreType string
+	if restore.Spec.Mode == v1alpha1.RestoreModePiTR {
+		// pitr restores the full backup and the log backup to the restored ts
+		restoreType = "point"
+	} else if restore.Spec.Type == "" {
+		restoreType = string(v1alpha1.BackupTypeFull)
+	} else {
+		restoreType = string(restore.Spec.Type)
+	}
+	return ro.runBRRestore(ctx, restore, restoreType, dataArgs, tracker)
+}
+
+// runBRRestore runs `br restore <restoreType>` against the cluster of the restore with the data args
+func (ro *Options) runBRRestore(ctx context.Context, restore *v1alpha1.Restore, restoreType string, dataArgs []string, tracker *backupUtil.ProgressTracker) error {
 	clusterNamespace := restore.Spec.BR.ClusterNamespace
 	if restore.Spec.BR.ClusterNamespace == "" {
 		clusterNamespace = restore.Namespace
@@ -46,19 +69,8 @@ func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore) e
 		args = append(args, fmt.Sprintf("--cert=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSCertKey)))
 		args = append(args, fmt.Sprintf("--key=%s", path.Join(util.ClusterClientTLSPath, corev1.TLSPrivateKeyKey)))
 	}
-	// `options` in spec are put to the last because we want them to have higher priority than generated arguments
-	dataArgs, err := constructBROptions(restore)
-	if err != nil {
-		return err
-	}
 	args = append(args, dataArgs...)
 
-	var restoreType string
-	if restore.Spec.Type == "" {
-		restoreType = string(v1alpha1.BackupTypeFull)
-	} else {
-		restoreType = string(restore.Spec.Type)
-	}
 	fullArgs := []string{
 		"restore",
 		restoreType,
@@ -87,6 +99,7 @@ func (ro *Options) restoreData(ctx context.Context, restore *v1alpha1.Restore) e
 		if strings.Contains(line, "[ERROR]") {
 			errMsg += line
 		}
+		tracker.Track(line)
 		klog.Info(strings.Replace(line, "\n", "", -1))
 		if err != nil || io.EOF == err {
 			break
@@ -111,6 +124,13 @@ func constructBROptions(restore *v1alpha1.Restore) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	if restore.Spec.Mode == v1alpha1.RestoreModePiTR {
+		restoredTs, err := backuputil.ParseTSString(restore.Spec.PitrRestoredTs)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, fmt.Sprintf("--restored-ts=%d", restoredTs))
+	}
 	config := restore.Spec.BR
 	if config.Concurrency != nil {
 		args = append(args, fmt.Sprintf("--concurrency=%d", *config.Concurrency))
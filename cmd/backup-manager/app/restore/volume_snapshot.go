@@ -0,0 +1,93 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	backupUtil "github.com/pingcap/tidb-operator/cmd/backup-manager/app/util"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+// volumeSnapshotBackupType is the full backup type of BR for the backups based on volume snapshots
+const volumeSnapshotBackupType = "aws-ebs"
+
+// volumeSnapshotBackupMeta is the backup meta read by BR to restore the data on the volumes
+// provisioned from the snapshots
+type volumeSnapshotBackupMeta struct {
+	ClusterInfo volumeSnapshotClusterInfo `json:"cluster_info"`
+	TiKV        volumeSnapshotTiKVInfo    `json:"tikv"`
+}
+
+type volumeSnapshotClusterInfo struct {
+	FullBackupType string `json:"full_backup_type"`
+	ResolvedTS     uint64 `json:"resolved_ts"`
+}
+
+type volumeSnapshotTiKVInfo struct {
+	Replicas int `json:"replicas"`
+}
+
+// restoreVolumeSnapshotData restores the data on the TiKV volumes provisioned from the snapshots to the
+// commit ts of the backup by `br restore full --type=aws-ebs`. BR waits for all the TiKV stores of the
+// backup to report, truncates their data to the resolved ts and unmarks the snapshot recovering of PD.
+func (rm *Manager) restoreVolumeSnapshotData(ctx context.Context, restore *v1alpha1.Restore, commitTs uint64, tracker *backupUtil.ProgressTracker) error {
+	backup, err := rm.backupLister.Backups(restore.Namespace).Get(restore.Spec.VolumeSnapshotBackupName)
+	if err != nil {
+		return fmt.Errorf("get backup %s/%s failed, err: %v", restore.Namespace, restore.Spec.VolumeSnapshotBackupName, err)
+	}
+
+	metaDir, err := ioutil.TempDir("", "volume-snapshot-restore")
+	if err != nil {
+		return fmt.Errorf("create backup meta dir failed, err: %v", err)
+	}
+	defer os.RemoveAll(metaDir)
+	if err := writeVolumeSnapshotBackupMeta(metaDir, commitTs, len(backup.Status.VolumeSnapshots)); err != nil {
+		return err
+	}
+
+	args := []string{
+		fmt.Sprintf("--type=%s", volumeSnapshotBackupType),
+		fmt.Sprintf("--storage=local://%s", metaDir),
+	}
+	args = append(args, restore.Spec.BR.Options...)
+	return rm.runBRRestore(ctx, restore, string(v1alpha1.BackupTypeFull), args, tracker)
+}
+
+// writeVolumeSnapshotBackupMeta writes the backup meta of BR for the volume snapshots into dir
+func writeVolumeSnapshotBackupMeta(dir string, resolvedTS uint64, replicas int) error {
+	meta := volumeSnapshotBackupMeta{
+		ClusterInfo: volumeSnapshotClusterInfo{
+			FullBackupType: volumeSnapshotBackupType,
+			ResolvedTS:     resolvedTS,
+		},
+		TiKV: volumeSnapshotTiKVInfo{
+			Replicas: replicas,
+		},
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("marshal backup meta failed, err: %v", err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, constants.MetaFile), data, 0644); err != nil {
+		return fmt.Errorf("write backup meta failed, err: %v", err)
+	}
+	return nil
+}
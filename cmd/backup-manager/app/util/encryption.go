@@ -0,0 +1,163 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	bkconstants "github.com/pingcap/tidb-operator/pkg/backup/constants"
+)
+
+// brCrypterKeyFile is the file which the data key is written into for BR, it is overridden in tests
+var brCrypterKeyFile = constants.BRCrypterKeyFile
+
+// GetEncryptionKey returns the data key of the encryption, which is hex encoded in the env
+func GetEncryptionKey(encryption *v1alpha1.BackupEncryption) ([]byte, error) {
+	keyHex := strings.TrimSpace(GetOptionValueFromEnv(bkconstants.EncryptionDataKey, bkconstants.BackupManagerEnvVarPrefix))
+	if keyHex == "" {
+		return nil, fmt.Errorf("data key of the encryption is not set")
+	}
+	key, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return nil, fmt.Errorf("data key of the encryption is not hex encoded, err: %v", err)
+	}
+	method := encryption.GetMethod()
+	if len(key) != method.KeySize() {
+		return nil, fmt.Errorf("data key of the encryption has %d bytes, but %s requires %d bytes", len(key), method, method.KeySize())
+	}
+	return key, nil
+}
+
+// constructBRCrypterOptions constructs the crypter options for BR to encrypt or decrypt the backup data,
+// the data key is written into a file only readable by the owner and passed to BR by the file
+func constructBRCrypterOptions(encryption *v1alpha1.BackupEncryption) ([]string, error) {
+	if encryption == nil {
+		return nil, nil
+	}
+	key, err := GetEncryptionKey(encryption)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBRCrypterKeyFile(brCrypterKeyFile, key); err != nil {
+		return nil, err
+	}
+	return []string{
+		fmt.Sprintf("--crypter.method=%s", encryption.GetMethod()),
+		fmt.Sprintf("--crypter.key-file=%s", brCrypterKeyFile),
+	}, nil
+}
+
+// writeBRCrypterKeyFile writes the hex encoded data key into the file with mode 0600
+func writeBRCrypterKeyFile(path string, key []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("create crypter key file %s failed, err: %v", path, err)
+	}
+	// the mode of an existing file is not changed by OpenFile
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return fmt.Errorf("chmod crypter key file %s failed, err: %v", path, err)
+	}
+	if _, err := f.WriteString(hex.EncodeToString(key)); err != nil {
+		f.Close()
+		return fmt.Errorf("write crypter key file %s failed, err: %v", path, err)
+	}
+	return f.Close()
+}
+
+// EncryptFile encrypts the src file into the dst file by AES in CTR mode with the key,
+// a random IV is generated and written at the head of the dst file
+func EncryptFile(src, dst string, key []byte) error {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	iv := make([]byte, block.BlockSize())
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return fmt.Errorf("generate iv failed, err: %v", err)
+	}
+
+	return transformFile(src, dst, func(r io.Reader, w io.Writer) error {
+		if _, err := w.Write(iv); err != nil {
+			return err
+		}
+		_, err := io.Copy(cipher.StreamWriter{S: cipher.NewCTR(block, iv), W: w}, r)
+		return err
+	})
+}
+
+// DecryptFile decrypts the src file encrypted by EncryptFile into the dst file
+func DecryptFile(src, dst string, key []byte) error {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	return transformFile(src, dst, func(r io.Reader, w io.Writer) error {
+		iv := make([]byte, block.BlockSize())
+		if _, err := io.ReadFull(r, iv); err != nil {
+			return fmt.Errorf("read iv failed, err: %v", err)
+		}
+		_, err := io.Copy(w, cipher.StreamReader{S: cipher.NewCTR(block, iv), R: r})
+		return err
+	})
+}
+
+// transformFile writes the content of the src file transformed by fn into the dst file
+func transformFile(src, dst string, fn func(r io.Reader, w io.Writer) error) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := fn(in, out); err != nil {
+		out.Close()
+		return fmt.Errorf("transform %s to %s failed, err: %v", src, dst, err)
+	}
+	return out.Close()
+}
+
+// decryptBRMetaData decrypts the backupmeta encrypted by the crypter of BR, which is
+// encrypted by AES in CTR mode with the IV at the head
+func decryptBRMetaData(data []byte, encryption *v1alpha1.BackupEncryption) ([]byte, error) {
+	key, err := GetEncryptionKey(encryption)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < block.BlockSize() {
+		return nil, fmt.Errorf("encrypted %s is too short", constants.MetaFile)
+	}
+	iv, encrypted := data[:block.BlockSize()], data[block.BlockSize():]
+	decrypted := make([]byte, len(encrypted))
+	cipher.NewCTR(block, iv).XORKeyStream(decrypted, encrypted)
+	return decrypted, nil
+}
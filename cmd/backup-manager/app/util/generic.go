@@ -22,9 +22,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -99,3 +101,32 @@ func (bo *GenericOptions) SetTikvGCLifeTime(ctx context.Context, db *sql.DB, gcT
 	}
 	return nil
 }
+
+// GetPDEtcdClient returns an etcd client of the pd cluster with the given address,
+// the cluster client certificates are used if cluster tls is enabled.
+func (bo *GenericOptions) GetPDEtcdClient(pdAddress string) (pdapi.PDEtcdClient, error) {
+	scheme := "http"
+	var tlsConfig *tls.Config
+	if bo.TLSCluster {
+		scheme = "https"
+		rootCertPool := x509.NewCertPool()
+		pem, err := ioutil.ReadFile(path.Join(util.ClusterClientTLSPath, corev1.ServiceAccountRootCAKey))
+		if err != nil {
+			return nil, err
+		}
+		if ok := rootCertPool.AppendCertsFromPEM(pem); !ok {
+			return nil, errors.New("Failed to append PEM")
+		}
+		cert, err := tls.LoadX509KeyPair(
+			path.Join(util.ClusterClientTLSPath, corev1.TLSCertKey),
+			path.Join(util.ClusterClientTLSPath, corev1.TLSPrivateKeyKey))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = &tls.Config{
+			RootCAs:      rootCertPool,
+			Certificates: []tls.Certificate{cert},
+		}
+	}
+	return pdapi.NewPdEtcdClient(fmt.Sprintf("%s://%s", scheme, pdAddress), 30*time.Second, tlsConfig)
+}
@@ -0,0 +1,151 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pingcap/tidb-operator/cmd/backup-manager/app/constants"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// brLogFieldRegexp matches the fields of the BR log, such as [step="Full Backup"] and [progress=7.48%]
+var brLogFieldRegexp = regexp.MustCompile(`\[([\w-]+)=("(?:[^"\\]|\\.)*"|[^\]]*)\]`)
+
+// brSummaryRegexp matches the message of the success summary of BR, such as ["Full Backup success summary"]
+var brSummaryRegexp = regexp.MustCompile(`\["([^"]+) success summary"\]`)
+
+// parseBRLogFields parses the fields of a line of the BR log into a map
+func parseBRLogFields(line string) map[string]string {
+	fields := map[string]string{}
+	for _, match := range brLogFieldRegexp.FindAllStringSubmatch(line, -1) {
+		value := match[2]
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		fields[match[1]] = value
+	}
+	return fields
+}
+
+// ParseBRProgress parses the progress from a line of the BR log, the progress is logged by BR like:
+// [INFO] [progress.go:123] [progress] [step="Full Backup"] [progress=7.48%] [count="1 / 20"] [speed="? p/s"] [elapsed=4s] [remaining=49s]
+// It returns nil if the line is not a progress log.
+func ParseBRProgress(line string) *v1alpha1.BRProgress {
+	if !strings.Contains(line, "[progress]") {
+		return nil
+	}
+	fields := parseBRLogFields(line)
+	step, ok := fields["step"]
+	if !ok {
+		return nil
+	}
+
+	progress := &v1alpha1.BRProgress{
+		Step:      step,
+		Speed:     fields["speed"],
+		Remaining: fields["remaining"],
+	}
+	if percentage, err := strconv.ParseFloat(strings.TrimSuffix(fields["progress"], "%"), 64); err == nil {
+		progress.Percentage = int32(percentage)
+	}
+	if count := strings.Split(fields["count"], "/"); len(count) == 2 {
+		progress.Completed, _ = strconv.ParseInt(strings.TrimSpace(count[0]), 10, 64)
+		progress.Total, _ = strconv.ParseInt(strings.TrimSpace(count[1]), 10, 64)
+	}
+	return progress
+}
+
+// ParseBRProcessedBytes parses the size of the processed data from the success summary in a line of the BR log,
+// which is logged by BR like:
+// [INFO] [collector.go:67] ["Full Backup success summary"] [total-ranges=20] [total-kv-size=6.425MB] [Size=1620690]
+// The raw Size is preferred, and the human readable total-kv-size is used by the older BR without it.
+// It returns the step of the summary and the size, or an empty step if the line is not a summary log.
+func ParseBRProcessedBytes(line string) (string, int64) {
+	match := brSummaryRegexp.FindStringSubmatch(line)
+	if match == nil {
+		return "", 0
+	}
+	fields := parseBRLogFields(line)
+	if size, err := strconv.ParseInt(fields["Size"], 10, 64); err == nil {
+		return match[1], size
+	}
+	if size, err := humanize.ParseBytes(fields["total-kv-size"]); err == nil {
+		return match[1], int64(size)
+	}
+	return "", 0
+}
+
+// ProgressTracker tracks the progress in the BR log and reports it periodically
+type ProgressTracker struct {
+	report     func(progress *v1alpha1.BRProgress) error
+	last       *v1alpha1.BRProgress
+	lastReport time.Time
+}
+
+// NewProgressTracker returns a ProgressTracker which reports the progress by the report func
+func NewProgressTracker(report func(progress *v1alpha1.BRProgress) error) *ProgressTracker {
+	return &ProgressTracker{report: report}
+}
+
+// Track parses the progress from a line of the BR log, the progress is reported when the step
+// changes, the step finishes, or the report interval has passed since the last report.
+// The failure of reporting is only logged because it should not interrupt BR.
+func (t *ProgressTracker) Track(line string) {
+	if t == nil {
+		return
+	}
+	progress := ParseBRProgress(line)
+	if progress == nil {
+		t.trackProcessedBytes(line)
+		return
+	}
+	stepChanged := t.last == nil || t.last.Step != progress.Step
+	finished := progress.Percentage >= 100 && (t.last == nil || t.last.Percentage < 100)
+	if !stepChanged && !finished && time.Since(t.lastReport) < constants.BRProgressInterval {
+		return
+	}
+
+	progress.LastUpdateTime = metav1.Now()
+	t.last = progress
+	t.lastReport = time.Now()
+	if err := t.report(progress.DeepCopy()); err != nil {
+		klog.Warningf("report progress of step %s failed, err: %s", progress.Step, err)
+	}
+}
+
+// trackProcessedBytes reports the size of the processed data in the success summary with the last progress
+func (t *ProgressTracker) trackProcessedBytes(line string) {
+	step, size := ParseBRProcessedBytes(line)
+	if step == "" {
+		return
+	}
+	progress := &v1alpha1.BRProgress{Step: step, Percentage: 100}
+	if t.last != nil {
+		progress = t.last.DeepCopy()
+	}
+	progress.ProcessedBytes = size
+	progress.LastUpdateTime = metav1.Now()
+	t.last = progress
+	t.lastReport = time.Now()
+	if err := t.report(progress.DeepCopy()); err != nil {
+		klog.Warningf("report processed bytes of step %s failed, err: %s", step, err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+)
+
+func TestParseBRProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	line := `[2022/10/10 10:00:00.000 +00:00] [INFO] [progress.go:176] [progress] [step="Full Backup"] [progress=7.48%] [count="1 / 20"] [speed="? p/s"] [elapsed=4s] [remaining=49s]`
+	g.Expect(ParseBRProgress(line)).To(Equal(&v1alpha1.BRProgress{
+		Step:       "Full Backup",
+		Percentage: 7,
+		Completed:  1,
+		Total:      20,
+		Speed:      "? p/s",
+		Remaining:  "49s",
+	}))
+
+	line = `[2022/10/10 10:01:00.000 +00:00] [INFO] [progress.go:176] [progress] [step=Checksum] [progress=100.00%] [count="20 / 20"] [speed="5 p/s"] [elapsed=4s] [remaining=0s]`
+	g.Expect(ParseBRProgress(line)).To(Equal(&v1alpha1.BRProgress{
+		Step:       "Checksum",
+		Percentage: 100,
+		Completed:  20,
+		Total:      20,
+		Speed:      "5 p/s",
+		Remaining:  "0s",
+	}))
+
+	// not a progress log
+	g.Expect(ParseBRProgress(`[2022/10/10 10:00:00.000 +00:00] [INFO] [collector.go:67] ["Full Backup success summary"] [total-ranges=20]`)).To(BeNil())
+	g.Expect(ParseBRProgress(`[2022/10/10 10:00:00.000 +00:00] [INFO] [client.go:100] [progress] [count="1 / 20"]`)).To(BeNil())
+}
+
+func TestParseBRProcessedBytes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	step, size := ParseBRProcessedBytes(`[2022/10/10 10:02:00.000 +00:00] [INFO] [collector.go:67] ["Full Backup success summary"] [total-ranges=20] [total-kv-size=6.425MB] [backup-data-size(after-compressed)=1.621MB] [Size=1620690]`)
+	g.Expect(step).To(Equal("Full Backup"))
+	g.Expect(size).To(Equal(int64(1620690)))
+
+	// the human readable size is used without the raw size
+	step, size = ParseBRProcessedBytes(`[2022/10/10 10:02:00.000 +00:00] [INFO] [collector.go:67] ["Full Restore success summary"] [total-ranges=20] [total-kv-size=6.4MB]`)
+	g.Expect(step).To(Equal("Full Restore"))
+	g.Expect(size).To(Equal(int64(6400000)))
+
+	step, _ = ParseBRProcessedBytes(`[2022/10/10 10:02:00.000 +00:00] [INFO] [collector.go:67] ["Full Backup success summary"] [total-ranges=20]`)
+	g.Expect(step).To(BeEmpty())
+	step, _ = ParseBRProcessedBytes(`[2022/10/10 10:00:00.000 +00:00] [INFO] [progress.go:176] [progress] [step="Full Backup"] [progress=7.48%]`)
+	g.Expect(step).To(BeEmpty())
+}
+
+func TestProgressTracker(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var reported []*v1alpha1.BRProgress
+	tracker := NewProgressTracker(func(progress *v1alpha1.BRProgress) error {
+		reported = append(reported, progress)
+		return nil
+	})
+	tracker.Track(`[INFO] [progress] [step="Full Backup"] [progress=7.48%] [count="1 / 20"] [remaining=49s]`)
+	g.Expect(reported).To(HaveLen(1))
+	g.Expect(reported[0].LastUpdateTime.IsZero()).To(BeFalse())
+
+	// the progress in the interval is not reported
+	tracker.Track(`[INFO] [progress] [step="Full Backup"] [progress=10.00%] [count="2 / 20"] [remaining=40s]`)
+	tracker.Track(`[INFO] [client.go:100] ["backup streaming finish"]`)
+	g.Expect(reported).To(HaveLen(1))
+
+	// the finished step is reported immediately
+	tracker.Track(`[INFO] [progress] [step="Full Backup"] [progress=100.00%] [count="20 / 20"] [remaining=0s]`)
+	g.Expect(reported).To(HaveLen(2))
+	g.Expect(reported[1].Percentage).To(Equal(int32(100)))
+
+	// the new step is reported immediately
+	tracker.Track(`[INFO] [progress] [step=Checksum] [progress=0.00%] [count="0 / 20"] [remaining=1m0s]`)
+	g.Expect(reported).To(HaveLen(3))
+	g.Expect(reported[2].Step).To(Equal("Checksum"))
+
+	// the processed bytes in the summary are reported with the last progress
+	tracker.Track(`[INFO] [collector.go:67] ["Full Backup success summary"] [total-ranges=20] [Size=1024]`)
+	g.Expect(reported).To(HaveLen(4))
+	g.Expect(reported[3].Step).To(Equal("Checksum"))
+	g.Expect(reported[3].ProcessedBytes).To(Equal(int64(1024)))
+
+	// nil tracker does nothing
+	var nilTracker *ProgressTracker
+	nilTracker.Track(`[INFO] [progress] [step=Checksum] [progress=0.00%]`)
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path"
 	"strings"
@@ -27,15 +28,18 @@ import (
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"gocloud.dev/blob"
 	"gocloud.dev/blob/azureblob"
 	"gocloud.dev/blob/fileblob"
 	"gocloud.dev/blob/gcsblob"
 	"gocloud.dev/blob/s3blob"
 	"gocloud.dev/gcp"
+	"golang.org/x/oauth2/google"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -44,6 +48,8 @@ import (
 
 const (
 	maxRetries = 3 // number of retries to make of operations
+
+	copyObjectsPageSize = 1000 // number of objects listed in a page when copying objects
 )
 
 type s3Config struct {
@@ -93,6 +99,16 @@ type StorageBackend struct {
 
 // NewStorageBackend creates new storage backend, now supports S3/GCS/Azblob/Local
 func NewStorageBackend(provider v1alpha1.StorageProvider) (*StorageBackend, error) {
+	return newStorageBackend(provider, "")
+}
+
+// NewReplicaStorageBackend creates the storage backend of the replica storage with the index,
+// whose credentials are read from the env vars with the prefix of the replica storage.
+func NewReplicaStorageBackend(provider v1alpha1.StorageProvider, index int) (*StorageBackend, error) {
+	return newStorageBackend(provider, util.ReplicaStorageEnvPrefix(index))
+}
+
+func newStorageBackend(provider v1alpha1.StorageProvider, envPrefix string) (*StorageBackend, error) {
 	var bucket *blob.Bucket
 	var err error
 
@@ -102,13 +118,13 @@ func NewStorageBackend(provider v1alpha1.StorageProvider) (*StorageBackend, erro
 	switch st {
 	case v1alpha1.BackupStorageTypeS3:
 		b.s3 = makeS3Config(provider.S3, true)
-		bucket, err = newS3Storage(b.s3)
+		bucket, err = newS3Storage(b.s3, envPrefix)
 	case v1alpha1.BackupStorageTypeGcs:
 		b.gcs = makeGcsConfig(provider.Gcs, true)
-		bucket, err = newGcsStorage(b.gcs)
+		bucket, err = newGcsStorage(b.gcs, envPrefix)
 	case v1alpha1.BackupStorageTypeAzblob:
 		b.azblob = makeAzblobConfig(provider.Azblob)
-		bucket, err = newAzblobStorage(b.azblob)
+		bucket, err = newAzblobStorage(b.azblob, envPrefix)
 	case v1alpha1.BackupStorageTypeLocal:
 		b.local = makeLocalConfig(provider.Local)
 		bucket, err = newLocalStorage(b.local)
@@ -311,6 +327,120 @@ func BatchDeleteObjectsConcurrently(ctx context.Context, bucket *blob.Bucket, ob
 	return result
 }
 
+// CopyProgress is the progress of copying objects from a storage to another
+type CopyProgress struct {
+	TotalObjects  int64
+	CopiedObjects int64
+	CopiedSize    int64
+}
+
+// CopyObjects copies all the objects in the src storage to the dst storage with the same keys.
+// The objects are listed page by page and each page is copied before the next one is listed,
+// so TotalObjects of the progress grows as the listing goes on. onProgress is called after each
+// object is copied and can be nil.
+func CopyObjects(ctx context.Context, src, dst *StorageBackend, concurrency int, onProgress func(CopyProgress)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	mu := &sync.Mutex{}
+	progress := CopyProgress{}
+	var copyErr error
+
+	iter := src.ListPage(nil)
+	for {
+		objs, err := iter.Next(ctx, copyObjectsPageSize)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("list objects failed, err: %v", err)
+		}
+
+		var keys []string
+		for _, obj := range objs {
+			if !obj.IsDir {
+				keys = append(keys, obj.Key)
+			}
+		}
+		mu.Lock()
+		progress.TotalObjects += int64(len(keys))
+		mu.Unlock()
+
+		workqueue.ParallelizeUntil(ctx, concurrency, len(keys), func(piece int) {
+			key := keys[piece]
+			size, err := copyObject(ctx, src, dst, key)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if copyErr == nil {
+					copyErr = fmt.Errorf("copy object %s failed, err: %v", key, err)
+				}
+				cancel()
+				return
+			}
+			progress.CopiedObjects++
+			progress.CopiedSize += size
+			if onProgress != nil {
+				onProgress(progress)
+			}
+		})
+		if copyErr != nil {
+			return copyErr
+		}
+		if progress.CopiedObjects < progress.TotalObjects {
+			return fmt.Errorf("copied objects are less than expected, copied: %d, expected: %d, err: %v", progress.CopiedObjects, progress.TotalObjects, ctx.Err())
+		}
+	}
+	return nil
+}
+
+// copyObject copies an object from the src storage to the dst storage and returns its size
+func copyObject(ctx context.Context, src, dst *StorageBackend, key string) (int64, error) {
+	r, err := src.NewReader(ctx, key, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	w, err := dst.NewWriter(ctx, key, dst.writerOptions())
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(w, r)
+	if err != nil {
+		w.Close()
+		return 0, err
+	}
+	return size, w.Close()
+}
+
+// writerOptions returns the options to write objects to the storage,
+// the storage class, acl and sse of S3 are applied to the objects.
+func (b *StorageBackend) writerOptions() *blob.WriterOptions {
+	if b.s3 == nil {
+		return nil
+	}
+	conf := b.s3
+	return &blob.WriterOptions{
+		BeforeWrite: func(asFunc func(interface{}) bool) error {
+			var input *s3manager.UploadInput
+			if !asFunc(&input) {
+				return nil
+			}
+			if conf.storageClass != "" {
+				input.StorageClass = aws.String(conf.storageClass)
+			}
+			if conf.acl != "" {
+				input.ACL = aws.String(conf.acl)
+			}
+			if conf.sse != "" {
+				input.ServerSideEncryption = aws.String(conf.sse)
+			}
+			return nil
+		},
+	}
+}
+
 // genStorageArgs returns the arg for --storage option and the remote/local path for br
 // TODO: add unit test
 func genStorageArgs(provider v1alpha1.StorageProvider) ([]string, error) {
@@ -340,6 +470,33 @@ func genStorageArgs(provider v1alpha1.StorageProvider) ([]string, error) {
 	}
 }
 
+// genStorageURL returns the storage url of the provider for the br options which take a single url,
+// such as --full-backup-storage. The storage options like `--s3.region=xxx` are encoded into the
+// query of the url as `region=xxx`.
+func genStorageURL(provider v1alpha1.StorageProvider) (string, error) {
+	args, err := genStorageArgs(provider)
+	if err != nil {
+		return "", err
+	}
+	storage := strings.TrimPrefix(args[0], "--storage=")
+	query := url.Values{}
+	for _, arg := range args[1:] {
+		kv := strings.SplitN(strings.TrimPrefix(arg, "--"), "=", 2)
+		if len(kv) != 2 {
+			return "", fmt.Errorf("unexpected storage option %s", arg)
+		}
+		key := kv[0]
+		if i := strings.Index(key, "."); i >= 0 {
+			key = key[i+1:]
+		}
+		query.Set(key, kv[1])
+	}
+	if len(query) == 0 {
+		return storage, nil
+	}
+	return storage + "?" + query.Encode(), nil
+}
+
 // newLocalStorageOption constructs `--storage local://$PATH` arg for br
 func newLocalStorageOption(conf *localConfig) ([]string, error) {
 	return []string{fmt.Sprintf("--storage=local://%s", path.Join(conf.mountPath, conf.prefix))}, nil
@@ -377,10 +534,18 @@ func newLocalStorage(conf *localConfig) (*blob.Bucket, error) {
 	return bucket, err
 }
 
-// newS3Storage initialize a new s3 storage
-func newS3Storage(conf *s3Config) (*blob.Bucket, error) {
+// newS3Storage initialize a new s3 storage, the access keys in the env vars with
+// the prefix are used if set, otherwise the default credential chain is used.
+func newS3Storage(conf *s3Config, envPrefix string) (*blob.Bucket, error) {
 	awsConfig := aws.NewConfig().WithMaxRetries(maxRetries).
 		WithS3ForcePathStyle(conf.forcePathStyle)
+	if envPrefix != "" {
+		accessKey := os.Getenv(envPrefix + "AWS_ACCESS_KEY_ID")
+		secretKey := os.Getenv(envPrefix + "AWS_SECRET_ACCESS_KEY")
+		if accessKey != "" && secretKey != "" {
+			awsConfig.WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, ""))
+		}
+	}
 	if conf.region != "" {
 		awsConfig.WithRegion(conf.region)
 	}
@@ -405,12 +570,19 @@ func newS3Storage(conf *s3Config) (*blob.Bucket, error) {
 
 }
 
-// newGcsStorage initialize a new gcs storage
-func newGcsStorage(conf *gcsConfig) (*blob.Bucket, error) {
+// newGcsStorage initialize a new gcs storage, the service account key in the env var with
+// the prefix is used if set, otherwise the default credentials are used.
+func newGcsStorage(conf *gcsConfig, envPrefix string) (*blob.Bucket, error) {
 	ctx := context.Background()
 
 	// Your GCP credentials.
-	creds, err := gcp.DefaultCredentials(ctx)
+	var creds *google.Credentials
+	var err error
+	if key := os.Getenv(envPrefix + "GCS_SERVICE_ACCOUNT_JSON_KEY"); envPrefix != "" && key != "" {
+		creds, err = google.CredentialsFromJSON(ctx, []byte(key), storage.ScopeFullControl)
+	} else {
+		creds, err = gcp.DefaultCredentials(ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -445,20 +617,20 @@ type azblobSharedKeyCred struct {
 	sharedKey string
 }
 
-// newAzblobStorage initialize a new azblob storage
-func newAzblobStorage(conf *azblobConfig) (*blob.Bucket, error) {
-	account := os.Getenv("AZURE_STORAGE_ACCOUNT")
+// newAzblobStorage initialize a new azblob storage with the credentials in the env vars with the prefix
+func newAzblobStorage(conf *azblobConfig, envPrefix string) (*blob.Bucket, error) {
+	account := os.Getenv(envPrefix + "AZURE_STORAGE_ACCOUNT")
 	if len(account) == 0 {
-		return nil, errors.New("No AZURE_STORAGE_ACCOUNT")
+		return nil, fmt.Errorf("No %sAZURE_STORAGE_ACCOUNT", envPrefix)
 	}
 
 	// Azure AAD Service Principal with access to the storage account.
-	clientID := os.Getenv("AZURE_CLIENT_ID")
-	clientSecret := os.Getenv("AZURE_CLIENT_SECRET")
-	tenantID := os.Getenv("AZURE_TENANT_ID")
+	clientID := os.Getenv(envPrefix + "AZURE_CLIENT_ID")
+	clientSecret := os.Getenv(envPrefix + "AZURE_CLIENT_SECRET")
+	tenantID := os.Getenv(envPrefix + "AZURE_TENANT_ID")
 
 	// Azure shared key with access to the storage account
-	accountKey := os.Getenv("AZURE_STORAGE_KEY")
+	accountKey := os.Getenv(envPrefix + "AZURE_STORAGE_KEY")
 
 	// check condition for using AAD credentials first
 	var usingAAD bool
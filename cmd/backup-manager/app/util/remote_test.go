@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"os"
+	"path"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,6 +35,7 @@ import (
 	"gocloud.dev/blob"
 	"gocloud.dev/blob/driver"
 	"gocloud.dev/gcerrors"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 )
@@ -335,15 +338,15 @@ func TestStorageBackendBasic(t *testing.T) {
 		provider := tcases.provider
 
 		// mock function
-		s3patches := gomonkey.ApplyFunc(newS3Storage, func(conf *s3Config) (*blob.Bucket, error) {
+		s3patches := gomonkey.ApplyFunc(newS3Storage, func(conf *s3Config, envPrefix string) (*blob.Bucket, error) {
 			return nil, nil
 		})
 		defer s3patches.Reset()
-		gcsPatches := gomonkey.ApplyFunc(newGcsStorage, func(conf *gcsConfig) (*blob.Bucket, error) {
+		gcsPatches := gomonkey.ApplyFunc(newGcsStorage, func(conf *gcsConfig, envPrefix string) (*blob.Bucket, error) {
 			return nil, nil
 		})
 		defer gcsPatches.Reset()
-		azblobPatches := gomonkey.ApplyFunc(newAzblobStorage, func(conf *azblobConfig) (*blob.Bucket, error) {
+		azblobPatches := gomonkey.ApplyFunc(newAzblobStorage, func(conf *azblobConfig, envPrefix string) (*blob.Bucket, error) {
 			return nil, nil
 		})
 		defer azblobPatches.Reset()
@@ -708,3 +711,61 @@ func objects(size int) []*blob.ListObject {
 	}
 	return objs
 }
+
+func TestCopyObjects(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+
+	mountPath := t.TempDir()
+	newLocalBackend := func(prefix string) *StorageBackend {
+		g.Expect(os.MkdirAll(path.Join(mountPath, prefix), 0755)).Should(gomega.Succeed())
+		b, err := NewStorageBackend(v1alpha1.StorageProvider{
+			Local: &v1alpha1.LocalStorageProvider{
+				VolumeMount: corev1.VolumeMount{MountPath: mountPath},
+				Prefix:      prefix,
+			},
+		})
+		g.Expect(err).Should(gomega.Succeed())
+		return b
+	}
+	src := newLocalBackend("primary")
+	defer src.Close()
+	dst := newLocalBackend("replica")
+	defer dst.Close()
+
+	objs := map[string]string{
+		"backupmeta":          "meta",
+		"1/1_2_default.sst":   "data of region 2",
+		"1/1_3_write.sst":     "data of region 3",
+		"2/2_4_default.sst":   "data of region 4",
+		"backup.lock":         "",
+		"checkpoint/progress": "100",
+	}
+	var size int64
+	for key, data := range objs {
+		g.Expect(src.WriteAll(ctx, key, []byte(data), nil)).Should(gomega.Succeed())
+		size += int64(len(data))
+	}
+
+	var progresses []CopyProgress
+	err := CopyObjects(ctx, src, dst, 2, func(progress CopyProgress) {
+		progresses = append(progresses, progress)
+	})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(progresses).Should(gomega.HaveLen(len(objs)))
+	g.Expect(progresses[len(progresses)-1]).Should(gomega.Equal(CopyProgress{
+		TotalObjects:  int64(len(objs)),
+		CopiedObjects: int64(len(objs)),
+		CopiedSize:    size,
+	}))
+	for key, data := range objs {
+		got, err := dst.ReadAll(ctx, key)
+		g.Expect(err).Should(gomega.Succeed())
+		g.Expect(string(got)).Should(gomega.Equal(data))
+	}
+
+	// copy from an empty storage
+	empty := newLocalBackend("empty")
+	defer empty.Close()
+	g.Expect(CopyObjects(ctx, empty, dst, 2, nil)).Should(gomega.Succeed())
+}
@@ -89,27 +89,32 @@ func EnsureDirectoryExist(dirName string) error {
 
 // GetStoragePath generate the path of a specific storage
 func GetStoragePath(backup *v1alpha1.Backup) (string, error) {
+	return GetProviderStoragePath(backup.Spec.StorageProvider)
+}
+
+// GetProviderStoragePath returns the full path of the storage
+func GetProviderStoragePath(provider v1alpha1.StorageProvider) (string, error) {
 	var url, bucket, prefix string
-	st := util.GetStorageType(backup.Spec.StorageProvider)
+	st := util.GetStorageType(provider)
 	switch st {
 	case v1alpha1.BackupStorageTypeS3:
-		prefix = backup.Spec.StorageProvider.S3.Prefix
-		bucket = backup.Spec.StorageProvider.S3.Bucket
+		prefix = provider.S3.Prefix
+		bucket = provider.S3.Bucket
 		url = fmt.Sprintf("s3://%s", path.Join(bucket, prefix))
 		return url, nil
 	case v1alpha1.BackupStorageTypeGcs:
-		prefix = backup.Spec.StorageProvider.Gcs.Prefix
-		bucket = backup.Spec.StorageProvider.Gcs.Bucket
+		prefix = provider.Gcs.Prefix
+		bucket = provider.Gcs.Bucket
 		url = fmt.Sprintf("gcs://%s/", path.Join(bucket, prefix))
 		return url, nil
 	case v1alpha1.BackupStorageTypeAzblob:
-		prefix = backup.Spec.StorageProvider.Azblob.Prefix
-		bucket = backup.Spec.StorageProvider.Azblob.Container
+		prefix = provider.Azblob.Prefix
+		bucket = provider.Azblob.Container
 		url = fmt.Sprintf("azure://%s/", path.Join(bucket, prefix))
 		return url, nil
 	case v1alpha1.BackupStorageTypeLocal:
-		prefix = backup.Spec.StorageProvider.Local.Prefix
-		mountPath := backup.Spec.StorageProvider.Local.VolumeMount.MountPath
+		prefix = provider.Local.Prefix
+		mountPath := provider.Local.VolumeMount.MountPath
 		url = fmt.Sprintf("local://%s", path.Join(mountPath, prefix))
 		return url, nil
 	default:
@@ -159,6 +164,11 @@ func ConstructBRGlobalOptionsForBackup(backup *v1alpha1.Backup) ([]string, error
 		return nil, err
 	}
 	args = append(args, storageArgs...)
+	crypterArgs, err := constructBRCrypterOptions(spec.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, crypterArgs...)
 
 	if spec.TableFilter != nil && len(spec.TableFilter) > 0 {
 		for _, tableFilter := range spec.TableFilter {
@@ -184,6 +194,32 @@ func ConstructBRGlobalOptionsForBackup(backup *v1alpha1.Backup) ([]string, error
 	return args, nil
 }
 
+// ConstructBRGlobalOptionsForLogBackup constructs BR global options for the subcommand of log backup.
+func ConstructBRGlobalOptionsForLogBackup(backup *v1alpha1.Backup, command v1alpha1.LogSubCommandType) ([]string, error) {
+	var args []string
+	spec := backup.Spec
+	if spec.BR == nil {
+		return nil, fmt.Errorf("no config for br in Backup %s/%s", backup.Namespace, backup.Name)
+	}
+	args = append(args, constructBRGlobalOptions(spec.BR)...)
+	// log stop only needs the task name, the storage is recorded in the task
+	if command == v1alpha1.LogStopCommand {
+		return args, nil
+	}
+	storageArgs, err := genStorageArgs(backup.Spec.StorageProvider)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, storageArgs...)
+
+	if command == v1alpha1.LogStartCommand && len(spec.TableFilter) > 0 {
+		for _, tableFilter := range spec.TableFilter {
+			args = append(args, "--filter", tableFilter)
+		}
+	}
+	return args, nil
+}
+
 // ConstructDumplingOptionsForBackup constructs dumpling options for backup
 func ConstructDumplingOptionsForBackup(backup *v1alpha1.Backup) []string {
 	var args []string
@@ -228,6 +264,19 @@ func ConstructBRGlobalOptionsForRestore(restore *v1alpha1.Restore) ([]string, er
 		return nil, err
 	}
 	args = append(args, storageArgs...)
+	crypterArgs, err := constructBRCrypterOptions(config.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, crypterArgs...)
+
+	if config.Mode == v1alpha1.RestoreModePiTR {
+		fullBackupStorage, err := genStorageURL(config.PitrFullBackupStorageProvider)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, fmt.Sprintf("--full-backup-storage=%s", fullBackupStorage))
+	}
 
 	if config.TableFilter != nil && len(config.TableFilter) > 0 {
 		for _, tableFilter := range config.TableFilter {
@@ -344,8 +393,8 @@ func GetBRArchiveSize(meta *kvbackup.BackupMeta) uint64 {
 	return total
 }
 
-// GetBRMetaData get backup metadata from cloud storage
-func GetBRMetaData(ctx context.Context, provider v1alpha1.StorageProvider) (*kvbackup.BackupMeta, error) {
+// GetBRMetaData get backup metadata from cloud storage, the metadata is decrypted if the encryption is not nil
+func GetBRMetaData(ctx context.Context, provider v1alpha1.StorageProvider, encryption *v1alpha1.BackupEncryption) (*kvbackup.BackupMeta, error) {
 	s, err := NewStorageBackend(provider)
 	if err != nil {
 		return nil, err
@@ -363,6 +412,12 @@ func GetBRMetaData(ctx context.Context, provider v1alpha1.StorageProvider) (*kvb
 	if err != nil {
 		return nil, err
 	}
+	if encryption != nil {
+		metaData, err = decryptBRMetaData(metaData, encryption)
+		if err != nil {
+			return nil, err
+		}
+	}
 	backupMeta := &kvbackup.BackupMeta{}
 	err = proto.Unmarshal(metaData, backupMeta)
 	if err != nil {
@@ -372,8 +427,8 @@ func GetBRMetaData(ctx context.Context, provider v1alpha1.StorageProvider) (*kvb
 }
 
 // GetCommitTsFromBRMetaData get backup position from `EndVersion` in BR backup meta
-func GetCommitTsFromBRMetaData(ctx context.Context, provider v1alpha1.StorageProvider) (uint64, error) {
-	backupMeta, err := GetBRMetaData(ctx, provider)
+func GetCommitTsFromBRMetaData(ctx context.Context, provider v1alpha1.StorageProvider, encryption *v1alpha1.BackupEncryption) (uint64, error) {
+	backupMeta, err := GetBRMetaData(ctx, provider, encryption)
 	if err != nil {
 		return 0, err
 	}
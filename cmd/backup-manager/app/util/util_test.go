@@ -385,6 +385,138 @@ func TestConstructBRGlobalOptionsForRestore(t *testing.T) {
 	}
 }
 
+func TestConstructBRGlobalOptionsForLogBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name    string
+		command v1alpha1.LogSubCommandType
+		expect  []string
+	}{
+		{
+			name:    "log start",
+			command: v1alpha1.LogStartCommand,
+			expect:  []string{"--storage=s3://test1-demo1", "--s3.provider=ceph", "--s3.endpoint=http://10.0.0.1", "--filter", "db.*"},
+		},
+		{
+			name:    "log truncate",
+			command: v1alpha1.LogTruncateCommand,
+			expect:  []string{"--storage=s3://test1-demo1", "--s3.provider=ceph", "--s3.endpoint=http://10.0.0.1"},
+		},
+		{
+			name:    "log stop",
+			command: v1alpha1.LogStopCommand,
+			expect:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := newBackup()
+			backup.Spec.Mode = v1alpha1.BackupModeLog
+			backup.Spec.TableFilter = []string{"db.*"}
+			backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "cluster-1", ClusterNamespace: "default"}
+
+			generateArgs, err := ConstructBRGlobalOptionsForLogBackup(backup, tt.command)
+			g.Expect(err).To(Succeed())
+			g.Expect(generateArgs).To(Equal(tt.expect))
+		})
+	}
+}
+
+func TestConstructBRGlobalOptionsForPiTRRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	restore := newRestore()
+	restore.Spec.Mode = v1alpha1.RestoreModePiTR
+	restore.Spec.BR = &v1alpha1.BRConfig{Cluster: "cluster-1", ClusterNamespace: "default"}
+	restore.Spec.PitrFullBackupStorageProvider = v1alpha1.StorageProvider{
+		S3: &v1alpha1.S3StorageProvider{
+			Provider: v1alpha1.S3StorageProviderTypeCeph,
+			Endpoint: "http://10.0.0.1",
+			Bucket:   "test1-demo1",
+			Prefix:   "full",
+		},
+	}
+
+	generateArgs, err := ConstructBRGlobalOptionsForRestore(restore)
+	g.Expect(err).To(Succeed())
+	g.Expect(generateArgs).To(ContainElement("--full-backup-storage=s3://test1-demo1/full?endpoint=http%3A%2F%2F10.0.0.1&provider=ceph"))
+}
+
+func TestConstructBRCrypterOptions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	keyFile := filepath.Join(t.TempDir(), "br-crypter.key")
+	defer func(origin string) { brCrypterKeyFile = origin }(brCrypterKeyFile)
+	brCrypterKeyFile = keyFile
+
+	backup := newBackup()
+	backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "cluster-1", ClusterNamespace: "default"}
+	backup.Spec.Encryption = &v1alpha1.BackupEncryption{SecretName: "encryption"}
+
+	// the data key is not set
+	os.Unsetenv("BACKUP_MANAGER_DATA_KEY")
+	_, err := ConstructBRGlobalOptionsForBackup(backup)
+	g.Expect(err).To(HaveOccurred())
+
+	// the data key is too short for aes256-ctr
+	key := "0123456789abcdef0123456789abcdef"
+	os.Setenv("BACKUP_MANAGER_DATA_KEY", key)
+	defer os.Unsetenv("BACKUP_MANAGER_DATA_KEY")
+	_, err = ConstructBRGlobalOptionsForBackup(backup)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("requires 32 bytes"))
+
+	backup.Spec.Encryption.Method = v1alpha1.EncryptionMethodAES128CTR
+	generateArgs, err := ConstructBRGlobalOptionsForBackup(backup)
+	g.Expect(err).To(Succeed())
+	g.Expect(generateArgs).To(ContainElements("--crypter.method=aes128-ctr", "--crypter.key-file="+keyFile))
+	for _, arg := range generateArgs {
+		g.Expect(arg).NotTo(ContainSubstring(key))
+	}
+	info, err := os.Stat(keyFile)
+	g.Expect(err).To(Succeed())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	data, err := ioutil.ReadFile(keyFile)
+	g.Expect(err).To(Succeed())
+	g.Expect(string(data)).To(Equal(key))
+}
+
+func TestEncryptFile(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tmpdir := t.TempDir()
+
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	data := []byte("backup data archived by dumpling")
+	plainFile := filepath.Join(tmpdir, "backup.tgz")
+	encryptedFile := filepath.Join(tmpdir, "backup.tgz.enc")
+	decryptedFile := filepath.Join(tmpdir, "backup.tgz.dec")
+	g.Expect(ioutil.WriteFile(plainFile, data, 0644)).To(Succeed())
+
+	g.Expect(EncryptFile(plainFile, encryptedFile, key)).To(Succeed())
+	encrypted, err := ioutil.ReadFile(encryptedFile)
+	g.Expect(err).To(Succeed())
+	// the IV is at the head of the encrypted file
+	g.Expect(encrypted).To(HaveLen(16 + len(data)))
+	g.Expect(encrypted[16:]).NotTo(Equal(data))
+
+	g.Expect(DecryptFile(encryptedFile, decryptedFile, key)).To(Succeed())
+	decrypted, err := ioutil.ReadFile(decryptedFile)
+	g.Expect(err).To(Succeed())
+	g.Expect(decrypted).To(Equal(data))
+
+	// the backupmeta of BR is encrypted in the same layout
+	os.Setenv("BACKUP_MANAGER_DATA_KEY", fmt.Sprintf("%x", key))
+	defer os.Unsetenv("BACKUP_MANAGER_DATA_KEY")
+	meta, err := decryptBRMetaData(encrypted, &v1alpha1.BackupEncryption{SecretName: "encryption"})
+	g.Expect(err).To(Succeed())
+	g.Expect(meta).To(Equal(data))
+}
+
 func TestGetCommitTsFromMetadata(t *testing.T) {
 	g := NewGomegaWithT(t)
 	tmpdir, err := ioutil.TempDir("", "test-get-commitTs-metadata")
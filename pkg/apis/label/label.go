@@ -61,6 +61,9 @@ const (
 
 	// BackupProtectionFinalizer is the name of finalizer on backups
 	BackupProtectionFinalizer string = "tidb.pingcap.com/backup-protection"
+	// TiCDCChangefeedFinalizer is the name of finalizer on changefeeds, the changefeed in TiCDC is
+	// removed before it is removed
+	TiCDCChangefeedFinalizer string = "tidb.pingcap.com/changefeed-protection"
 
 	// AutoScalingGroupLabelKey describes the autoscaling group of the TiDB
 	AutoScalingGroupLabelKey = "tidb.pingcap.com/autoscaling-group"
@@ -89,6 +92,10 @@ const (
 	AnnTiKVPartition string = "tidb.pingcap.com/tikv-partition"
 	// AnnForceUpgradeKey is tc annotation key to indicate whether force upgrade should be done
 	AnnForceUpgradeKey = "tidb.pingcap.com/force-upgrade"
+	// AnnSkipUpgradePreflightKey is tc annotation key to indicate whether to skip the upgrade pre-flight checks
+	AnnSkipUpgradePreflightKey = "tidb.pingcap.com/skip-upgrade-preflight"
+	// AnnForceScaleInKey is tc annotation key to indicate whether to skip the safety analysis when scaling in TiKV
+	AnnForceScaleInKey = "tidb.pingcap.com/force-scale-in"
 	// AnnPDDeferDeleting is pd pod annotation key  in pod for defer for deleting pod
 	AnnPDDeferDeleting = "tidb.pingcap.com/pd-defer-deleting"
 	// AnnSysctlInit is pod annotation key to indicate whether configuring sysctls with init container
@@ -100,6 +107,10 @@ const (
 
 	// AnnForceUpgradeVal is tc annotation value to indicate whether force upgrade should be done
 	AnnForceUpgradeVal = "true"
+	// AnnSkipUpgradePreflightVal is tc annotation value to indicate whether to skip the upgrade pre-flight checks
+	AnnSkipUpgradePreflightVal = "true"
+	// AnnForceScaleInVal is tc annotation value to indicate whether to skip the safety analysis when scaling in TiKV
+	AnnForceScaleInVal = "true"
 	// AnnSysctlInitVal is pod annotation value to indicate whether configuring sysctls with init container
 	AnnSysctlInitVal = "true"
 
@@ -116,11 +127,28 @@ const (
 	// AnnDMWorkerDeleteSlots is annotation key of dm-worker delete slots.
 	AnnDMWorkerDeleteSlots = "dm-worker.tidb.pingcap.com/delete-slots"
 
+	// AnnPDUpgradeApprovedStep is annotation key of the approved step of pd staged upgrade.
+	AnnPDUpgradeApprovedStep = "pd.tidb.pingcap.com/upgrade-approved-step"
+	// AnnTiDBUpgradeApprovedStep is annotation key of the approved step of tidb staged upgrade.
+	AnnTiDBUpgradeApprovedStep = "tidb.tidb.pingcap.com/upgrade-approved-step"
+	// AnnTiKVUpgradeApprovedStep is annotation key of the approved step of tikv staged upgrade.
+	AnnTiKVUpgradeApprovedStep = "tikv.tidb.pingcap.com/upgrade-approved-step"
+	// AnnTiFlashUpgradeApprovedStep is annotation key of the approved step of tiflash staged upgrade.
+	AnnTiFlashUpgradeApprovedStep = "tiflash.tidb.pingcap.com/upgrade-approved-step"
+	// AnnTiCDCUpgradeApprovedStep is annotation key of the approved step of ticdc staged upgrade.
+	AnnTiCDCUpgradeApprovedStep = "ticdc.tidb.pingcap.com/upgrade-approved-step"
+	// AnnTiProxyUpgradeApprovedStep is annotation key of the approved step of tiproxy staged upgrade.
+	AnnTiProxyUpgradeApprovedStep = "tiproxy.tidb.pingcap.com/upgrade-approved-step"
+
 	// AnnSkipTLSWhenConnectTiDB describes whether skip TLS when connecting to TiDB Server
 	AnnSkipTLSWhenConnectTiDB = "tidb.tidb.pingcap.com/skip-tls-when-connect-tidb"
 
 	// PDLabelVal is PD label value
 	PDLabelVal string = "pd"
+	// PDMSTSOLabelVal is the label value of the PD TSO microservice
+	PDMSTSOLabelVal string = "tso"
+	// PDMSSchedulingLabelVal is the label value of the PD scheduling microservice
+	PDMSSchedulingLabelVal string = "scheduling"
 	// TiDBLabelVal is TiDB label value
 	TiDBLabelVal string = "tidb"
 	// TiKVLabelVal is TiKV label value
@@ -129,8 +157,12 @@ const (
 	TiFlashLabelVal string = "tiflash"
 	// TiCDCLabelVal is TiCDC label value
 	TiCDCLabelVal string = "ticdc"
+	// TiProxyLabelVal is TiProxy label value
+	TiProxyLabelVal string = "tiproxy"
 	// PumpLabelVal is Pump label value
 	PumpLabelVal string = "pump"
+	// DrainerLabelVal is Drainer label value
+	DrainerLabelVal string = "drainer"
 	// DiscoveryLabelVal is Discovery label value
 	DiscoveryLabelVal string = "discovery"
 	// TiDBMonitorVal is Monitor label value
@@ -342,6 +374,16 @@ func (l Label) IsPD() bool {
 	return l[ComponentLabelKey] == PDLabelVal
 }
 
+// PDMS assigns the name of the PD microservice to component key in label
+func (l Label) PDMS(name string) Label {
+	return l.Component(name)
+}
+
+// IsPDMS returns whether label is a PD microservice component
+func (l Label) IsPDMS() bool {
+	return l[ComponentLabelKey] == PDMSTSOLabelVal || l[ComponentLabelKey] == PDMSSchedulingLabelVal
+}
+
 // Pump assigns pump to component key in label
 func (l Label) Pump() Label {
 	return l.Component(PumpLabelVal)
@@ -352,6 +394,16 @@ func (l Label) IsPump() bool {
 	return l[ComponentLabelKey] == PumpLabelVal
 }
 
+// Drainer assigns drainer to component key in label
+func (l Label) Drainer() Label {
+	return l.Component(DrainerLabelVal)
+}
+
+// IsDrainer returns whether label is a Drainer component
+func (l Label) IsDrainer() bool {
+	return l[ComponentLabelKey] == DrainerLabelVal
+}
+
 // DMMaster assigns dm-master to component key in label
 func (l Label) DMMaster() Label {
 	return l.Component(DMMasterLabelVal)
@@ -447,6 +499,16 @@ func (l Label) IsTiCDC() bool {
 	return l[ComponentLabelKey] == TiCDCLabelVal
 }
 
+// TiProxy assigns tiproxy to component key in label
+func (l Label) TiProxy() Label {
+	return l.Component(TiProxyLabelVal)
+}
+
+// IsTiProxy returns whether label is a TiProxy component
+func (l Label) IsTiProxy() bool {
+	return l[ComponentLabelKey] == TiProxyLabelVal
+}
+
 // Selector gets labels.Selector from label
 func (l Label) Selector() (labels.Selector, error) {
 	return metav1.LabelSelectorAsSelector(l.LabelSelector())
@@ -44,6 +44,21 @@ func (bk *Backup) GetBackupJobName() string {
 	return fmt.Sprintf("backup-%s", bk.GetName())
 }
 
+// GetLogBackupJobName return the log backup job name of the subcommand
+func (bk *Backup) GetLogBackupJobName(command LogSubCommandType) string {
+	return fmt.Sprintf("backup-%s-%s", bk.GetName(), command)
+}
+
+// GetVolumeSnapshotName return the name of the volume snapshot of the TiKV with the ordinal
+func (bk *Backup) GetVolumeSnapshotName(ordinal int32) string {
+	return fmt.Sprintf("backup-%s-tikv-%d", bk.GetName(), ordinal)
+}
+
+// GetVerifyName return the name of the scratch TidbCluster and Restore used to verify the backup
+func (bk *Backup) GetVerifyName() string {
+	return fmt.Sprintf("%s-verify", bk.GetName())
+}
+
 // GetTidbEndpointHash return the hash string base on tidb cluster's host and port
 func (bk *Backup) GetTidbEndpointHash() string {
 	return HashContents([]byte(bk.Spec.From.GetTidbEndpoint()))
@@ -163,6 +178,12 @@ func IsBackupPrepared(backup *Backup) bool {
 	return condition != nil && condition.Status == corev1.ConditionTrue
 }
 
+// IsVolumeSnapshotPrepared returns true if the TiKV stores have been prepared for the volume snapshots of a Backup
+func IsVolumeSnapshotPrepared(backup *Backup) bool {
+	_, condition := GetBackupCondition(&backup.Status, BackupVolumeSnapshotPrepared)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
 // IsBackupClean returns true if a Backup has been successfully cleaned up
 func IsBackupClean(backup *Backup) bool {
 	_, condition := GetBackupCondition(&backup.Status, BackupClean)
@@ -183,3 +204,110 @@ func IsCleanCandidate(backup *Backup) bool {
 func NeedNotClean(backup *Backup) bool {
 	return backup.Spec.CleanPolicy == CleanPolicyTypeOnFailure && !IsBackupFailed(backup)
 }
+
+// IsLogBackup returns true if a Backup is a log backup
+func IsLogBackup(backup *Backup) bool {
+	return backup.Spec.Mode == BackupModeLog
+}
+
+// IsVolumeSnapshotBackup returns true if a Backup takes volume snapshots of TiKV
+func IsVolumeSnapshotBackup(backup *Backup) bool {
+	return backup.Spec.Type == BackupTypeVolumeSnapshot
+}
+
+// NeedVerifyBackup returns true if a completed Backup has not been verified yet
+func NeedVerifyBackup(backup *Backup) bool {
+	if backup.Spec.Verify == nil || !IsBackupComplete(backup) {
+		return false
+	}
+	_, condition := GetBackupCondition(&backup.Status, BackupVerified)
+	return condition == nil || condition.Status == corev1.ConditionUnknown
+}
+
+// IsBackupVerified returns true if a Backup has been verified successfully
+func IsBackupVerified(backup *Backup) bool {
+	_, condition := GetBackupCondition(&backup.Status, BackupVerified)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// IsBackupStopped returns true if a log Backup has been stopped
+func IsBackupStopped(backup *Backup) bool {
+	_, condition := GetBackupCondition(&backup.Status, BackupStopped)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// GetLogSubCommandStatus returns the status of the log backup subcommand, nil if the subcommand has not been scheduled
+func GetLogSubCommandStatus(backup *Backup, command LogSubCommandType) *LogSubCommandStatus {
+	if backup.Status.LogSubCommandStatuses == nil {
+		return nil
+	}
+	status, ok := backup.Status.LogSubCommandStatuses[command]
+	if !ok {
+		return nil
+	}
+	return &status
+}
+
+// IsLogSubCommandFinished returns true if the log backup subcommand has completed or failed
+func IsLogSubCommandFinished(backup *Backup, command LogSubCommandType) bool {
+	status := GetLogSubCommandStatus(backup, command)
+	return status != nil && (status.Phase == BackupComplete || status.Phase == BackupFailed)
+}
+
+// ParseLogBackupSubcommand returns the log backup subcommand which should be run
+// according to the spec and status of the backup, empty if there is nothing to do.
+func ParseLogBackupSubcommand(backup *Backup) LogSubCommandType {
+	if !IsLogBackup(backup) {
+		return ""
+	}
+
+	start := GetLogSubCommandStatus(backup, LogStartCommand)
+	// the log backup task is stopped before the backup data is cleaned
+	if backup.Spec.LogStop || backup.DeletionTimestamp != nil {
+		if IsBackupStopped(backup) || start == nil || start.Phase == BackupFailed ||
+			IsLogSubCommandFinished(backup, LogStopCommand) {
+			// nothing to stop, or the stop has been tried
+			return ""
+		}
+		return LogStopCommand
+	}
+
+	if start == nil {
+		return LogStartCommand
+	}
+	if start.Phase != BackupComplete {
+		// truncate only makes sense after the log backup task is started
+		return ""
+	}
+
+	if backup.Spec.LogTruncateUntil != "" && backup.Spec.LogTruncateUntil != backup.Status.LogSuccessTruncateUntil {
+		truncate := GetLogSubCommandStatus(backup, LogTruncateCommand)
+		if truncate != nil && truncate.LogTruncatingUntil == backup.Spec.LogTruncateUntil && truncate.Phase == BackupFailed {
+			// don't retry the failed truncate until the spec is changed
+			return ""
+		}
+		return LogTruncateCommand
+	}
+	return ""
+}
+
+// GetMethod returns the encryption method, which defaults to aes256-ctr
+func (e *BackupEncryption) GetMethod() EncryptionMethod {
+	if e.Method == "" {
+		return EncryptionMethodAES256CTR
+	}
+	return e.Method
+}
+
+// KeySize returns the size in bytes of the data key used by the method, 0 if the method is unknown
+func (m EncryptionMethod) KeySize() int {
+	switch m {
+	case EncryptionMethodAES128CTR:
+		return 16
+	case EncryptionMethodAES192CTR:
+		return 24
+	case EncryptionMethodAES256CTR:
+		return 32
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseLogBackupSubcommand(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	withStatus := func(backup *Backup, command LogSubCommandType, phase BackupConditionType) *Backup {
+		if backup.Status.LogSubCommandStatuses == nil {
+			backup.Status.LogSubCommandStatuses = map[LogSubCommandType]LogSubCommandStatus{}
+		}
+		backup.Status.LogSubCommandStatuses[command] = LogSubCommandStatus{Command: command, Phase: phase}
+		return backup
+	}
+	newLogBackup := func() *Backup {
+		return &Backup{Spec: BackupSpec{Mode: BackupModeLog}}
+	}
+
+	// snapshot backup has no subcommand
+	g.Expect(ParseLogBackupSubcommand(&Backup{})).To(BeEmpty())
+
+	// start
+	backup := newLogBackup()
+	g.Expect(ParseLogBackupSubcommand(backup)).To(Equal(LogStartCommand))
+	withStatus(backup, LogStartCommand, BackupRunning)
+	g.Expect(ParseLogBackupSubcommand(backup)).To(BeEmpty())
+	withStatus(backup, LogStartCommand, BackupComplete)
+	g.Expect(ParseLogBackupSubcommand(backup)).To(BeEmpty())
+
+	// truncate
+	backup.Spec.LogTruncateUntil = "400036290571534337"
+	g.Expect(ParseLogBackupSubcommand(backup)).To(Equal(LogTruncateCommand))
+	backup.Status.LogSubCommandStatuses[LogTruncateCommand] = LogSubCommandStatus{
+		Command:            LogTruncateCommand,
+		LogTruncatingUntil: backup.Spec.LogTruncateUntil,
+		Phase:              BackupFailed,
+	}
+	g.Expect(ParseLogBackupSubcommand(backup)).To(BeEmpty())
+	backup.Spec.LogTruncateUntil = "400036290571534338"
+	g.Expect(ParseLogBackupSubcommand(backup)).To(Equal(LogTruncateCommand))
+	backup.Status.LogSuccessTruncateUntil = backup.Spec.LogTruncateUntil
+	g.Expect(ParseLogBackupSubcommand(backup)).To(BeEmpty())
+
+	// stop
+	backup.Spec.LogStop = true
+	g.Expect(ParseLogBackupSubcommand(backup)).To(Equal(LogStopCommand))
+	withStatus(backup, LogStopCommand, BackupFailed)
+	g.Expect(ParseLogBackupSubcommand(backup)).To(BeEmpty())
+	backup.Status.Conditions = append(backup.Status.Conditions, BackupCondition{Type: BackupStopped, Status: corev1.ConditionTrue})
+	g.Expect(ParseLogBackupSubcommand(backup)).To(BeEmpty())
+
+	// a deleted log backup is stopped only if it has been started
+	backup = newLogBackup()
+	backup.DeletionTimestamp = &metav1.Time{}
+	g.Expect(ParseLogBackupSubcommand(backup)).To(BeEmpty())
+	withStatus(backup, LogStartCommand, BackupComplete)
+	g.Expect(ParseLogBackupSubcommand(backup)).To(Equal(LogStopCommand))
+}
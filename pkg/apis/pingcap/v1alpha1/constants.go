@@ -20,6 +20,9 @@ const (
 	// DefaultTiDBServicePort is the default tidb cluster port for connecting
 	DefaultTiDBServicePort = int32(4000)
 
+	// DefaultTiProxyServicePort is the default tiproxy port for connecting
+	DefaultTiProxyServicePort = int32(6000)
+
 	// DefaultTidbUser is the default tidb user for login tidb cluster
 	DefaultTidbUser = "root"
 )
@@ -57,6 +57,14 @@ const (
 	TiDBNGMonitoringKind    = "TidbNGMonitoring"
 	TiDBNGMonitoringKindKey = "tidbngmonitoring"
 
+	TiCDCChangefeedName    = "ticdcchangefeeds"
+	TiCDCChangefeedKind    = "TiCDCChangefeed"
+	TiCDCChangefeedKindKey = "ticdcchangefeed"
+
+	TidbClusterReplicationName    = "tidbclusterreplications"
+	TidbClusterReplicationKind    = "TidbClusterReplication"
+	TidbClusterReplicationKindKey = "tidbclusterreplication"
+
 	SpecPath = "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1."
 )
 
@@ -69,27 +77,31 @@ type CrdKind struct {
 }
 
 type CrdKinds struct {
-	KindsString           string
-	TiDBCluster           CrdKind
-	DMCluster             CrdKind
-	Backup                CrdKind
-	Restore               CrdKind
-	BackupSchedule        CrdKind
-	TiDBMonitor           CrdKind
-	TiDBInitializer       CrdKind
-	TidbClusterAutoScaler CrdKind
-	TiDBNGMonitoring      CrdKind
+	KindsString            string
+	TiDBCluster            CrdKind
+	DMCluster              CrdKind
+	Backup                 CrdKind
+	Restore                CrdKind
+	BackupSchedule         CrdKind
+	TiDBMonitor            CrdKind
+	TiDBInitializer        CrdKind
+	TidbClusterAutoScaler  CrdKind
+	TiDBNGMonitoring       CrdKind
+	TiCDCChangefeed        CrdKind
+	TidbClusterReplication CrdKind
 }
 
 var DefaultCrdKinds = CrdKinds{
-	KindsString:           "",
-	TiDBCluster:           CrdKind{Plural: TiDBClusterName, Kind: TiDBClusterKind, ShortNames: []string{"tc"}, SpecName: SpecPath + TiDBClusterKind},
-	DMCluster:             CrdKind{Plural: DMClusterName, Kind: DMClusterKind, ShortNames: []string{"dc"}, SpecName: SpecPath + DMClusterKind},
-	Backup:                CrdKind{Plural: BackupName, Kind: BackupKind, ShortNames: []string{"bk"}, SpecName: SpecPath + BackupKind},
-	Restore:               CrdKind{Plural: RestoreName, Kind: RestoreKind, ShortNames: []string{"rt"}, SpecName: SpecPath + RestoreKind},
-	BackupSchedule:        CrdKind{Plural: BackupScheduleName, Kind: BackupScheduleKind, ShortNames: []string{"bks"}, SpecName: SpecPath + BackupScheduleKind},
-	TiDBMonitor:           CrdKind{Plural: TiDBMonitorName, Kind: TiDBMonitorKind, ShortNames: []string{"tm"}, SpecName: SpecPath + TiDBMonitorKind},
-	TiDBInitializer:       CrdKind{Plural: TiDBInitializerName, Kind: TiDBInitializerKind, ShortNames: []string{"ti"}, SpecName: SpecPath + TiDBInitializerKind},
-	TidbClusterAutoScaler: CrdKind{Plural: TidbClusterAutoScalerName, Kind: TidbClusterAutoScalerKind, ShortNames: []string{"ta"}, SpecName: SpecPath + TidbClusterAutoScalerKind},
-	TiDBNGMonitoring:      CrdKind{Plural: TiDBNGMonitoringName, Kind: TiDBNGMonitoringKind, ShortNames: []string{"tngm"}, SpecName: SpecPath + TiDBNGMonitoringKind},
+	KindsString:            "",
+	TiDBCluster:            CrdKind{Plural: TiDBClusterName, Kind: TiDBClusterKind, ShortNames: []string{"tc"}, SpecName: SpecPath + TiDBClusterKind},
+	DMCluster:              CrdKind{Plural: DMClusterName, Kind: DMClusterKind, ShortNames: []string{"dc"}, SpecName: SpecPath + DMClusterKind},
+	Backup:                 CrdKind{Plural: BackupName, Kind: BackupKind, ShortNames: []string{"bk"}, SpecName: SpecPath + BackupKind},
+	Restore:                CrdKind{Plural: RestoreName, Kind: RestoreKind, ShortNames: []string{"rt"}, SpecName: SpecPath + RestoreKind},
+	BackupSchedule:         CrdKind{Plural: BackupScheduleName, Kind: BackupScheduleKind, ShortNames: []string{"bks"}, SpecName: SpecPath + BackupScheduleKind},
+	TiDBMonitor:            CrdKind{Plural: TiDBMonitorName, Kind: TiDBMonitorKind, ShortNames: []string{"tm"}, SpecName: SpecPath + TiDBMonitorKind},
+	TiDBInitializer:        CrdKind{Plural: TiDBInitializerName, Kind: TiDBInitializerKind, ShortNames: []string{"ti"}, SpecName: SpecPath + TiDBInitializerKind},
+	TidbClusterAutoScaler:  CrdKind{Plural: TidbClusterAutoScalerName, Kind: TidbClusterAutoScalerKind, ShortNames: []string{"ta"}, SpecName: SpecPath + TidbClusterAutoScalerKind},
+	TiDBNGMonitoring:       CrdKind{Plural: TiDBNGMonitoringName, Kind: TiDBNGMonitoringKind, ShortNames: []string{"tngm"}, SpecName: SpecPath + TiDBNGMonitoringKind},
+	TiCDCChangefeed:        CrdKind{Plural: TiCDCChangefeedName, Kind: TiCDCChangefeedKind, ShortNames: []string{"cf"}, SpecName: SpecPath + TiCDCChangefeedKind},
+	TidbClusterReplication: CrdKind{Plural: TidbClusterReplicationName, Kind: TidbClusterReplicationKind, ShortNames: []string{"tcr"}, SpecName: SpecPath + TidbClusterReplicationKind},
 }
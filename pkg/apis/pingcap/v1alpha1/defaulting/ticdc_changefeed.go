@@ -0,0 +1,35 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulting
+
+import "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+const (
+	defaultSinkUserKey     = "user"
+	defaultSinkPasswordKey = "password"
+)
+
+func SetTiCDCChangefeedDefault(cf *v1alpha1.TiCDCChangefeed) {
+	if cf.Spec.Cluster.Namespace == "" {
+		cf.Spec.Cluster.Namespace = cf.Namespace
+	}
+	if ref := cf.Spec.SinkSecretRef; ref != nil {
+		if ref.UserKey == "" {
+			ref.UserKey = defaultSinkUserKey
+		}
+		if ref.PasswordKey == "" {
+			ref.PasswordKey = defaultSinkPasswordKey
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulting
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSetTiCDCChangefeedDefault(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cf := &v1alpha1.TiCDCChangefeed{}
+	cf.Name = "cf"
+	cf.Namespace = "cf-ns"
+	cf.Spec.Cluster.Name = "tc"
+	cf.Spec.SinkSecretRef = &v1alpha1.TiCDCChangefeedSinkSecretRef{Name: "sink", PasswordKey: "pswd"}
+	SetTiCDCChangefeedDefault(cf)
+	g.Expect(cf.Spec.Cluster.Namespace).To(Equal("cf-ns"))
+	g.Expect(cf.Spec.SinkSecretRef.UserKey).To(Equal("user"))
+	g.Expect(cf.Spec.SinkSecretRef.PasswordKey).To(Equal("pswd"))
+
+	cf = &v1alpha1.TiCDCChangefeed{}
+	cf.Namespace = "cf-ns"
+	cf.Spec.Cluster = v1alpha1.TidbClusterRef{Name: "tc", Namespace: "tc-ns"}
+	SetTiCDCChangefeedDefault(cf)
+	g.Expect(cf.Spec.Cluster.Namespace).To(Equal("tc-ns"))
+	g.Expect(cf.Spec.SinkSecretRef).To(BeNil())
+}
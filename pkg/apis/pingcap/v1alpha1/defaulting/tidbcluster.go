@@ -26,6 +26,7 @@ const (
 	defaultBinlogImage  = "pingcap/tidb-binlog"
 	defaultTiFlashImage = "pingcap/tiflash"
 	defaultTiCDCImage   = "pingcap/ticdc"
+	defaultTiProxyImage = "pingcap/tiproxy"
 )
 
 var (
@@ -43,15 +44,24 @@ func SetTidbClusterDefault(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.TiDB != nil {
 		setTidbSpecDefault(tc)
 	}
+	for _, spec := range tc.Spec.PDMS {
+		setPDMSSpecDefault(tc, spec)
+	}
 	if tc.Spec.Pump != nil {
 		setPumpSpecDefault(tc)
 	}
+	if tc.Spec.Drainer != nil {
+		setDrainerSpecDefault(tc)
+	}
 	if tc.Spec.TiFlash != nil {
 		setTiFlashSpecDefault(tc)
 	}
 	if tc.Spec.TiCDC != nil {
 		setTiCDCSpecDefault(tc)
 	}
+	if tc.Spec.TiProxy != nil {
+		setTiProxySpecDefault(tc)
+	}
 }
 
 // setTidbClusterSpecDefault is only managed the property under Spec
@@ -129,6 +139,22 @@ func setPumpSpecDefault(tc *v1alpha1.TidbCluster) {
 	}
 }
 
+func setPDMSSpecDefault(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec) {
+	if len(tc.Spec.Version) > 0 || spec.Version != nil {
+		if spec.BaseImage == "" {
+			spec.BaseImage = defaultPDImage
+		}
+	}
+}
+
+func setDrainerSpecDefault(tc *v1alpha1.TidbCluster) {
+	if len(tc.Spec.Version) > 0 || tc.Spec.Drainer.Version != nil {
+		if tc.Spec.Drainer.BaseImage == "" {
+			tc.Spec.Drainer.BaseImage = defaultBinlogImage
+		}
+	}
+}
+
 func setTiFlashSpecDefault(tc *v1alpha1.TidbCluster) {
 	if len(tc.Spec.Version) > 0 || tc.Spec.TiFlash.Version != nil {
 		if tc.Spec.TiFlash.BaseImage == "" {
@@ -147,3 +173,10 @@ func setTiCDCSpecDefault(tc *v1alpha1.TidbCluster) {
 		}
 	}
 }
+
+func setTiProxySpecDefault(tc *v1alpha1.TidbCluster) {
+	// the version of TiProxy is independent of the cluster version
+	if tc.Spec.TiProxy.BaseImage == "" && tc.Spec.TiProxy.Image == "" {
+		tc.Spec.TiProxy.BaseImage = defaultTiProxyImage
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulting
+
+import "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+func SetTidbClusterReplicationDefault(tcr *v1alpha1.TidbClusterReplication) {
+	for _, m := range []*v1alpha1.TidbClusterReplicationMember{&tcr.Spec.Primary, &tcr.Spec.Secondary} {
+		if m.Namespace == "" {
+			m.Namespace = tcr.Namespace
+		}
+		if m.SecretRef.UserKey == "" {
+			m.SecretRef.UserKey = defaultSinkUserKey
+		}
+		if m.SecretRef.PasswordKey == "" {
+			m.SecretRef.PasswordKey = defaultSinkPasswordKey
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulting
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSetTidbClusterReplicationDefault(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tcr := &v1alpha1.TidbClusterReplication{}
+	tcr.Namespace = "tcr-ns"
+	tcr.Spec.Primary.Name = "primary"
+	tcr.Spec.Secondary = v1alpha1.TidbClusterReplicationMember{
+		TidbClusterRef: v1alpha1.TidbClusterRef{Name: "secondary", Namespace: "dr"},
+		SecretRef:      v1alpha1.TiCDCChangefeedSinkSecretRef{Name: "secondary", UserKey: "username"},
+	}
+	SetTidbClusterReplicationDefault(tcr)
+	g.Expect(tcr.Spec.Primary.Key()).To(Equal("tcr-ns/primary"))
+	g.Expect(tcr.Spec.Primary.SecretRef.UserKey).To(Equal("user"))
+	g.Expect(tcr.Spec.Secondary.Key()).To(Equal("dr/secondary"))
+	g.Expect(tcr.Spec.Secondary.SecretRef.UserKey).To(Equal("username"))
+	g.Expect(tcr.Spec.Secondary.SecretRef.PasswordKey).To(Equal("password"))
+}
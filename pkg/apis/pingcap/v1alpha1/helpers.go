@@ -94,7 +94,7 @@ func GetMaxReplicaCountAndDeleteSlots(replicas int32, deleteSlots sets.Int32) (i
 func GetStorageVolumeName(storageVolumeName string, memberType MemberType) StorageVolumeName {
 	if storageVolumeName == "" {
 		switch memberType {
-		case PumpMemberType:
+		case PumpMemberType, DrainerMemberType:
 			return StorageVolumeName("data")
 		default:
 			return StorageVolumeName(memberType.String())
@@ -110,11 +110,14 @@ func GetStorageVolumeNameForTiFlash(index int) StorageVolumeName {
 
 var (
 	_ ComponentStatus = &PDStatus{}
+	_ ComponentStatus = &PDMSStatus{}
 	_ ComponentStatus = &TiKVStatus{}
 	_ ComponentStatus = &TiDBStatus{}
 	_ ComponentStatus = &PumpStatus{}
+	_ ComponentStatus = &DrainerStatus{}
 	_ ComponentStatus = &TiFlashStatus{}
 	_ ComponentStatus = &TiCDCStatus{}
+	_ ComponentStatus = &TiProxyStatus{}
 	_ ComponentStatus = &MasterStatus{}
 	_ ComponentStatus = &WorkerStatus{}
 )
@@ -123,7 +126,7 @@ type ComponentStatus interface {
 	GetMemberType() MemberType
 	// GetSynced returns `status.synced`
 	//
-	// For tidb and pump, it is always true.
+	// For tidb, pump and drainer, it is always true.
 	GetSynced() bool
 	// GetSynced returns `status.phase`
 	GetPhase() MemberPhase
@@ -150,6 +153,11 @@ func ComponentStatusFromTC(tc *TidbCluster) []ComponentStatus {
 	if tc.Spec.PD != nil {
 		components = append(components, &tc.Status.PD)
 	}
+	for _, spec := range tc.Spec.PDMS {
+		if status := tc.Status.PDMS[spec.Name]; status != nil {
+			components = append(components, status)
+		}
+	}
 	if tc.Spec.TiDB != nil {
 		components = append(components, &tc.Status.TiDB)
 	}
@@ -162,9 +170,15 @@ func ComponentStatusFromTC(tc *TidbCluster) []ComponentStatus {
 	if tc.Spec.TiCDC != nil {
 		components = append(components, &tc.Status.TiCDC)
 	}
+	if tc.Spec.TiProxy != nil {
+		components = append(components, &tc.Status.TiProxy)
+	}
 	if tc.Spec.Pump != nil {
 		components = append(components, &tc.Status.Pump)
 	}
+	if tc.Spec.Drainer != nil {
+		components = append(components, &tc.Status.Drainer)
+	}
 	return components
 }
 
@@ -305,6 +319,38 @@ func (s *PumpStatus) RemoveCondition(conditionType string) {
 	s.Conditions = conditions
 }
 
+func (s *DrainerStatus) GetMemberType() MemberType {
+	return DrainerMemberType
+}
+func (s *DrainerStatus) GetSynced() bool {
+	return true
+}
+func (s *DrainerStatus) GetPhase() MemberPhase {
+	return s.Phase
+}
+func (s *DrainerStatus) GetVolumes() map[StorageVolumeName]*StorageVolumeStatus {
+	return s.Volumes
+}
+func (s *DrainerStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
+func (s *DrainerStatus) SetCondition(newCondition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = []metav1.Condition{}
+	}
+	conditions := s.Conditions
+	meta.SetStatusCondition(&conditions, newCondition)
+	s.Conditions = conditions
+}
+func (s *DrainerStatus) RemoveCondition(conditionType string) {
+	if s.Conditions == nil {
+		return
+	}
+	conditions := s.Conditions
+	meta.RemoveStatusCondition(&conditions, conditionType)
+	s.Conditions = conditions
+}
+
 func (s *TiFlashStatus) GetMemberType() MemberType {
 	return TiFlashMemberType
 }
@@ -369,6 +415,75 @@ func (s *TiCDCStatus) RemoveCondition(conditionType string) {
 	s.Conditions = conditions
 }
 
+func (s *TiProxyStatus) GetMemberType() MemberType {
+	return TiProxyMemberType
+}
+func (s *TiProxyStatus) GetSynced() bool {
+	return s.Synced
+}
+func (s *TiProxyStatus) GetPhase() MemberPhase {
+	return s.Phase
+}
+func (s *TiProxyStatus) GetVolumes() map[StorageVolumeName]*StorageVolumeStatus {
+	return s.Volumes
+}
+func (s *TiProxyStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
+func (s *TiProxyStatus) SetCondition(newCondition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = []metav1.Condition{}
+	}
+	conditions := s.Conditions
+	meta.SetStatusCondition(&conditions, newCondition)
+	s.Conditions = conditions
+}
+func (s *TiProxyStatus) RemoveCondition(conditionType string) {
+	if s.Conditions == nil {
+		return
+	}
+	conditions := s.Conditions
+	meta.RemoveStatusCondition(&conditions, conditionType)
+	s.Conditions = conditions
+}
+
+// PDMSMemberType returns the member type of the PD microservice
+func PDMSMemberType(name string) MemberType {
+	return MemberType(name)
+}
+
+func (s *PDMSStatus) GetMemberType() MemberType {
+	return PDMSMemberType(s.Name)
+}
+func (s *PDMSStatus) GetSynced() bool {
+	return s.Synced
+}
+func (s *PDMSStatus) GetPhase() MemberPhase {
+	return s.Phase
+}
+func (s *PDMSStatus) GetVolumes() map[StorageVolumeName]*StorageVolumeStatus {
+	return s.Volumes
+}
+func (s *PDMSStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
+func (s *PDMSStatus) SetCondition(newCondition metav1.Condition) {
+	if s.Conditions == nil {
+		s.Conditions = []metav1.Condition{}
+	}
+	conditions := s.Conditions
+	meta.SetStatusCondition(&conditions, newCondition)
+	s.Conditions = conditions
+}
+func (s *PDMSStatus) RemoveCondition(conditionType string) {
+	if s.Conditions == nil {
+		return
+	}
+	conditions := s.Conditions
+	meta.RemoveStatusCondition(&conditions, conditionType)
+	s.Conditions = conditions
+}
+
 func (s *MasterStatus) GetMemberType() MemberType {
 	return DMMasterMemberType
 }
@@ -70,6 +70,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DMClusterList{},
 		&TidbNGMonitoring{},
 		&TidbNGMonitoringList{},
+		&TiCDCChangefeed{},
+		&TiCDCChangefeedList{},
+		&TidbClusterReplication{},
+		&TidbClusterReplicationList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -113,6 +113,18 @@ func IsRestoreRunning(restore *Restore) bool {
 	return condition != nil && condition.Status == corev1.ConditionTrue
 }
 
+// IsRestoreVolumeComplete returns true if the TiKV volumes of a volume-snapshot Restore are provisioned
+func IsRestoreVolumeComplete(restore *Restore) bool {
+	_, condition := GetRestoreCondition(&restore.Status, RestoreVolumeComplete)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// IsRestoreDataComplete returns true if the data of a volume-snapshot Restore is restored by BR
+func IsRestoreDataComplete(restore *Restore) bool {
+	_, condition := GetRestoreCondition(&restore.Status, RestoreDataComplete)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
 // IsRestoreFailed returns true if a Restore is Failed
 func IsRestoreFailed(restore *Restore) bool {
 	_, condition := GetRestoreCondition(&restore.Status, RestoreFailed)
@@ -0,0 +1,202 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TiCDCChangefeed is a changefeed replicating the data of a TidbCluster by its TiCDC
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="cf"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.cluster.name`,description="The TidbCluster running the changefeed"
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.changefeedID`,description="The ID of the changefeed in TiCDC"
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="The state of the changefeed"
+// +kubebuilder:printcolumn:name="Checkpoint",type=date,JSONPath=`.status.checkpointTime`,description="The time of the checkpoint of the changefeed"
+// +kubebuilder:printcolumn:name="Lag",type=integer,JSONPath=`.status.lagSeconds`,description="The seconds the checkpoint lags behind"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TiCDCChangefeed struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the changefeed
+	Spec TiCDCChangefeedSpec `json:"spec"`
+
+	// Status is most recently observed status of the changefeed
+	//
+	// +k8s:openapi-gen=false
+	Status TiCDCChangefeedStatus `json:"status,omitempty"`
+}
+
+// TiCDCChangefeedList is TiCDCChangefeed list
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TiCDCChangefeedList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TiCDCChangefeed `json:"items"`
+}
+
+// TiCDCChangefeedSpec is spec of the changefeed
+//
+// +k8s:openapi-gen=true
+type TiCDCChangefeedSpec struct {
+	// Cluster references the TidbCluster whose TiCDC runs the changefeed
+	Cluster TidbClusterRef `json:"cluster"`
+
+	// ChangefeedID is the ID of the changefeed in TiCDC, default to the name of the TiCDCChangefeed.
+	// It can not be changed after the changefeed is created.
+	// +optional
+	ChangefeedID string `json:"changefeedID,omitempty"`
+
+	// SinkURI is the URI of the downstream, such as `mysql://mysql:3306/` or `kafka://kafka:9092/topic`.
+	// The user and password should be provided by SinkSecretRef rather than embedded in the URI.
+	SinkURI string `json:"sinkURI"`
+
+	// SinkSecretRef references the Secret holding the credentials of the downstream,
+	// they are set as the user info of the sink URI. The changes of the Secret are applied
+	// to the changefeed along with the next change of the spec.
+	// +optional
+	SinkSecretRef *TiCDCChangefeedSinkSecretRef `json:"sinkSecretRef,omitempty"`
+
+	// StartTS is the TSO the changefeed starts to replicate from, default to the current TSO.
+	// It only takes effect when the changefeed is created.
+	// +optional
+	StartTS uint64 `json:"startTS,omitempty"`
+
+	// TargetTS is the TSO the changefeed stops to replicate at, 0 means never stop.
+	// +optional
+	TargetTS uint64 `json:"targetTS,omitempty"`
+
+	// Filter is the filter of the tables and the transactions to replicate
+	// +optional
+	Filter *TiCDCChangefeedFilter `json:"filter,omitempty"`
+
+	// Config is the replica config of the changefeed in the format of the TiCDC open API v2,
+	// such as `{"sink": {"protocol": "canal-json"}}`. It is passed to TiCDC as is except that
+	// the filter rules are overridden by Filter.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
+
+	// Paused pauses the changefeed if it is true
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// TiCDCChangefeedSinkSecretRef references the Secret holding the credentials of the downstream
+//
+// +k8s:openapi-gen=true
+type TiCDCChangefeedSinkSecretRef struct {
+	// Name is the name of the Secret in the namespace of the TiCDCChangefeed
+	Name string `json:"name"`
+
+	// UserKey is the key of the user in the Secret, default to `user`
+	// +optional
+	UserKey string `json:"userKey,omitempty"`
+
+	// PasswordKey is the key of the password in the Secret, default to `password`
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// TiCDCChangefeedFilter is the filter of the changefeed
+//
+// +k8s:openapi-gen=true
+type TiCDCChangefeedFilter struct {
+	// Rules are the table filter rules, such as `test.*` or `!test.tmp_*`
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+
+	// IgnoreTxnStartTS are the start TS of the transactions that are not replicated
+	// +optional
+	IgnoreTxnStartTS []uint64 `json:"ignoreTxnStartTS,omitempty"`
+}
+
+// TiCDCChangefeedState is the state of the changefeed reported by TiCDC
+type TiCDCChangefeedState string
+
+const (
+	TiCDCChangefeedStateNormal   TiCDCChangefeedState = "normal"
+	TiCDCChangefeedStateStopped  TiCDCChangefeedState = "stopped"
+	TiCDCChangefeedStateWarning  TiCDCChangefeedState = "warning"
+	TiCDCChangefeedStateError    TiCDCChangefeedState = "error"
+	TiCDCChangefeedStateFailed   TiCDCChangefeedState = "failed"
+	TiCDCChangefeedStateFinished TiCDCChangefeedState = "finished"
+)
+
+const (
+	// TiCDCChangefeedSynced means the spec of the changefeed has been applied to TiCDC
+	TiCDCChangefeedSynced string = "Synced"
+)
+
+// TiCDCChangefeedStatus is status of the changefeed
+type TiCDCChangefeedStatus struct {
+	// ObservedGeneration is the generation of the spec that has been applied to TiCDC
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ObservedSpecHash is the hash of the spec except Paused that has been applied to TiCDC,
+	// the changefeed is not updated if only Paused is changed
+	// +optional
+	ObservedSpecHash string `json:"observedSpecHash,omitempty"`
+
+	// ChangefeedID is the ID of the changefeed created in TiCDC
+	// +optional
+	ChangefeedID string `json:"changefeedID,omitempty"`
+
+	// State is the state of the changefeed
+	// +optional
+	State TiCDCChangefeedState `json:"state,omitempty"`
+
+	// CheckpointTS is the TSO before which all the data has been replicated to the downstream
+	// +optional
+	CheckpointTS uint64 `json:"checkpointTS,omitempty"`
+
+	// CheckpointTime is the physical time of CheckpointTS
+	// +nullable
+	// +optional
+	CheckpointTime *metav1.Time `json:"checkpointTime,omitempty"`
+
+	// LagSeconds is how many seconds the checkpoint lags behind the time it is observed
+	// +optional
+	LagSeconds int64 `json:"lagSeconds,omitempty"`
+
+	// Error is the last error the changefeed encountered
+	// +optional
+	Error *TiCDCChangefeedError `json:"error,omitempty"`
+
+	// Conditions of the changefeed
+	// +optional
+	// +nullable
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// TiCDCChangefeedError is the error reported by TiCDC
+type TiCDCChangefeedError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -38,6 +38,12 @@ const (
 	defaultEnablePVReclaim    = false
 	// defaultEvictLeaderTimeout is the timeout limit of evict leader
 	defaultEvictLeaderTimeout = 1500 * time.Minute
+	// defaultTiCDCGracefulShutdownTimeout is the timeout limit of graceful shutdown of a TiCDC capture
+	defaultTiCDCGracefulShutdownTimeout = 10 * time.Minute
+	// defaultTiDBGracefulShutdownTimeout is the timeout limit of draining the connections of a TiDB pod
+	defaultTiDBGracefulShutdownTimeout = 10 * time.Minute
+	// defaultUpgradeUnhealthyTimeout is the max time an upgraded pod can be unhealthy before rolling back the upgrade
+	defaultUpgradeUnhealthyTimeout = 10 * time.Minute
 )
 
 var (
@@ -92,6 +98,25 @@ func (tc *TidbCluster) PDVersion() string {
 	return "latest"
 }
 
+// PDMSImage return the image used by the PD microservice.
+func (tc *TidbCluster) PDMSImage(spec *PDMSSpec) string {
+	image := spec.Image
+	baseImage := spec.BaseImage
+	// base image takes higher priority
+	if baseImage != "" {
+		version := spec.Version
+		if version == nil {
+			version = &tc.Spec.Version
+		}
+		if *version == "" {
+			image = baseImage
+		} else {
+			image = fmt.Sprintf("%s:%s", baseImage, *version)
+		}
+	}
+	return image
+}
+
 // TiKVImage return the image used by TiKV.
 //
 // If TiKV isn't specified, return empty string.
@@ -152,6 +177,41 @@ func (tc *TidbCluster) TiKVEvictLeaderTimeout() time.Duration {
 	return defaultEvictLeaderTimeout
 }
 
+// TiDBGracefulShutdownEnabled returns whether to drain the client connections before restarting or deleting TiDB
+func (tc *TidbCluster) TiDBGracefulShutdownEnabled() bool {
+	return tc.Spec.TiDB != nil && tc.Spec.TiDB.GracefulShutdown != nil
+}
+
+// TiDBGracefulShutdownMaxConnections returns the threshold of the active connections to restart or delete TiDB
+func (tc *TidbCluster) TiDBGracefulShutdownMaxConnections() int32 {
+	if tc.TiDBGracefulShutdownEnabled() && tc.Spec.TiDB.GracefulShutdown.MaxConnections != nil {
+		return *tc.Spec.TiDB.GracefulShutdown.MaxConnections
+	}
+	return 0
+}
+
+// TiDBGracefulShutdownTimeout returns the timeout of draining the client connections of TiDB
+func (tc *TidbCluster) TiDBGracefulShutdownTimeout() time.Duration {
+	if tc.TiDBGracefulShutdownEnabled() && tc.Spec.TiDB.GracefulShutdown.Timeout != nil {
+		d, err := time.ParseDuration(*tc.Spec.TiDB.GracefulShutdown.Timeout)
+		if err == nil {
+			return d
+		}
+	}
+	return defaultTiDBGracefulShutdownTimeout
+}
+
+// TiCDCGracefulShutdownTimeout returns the timeout of graceful shutdown of a TiCDC capture
+func (tc *TidbCluster) TiCDCGracefulShutdownTimeout() time.Duration {
+	if tc.Spec.TiCDC != nil && tc.Spec.TiCDC.GracefulShutdownTimeout != nil {
+		d, err := time.ParseDuration(*tc.Spec.TiCDC.GracefulShutdownTimeout)
+		if err == nil {
+			return d
+		}
+	}
+	return defaultTiCDCGracefulShutdownTimeout
+}
+
 // TiFlashImage return the image used by TiFlash.
 //
 // If TiFlash isn't specified, return empty string.
@@ -219,6 +279,64 @@ func (tc *TidbCluster) TiCDCImage() string {
 	return image
 }
 
+// TiCDCVersion returns the image version used by TiCDC.
+//
+// If TiCDC isn't specified, return empty string.
+func (tc *TidbCluster) TiCDCVersion() string {
+	if tc.Spec.TiCDC == nil {
+		return ""
+	}
+
+	image := tc.TiCDCImage()
+	colonIdx := strings.LastIndexByte(image, ':')
+	if colonIdx >= 0 {
+		return image[colonIdx+1:]
+	}
+
+	return "latest"
+}
+
+// TiProxyImage return the image used by TiProxy.
+//
+// TiProxy is released independently of the other components, so the
+// version of the cluster is not used as the image tag.
+// If TiProxy isn't specified, return empty string.
+func (tc *TidbCluster) TiProxyImage() string {
+	if tc.Spec.TiProxy == nil {
+		return ""
+	}
+
+	image := tc.Spec.TiProxy.Image
+	baseImage := tc.Spec.TiProxy.BaseImage
+	// base image takes higher priority
+	if baseImage != "" {
+		version := tc.Spec.TiProxy.Version
+		if version == nil || *version == "" {
+			image = baseImage
+		} else {
+			image = fmt.Sprintf("%s:%s", baseImage, *version)
+		}
+	}
+	return image
+}
+
+// TiProxyVersion returns the image version used by TiProxy.
+//
+// If TiProxy isn't specified, return empty string.
+func (tc *TidbCluster) TiProxyVersion() string {
+	if tc.Spec.TiProxy == nil {
+		return ""
+	}
+
+	image := tc.TiProxyImage()
+	colonIdx := strings.LastIndexByte(image, ':')
+	if colonIdx >= 0 {
+		return image[colonIdx+1:]
+	}
+
+	return "latest"
+}
+
 func (tc *TidbCluster) TiFlashContainerPrivilege() *bool {
 	if tc.Spec.TiFlash == nil || tc.Spec.TiFlash.Privileged == nil {
 		pri := false
@@ -252,6 +370,23 @@ func (tc *TidbCluster) TiDBImage() string {
 	return image
 }
 
+// TiDBVersion return the image version used by TiDB.
+//
+// If TiDB isn't specified, return empty string.
+func (tc *TidbCluster) TiDBVersion() string {
+	if tc.Spec.TiDB == nil {
+		return ""
+	}
+
+	image := tc.TiDBImage()
+	colonIdx := strings.LastIndexByte(image, ':')
+	if colonIdx >= 0 {
+		return image[colonIdx+1:]
+	}
+
+	return "latest"
+}
+
 // PumpImage return the image used by Pump.
 //
 // If Pump isn't specified, return nil.
@@ -277,6 +412,31 @@ func (tc *TidbCluster) PumpImage() *string {
 	return &image
 }
 
+// DrainerImage return the image used by Drainer.
+//
+// If Drainer isn't specified, return nil.
+func (tc *TidbCluster) DrainerImage() *string {
+	if tc.Spec.Drainer == nil {
+		return nil
+	}
+
+	image := tc.Spec.Drainer.Image
+	baseImage := tc.Spec.Drainer.BaseImage
+	// base image takes higher priority
+	if baseImage != "" {
+		version := tc.Spec.Drainer.Version
+		if version == nil {
+			version = &tc.Spec.Version
+		}
+		if *version == "" {
+			image = baseImage
+		} else {
+			image = fmt.Sprintf("%s:%s", baseImage, *version)
+		}
+	}
+	return &image
+}
+
 func (tc *TidbCluster) HelperImage() string {
 	image := tc.GetHelperSpec().Image
 	if image == nil && tc.Spec.TiDB != nil {
@@ -320,6 +480,21 @@ func (tc *TidbCluster) PDScaling() bool {
 	return tc.Status.PD.Phase == ScalePhase
 }
 
+// PDMSEnabled returns whether PD runs in the microservice mode
+func (tc *TidbCluster) PDMSEnabled() bool {
+	return tc.Spec.PD != nil && tc.Spec.PD.Mode == PDModeMS
+}
+
+// PDMSUpgrading returns whether any of the PD microservices is upgrading
+func (tc *TidbCluster) PDMSUpgrading() bool {
+	for _, status := range tc.Status.PDMS {
+		if status != nil && status.Phase == UpgradePhase {
+			return true
+		}
+	}
+	return false
+}
+
 func (tc *TidbCluster) TiKVUpgrading() bool {
 	return tc.Status.TiKV.Phase == UpgradePhase
 }
@@ -553,6 +728,27 @@ func (tc *TidbCluster) TiFlashStsDesiredReplicas() int32 {
 	return tc.Spec.TiFlash.Replicas + int32(len(tc.Status.TiFlash.FailureStores))
 }
 
+// TiCDCAllCapturesReady return whether all captures of TiCDC are ready.
+//
+// If TiCDC isn't specified, return false.
+func (tc *TidbCluster) TiCDCAllCapturesReady() bool {
+	if tc.Spec.TiCDC == nil {
+		return false
+	}
+
+	if int(tc.TiCDCDeployDesiredReplicas()) != len(tc.Status.TiCDC.Captures) {
+		return false
+	}
+
+	for _, capture := range tc.Status.TiCDC.Captures {
+		if !capture.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (tc *TidbCluster) TiCDCDeployDesiredReplicas() int32 {
 	if tc.Spec.TiCDC == nil {
 		return 0
@@ -561,6 +757,35 @@ func (tc *TidbCluster) TiCDCDeployDesiredReplicas() int32 {
 	return tc.Spec.TiCDC.Replicas
 }
 
+// TiProxyAllMembersReady return whether all members of TiProxy are healthy.
+//
+// If TiProxy isn't specified, return false.
+func (tc *TidbCluster) TiProxyAllMembersReady() bool {
+	if tc.Spec.TiProxy == nil {
+		return false
+	}
+
+	if int(tc.TiProxyDeployDesiredReplicas()) != len(tc.Status.TiProxy.Members) {
+		return false
+	}
+
+	for _, member := range tc.Status.TiProxy.Members {
+		if !member.Health {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (tc *TidbCluster) TiProxyDeployDesiredReplicas() int32 {
+	if tc.Spec.TiProxy == nil {
+		return 0
+	}
+
+	return tc.Spec.TiProxy.Replicas
+}
+
 func (tc *TidbCluster) TiFlashStsActualReplicas() int32 {
 	stsStatus := tc.Status.TiFlash.StatefulSet
 	if stsStatus == nil {
@@ -801,6 +1026,15 @@ func (tidb *TiDBSpec) GetServicePort() int32 {
 	return port
 }
 
+// GetServicePort returns the port of the TiProxy service
+func (tiproxy *TiProxySpec) GetServicePort() int32 {
+	port := DefaultTiProxyServicePort
+	if tiproxy.Service != nil && tiproxy.Service.Port != nil {
+		port = *tiproxy.Service.Port
+	}
+	return port
+}
+
 func (tikv *TiKVSpec) ShouldSeparateRocksDBLog() bool {
 	separateRocksDBLog := tikv.SeparateRocksDBLog
 	if separateRocksDBLog == nil {
@@ -966,6 +1200,22 @@ func (tc *TidbCluster) AcrossK8s() bool {
 	return tc.Spec.AcrossK8s
 }
 
+// UpgradeUnhealthyTimeout returns the max time an upgraded pod can be unhealthy before rolling back the upgrade
+func (s *UpgradeStrategy) UpgradeUnhealthyTimeout() time.Duration {
+	if s != nil && s.AutoRollback != nil && s.AutoRollback.UnhealthyTimeout != nil {
+		d, err := time.ParseDuration(*s.AutoRollback.UnhealthyTimeout)
+		if err == nil {
+			return d
+		}
+	}
+	return defaultUpgradeUnhealthyTimeout
+}
+
+// AutoRollbackEnabled returns whether to roll back the upgrade on health regression
+func (s *UpgradeStrategy) AutoRollbackEnabled() bool {
+	return s != nil && s.AutoRollback != nil
+}
+
 // IsComponentVolumeResizing returns true if any volume of component is resizing.
 func (tc *TidbCluster) IsComponentVolumeResizing(compType MemberType) bool {
 	comps := ComponentStatusFromTC(tc)
@@ -58,11 +58,15 @@ type Component int
 
 const (
 	ComponentPD Component = iota
+	ComponentPDMSTSO
+	ComponentPDMSScheduling
 	ComponentTiDB
 	ComponentTiKV
 	ComponentTiFlash
 	ComponentTiCDC
+	ComponentTiProxy
 	ComponentPump
+	ComponentDrainer
 	ComponentDiscovery
 	ComponentDMDiscovery
 	ComponentDMMaster
@@ -370,6 +374,10 @@ func getComponentLabelValue(c Component) string {
 	switch c {
 	case ComponentPD:
 		return label.PDLabelVal
+	case ComponentPDMSTSO:
+		return label.PDMSTSOLabelVal
+	case ComponentPDMSScheduling:
+		return label.PDMSSchedulingLabelVal
 	case ComponentTiDB:
 		return label.TiDBLabelVal
 	case ComponentTiKV:
@@ -378,8 +386,12 @@ func getComponentLabelValue(c Component) string {
 		return label.TiFlashLabelVal
 	case ComponentTiCDC:
 		return label.TiCDCLabelVal
+	case ComponentTiProxy:
+		return label.TiProxyLabelVal
 	case ComponentPump:
 		return label.PumpLabelVal
+	case ComponentDrainer:
+		return label.DrainerLabelVal
 	case ComponentDiscovery:
 		return label.DiscoveryLabelVal
 	case ComponentDMDiscovery:
@@ -493,6 +505,16 @@ func (tc *TidbCluster) BaseTiCDCSpec() ComponentAccessor {
 	return buildTidbClusterComponentAccessor(ComponentTiCDC, tc, spec)
 }
 
+// BaseTiProxySpec returns the base spec of TiProxy servers
+func (tc *TidbCluster) BaseTiProxySpec() ComponentAccessor {
+	var spec *ComponentSpec
+	if tc.Spec.TiProxy != nil {
+		spec = &tc.Spec.TiProxy.ComponentSpec
+	}
+
+	return buildTidbClusterComponentAccessor(ComponentTiProxy, tc, spec)
+}
+
 // BasePDSpec returns the base spec of PD servers
 func (tc *TidbCluster) BasePDSpec() ComponentAccessor {
 	var spec *ComponentSpec
@@ -503,6 +525,15 @@ func (tc *TidbCluster) BasePDSpec() ComponentAccessor {
 	return buildTidbClusterComponentAccessor(ComponentPD, tc, spec)
 }
 
+// BasePDMSSpec returns the base spec of the PD microservice
+func (tc *TidbCluster) BasePDMSSpec(spec *PDMSSpec) ComponentAccessor {
+	component := ComponentPDMSTSO
+	if spec.Name == PDMSScheduling {
+		component = ComponentPDMSScheduling
+	}
+	return buildTidbClusterComponentAccessor(component, tc, &spec.ComponentSpec)
+}
+
 // BasePumpSpec returns the base spec of Pump:
 func (tc *TidbCluster) BasePumpSpec() ComponentAccessor {
 	var spec *ComponentSpec
@@ -513,6 +544,16 @@ func (tc *TidbCluster) BasePumpSpec() ComponentAccessor {
 	return buildTidbClusterComponentAccessor(ComponentPump, tc, spec)
 }
 
+// BaseDrainerSpec returns the base spec of Drainer:
+func (tc *TidbCluster) BaseDrainerSpec() ComponentAccessor {
+	var spec *ComponentSpec
+	if tc.Spec.Drainer != nil {
+		spec = &tc.Spec.Drainer.ComponentSpec
+	}
+
+	return buildTidbClusterComponentAccessor(ComponentDrainer, tc, spec)
+}
+
 func (dc *DMCluster) BaseDiscoverySpec() ComponentAccessor {
 	return buildDMClusterComponentAccessor(ComponentDMDiscovery, dc, dc.Spec.Discovery.ComponentSpec)
 }
@@ -68,6 +68,14 @@ type TidbClusterAutoScalerSpec struct {
 	// TiDB represents the auto-scaling spec for tidb
 	// +optional
 	TiDB *TidbAutoScalerSpec `json:"tidb,omitempty"`
+
+	// TiFlash represents the auto-scaling spec for tiflash
+	// +optional
+	TiFlash *TiflashAutoScalerSpec `json:"tiflash,omitempty"`
+
+	// TiCDC represents the auto-scaling spec for ticdc
+	// +optional
+	TiCDC *TicdcAutoScalerSpec `json:"ticdc,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -106,6 +114,28 @@ type TidbAutoScalerSpec struct {
 	BasicAutoScalerSpec `json:",inline"`
 }
 
+// +k8s:openapi-gen=true
+// TiflashAutoScalerSpec describes the spec for tiflash auto-scaling.
+// Only External and Metrics are supported for tiflash, and the auto-scaling never scales in
+// tiflash below the largest replica count of the tables.
+type TiflashAutoScalerSpec struct {
+	BasicAutoScalerSpec `json:",inline"`
+}
+
+// +k8s:openapi-gen=true
+// TicdcAutoScalerSpec describes the spec for ticdc auto-scaling.
+// Only External and Metrics are supported for ticdc.
+type TicdcAutoScalerSpec struct {
+	BasicAutoScalerSpec `json:",inline"`
+
+	// TargetChangefeedLagSeconds is the desired checkpoint lag of the changefeeds.
+	// If set, the largest checkpoint lag of the changefeeds is queried from the Prometheus of
+	// Metrics, and the captures are scaled in proportion to the ratio of the lag to it.
+	// The queries of Metrics are optional if this is set.
+	// +optional
+	TargetChangefeedLagSeconds *int32 `json:"targetChangefeedLagSeconds,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 // BasicAutoScalerSpec describes the basic spec for auto-scaling
 type BasicAutoScalerSpec struct {
@@ -123,27 +153,197 @@ type BasicAutoScalerSpec struct {
 	ScaleOutIntervalSeconds *int32 `json:"scaleOutIntervalSeconds,omitempty"`
 
 	// External makes the auto-scaler controller able to query the external service
-	// to fetch the recommended replicas for TiKV/TiDB
+	// to fetch the recommended replicas for TiKV/TiDB/TiFlash/TiCDC
 	// +optional
 	External *ExternalConfig `json:"external,omitempty"`
 
+	// Metrics makes the auto-scaler controller evaluate PromQL queries against Prometheus
+	// to calculate the recommended replicas for TiKV/TiDB/TiFlash/TiCDC, without the auto-scaling plans of PD
+	// +optional
+	Metrics *MetricsConfig `json:"metrics,omitempty"`
+
+	// Vertical makes the auto-scaler controller recommend the resource requests and limits of the
+	// component in the target TidbCluster from the historical usage in Prometheus.
+	// It works together with the horizontal auto-scaling.
+	// +optional
+	Vertical *VerticalAutoScalerConfig `json:"vertical,omitempty"`
+
 	// Resources represent the resource type definitions that can be used for TiDB/TiKV
 	// The key is resource_type name of the resource
 	// +optional
 	Resources map[string]AutoResource `json:"resources,omitempty"`
+
+	// Schedules bound the replicas recommended by the rules or the external service within
+	// time windows defined by cron expressions. If several schedules are active at the same
+	// time, the first one in the list is applied.
+	// +optional
+	Schedules []AutoScalerSchedule `json:"schedules,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// AutoScalerSchedule describes a time window during which the auto-scaled replicas are bounded.
+// The replicas are the total replicas of the auto-scaled TidbClusters of the component,
+// setting MinReplicas and MaxReplicas to the same value pins the replicas during the window.
+// With the auto-scaling plans of PD, a group of the schedule is created to reach MinReplicas if PD recommends no group.
+type AutoScalerSchedule struct {
+	// Name is the name of the schedule
+	Name string `json:"name"`
+
+	// Schedule is the cron expression at which the window starts, in the format of
+	// https://en.wikipedia.org/wiki/Cron
+	Schedule string `json:"schedule"`
+
+	// DurationSeconds is how long the window lasts after it starts, at most 7 days
+	DurationSeconds int32 `json:"durationSeconds"`
+
+	// TimeZone is the IANA time zone the cron expression is evaluated in, such as `Asia/Shanghai`.
+	// If not set, the time zone of the tidb-operator is used.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// MinReplicas is the floor of the auto-scaled replicas during the window
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the ceiling of the auto-scaled replicas during the window
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
 }
 
 // +k8s:openapi-gen=true
 // ExternalConfig represents the external config.
 type ExternalConfig struct {
 	// ExternalEndpoint makes the auto-scaler controller able to query the
-	// external service to fetch the recommended replicas for TiKV/TiDB
+	// external service to fetch the recommended replicas for TiKV/TiDB/TiFlash/TiCDC
 	// +optional
 	Endpoint ExternalEndpoint `json:"endpoint"`
 	// maxReplicas is the upper limit for the number of replicas to which the autoscaler can scale out.
 	MaxReplicas int32 `json:"maxReplicas"`
 }
 
+// +k8s:openapi-gen=true
+// MetricsConfig represents the config to recommend the replicas from the metrics in Prometheus.
+// The replicas are recommended the same way as the HorizontalPodAutoscaler does, and are applied
+// to the same auto-scaled TidbCluster as ExternalConfig.
+type MetricsConfig struct {
+	// Prometheus is the Prometheus the queries are evaluated against
+	Prometheus PrometheusSource `json:"prometheus"`
+
+	// Queries are the PromQL queries to evaluate, the largest replicas recommended by them is applied
+	Queries []MetricsQuery `json:"queries"`
+
+	// MaxReplicas is the upper limit for the number of replicas to which the autoscaler can scale out.
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Tolerance is the ratio the current value may deviate from the target value without scaling.
+	// If not set, the default Tolerance will be set to 0.1
+	// +optional
+	Tolerance *float64 `json:"tolerance,omitempty"`
+
+	// ScaleOutStabilizationWindowSeconds is the duration seconds of the past recommendations considered
+	// when scaling out, the lowest one is applied.
+	// If not set, the default ScaleOutStabilizationWindowSeconds will be set to 0
+	// +optional
+	ScaleOutStabilizationWindowSeconds *int32 `json:"scaleOutStabilizationWindowSeconds,omitempty"`
+
+	// ScaleInStabilizationWindowSeconds is the duration seconds of the past recommendations considered
+	// when scaling in, the highest one is applied.
+	// If not set, the default ScaleInStabilizationWindowSeconds will be set to 300
+	// +optional
+	ScaleInStabilizationWindowSeconds *int32 `json:"scaleInStabilizationWindowSeconds,omitempty"`
+}
+
+// VerticalScalingMode is the mode of the vertical auto-scaling
+type VerticalScalingMode string
+
+const (
+	// VerticalScalingModeRecommend only publishes the recommendation in the status
+	VerticalScalingModeRecommend VerticalScalingMode = "Recommend"
+	// VerticalScalingModeApply applies the recommendation to the target TidbCluster,
+	// the pods are rolling restarted by the upgrader of the component
+	VerticalScalingModeApply VerticalScalingMode = "Apply"
+)
+
+// +k8s:openapi-gen=true
+// VerticalAutoScalerConfig represents the config to recommend the resource requests and limits from the usage in Prometheus.
+// The CPU and memory requests are recommended from the percentile of the CPU usage and the peak of the memory
+// working set of the containers, the limits are scaled in proportion to the requests.
+type VerticalAutoScalerConfig struct {
+	// Prometheus is the Prometheus with the container metrics of cAdvisor
+	Prometheus PrometheusSource `json:"prometheus"`
+
+	// Mode is either `Recommend` or `Apply`.
+	// If not set, the default Mode will be set to `Recommend`
+	// +kubebuilder:validation:Enum=Recommend;Apply
+	// +optional
+	Mode VerticalScalingMode `json:"mode,omitempty"`
+
+	// HistoryWindowSeconds is the duration seconds of the historical usage observed.
+	// If not set, the default HistoryWindowSeconds will be set to 86400
+	// +optional
+	HistoryWindowSeconds *int32 `json:"historyWindowSeconds,omitempty"`
+
+	// CPUPercentile is the percentile of the CPU usage the CPU request is recommended from.
+	// If not set, the default CPUPercentile will be set to 0.9
+	// +optional
+	CPUPercentile *float64 `json:"cpuPercentile,omitempty"`
+
+	// Margin is the ratio added to the usage for the recommended requests.
+	// If not set, the default Margin will be set to 0.15
+	// +optional
+	Margin *float64 `json:"margin,omitempty"`
+
+	// MinAllowed is the lower bound of the recommended requests
+	// +optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// MaxAllowed is the upper bound of the recommended requests
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+
+	// MaxStepRatio is the largest ratio of the current requests the recommended requests differ by in one step.
+	// If not set, the default MaxStepRatio will be set to 0.5
+	// +optional
+	MaxStepRatio *float64 `json:"maxStepRatio,omitempty"`
+
+	// Tolerance is the ratio the recommended requests may deviate from the current requests without being applied.
+	// If not set, the default Tolerance will be set to 0.1
+	// +optional
+	Tolerance *float64 `json:"tolerance,omitempty"`
+
+	// UpdateIntervalSeconds represents the duration seconds between each applied recommendation.
+	// If not set, the default UpdateIntervalSeconds will be set to 3600
+	// +optional
+	UpdateIntervalSeconds *int32 `json:"updateIntervalSeconds,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// PrometheusSource describes the Prometheus to query, either Monitor or URL should be set
+type PrometheusSource struct {
+	// Monitor references the TidbMonitor whose Prometheus is queried
+	// +optional
+	Monitor *TidbMonitorRef `json:"monitor,omitempty"`
+
+	// URL is the address of the Prometheus, such as `http://prometheus:9090`
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// MetricsQuery describes a PromQL query and its target value
+type MetricsQuery struct {
+	// Name is the name of the query
+	Name string `json:"name"`
+
+	// Query is the PromQL query returning the total value of the component, such as
+	// `sum(rate(tidb_server_query_total{tidb_cluster="basic"}[1m]))`.
+	// The values of all the series in the result are summed.
+	Query string `json:"query"`
+
+	// TargetAverageValue is the desired value of the query per replica
+	TargetAverageValue float64 `json:"targetAverageValue"`
+}
+
 // +k8s:openapi-gen=true
 // TidbMonitorRef reference to a TidbMonitor
 type TidbMonitorRef struct {
@@ -169,6 +369,28 @@ type TidbClusterAutoScalerStatus struct {
 	// Tidb describes the status of each group for the tidb in the last auto-scaling reconciliation
 	// +optional
 	TiDB map[string]TidbAutoScalerStatus `json:"tidb,omitempty"`
+	// TiFlash describes the status of each group for the tiflash in the last auto-scaling reconciliation
+	// +optional
+	TiFlash map[string]TiflashAutoScalerStatus `json:"tiflash,omitempty"`
+	// TiCDC describes the status of each group for the ticdc in the last auto-scaling reconciliation
+	// +optional
+	TiCDC map[string]TicdcAutoScalerStatus `json:"ticdc,omitempty"`
+	// Components describes the status of each component(tidb/tikv/tiflash/ticdc) that is not specific
+	// to a group in the last auto-scaling reconciliation, the key is the component
+	// +optional
+	Components map[string]ComponentAutoScalerStatus `json:"components,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// ComponentAutoScalerStatus describe the auto-scaling status of a component that is not specific to a group
+type ComponentAutoScalerStatus struct {
+	// VerticalRecommendation describes the resources recommended by the vertical auto-scaling
+	// +optional
+	VerticalRecommendation *VerticalRecommendation `json:"verticalRecommendation,omitempty"`
+
+	// LastVerticalScalingTimestamp describes the last time the recommended resources are applied
+	// +optional
+	LastVerticalScalingTimestamp *metav1.Time `json:"lastVerticalScalingTimestamp,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -183,12 +405,61 @@ type TikvAutoScalerStatus struct {
 	BasicAutoScalerStatus `json:",inline"`
 }
 
+// +k8s:openapi-gen=true
+// TiflashAutoScalerStatus describe the auto-scaling status of tiflash
+type TiflashAutoScalerStatus struct {
+	BasicAutoScalerStatus `json:",inline"`
+}
+
+// +k8s:openapi-gen=true
+// TicdcAutoScalerStatus describe the auto-scaling status of ticdc
+type TicdcAutoScalerStatus struct {
+	BasicAutoScalerStatus `json:",inline"`
+}
+
 // +k8s:openapi-gen=true
 // BasicAutoScalerStatus describe the basic auto-scaling status
 type BasicAutoScalerStatus struct {
-	// LastAutoScalingTimestamp describes the last auto-scaling timestamp for the component(tidb/tikv)
+	// LastAutoScalingTimestamp describes the last auto-scaling timestamp for the component(tidb/tikv/tiflash/ticdc)
 	// +optional
 	LastAutoScalingTimestamp *metav1.Time `json:"lastAutoScalingTimestamp,omitempty"`
+
+	// ActiveSchedule describes the schedule window bounding the replicas in the last auto-scaling reconciliation
+	// +optional
+	ActiveSchedule *AutoScalerScheduleWindow `json:"activeSchedule,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// VerticalRecommendation describes the resource requests and limits recommended at a time
+type VerticalRecommendation struct {
+	// Requests is the recommended requests
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// Limits is the recommended limits, only the limits set in the target TidbCluster are recommended
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+	// Timestamp is when the resources are recommended
+	Timestamp metav1.Time `json:"timestamp"`
+	// Applied indicates whether the target TidbCluster has the recommended requests, within the tolerance
+	// +optional
+	Applied bool `json:"applied,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// AutoScalerScheduleWindow describes an active window of an AutoScalerSchedule
+type AutoScalerScheduleWindow struct {
+	// Name is the name of the schedule
+	Name string `json:"name"`
+	// StartTime is when the window starts
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is when the window ends
+	EndTime metav1.Time `json:"endTime"`
+	// MinReplicas is the floor of the auto-scaled replicas during the window
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the ceiling of the auto-scaled replicas during the window
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
 }
 
 // +k8s:openapi-gen=true
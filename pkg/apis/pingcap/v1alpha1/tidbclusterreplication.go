@@ -0,0 +1,36 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "fmt"
+
+// Key returns the `namespace/name` of the TidbCluster
+func (m *TidbClusterReplicationMember) Key() string {
+	return fmt.Sprintf("%s/%s", m.Namespace, m.Name)
+}
+
+// GetBootstrapName returns the name of the Backup and the Restore bootstrapping the secondary
+func (tcr *TidbClusterReplication) GetBootstrapName() string {
+	return fmt.Sprintf("%s-bootstrap", tcr.Name)
+}
+
+// GetChangefeedName returns the name of the TiCDCChangefeed replicating the data from the primary
+func (tcr *TidbClusterReplication) GetChangefeedName(primary *TidbClusterReplicationMember) string {
+	return fmt.Sprintf("%s-from-%s", tcr.Name, primary.Name)
+}
+
+// IsSwitchingOver returns whether the direction of the replication is being reversed
+func (tcr *TidbClusterReplication) IsSwitchingOver() bool {
+	return tcr.Status.SwitchoverStep != ""
+}
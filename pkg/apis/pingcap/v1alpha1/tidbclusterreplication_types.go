@@ -0,0 +1,204 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TidbClusterReplication pairs two TidbClusters for disaster recovery, the data of the
+// primary is replicated to the secondary by a TiCDC changefeed
+//
+// +genclient
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="tcr"
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Primary",type=string,JSONPath=`.status.primary`,description="The TidbCluster the data is replicated from"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="The phase of the replication"
+// +kubebuilder:printcolumn:name="RPO",type=integer,JSONPath=`.status.rpoSeconds`,description="The seconds of the data that may be lost if the primary fails"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type TidbClusterReplication struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec contains all spec about the replication
+	Spec TidbClusterReplicationSpec `json:"spec"`
+
+	// Status is most recently observed status of the replication
+	//
+	// +k8s:openapi-gen=false
+	Status TidbClusterReplicationStatus `json:"status,omitempty"`
+}
+
+// TidbClusterReplicationList is TidbClusterReplication list
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TidbClusterReplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +k8s:openapi-gen=false
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TidbClusterReplication `json:"items"`
+}
+
+// TidbClusterReplicationSpec is spec of the replication.
+// The Backup, Restore and TiCDCChangefeed of the replication are created in the namespace of the
+// TidbClusterReplication, so the Secrets they reference should be in the same namespace.
+// Both TidbClusters must be in the Kubernetes cluster of the TidbClusterReplication.
+//
+// +k8s:openapi-gen=true
+type TidbClusterReplicationSpec struct {
+	// Primary is the TidbCluster serving the writes, its data is replicated to Secondary.
+	// Swap Primary and Secondary to switch over, the writes to the old primary are stopped
+	// and the direction of the replication is reversed after the secondary catches up.
+	// The secondary is kept in `tidb_restricted_read_only`, only the changefeed writes to it.
+	Primary TidbClusterReplicationMember `json:"primary"`
+
+	// Secondary is the TidbCluster the data is replicated to
+	Secondary TidbClusterReplicationMember `json:"secondary"`
+
+	// Bootstrap bootstraps the secondary from a BR backup of the primary before the replication starts.
+	// The replication starts from the current TSO without bootstrapping if it is not set.
+	// +optional
+	Bootstrap *TidbClusterReplicationBootstrap `json:"bootstrap,omitempty"`
+
+	// Filter is the filter of the tables and the transactions to replicate
+	// +optional
+	Filter *TiCDCChangefeedFilter `json:"filter,omitempty"`
+
+	// Config is the replica config of the changefeed, see TiCDCChangefeedSpec.Config
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
+}
+
+// TidbClusterReplicationMember is a TidbCluster of the replication
+//
+// +k8s:openapi-gen=true
+type TidbClusterReplicationMember struct {
+	TidbClusterRef `json:",inline"`
+
+	// SecretRef references the Secret holding the user and password of the TidbCluster, they are
+	// used by the changefeed to write to the TidbCluster and by the switchover to stop the writes.
+	// The user is granted `RESTRICTED_REPLICA_WRITER_ADMIN` to write to the TidbCluster while it
+	// is the secondary, so it must be able to grant the privilege and set the global variables.
+	SecretRef TiCDCChangefeedSinkSecretRef `json:"secretRef"`
+}
+
+// TidbClusterReplicationBootstrap is how the secondary is bootstrapped
+//
+// +k8s:openapi-gen=true
+type TidbClusterReplicationBootstrap struct {
+	// StorageProvider is where the backup of the primary is stored and restored from
+	StorageProvider `json:",inline"`
+
+	// BR is the configuration of BR for both backup and restore, the cluster is overridden
+	// +optional
+	BR *BRConfig `json:"br,omitempty"`
+
+	// ToolImage specifies the tool image used by the backup and the restore
+	// +optional
+	ToolImage string `json:"toolImage,omitempty"`
+
+	// ServiceAccount specifies the service account of the backup and the restore
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// TidbClusterReplicationPhase is the phase of the replication
+type TidbClusterReplicationPhase string
+
+const (
+	// TidbClusterReplicationBootstrapping means the secondary is being bootstrapped
+	TidbClusterReplicationBootstrapping TidbClusterReplicationPhase = "Bootstrapping"
+	// TidbClusterReplicationReplicating means the data of the primary is being replicated
+	TidbClusterReplicationReplicating TidbClusterReplicationPhase = "Replicating"
+	// TidbClusterReplicationSwitchingOver means the direction of the replication is being reversed
+	TidbClusterReplicationSwitchingOver TidbClusterReplicationPhase = "SwitchingOver"
+)
+
+// TidbClusterReplicationSwitchoverStep is the step of the switchover
+type TidbClusterReplicationSwitchoverStep string
+
+const (
+	// SwitchoverStepStopWrites stops the writes to the old primary
+	SwitchoverStepStopWrites TidbClusterReplicationSwitchoverStep = "StopWrites"
+	// SwitchoverStepCatchUp waits for the checkpoint of the changefeed to catch up with the stopped writes
+	SwitchoverStepCatchUp TidbClusterReplicationSwitchoverStep = "CatchUp"
+	// SwitchoverStepReverse removes the changefeed, resumes the writes to the new primary and creates
+	// the changefeed in the reversed direction
+	SwitchoverStepReverse TidbClusterReplicationSwitchoverStep = "Reverse"
+)
+
+const (
+	// TidbClusterReplicationSynced means the last reconciliation of the replication succeeded
+	TidbClusterReplicationSynced string = "Synced"
+)
+
+// TidbClusterReplicationStatus is status of the replication
+type TidbClusterReplicationStatus struct {
+	// Phase is the phase of the replication
+	// +optional
+	Phase TidbClusterReplicationPhase `json:"phase,omitempty"`
+
+	// Primary is the `namespace/name` of the TidbCluster the data is currently replicated from
+	// +optional
+	Primary string `json:"primary,omitempty"`
+
+	// BackupName is the name of the Backup bootstrapping the secondary
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// RestoreName is the name of the Restore bootstrapping the secondary
+	// +optional
+	RestoreName string `json:"restoreName,omitempty"`
+
+	// StartTS is the TSO the changefeed starts from, it is the commit TS of the backup
+	// or the TSO of the new primary before its writes are resumed by the switchover
+	// +optional
+	StartTS uint64 `json:"startTS,omitempty"`
+
+	// StoppedTS is the TSO of the old primary the writes are stopped at during the switchover,
+	// the changefeed is removed after its checkpoint catches up with it
+	// +optional
+	StoppedTS uint64 `json:"stoppedTS,omitempty"`
+
+	// ChangefeedName is the name of the TiCDCChangefeed replicating the data
+	// +optional
+	ChangefeedName string `json:"changefeedName,omitempty"`
+
+	// CheckpointTS is the checkpoint of the changefeed
+	// +optional
+	CheckpointTS uint64 `json:"checkpointTS,omitempty"`
+
+	// RPOSeconds is how many seconds of the data may be lost if the primary fails
+	// +optional
+	RPOSeconds int64 `json:"rpoSeconds,omitempty"`
+
+	// SwitchoverStep is the ongoing step of the switchover
+	// +optional
+	SwitchoverStep TidbClusterReplicationSwitchoverStep `json:"switchoverStep,omitempty"`
+
+	// Conditions of the replication
+	// +optional
+	// +nullable
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
 )
@@ -45,6 +46,11 @@ const (
 	PumpStateOnline string = "online"
 	// PumpStateOffline represents status of offline of Pump
 	PumpStateOffline string = "offline"
+
+	// DrainerStateOnline represents status of online of Drainer
+	DrainerStateOnline string = "online"
+	// DrainerStateOffline represents status of offline of Drainer
+	DrainerStateOffline string = "offline"
 )
 
 // MemberType represents member type
@@ -61,8 +67,17 @@ const (
 	TiFlashMemberType MemberType = "tiflash"
 	// TiCDCMemberType is ticdc container type
 	TiCDCMemberType MemberType = "ticdc"
+
+	// PDMSTSOMemberType is the container type of the pd tso microservice
+	PDMSTSOMemberType MemberType = "tso"
+	// PDMSSchedulingMemberType is the container type of the pd scheduling microservice
+	PDMSSchedulingMemberType MemberType = "scheduling"
+	// TiProxyMemberType is tiproxy container type
+	TiProxyMemberType MemberType = "tiproxy"
 	// PumpMemberType is pump container type
 	PumpMemberType MemberType = "pump"
+	// DrainerMemberType is drainer container type
+	DrainerMemberType MemberType = "drainer"
 	// DMMasterMemberType is dm-master container type
 	DMMasterMemberType MemberType = "dm-master"
 	// DMWorkerMemberType is dm-worker container type
@@ -164,6 +179,10 @@ type TidbClusterSpec struct {
 	// +optional
 	PD *PDSpec `json:"pd,omitempty"`
 
+	// PDMS is the spec of the PD microservices, it takes effect only when `.spec.pd.mode` is "ms"
+	// +optional
+	PDMS []*PDMSSpec `json:"pdms,omitempty"`
+
 	// TiDB cluster spec
 	// +optional
 	TiDB *TiDBSpec `json:"tidb,omitempty"`
@@ -180,10 +199,18 @@ type TidbClusterSpec struct {
 	// +optional
 	TiCDC *TiCDCSpec `json:"ticdc,omitempty"`
 
+	// TiProxy cluster spec
+	// +optional
+	TiProxy *TiProxySpec `json:"tiproxy,omitempty"`
+
 	// Pump cluster spec
 	// +optional
 	Pump *PumpSpec `json:"pump,omitempty"`
 
+	// Drainer spec
+	// +optional
+	Drainer *DrainerSpec `json:"drainer,omitempty"`
+
 	// Helper spec
 	// +optional
 	Helper *HelperSpec `json:"helper,omitempty"`
@@ -193,6 +220,12 @@ type TidbClusterSpec struct {
 	// +optional
 	Paused bool `json:"paused,omitempty"`
 
+	// RecoveryMode indicates that the TiKV volumes are restored from volume snapshots by a
+	// volume-snapshot Restore. PD is marked as snapshot recovering before TiKV is started,
+	// and TiDB is not started until the data is restored. It is turned off by the Restore.
+	// +optional
+	RecoveryMode bool `json:"recoveryMode,omitempty"`
+
 	// TiDB cluster version
 	// +optional
 	Version string `json:"version"`
@@ -323,17 +356,41 @@ type TidbClusterSpec struct {
 	// +listType=map
 	// +listMapKey=topologyKey
 	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// UpgradePreflight enables the pre-flight checks before changing the version of the components,
+	// the version change is blocked if the checks fail.
+	// Optional: Defaults to nil, the pre-flight checks are disabled
+	// +optional
+	UpgradePreflight *UpgradePreflight `json:"upgradePreflight,omitempty"`
+}
+
+// UpgradePreflight is the pre-flight checks before changing the version of the components, it checks that
+//   - the version jump is supported and the components are upgraded in order
+//   - PD has no down stores, no pending region merges and the unhealthy regions are no more than the threshold
+//   - the cluster is not being backed up
+//
+// The checks can be skipped by the annotation `tidb.pingcap.com/skip-upgrade-preflight: "true"` of TidbCluster.
+// +k8s:openapi-gen=true
+type UpgradePreflight struct {
+	// MaxUnhealthyRegions is the max count of the regions with down, pending, missing or extra peers
+	// to start a version change.
+	// Optional: Defaults to 0
+	// +optional
+	MaxUnhealthyRegions *int32 `json:"maxUnhealthyRegions,omitempty"`
 }
 
 // TidbClusterStatus represents the current status of a tidb cluster.
 type TidbClusterStatus struct {
 	ClusterID  string                    `json:"clusterID,omitempty"`
 	PD         PDStatus                  `json:"pd,omitempty"`
+	PDMS       map[string]*PDMSStatus    `json:"pdms,omitempty"`
 	TiKV       TiKVStatus                `json:"tikv,omitempty"`
 	TiDB       TiDBStatus                `json:"tidb,omitempty"`
 	Pump       PumpStatus                `json:"pump,omitempty"`
+	Drainer    DrainerStatus             `json:"drainer,omitempty"`
 	TiFlash    TiFlashStatus             `json:"tiflash,omitempty"`
 	TiCDC      TiCDCStatus               `json:"ticdc,omitempty"`
+	TiProxy    TiProxyStatus             `json:"tiproxy,omitempty"`
 	AutoScaler *TidbClusterAutoScalerRef `json:"auto-scaler,omitempty"`
 	// Represents the latest available observations of a tidb cluster's state.
 	// +optional
@@ -374,12 +431,21 @@ const (
 	// - All TiKV stores are up.
 	// - All TiFlash stores are up.
 	TidbClusterReady TidbClusterConditionType = "Ready"
+	// TidbClusterUpgradePreflightPassed indicates whether the pre-flight checks before changing the version
+	// of the components are passed, the version change is blocked if it is false.
+	TidbClusterUpgradePreflightPassed TidbClusterConditionType = "UpgradePreflightPassed"
 )
 
 // The `Type` of the component condition
 const (
 	// ComponentVolumeResizing indicates that any volume of this component is resizing.
 	ComponentVolumeResizing string = "ComponentVolumeResizing"
+	// ComponentUpgradeRolledBack indicates that the upgrade of this component is rolled back and
+	// the following upgrades are paused.
+	ComponentUpgradeRolledBack string = "UpgradeRolledBack"
+	// ComponentScaleInBlocked indicates that the scale-in of this component is blocked because
+	// the remaining stores can not hold the data safely.
+	ComponentScaleInBlocked string = "ScaleInBlocked"
 )
 
 // +k8s:openapi-gen=true
@@ -398,6 +464,11 @@ type PDSpec struct {
 	// Specify a Service Account for pd
 	ServiceAccount string `json:"serviceAccount,omitempty"`
 
+	// UpgradeStrategy rolls out the new revision of the PD pods in stages
+	// Optional: Defaults to nil, all the pods are upgraded one by one without pause
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
 	// The desired ready replicas
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas"`
@@ -461,6 +532,57 @@ type PDSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:="";"v1"
 	StartUpScriptVersion string `json:"startUpScriptVersion,omitempty"`
+
+	// Mode is the mode of the PD servers.
+	// When set to "ms", PD runs in the API mode and the TSO and scheduling are
+	// served by the microservices in `.spec.pdms`.
+	// Optional: Defaults to "", the PD servers serve all the functions
+	// +optional
+	// +kubebuilder:validation:Enum:="";"ms"
+	Mode string `json:"mode,omitempty"`
+}
+
+const (
+	// PDModeMS is the PD mode in which the TSO and scheduling are served by the PD microservices
+	PDModeMS = "ms"
+	// PDMSTSO is the name of the TSO microservice
+	PDMSTSO = "tso"
+	// PDMSScheduling is the name of the scheduling microservice
+	PDMSScheduling = "scheduling"
+)
+
+// PDMSSpec contains details of a PD microservice
+// +k8s:openapi-gen=true
+type PDMSSpec struct {
+	ComponentSpec               `json:",inline"`
+	corev1.ResourceRequirements `json:",inline"`
+
+	// Name of the PD microservice
+	// +kubebuilder:validation:Enum:="tso";"scheduling"
+	Name string `json:"name"`
+
+	// Specify a Service Account for the PD microservice
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// The desired ready replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// Base image of the component, image tag is now allowed during validation
+	// +kubebuilder:default=pingcap/pd
+	// +optional
+	BaseImage string `json:"baseImage"`
+
+	// Service defines a Kubernetes service of the PD microservice.
+	// Optional: Defaults to a ClusterIP service
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+
+	// Config is the Configuration of the PD microservice
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
 }
 
 // TiKVSpec contains details of TiKV members
@@ -472,6 +594,11 @@ type TiKVSpec struct {
 	// Specify a Service Account for tikv
 	ServiceAccount string `json:"serviceAccount,omitempty"`
 
+	// UpgradeStrategy rolls out the new revision of the TiKV pods in stages
+	// Optional: Defaults to nil, all the pods are upgraded one by one without pause
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
 	// The desired ready replicas
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas"`
@@ -576,6 +703,11 @@ type TiFlashSpec struct {
 	// Specify a Service Account for TiFlash
 	ServiceAccount string `json:"serviceAccount,omitempty"`
 
+	// UpgradeStrategy rolls out the new revision of the TiFlash pods in stages
+	// Optional: Defaults to nil, all the pods are upgraded one by one without pause
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
 	// The desired ready replicas
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas"`
@@ -632,6 +764,11 @@ type TiCDCSpec struct {
 	// Specify a Service Account for TiCDC
 	ServiceAccount string `json:"serviceAccount,omitempty"`
 
+	// UpgradeStrategy rolls out the new revision of the TiCDC pods in stages
+	// Optional: Defaults to nil, all the pods are upgraded one by one without pause
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
 	// The desired ready replicas
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas"`
@@ -660,6 +797,13 @@ type TiCDCSpec struct {
 	// Defaults to Kubernetes default storage class.
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// GracefulShutdownTimeout is the timeout to drain the tables of a capture and resign its ownership
+	// before the capture is restarted or deleted, in the format of Go Duration.
+	// The pod is deleted anyway after the timeout.
+	// Defaults to 10m
+	// +optional
+	GracefulShutdownTimeout *string `json:"gracefulShutdownTimeout,omitempty"`
 }
 
 // TiCDCConfig is the configuration of tidbcdc
@@ -687,6 +831,52 @@ type TiCDCConfig struct {
 	LogFile *string `toml:"log-file,omitempty" json:"logFile,omitempty"`
 }
 
+// TiProxySpec contains details of TiProxy members
+// +k8s:openapi-gen=true
+type TiProxySpec struct {
+	ComponentSpec               `json:",inline"`
+	corev1.ResourceRequirements `json:",inline"`
+
+	// Specify a Service Account for TiProxy
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// UpgradeStrategy rolls out the new revision of the TiProxy pods in stages
+	// Optional: Defaults to nil, all the pods are upgraded one by one without pause
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
+	// The desired ready replicas
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// Base image of the component, image tag is now allowed during validation.
+	// TiProxy is versioned independently, so the image tag is `.tiproxy.version`
+	// instead of `.version` of the cluster.
+	// +kubebuilder:default=pingcap/tiproxy
+	// +optional
+	BaseImage string `json:"baseImage"`
+
+	// Service defines a Kubernetes service of TiProxy, the SQL clients connect to TiDB through it.
+	// Optional: Defaults to a ClusterIP service
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+
+	// Config is the Configuration of TiProxy servers
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
+
+	// StorageVolumes configure additional storage for TiProxy pods.
+	// +optional
+	StorageVolumes []StorageVolume `json:"storageVolumes,omitempty"`
+
+	// The storageClassName of the persistent volume for TiProxy data storage.
+	// Defaults to Kubernetes default storage class.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
 // LogTailerSpec represents an optional log tailer sidecar container
 // +k8s:openapi-gen=true
 type LogTailerSpec struct {
@@ -722,6 +912,11 @@ type TiDBSpec struct {
 	// Specify a Service Account for tidb
 	ServiceAccount string `json:"serviceAccount,omitempty"`
 
+	// UpgradeStrategy rolls out the new revision of the TiDB pods in stages
+	// Optional: Defaults to nil, all the pods are upgraded one by one without pause
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
 	// The desired ready replicas
 	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas"`
@@ -800,12 +995,36 @@ type TiDBSpec struct {
 	//
 	// +optional
 	Initializer *TiDBInitializer `json:"initializer,omitempty"`
+
+	// GracefulShutdown drains the client connections of a TiDB pod before it is restarted or deleted.
+	// The pod is removed from the endpoints of the Service by a readiness gate first, and then it is
+	// restarted or deleted after its active connections drop to the threshold or the timeout expires.
+	// Enabling or disabling it changes the readiness gates of the pods, which triggers a rolling update.
+	// Optional: Defaults to nil
+	// +optional
+	GracefulShutdown *TiDBGracefulShutdown `json:"gracefulShutdown,omitempty"`
 }
 
 type TiDBInitializer struct {
 	CreatePassword bool `json:"createPassword,omitempty"`
 }
 
+// TiDBGracefulShutdown is the configuration to drain the client connections of TiDB
+// +k8s:openapi-gen=true
+type TiDBGracefulShutdown struct {
+	// MaxConnections is the threshold of the active connections, the pod is restarted or deleted
+	// once its active connections drop to no more than it.
+	// Defaults to 0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// Timeout is the max time to wait for the connections to drain, in the format of Go Duration.
+	// Defaults to 10m
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+}
+
 const (
 	// TCPProbeType represents the readiness prob method with TCP
 	TCPProbeType string = "tcp"
@@ -861,6 +1080,123 @@ type PumpSpec struct {
 	SetTimeZone *bool `json:"setTimeZone,omitempty"`
 }
 
+// DrainerSpec contains details of Drainer members
+// +k8s:openapi-gen=true
+type DrainerSpec struct {
+	ComponentSpec               `json:",inline"`
+	corev1.ResourceRequirements `json:",inline"`
+
+	// Specify a Service Account for drainer
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// The desired ready replicas.
+	// Every drainer replicates all the binlog to the downstream, so only 0 and 1 are supported.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	Replicas int32 `json:"replicas"`
+
+	// Base image of the component, image tag is now allowed during validation
+	// +kubebuilder:default=pingcap/tidb-binlog
+	// +optional
+	BaseImage string `json:"baseImage"`
+
+	// The storageClassName of the persistent volume for Drainer data storage.
+	// The checkpoint of the file and kafka sinks is saved in the data storage.
+	// Defaults to Kubernetes default storage class.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// InitialCommitTs is the commit ts from which drainer starts to replicate
+	// if there is no checkpoint.
+	// Optional: Defaults to -1, which means the current ts
+	// +optional
+	InitialCommitTs *int64 `json:"initialCommitTs,omitempty"`
+
+	// Syncer is the downstream that drainer replicates the binlog to
+	Syncer DrainerSyncer `json:"syncer"`
+
+	// The configuration of Drainer.
+	// The addresses, TLS settings and the syncer settings are managed by the operator.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:XPreserveUnknownFields
+	Config *config.GenericConfig `json:"config,omitempty"`
+}
+
+// DrainerSinkType is the type of the downstream of drainer
+type DrainerSinkType string
+
+const (
+	// DrainerSinkTypeMySQL replicates the binlog to MySQL
+	DrainerSinkTypeMySQL DrainerSinkType = "mysql"
+	// DrainerSinkTypeTiDB replicates the binlog to TiDB
+	DrainerSinkTypeTiDB DrainerSinkType = "tidb"
+	// DrainerSinkTypeKafka replicates the binlog to Kafka
+	DrainerSinkTypeKafka DrainerSinkType = "kafka"
+	// DrainerSinkTypeFile saves the binlog to the data storage of drainer
+	DrainerSinkTypeFile DrainerSinkType = "file"
+)
+
+// DrainerSyncer is the downstream of drainer
+// +k8s:openapi-gen=true
+type DrainerSyncer struct {
+	// DBType is the type of the downstream
+	// +kubebuilder:validation:Enum=mysql;tidb;kafka;file
+	DBType DrainerSinkType `json:"dbType"`
+
+	// To is the MySQL or TiDB downstream, the password is read from the key `password`
+	// of the secret. Required when dbType is mysql or tidb.
+	// +optional
+	To *TiDBAccessConfig `json:"to,omitempty"`
+
+	// Kafka is the Kafka downstream. Required when dbType is kafka.
+	// +optional
+	Kafka *DrainerKafkaSink `json:"kafka,omitempty"`
+
+	// File is the downstream that saves the binlog files in the data storage of drainer.
+	// +optional
+	File *DrainerFileSink `json:"file,omitempty"`
+
+	// Checkpoint is the settings of the replication checkpoint
+	// +optional
+	Checkpoint *DrainerCheckpoint `json:"checkpoint,omitempty"`
+}
+
+// DrainerKafkaSink is the Kafka downstream of drainer
+// +k8s:openapi-gen=true
+type DrainerKafkaSink struct {
+	// KafkaAddrs is the addresses of the Kafka brokers
+	KafkaAddrs []string `json:"kafkaAddrs"`
+	// KafkaVersion is the version of the Kafka brokers
+	// +optional
+	KafkaVersion string `json:"kafkaVersion,omitempty"`
+	// TopicName is the topic that the binlog is written to.
+	// Optional: Defaults to <clusterID>_obinlog
+	// +optional
+	TopicName string `json:"topicName,omitempty"`
+}
+
+// DrainerFileSink is the file downstream of drainer
+// +k8s:openapi-gen=true
+type DrainerFileSink struct {
+	// Dir is the directory that the binlog files are saved to, it should be in the data storage
+	// Optional: Defaults to /data/pb
+	// +optional
+	Dir string `json:"dir,omitempty"`
+}
+
+// DrainerCheckpoint is the checkpoint settings of drainer.
+// The checkpoint is saved to the downstream for mysql and tidb sinks,
+// and to the data storage of drainer for other sinks.
+// +k8s:openapi-gen=true
+type DrainerCheckpoint struct {
+	// Schema is the schema in the downstream that saves the checkpoint,
+	// only used for mysql and tidb sinks.
+	// Optional: Defaults to tidb_binlog
+	// +optional
+	Schema string `json:"schema,omitempty"`
+}
+
 // HelperSpec contains details of helper component
 // +k8s:openapi-gen=true
 type HelperSpec struct {
@@ -1035,6 +1371,58 @@ type ComponentSpec struct {
 	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
+// UpgradeStrategy is the strategy to upgrade the pods of a component in stages
+// +k8s:openapi-gen=true
+type UpgradeStrategy struct {
+	// Steps are the count or percentage of the pods that have been upgraded at the end of each stage,
+	// e.g. [1, "50%"] upgrades one pod first and then half of the pods.
+	// The upgrade pauses at the end of each step until it is approved or the bake time passes,
+	// and the rest of the pods are upgraded after the last step.
+	// A step is approved by annotating the TidbCluster with `<component>.tidb.pingcap.com/upgrade-approved-step`
+	// whose value is the index of the step, e.g. `tikv.tidb.pingcap.com/upgrade-approved-step: "0"`.
+	// +optional
+	Steps []intstr.IntOrString `json:"steps,omitempty"`
+
+	// BakeTime is the time to wait at the end of each step before continuing the upgrade automatically,
+	// in the format of Go Duration.
+	// Optional: Defaults to nil, the upgrade waits for the approval
+	// +optional
+	BakeTime *string `json:"bakeTime,omitempty"`
+
+	// AutoRollback reverts the upgrade if an upgraded pod keeps unhealthy, it only takes effect for TiKV and TiDB.
+	// Optional: Defaults to nil
+	// +optional
+	AutoRollback *AutoRollbackPolicy `json:"autoRollback,omitempty"`
+}
+
+// AutoRollbackPolicy is the policy to roll back an upgrade on health regression
+// +k8s:openapi-gen=true
+type AutoRollbackPolicy struct {
+	// UnhealthyTimeout is the max time an upgraded pod can be unhealthy before the upgrade is rolled back,
+	// in the format of Go Duration.
+	// The StatefulSet is reverted to the spec before the upgrade, and the following upgrades are paused
+	// until the spec of the component is changed.
+	// Defaults to 10m
+	// +optional
+	UnhealthyTimeout *string `json:"unhealthyTimeout,omitempty"`
+}
+
+// UpgradeStepStatus is the progress of the staged upgrade of a component
+type UpgradeStepStatus struct {
+	// Revision is the revision of the StatefulSet being rolled out
+	Revision string `json:"revision"`
+	// Step is the index of the current step, it equals the count of the steps after the last step is approved
+	Step int32 `json:"step"`
+	// Replicas is the count of the pods to be upgraded at the end of the current step
+	Replicas int32 `json:"replicas"`
+	// UpgradedReplicas is the count of the pods that have been upgraded
+	UpgradedReplicas int32 `json:"upgradedReplicas"`
+	// PausedTime is the time when the upgrade is paused at the end of the current step
+	// +optional
+	// +nullable
+	PausedTime *metav1.Time `json:"pausedTime,omitempty"`
+}
+
 // ServiceSpec specifies the service object in k8s
 // +k8s:openapi-gen=true
 type ServiceSpec struct {
@@ -1064,7 +1452,7 @@ type ServiceSpec struct {
 
 	// The port that will be exposed by this service.
 	//
-	// NOTE: only used for TiDB
+	// NOTE: only used for TiDB and TiProxy
 	//
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
@@ -1134,6 +1522,9 @@ type PDStatus struct {
 	FailureMembers  map[string]PDFailureMember `json:"failureMembers,omitempty"`
 	UnjoinedMembers map[string]UnjoinedMember  `json:"unjoinedMembers,omitempty"`
 	Image           string                     `json:"image,omitempty"`
+	// UpgradeStep is the progress of the staged upgrade
+	// +optional
+	UpgradeStep *UpgradeStepStatus `json:"upgradeStep,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
@@ -1189,6 +1580,9 @@ type TiDBStatus struct {
 	ResignDDLOwnerRetryCount int32                        `json:"resignDDLOwnerRetryCount,omitempty"`
 	Image                    string                       `json:"image,omitempty"`
 	PasswordInitialized      *bool                        `json:"passwordInitialized,omitempty"`
+	// UpgradeStep is the progress of the staged upgrade
+	// +optional
+	UpgradeStep *UpgradeStepStatus `json:"upgradeStep,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
@@ -1253,6 +1647,9 @@ type TiKVStatus struct {
 	FailoverUID     types.UID                     `json:"failoverUID,omitempty"`
 	Image           string                        `json:"image,omitempty"`
 	EvictLeader     map[string]*EvictLeaderStatus `json:"evictLeader,omitempty"`
+	// UpgradeStep is the progress of the staged upgrade
+	// +optional
+	UpgradeStep *UpgradeStepStatus `json:"upgradeStep,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
@@ -1272,6 +1669,9 @@ type TiFlashStatus struct {
 	FailureStores   map[string]TiKVFailureStore `json:"failureStores,omitempty"`
 	FailoverUID     types.UID                   `json:"failoverUID,omitempty"`
 	Image           string                      `json:"image,omitempty"`
+	// UpgradeStep is the progress of the staged upgrade
+	// +optional
+	UpgradeStep *UpgradeStepStatus `json:"upgradeStep,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
@@ -1286,6 +1686,9 @@ type TiCDCStatus struct {
 	Phase       MemberPhase             `json:"phase,omitempty"`
 	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
 	Captures    map[string]TiCDCCapture `json:"captures,omitempty"`
+	// UpgradeStep is the progress of the staged upgrade
+	// +optional
+	UpgradeStep *UpgradeStepStatus `json:"upgradeStep,omitempty"`
 	// Volumes contains the status of all volumes.
 	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
 	// Represents the latest available observations of a component's state.
@@ -1303,6 +1706,51 @@ type TiCDCCapture struct {
 	Ready   bool   `json:"ready,omitempty"`
 }
 
+// PDMSStatus is the status of a PD microservice
+type PDMSStatus struct {
+	Name        string                  `json:"name,omitempty"`
+	Synced      bool                    `json:"synced,omitempty"`
+	Phase       MemberPhase             `json:"phase,omitempty"`
+	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+	// Members are the addresses of the members registered in PD
+	Members []string `json:"members,omitempty"`
+	// Primary is the address of the primary member
+	Primary string `json:"primary,omitempty"`
+	Image   string `json:"image,omitempty"`
+	// Volumes contains the status of all volumes.
+	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// Represents the latest available observations of a component's state.
+	// +optional
+	// +nullable
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// TiProxyStatus is TiProxy status
+type TiProxyStatus struct {
+	Synced      bool                     `json:"synced,omitempty"`
+	Phase       MemberPhase              `json:"phase,omitempty"`
+	StatefulSet *apps.StatefulSetStatus  `json:"statefulSet,omitempty"`
+	Members     map[string]TiProxyMember `json:"members,omitempty"`
+	// UpgradeStep is the progress of the staged upgrade
+	// +optional
+	UpgradeStep *UpgradeStepStatus `json:"upgradeStep,omitempty"`
+	// Volumes contains the status of all volumes.
+	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// Represents the latest available observations of a component's state.
+	// +optional
+	// +nullable
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// TiProxyMember is TiProxy member status
+type TiProxyMember struct {
+	Name   string `json:"name"`
+	Health bool   `json:"health"`
+	// Last time the health transitioned from one to another.
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
 // TiKVStores is either Up/Down/Offline/Tombstone
 type TiKVStore struct {
 	// store id is also uint64, due to the same reason as pd id, we store id as string
@@ -1351,6 +1799,20 @@ type PumpStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// DrainerStatus is Drainer status
+type DrainerStatus struct {
+	Phase       MemberPhase             `json:"phase,omitempty"`
+	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+	// Members is the status of the drainers registered in PD
+	Members []*PumpNodeStatus `json:"members,omitempty"`
+	// Volumes contains the status of all volumes.
+	Volumes map[StorageVolumeName]*StorageVolumeStatus `json:"volumes,omitempty"`
+	// Represents the latest available observations of a component's state.
+	// +optional
+	// +nullable
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
 // TiDBTLSClient can enable TLS connection between TiDB server and MySQL client
 // +k8s:openapi-gen=true
 type TiDBTLSClient struct {
@@ -1571,6 +2033,47 @@ const (
 	BackupTypeTable BackupType = "table"
 	// BackupTypeTiFlashReplica represents restoring the tiflash replica removed by a failed restore of the older version BR
 	BackupTypeTiFlashReplica BackupType = "tiflash-replica"
+	// BackupTypeVolumeSnapshot represents the backup of all TiKV volumes by CSI volume snapshots.
+	BackupTypeVolumeSnapshot BackupType = "volume-snapshot"
+)
+
+// BackupMode represents the backup mode, such as snapshot backup or log backup.
+// +k8s:openapi-gen=true
+type BackupMode string
+
+const (
+	// BackupModeSnapshot represents the snapshot backup of tidb cluster.
+	BackupModeSnapshot BackupMode = "snapshot"
+	// BackupModeLog represents the log backup of tidb cluster.
+	BackupModeLog BackupMode = "log"
+)
+
+// RestoreMode represents the restore mode, such as snapshot or pitr.
+// +k8s:openapi-gen=true
+type RestoreMode string
+
+const (
+	// RestoreModeSnapshot represents restore from a snapshot backup.
+	RestoreModeSnapshot RestoreMode = "snapshot"
+	// RestoreModePiTR represents PiTR restore which is from a snapshot backup and log backup.
+	RestoreModePiTR RestoreMode = "pitr"
+	// RestoreModeVolumeSnapshot represents restoring a fresh cluster from the volume snapshots taken
+	// by a volume-snapshot backup, the TiKV volumes are provisioned from the snapshots and their data
+	// is restored to the commit ts of the backup by BR.
+	RestoreModeVolumeSnapshot RestoreMode = "volume-snapshot"
+)
+
+// LogSubCommandType is the log backup subcommand type.
+// +k8s:openapi-gen=true
+type LogSubCommandType string
+
+const (
+	// LogStartCommand is the start command of log backup.
+	LogStartCommand LogSubCommandType = "log-start"
+	// LogTruncateCommand is the truncate command of log backup.
+	LogTruncateCommand LogSubCommandType = "log-truncate"
+	// LogStopCommand is the stop command of log backup.
+	LogStopCommand LogSubCommandType = "log-stop"
 )
 
 // TiDBAccessConfig defines the configuration for access tidb cluster
@@ -1629,6 +2132,93 @@ type CleanOption struct {
 	BatchDeleteOption `json:",inline"`
 }
 
+// BackupVerifySpec defines how a completed backup is verified by restoring it
+// into a scratch TidbCluster and checking the BR checksums.
+//
+// +k8s:openapi-gen=true
+type BackupVerifySpec struct {
+	// Timeout is how long the verification can take before it is failed, in the format of Go Duration.
+	// Defaults to 24h.
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+	// TiKVReplicas is the number of TiKV of the scratch cluster.
+	// Defaults to 1.
+	// +optional
+	TiKVReplicas *int32 `json:"tikvReplicas,omitempty"`
+	// StorageClassName is the storage class of PD and TiKV of the scratch cluster.
+	// Defaults to the storage class of the backed up cluster.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// TiKVStorageSize is the storage size of each TiKV of the scratch cluster.
+	// Defaults to the storage size of TiKV of the backed up cluster.
+	// +optional
+	TiKVStorageSize string `json:"tikvStorageSize,omitempty"`
+}
+
+// BackoffRetryPolicy defines how the failed job of a backup or restore is retried.
+// Only the failures caused by the environment, such as the eviction or OOM of the pod,
+// are retried, and the n-th retry is delayed by MinRetryDuration * 2^(n-1).
+//
+// +k8s:openapi-gen=true
+type BackoffRetryPolicy struct {
+	// MinRetryDuration is the delay of the first retry after the failure is detected, in the format of Go Duration.
+	// Defaults to 300s.
+	// +optional
+	MinRetryDuration *string `json:"minRetryDuration,omitempty"`
+	// MaxRetryTimes is the maximum number of retries.
+	// Defaults to 2.
+	// +optional
+	MaxRetryTimes *int32 `json:"maxRetryTimes,omitempty"`
+	// RetryTimeout is how long the job can be retried since the first failure is detected, in the format of Go Duration.
+	// Defaults to 30m.
+	// +optional
+	RetryTimeout *string `json:"retryTimeout,omitempty"`
+}
+
+// BackoffRetryRecord is the record of a retry of the failed job.
+type BackoffRetryRecord struct {
+	// RetryNum is the number of the retry, starting from 1.
+	RetryNum int32 `json:"retryNum"`
+	// DetectFailedAt is the time at which the failure was detected.
+	// +nullable
+	DetectFailedAt metav1.Time `json:"detectFailedAt,omitempty"`
+	// ExpectedRetryAt is the time at which the job is expected to be retried.
+	// +nullable
+	ExpectedRetryAt metav1.Time `json:"expectedRetryAt,omitempty"`
+	// RealRetryAt is the time at which the failed job was deleted to be recreated.
+	// +nullable
+	RealRetryAt metav1.Time `json:"realRetryAt,omitempty"`
+	// Reason is the reason of the failure, such as PodEvicted and OOMKilled.
+	Reason string `json:"reason,omitempty"`
+	// Message is the detail message of the failure.
+	Message string `json:"message,omitempty"`
+}
+
+// EncryptionMethod is the algorithm to encrypt the backup data.
+type EncryptionMethod string
+
+const (
+	// EncryptionMethodAES128CTR encrypts the backup data by AES-128 in CTR mode with a 16 bytes data key.
+	EncryptionMethodAES128CTR EncryptionMethod = "aes128-ctr"
+	// EncryptionMethodAES192CTR encrypts the backup data by AES-192 in CTR mode with a 24 bytes data key.
+	EncryptionMethodAES192CTR EncryptionMethod = "aes192-ctr"
+	// EncryptionMethodAES256CTR encrypts the backup data by AES-256 in CTR mode with a 32 bytes data key.
+	EncryptionMethodAES256CTR EncryptionMethod = "aes256-ctr"
+)
+
+// BackupEncryption is the client-side encryption of the backup data, which is done by
+// the crypter of BR or by backup-manager for the archives of Dumpling.
+//
+// +k8s:openapi-gen=true
+type BackupEncryption struct {
+	// Method is the algorithm to encrypt the backup data.
+	// Defaults to aes256-ctr.
+	// +optional
+	Method EncryptionMethod `json:"method,omitempty"`
+	// SecretName is the name of the secret which stores the hex encoded data key in the key `data_key`.
+	SecretName string `json:"secretName"`
+}
+
 // BackupSpec contains the backup specification for a tidb cluster.
 // +k8s:openapi-gen=true
 type BackupSpec struct {
@@ -1656,12 +2246,38 @@ type BackupSpec struct {
 	From *TiDBAccessConfig `json:"from,omitempty"`
 	// Type is the backup type for tidb cluster.
 	Type BackupType `json:"backupType,omitempty"`
+	// Mode is the backup mode, such as snapshot backup or log backup.
+	// Defaults to snapshot.
+	// +optional
+	Mode BackupMode `json:"backupMode,omitempty"`
+	// CommitTs is the start ts of log backup.
+	// Format supports TSO or datetime, e.g. '400036290571534337', '2018-05-11 01:42:23'.
+	// Default is current timestamp.
+	// +optional
+	CommitTs string `json:"commitTs,omitempty"`
+	// LogTruncateUntil is the timestamp before which the log backup data will be truncated.
+	// Format supports TSO or datetime, e.g. '400036290571534337', '2018-05-11 01:42:23'.
+	// +optional
+	LogTruncateUntil string `json:"logTruncateUntil,omitempty"`
+	// LogStop indicates that the log backup will be stopped.
+	// +optional
+	LogStop bool `json:"logStop,omitempty"`
+	// VolumeSnapshotClassName is the VolumeSnapshotClass used to snapshot the TiKV volumes
+	// in volume-snapshot backup. Defaults to the default VolumeSnapshotClass of the CSI driver.
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
 	// TikvGCLifeTime is to specify the safe gc life time for backup.
 	// The time limit during which data is retained for each GC, in the format of Go Duration.
 	// When a GC happens, the current time minus this value is the safe point.
 	TikvGCLifeTime *string `json:"tikvGCLifeTime,omitempty"`
 	// StorageProvider configures where and how backups should be stored.
 	StorageProvider `json:",inline"`
+	// ReplicaStorages are the secondary storages, such as buckets in another region or of another provider,
+	// which the backup data is copied to after the backup succeeds. Only snapshot backup with BR is supported.
+	// Each replica storage uses the credentials in its own secret, and the failure of copying to a
+	// replica storage is recorded in the Replicated condition without failing the backup.
+	// +optional
+	ReplicaStorages []StorageProvider `json:"replicaStorages,omitempty"`
 	// The storageClassName of the persistent volume for Backup data storage.
 	// Defaults to Kubernetes default storage class.
 	// +optional
@@ -1696,6 +2312,16 @@ type BackupSpec struct {
 	CleanPolicy CleanPolicyType `json:"cleanPolicy,omitempty"`
 	// CleanOption controls the behavior of clean.
 	CleanOption *CleanOption `json:"cleanOption,omitempty"`
+	// Verify enables restoring the backup into a scratch cluster to verify it after the backup is complete.
+	// +optional
+	Verify *BackupVerifySpec `json:"verify,omitempty"`
+	// Encryption enables encrypting the backup data with a data key before it is uploaded.
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+	// BackoffRetryPolicy enables retrying the failed backup job, only the snapshot backup
+	// with a backup job can be retried.
+	// +optional
+	BackoffRetryPolicy *BackoffRetryPolicy `json:"backoffRetryPolicy,omitempty"`
 
 	// PodSecurityContext of the component
 	// +optional
@@ -1766,6 +2392,16 @@ const (
 	BackupInvalid BackupConditionType = "Invalid"
 	// BackupPrepare means the backup prepare backup process
 	BackupPrepare BackupConditionType = "Prepare"
+	// BackupStopped means the log backup has been stopped.
+	BackupStopped BackupConditionType = "Stopped"
+	// BackupVolumeSnapshotPrepared means the writes of all TiKV stores have been suspended for the volume snapshots.
+	BackupVolumeSnapshotPrepared BackupConditionType = "VolumeSnapshotPrepared"
+	// BackupVerified means the backup has been restored into a scratch cluster and the checksums match.
+	// The condition is Unknown while the verification is in progress, and False if it fails.
+	BackupVerified BackupConditionType = "Verified"
+	// BackupReplicated means the backup data has been copied to all the replica storages.
+	// The condition is False if copying to any replica storage fails, which doesn't fail the backup.
+	BackupReplicated BackupConditionType = "Replicated"
 )
 
 // BackupCondition describes the observed state of a Backup at a certain point.
@@ -1796,13 +2432,140 @@ type BackupStatus struct {
 	// BackupSize is the data size of the backup.
 	BackupSize int64 `json:"backupSize,omitempty"`
 	// CommitTs is the snapshot time point of tidb cluster.
+	// For log backup, it is the start ts of the log backup task.
 	CommitTs string `json:"commitTs,omitempty"`
+	// LogCheckpointTs is the checkpoint ts of the log backup task,
+	// all the logs before this ts have been backed up.
+	LogCheckpointTs string `json:"logCheckpointTs,omitempty"`
+	// LogSuccessTruncateUntil is the ts before which the log backup data has been truncated successfully.
+	LogSuccessTruncateUntil string `json:"logSuccessTruncateUntil,omitempty"`
+	// LogSubCommandStatuses is the detail status of log backup subcommands.
+	LogSubCommandStatuses map[LogSubCommandType]LogSubCommandStatus `json:"logSubCommandStatuses,omitempty"`
+	// LogProgress is the range of the logs that have been backed up by the log backup task.
+	// +optional
+	LogProgress *LogBackupProgress `json:"logProgress,omitempty"`
+	// VolumeSnapshots are the snapshots of the TiKV volumes taken by volume-snapshot backup.
+	// +optional
+	VolumeSnapshots []TiKVVolumeSnapshot `json:"volumeSnapshots,omitempty"`
+	// PausedSchedulers are the PD schedulers paused by volume-snapshot backup, only they are resumed after the snapshots are taken.
+	// +optional
+	PausedSchedulers []string `json:"pausedSchedulers,omitempty"`
+	// ReplicaStatuses are the status of copying the backup data to the replica storages.
+	// +optional
+	ReplicaStatuses []BackupReplicaStatus `json:"replicaStatuses,omitempty"`
+	// Progress is the progress of the running backup reported by BR.
+	// +optional
+	Progress *BRProgress `json:"progress,omitempty"`
+	// BackoffRetryStatus is the records of the retries of the failed backup job.
+	// +optional
+	BackoffRetryStatus []BackoffRetryRecord `json:"backoffRetryStatus,omitempty"`
 	// Phase is a user readable state inferred from the underlying Backup conditions
 	Phase BackupConditionType `json:"phase,omitempty"`
 	// +nullable
 	Conditions []BackupCondition `json:"conditions,omitempty"`
 }
 
+// LogSubCommandStatus is the status of a log backup subcommand.
+type LogSubCommandStatus struct {
+	// Command is the log backup subcommand.
+	Command LogSubCommandType `json:"command,omitempty"`
+	// TimeStarted is the time at which the subcommand was started.
+	// +nullable
+	TimeStarted metav1.Time `json:"timeStarted,omitempty"`
+	// TimeCompleted is the time at which the subcommand was completed.
+	// +nullable
+	TimeCompleted metav1.Time `json:"timeCompleted,omitempty"`
+	// LogTruncatingUntil is the truncate ts which the truncate subcommand is working on.
+	LogTruncatingUntil string `json:"logTruncatingUntil,omitempty"`
+	// Phase is the current phase of the subcommand.
+	Phase BackupConditionType `json:"phase,omitempty"`
+	// Message is the detail message of the subcommand, such as the failure reason.
+	Message string `json:"message,omitempty"`
+}
+
+// LogBackupProgress is the progress of the log backup task, the cluster can be restored to
+// any time in the range of [StartTime, CheckpointTime] by PiTR.
+type LogBackupProgress struct {
+	// StartTime is the start of the backed up logs, which is the time of the start ts
+	// or the truncated ts of the log backup task.
+	// +nullable
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// CheckpointTime is the time of the checkpoint ts, all the logs before it have been backed up.
+	// +nullable
+	CheckpointTime metav1.Time `json:"checkpointTime,omitempty"`
+	// CheckpointLagSeconds is how long the checkpoint lags behind the time it is synced.
+	CheckpointLagSeconds int64 `json:"checkpointLagSeconds,omitempty"`
+	// LastUpdateTime is the time at which the progress was updated.
+	// +nullable
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// BackupReplicaStatus is the status of copying the backup data to a replica storage.
+type BackupReplicaStatus struct {
+	// Index is the index of the replica storage in spec.replicaStorages.
+	Index int32 `json:"index"`
+	// Path is the location of the backup in the replica storage.
+	Path string `json:"path,omitempty"`
+	// Phase is the phase of copying, which is Running, Complete or Failed.
+	Phase BackupConditionType `json:"phase,omitempty"`
+	// TotalObjects is the number of the objects to copy.
+	TotalObjects int64 `json:"totalObjects,omitempty"`
+	// CopiedObjects is the number of the objects that have been copied.
+	CopiedObjects int64 `json:"copiedObjects,omitempty"`
+	// CopiedSize is the data size in bytes that has been copied.
+	CopiedSize int64 `json:"copiedSize,omitempty"`
+	// TimeStarted is the time at which the copying was started.
+	// +nullable
+	TimeStarted metav1.Time `json:"timeStarted,omitempty"`
+	// TimeCompleted is the time at which the copying was completed.
+	// +nullable
+	TimeCompleted metav1.Time `json:"timeCompleted,omitempty"`
+	// Message is the detail message of the copying, such as the failure reason.
+	Message string `json:"message,omitempty"`
+}
+
+// BRProgress is the progress of the current step of BR, which is parsed from the log of BR.
+type BRProgress struct {
+	// Step is the current step of BR, such as "Full Backup", "Checksum" and "Full Restore".
+	Step string `json:"step,omitempty"`
+	// Percentage is the percentage of the step that has been done, in the range of [0, 100].
+	Percentage int32 `json:"percentage,omitempty"`
+	// Completed is the number of the units processed in the step, the unit depends on
+	// the step, such as ranges for backup and files for restore.
+	Completed int64 `json:"completed,omitempty"`
+	// Total is the number of the units to process in the step.
+	Total int64 `json:"total,omitempty"`
+	// Speed is the processing speed of the step reported by BR.
+	Speed string `json:"speed,omitempty"`
+	// Remaining is the estimated time to finish the step reported by BR, such as "1m30s".
+	Remaining string `json:"remaining,omitempty"`
+	// ProcessedBytes is the size in bytes of the data processed by BR, which is parsed
+	// from the success summary logged by BR when the backup or restore finishes.
+	ProcessedBytes int64 `json:"processedBytes,omitempty"`
+	// LastUpdateTime is the time at which the progress was updated.
+	// +nullable
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// TiKVVolumeSnapshot is the CSI volume snapshot of a TiKV data volume.
+type TiKVVolumeSnapshot struct {
+	// Ordinal is the ordinal of the TiKV Pod which the volume belongs to.
+	Ordinal int32 `json:"ordinal"`
+	// StoreID is the id of the TiKV store which the volume belongs to.
+	StoreID string `json:"storeID,omitempty"`
+	// PVCName is the name of the snapshotted PVC.
+	PVCName string `json:"pvcName"`
+	// SnapshotName is the name of the VolumeSnapshot object.
+	SnapshotName string `json:"snapshotName"`
+	// StorageClassName is the storage class of the snapshotted PVC.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Size is the requested storage size of the snapshotted PVC.
+	Size string `json:"size,omitempty"`
+	// ReadyToUse indicates whether the snapshot is ready to be used to restore a volume.
+	ReadyToUse bool `json:"readyToUse,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -1850,8 +2613,15 @@ type BackupScheduleSpec struct {
 	MaxBackups *int32 `json:"maxBackups,omitempty"`
 	// MaxReservedTime is to specify how long backups we want to keep.
 	MaxReservedTime *string `json:"maxReservedTime,omitempty"`
+	// RetentionPolicy is the tiered grandfather-father-son retention of the scheduled backups.
+	// It can't be set together with MaxBackups or MaxReservedTime.
+	// +optional
+	RetentionPolicy *BackupRetentionPolicy `json:"retentionPolicy,omitempty"`
 	// BackupTemplate is the specification of the backup structure to get scheduled.
 	BackupTemplate BackupSpec `json:"backupTemplate"`
+	// Verify enables the verification of the scheduled backups if not set in BackupTemplate.
+	// +optional
+	Verify *BackupVerifySpec `json:"verify,omitempty"`
 	// The storageClassName of the persistent volume for Backup data storage if not storage class name set in BackupSpec.
 	// Defaults to Kubernetes default storage class.
 	// +optional
@@ -1871,6 +2641,68 @@ type BackupScheduleStatus struct {
 	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
 	// AllBackupCleanTime represents the time when all backup entries are cleaned up
 	AllBackupCleanTime *metav1.Time `json:"allBackupCleanTime,omitempty"`
+	// RetainedBackups lists the backups retained by each tier of the retention policy.
+	// +optional
+	RetainedBackups []BackupRetentionStatus `json:"retainedBackups,omitempty"`
+}
+
+// BackupRetentionTierType represents a tier of the backup retention policy.
+type BackupRetentionTierType string
+
+const (
+	// BackupRetentionTierHourly retains the first backup of each hour.
+	BackupRetentionTierHourly BackupRetentionTierType = "hourly"
+	// BackupRetentionTierDaily retains the first backup of each day.
+	BackupRetentionTierDaily BackupRetentionTierType = "daily"
+	// BackupRetentionTierWeekly retains the first backup of each ISO week.
+	BackupRetentionTierWeekly BackupRetentionTierType = "weekly"
+	// BackupRetentionTierMonthly retains the first backup of each month.
+	BackupRetentionTierMonthly BackupRetentionTierType = "monthly"
+)
+
+// BackupRetentionPolicy is the grandfather-father-son retention of scheduled backups,
+// e.g. keep 24 hourly, 7 daily, 4 weekly and 12 monthly backups.
+// A backup is deleted once it is not retained by any tier, the periods are of the scheduled time in UTC.
+// +k8s:openapi-gen=true
+type BackupRetentionPolicy struct {
+	// Hourly retains the first backup of each of the latest hours.
+	// +optional
+	Hourly *BackupRetentionTier `json:"hourly,omitempty"`
+	// Daily retains the first backup of each of the latest days.
+	// +optional
+	Daily *BackupRetentionTier `json:"daily,omitempty"`
+	// Weekly retains the first backup of each of the latest ISO weeks.
+	// +optional
+	Weekly *BackupRetentionTier `json:"weekly,omitempty"`
+	// Monthly retains the first backup of each of the latest months.
+	// +optional
+	Monthly *BackupRetentionTier `json:"monthly,omitempty"`
+}
+
+// BackupRetentionTier is a tier of the backup retention policy.
+// A new backup belongs to the coarsest tier it is the first backup of the period in,
+// and is written with the storage class and prefix of the tier.
+// +k8s:openapi-gen=true
+type BackupRetentionTier struct {
+	// Keep is the number of the latest periods whose first backup is retained.
+	// +kubebuilder:validation:Minimum=0
+	Keep int32 `json:"keep"`
+	// StorageClass overrides the storage class of S3 and GCS, or the access tier of Azure Blob Storage,
+	// for the backups belonging to the tier.
+	// +optional
+	StorageClass string `json:"storageClass,omitempty"`
+	// Prefix is appended to the prefix of the storage provider for the backups belonging to the tier.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// BackupRetentionStatus is the backups retained by a tier of the retention policy.
+type BackupRetentionStatus struct {
+	// Tier is the tier of the retention policy.
+	Tier BackupRetentionTierType `json:"tier"`
+	// Backups are the names of the backups retained by the tier, from the oldest to the newest.
+	// +optional
+	Backups []string `json:"backups,omitempty"`
 }
 
 // +genclient
@@ -1924,6 +2756,12 @@ const (
 	RestoreRetryFailed RestoreConditionType = "RetryFailed"
 	// RestoreInvalid means invalid restore CR.
 	RestoreInvalid RestoreConditionType = "Invalid"
+	// RestoreVolumeComplete means the TiKV volumes have been provisioned from the volume snapshots,
+	// the TidbCluster can be created in recovery mode then.
+	RestoreVolumeComplete RestoreConditionType = "VolumeComplete"
+	// RestoreDataComplete means BR has restored the data on the TiKV volumes to the commit ts of the
+	// volume-snapshot backup, the TidbCluster is switched out of recovery mode then.
+	RestoreDataComplete RestoreConditionType = "DataComplete"
 )
 
 // RestoreCondition describes the observed state of a Restore at a certain point.
@@ -1964,6 +2802,22 @@ type RestoreSpec struct {
 	To *TiDBAccessConfig `json:"to,omitempty"`
 	// Type is the backup type for tidb cluster.
 	Type BackupType `json:"backupType,omitempty"`
+	// Mode is the restore mode, such as snapshot or pitr.
+	// Defaults to snapshot.
+	// +optional
+	Mode RestoreMode `json:"restoreMode,omitempty"`
+	// PitrRestoredTs is the ts which the cluster will be restored to in pitr mode.
+	// Format supports TSO or datetime, e.g. '400036290571534337', '2018-05-11 01:42:23'.
+	// +optional
+	PitrRestoredTs string `json:"pitrRestoredTs,omitempty"`
+	// PitrFullBackupStorageProvider configures where the full backup which pitr depends on is stored.
+	// The log backup data is read from the StorageProvider of the restore.
+	// +optional
+	PitrFullBackupStorageProvider StorageProvider `json:"pitrFullBackupStorageProvider,omitempty"`
+	// VolumeSnapshotBackupName is the name of the volume-snapshot Backup in the same namespace
+	// whose snapshots are used to provision the TiKV volumes in volume-snapshot mode.
+	// +optional
+	VolumeSnapshotBackupName string `json:"volumeSnapshotBackupName,omitempty"`
 	// TikvGCLifeTime is to specify the safe gc life time for restore.
 	// The time limit during which data is retained for each GC, in the format of Go Duration.
 	// When a GC happens, the current time minus this value is the safe point.
@@ -1978,6 +2832,13 @@ type RestoreSpec struct {
 	StorageSize string `json:"storageSize,omitempty"`
 	// BR is the configs for BR.
 	BR *BRConfig `json:"br,omitempty"`
+	// Encryption is the encryption of the backup data to restore, which should be the same as the backup.
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+	// BackoffRetryPolicy enables retrying the failed restore job, the volume-snapshot restore
+	// can not be retried.
+	// +optional
+	BackoffRetryPolicy *BackoffRetryPolicy `json:"backoffRetryPolicy,omitempty"`
 	// Base tolerations of restore Pods, components may add more tolerations upon this respectively
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
@@ -2017,6 +2878,12 @@ type RestoreStatus struct {
 	TimeCompleted metav1.Time `json:"timeCompleted,omitempty"`
 	// CommitTs is the snapshot time point of tidb cluster.
 	CommitTs string `json:"commitTs,omitempty"`
+	// Progress is the progress of the running restore reported by BR.
+	// +optional
+	Progress *BRProgress `json:"progress,omitempty"`
+	// BackoffRetryStatus is the records of the retries of the failed restore job.
+	// +optional
+	BackoffRetryStatus []BackoffRetryRecord `json:"backoffRetryStatus,omitempty"`
 	// Phase is a user readable state inferred from the underlying Restore conditions
 	Phase RestoreConditionType `json:"phase,omitempty"`
 	// +nullable
@@ -21,6 +21,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilnet "k8s.io/utils/net"
@@ -71,6 +73,81 @@ func ValidateTiDBNGMonitoring(tngm *v1alpha1.TidbNGMonitoring) field.ErrorList {
 	return allErrs
 }
 
+// changefeedIDRegexp is the format of the changefeed ID accepted by TiCDC
+var changefeedIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9]+(\-[a-zA-Z0-9]+)*$`)
+
+// ValidateTiCDCChangefeed validates a TiCDCChangefeed
+func ValidateTiCDCChangefeed(cf *v1alpha1.TiCDCChangefeed) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &cf.Spec
+
+	if spec.Cluster.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cluster", "name"), "the TidbCluster running the changefeed must be specified"))
+	}
+
+	id := cf.GetChangefeedID()
+	if len(id) > 128 || !changefeedIDRegexp.MatchString(id) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("changefeedID"), id, "must consist of alphanumeric characters or '-', and no longer than 128 characters"))
+	}
+	if cf.Status.ChangefeedID != "" && cf.Status.ChangefeedID != id {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("changefeedID"), fmt.Sprintf("can not be changed after the changefeed %s is created", cf.Status.ChangefeedID)))
+	}
+
+	if spec.SinkURI == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("sinkURI"), "the sink URI must be specified"))
+	} else if u, err := url.Parse(spec.SinkURI); err != nil || u.Scheme == "" {
+		// do not show the URI in the message as it may contain the credentials
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sinkURI"), "", "must be a valid URI with a scheme"))
+	} else if u.User != nil && spec.SinkSecretRef != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sinkURI"), "", "must not contain the user info if sinkSecretRef is specified"))
+	}
+	if spec.SinkSecretRef != nil && spec.SinkSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("sinkSecretRef", "name"), "the name of the Secret must be specified"))
+	}
+
+	if spec.TargetTS != 0 && spec.TargetTS <= spec.StartTS {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetTS"), spec.TargetTS, "must be greater than startTS"))
+	}
+
+	return allErrs
+}
+
+// ValidateTidbClusterReplication validates a TidbClusterReplication
+func ValidateTidbClusterReplication(tcr *v1alpha1.TidbClusterReplication) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	spec := &tcr.Spec
+
+	for _, m := range []struct {
+		member  *v1alpha1.TidbClusterReplicationMember
+		fldPath *field.Path
+	}{
+		{&spec.Primary, fldPath.Child("primary")},
+		{&spec.Secondary, fldPath.Child("secondary")},
+	} {
+		if m.member.Name == "" {
+			allErrs = append(allErrs, field.Required(m.fldPath.Child("name"), "the name of the TidbCluster must be specified"))
+		}
+		if m.member.SecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(m.fldPath.Child("secretRef", "name"), "the Secret of the user and password must be specified"))
+		}
+		// the changefeed and the restore are created in the local Kubernetes cluster
+		if m.member.ClusterDomain != "" {
+			allErrs = append(allErrs, field.Forbidden(m.fldPath.Child("clusterDomain"), "the TidbClusters in other Kubernetes clusters are not supported"))
+		}
+	}
+	if spec.Primary.Key() == spec.Secondary.Key() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("secondary"), spec.Secondary.Key(), "must be different from the primary"))
+	}
+	// the members can only be swapped after the replication starts
+	if primary := tcr.Status.Primary; primary != "" && primary != spec.Primary.Key() && primary != spec.Secondary.Key() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("the TidbClusters can not be changed, %s must be either the primary or the secondary", primary)))
+	}
+
+	return allErrs
+}
+
 func ValidateTidbMonitor(monitor *v1alpha1.TidbMonitor) field.ErrorList {
 	allErrs := field.ErrorList{}
 	// validate monitor service
@@ -112,6 +189,9 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	if spec.PD != nil {
 		allErrs = append(allErrs, validatePDSpec(spec.PD, fldPath.Child("pd"))...)
 	}
+	if len(spec.PDMS) > 0 || (spec.PD != nil && spec.PD.Mode == v1alpha1.PDModeMS) {
+		allErrs = append(allErrs, validatePDMSSpecs(spec, fldPath.Child("pdms"))...)
+	}
 	if spec.TiKV != nil {
 		allErrs = append(allErrs, validateTiKVSpec(spec.TiKV, fldPath.Child("tikv"))...)
 	}
@@ -121,15 +201,32 @@ func validateTiDBClusterSpec(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path
 	if spec.Pump != nil {
 		allErrs = append(allErrs, validatePumpSpec(spec.Pump, fldPath.Child("pump"))...)
 	}
+	if spec.Drainer != nil {
+		allErrs = append(allErrs, validateDrainerSpec(spec.Drainer, fldPath.Child("drainer"))...)
+	}
 	if spec.TiFlash != nil {
 		allErrs = append(allErrs, validateTiFlashSpec(spec.TiFlash, fldPath.Child("tiflash"))...)
 	}
 	if spec.TiCDC != nil {
 		allErrs = append(allErrs, validateTiCDCSpec(spec.TiCDC, fldPath.Child("ticdc"))...)
 	}
+	if spec.TiProxy != nil {
+		allErrs = append(allErrs, validateTiProxySpec(spec.TiProxy, fldPath.Child("tiproxy"))...)
+	}
 	if spec.PDAddresses != nil {
 		allErrs = append(allErrs, validatePDAddresses(spec.PDAddresses, fldPath.Child("pdAddresses"))...)
 	}
+	if spec.UpgradePreflight != nil {
+		allErrs = append(allErrs, validateUpgradePreflight(spec.UpgradePreflight, fldPath.Child("upgradePreflight"))...)
+	}
+	return allErrs
+}
+
+func validateUpgradePreflight(preflight *v1alpha1.UpgradePreflight, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if preflight.MaxUnhealthyRegions != nil && *preflight.MaxUnhealthyRegions < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxUnhealthyRegions"), *preflight.MaxUnhealthyRegions, "must be greater than or equal to 0"))
+	}
 	return allErrs
 }
 
@@ -144,6 +241,9 @@ func validateDiscoverySpec(spec v1alpha1.DiscoverySpec, fldPath *field.Path) fie
 func validatePDSpec(spec *v1alpha1.PDSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	if spec.UpgradeStrategy != nil {
+		allErrs = append(allErrs, validateUpgradeStrategy(spec.UpgradeStrategy, fldPath.Child("upgradeStrategy"))...)
+	}
 	allErrs = append(allErrs, validateRequestsStorage(spec.ResourceRequirements.Requests, fldPath)...)
 	if len(spec.StorageVolumes) > 0 {
 		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
@@ -151,6 +251,32 @@ func validatePDSpec(spec *v1alpha1.PDSpec, fldPath *field.Path) field.ErrorList
 	return allErrs
 }
 
+func validatePDMSSpecs(spec *v1alpha1.TidbClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.PD == nil || spec.PD.Mode != v1alpha1.PDModeMS {
+		return append(allErrs, field.Invalid(fldPath, len(spec.PDMS), "the PD microservices require .spec.pd.mode to be \"ms\""))
+	}
+	names := map[string]bool{}
+	for i, ms := range spec.PDMS {
+		idxPath := fldPath.Index(i)
+		allErrs = append(allErrs, validateComponentSpec(&ms.ComponentSpec, idxPath)...)
+		switch ms.Name {
+		case v1alpha1.PDMSTSO, v1alpha1.PDMSScheduling:
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("name"), ms.Name, []string{v1alpha1.PDMSTSO, v1alpha1.PDMSScheduling}))
+		}
+		if names[ms.Name] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), ms.Name))
+		}
+		names[ms.Name] = true
+	}
+	// PD in the API mode does not allocate timestamps by itself
+	if !names[v1alpha1.PDMSTSO] {
+		allErrs = append(allErrs, field.Required(fldPath, "the tso microservice must be deployed when .spec.pd.mode is \"ms\""))
+	}
+	return allErrs
+}
+
 func validatePDAddresses(arrayOfAddresses []string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, address := range arrayOfAddresses {
@@ -169,6 +295,9 @@ func validatePDAddresses(arrayOfAddresses []string, fldPath *field.Path) field.E
 func validateTiKVSpec(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	if spec.UpgradeStrategy != nil {
+		allErrs = append(allErrs, validateUpgradeStrategy(spec.UpgradeStrategy, fldPath.Child("upgradeStrategy"))...)
+	}
 	allErrs = append(allErrs, validateRequestsStorage(spec.ResourceRequirements.Requests, fldPath)...)
 	if len(spec.DataSubDir) > 0 {
 		allErrs = append(allErrs, validateLocalDescendingPath(spec.DataSubDir, fldPath.Child("dataSubDir"))...)
@@ -189,6 +318,9 @@ func validateTiKVSpec(spec *v1alpha1.TiKVSpec, fldPath *field.Path) field.ErrorL
 func validateTiFlashSpec(spec *v1alpha1.TiFlashSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	if spec.UpgradeStrategy != nil {
+		allErrs = append(allErrs, validateUpgradeStrategy(spec.UpgradeStrategy, fldPath.Child("upgradeStrategy"))...)
+	}
 	allErrs = append(allErrs, validateTiFlashConfig(spec.Config, fldPath)...)
 	if len(spec.StorageClaims) < 1 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("spec.StorageClaims"),
@@ -200,6 +332,22 @@ func validateTiFlashSpec(spec *v1alpha1.TiFlashSpec, fldPath *field.Path) field.
 func validateTiCDCSpec(spec *v1alpha1.TiCDCSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	if spec.UpgradeStrategy != nil {
+		allErrs = append(allErrs, validateUpgradeStrategy(spec.UpgradeStrategy, fldPath.Child("upgradeStrategy"))...)
+	}
+	if len(spec.StorageVolumes) > 0 {
+		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
+	}
+	allErrs = append(allErrs, validateTimeDurationStr(spec.GracefulShutdownTimeout, fldPath.Child("gracefulShutdownTimeout"))...)
+	return allErrs
+}
+
+func validateTiProxySpec(spec *v1alpha1.TiProxySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	if spec.UpgradeStrategy != nil {
+		allErrs = append(allErrs, validateUpgradeStrategy(spec.UpgradeStrategy, fldPath.Child("upgradeStrategy"))...)
+	}
 	if len(spec.StorageVolumes) > 0 {
 		allErrs = append(allErrs, validateStorageVolumes(spec.StorageVolumes, fldPath.Child("storageVolumes"))...)
 	}
@@ -260,6 +408,9 @@ func validateTiFlashConfig(config *v1alpha1.TiFlashConfigWraper, path *field.Pat
 func validateTiDBSpec(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	if spec.UpgradeStrategy != nil {
+		allErrs = append(allErrs, validateUpgradeStrategy(spec.UpgradeStrategy, fldPath.Child("upgradeStrategy"))...)
+	}
 	if spec.Service != nil {
 		allErrs = append(allErrs, validateService(&spec.Service.ServiceSpec, fldPath)...)
 	}
@@ -269,6 +420,9 @@ func validateTiDBSpec(spec *v1alpha1.TiDBSpec, fldPath *field.Path) field.ErrorL
 	if spec.ShouldSeparateSlowLog() && spec.SlowLogVolumeName != "" {
 		allErrs = append(allErrs, validateVolumeName(spec.SlowLogVolumeName, spec.StorageVolumes, spec.AdditionalVolumes, spec.AdditionalVolumeMounts, fldPath)...)
 	}
+	if spec.GracefulShutdown != nil {
+		allErrs = append(allErrs, validateTimeDurationStr(spec.GracefulShutdown.Timeout, fldPath.Child("gracefulShutdown", "timeout"))...)
+	}
 	return allErrs
 }
 
@@ -278,6 +432,30 @@ func validatePumpSpec(spec *v1alpha1.PumpSpec, fldPath *field.Path) field.ErrorL
 	return allErrs
 }
 
+func validateDrainerSpec(spec *v1alpha1.DrainerSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateComponentSpec(&spec.ComponentSpec, fldPath)...)
+	if spec.Replicas > 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), spec.Replicas, "every drainer replicates all the binlog, replicas must not be greater than 1"))
+	}
+	syncerPath := fldPath.Child("syncer")
+	switch spec.Syncer.DBType {
+	case v1alpha1.DrainerSinkTypeMySQL, v1alpha1.DrainerSinkTypeTiDB:
+		if spec.Syncer.To == nil || spec.Syncer.To.Host == "" {
+			allErrs = append(allErrs, field.Required(syncerPath.Child("to", "host"), fmt.Sprintf("the host of the %s downstream must be set", spec.Syncer.DBType)))
+		}
+	case v1alpha1.DrainerSinkTypeKafka:
+		if spec.Syncer.Kafka == nil || len(spec.Syncer.Kafka.KafkaAddrs) == 0 {
+			allErrs = append(allErrs, field.Required(syncerPath.Child("kafka", "kafkaAddrs"), "the addresses of the kafka downstream must be set"))
+		}
+	case v1alpha1.DrainerSinkTypeFile:
+	default:
+		allErrs = append(allErrs, field.NotSupported(syncerPath.Child("dbType"), spec.Syncer.DBType,
+			[]string{string(v1alpha1.DrainerSinkTypeMySQL), string(v1alpha1.DrainerSinkTypeTiDB), string(v1alpha1.DrainerSinkTypeKafka), string(v1alpha1.DrainerSinkTypeFile)}))
+	}
+	return allErrs
+}
+
 func validateDMClusterSpec(spec *v1alpha1.DMClusterSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if spec.Version != "" {
@@ -349,6 +527,26 @@ func validateComponentSpec(spec *v1alpha1.ComponentSpec, fldPath *field.Path) fi
 	return allErrs
 }
 
+// validateUpgradeStrategy validates the steps and the bake time of the staged upgrade
+func validateUpgradeStrategy(strategy *v1alpha1.UpgradeStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, step := range strategy.Steps {
+		idxPath := fldPath.Child("steps").Index(i)
+		// the percentage is scaled on 100 replicas to check its range
+		v, err := intstr.GetValueFromIntOrPercent(&strategy.Steps[i], 100, true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath, step.String(), err.Error()))
+		} else if v <= 0 || (step.Type == intstr.String && v > 100) {
+			allErrs = append(allErrs, field.Invalid(idxPath, step.String(), "must be a positive integer or a percentage between 1% and 100%"))
+		}
+	}
+	allErrs = append(allErrs, validateTimeDurationStr(strategy.BakeTime, fldPath.Child("bakeTime"))...)
+	if strategy.AutoRollback != nil {
+		allErrs = append(allErrs, validateTimeDurationStr(strategy.AutoRollback.UnhealthyTimeout, fldPath.Child("autoRollback", "unhealthyTimeout"))...)
+	}
+	return allErrs
+}
+
 //validateRequestsStorage validates resources requests storage
 func validateRequestsStorage(requests corev1.ResourceList, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -16,27 +16,49 @@ package autoscaler
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/autoscaler/autoscaler/calculate"
 	"github.com/pingcap/tidb-operator/pkg/autoscaler/autoscaler/query"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/monitor/monitor"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 )
 
+const (
+	// The windows of the schedules are at most 7 days, which bounds the cron fires
+	// iterated to find the active window
+	maxScheduleDurationSeconds = 7 * 24 * 3600
+	// The placement rules of the tiflash replicas of the tables are in this group
+	tiflashRuleGroup = "tiflash"
+)
+
 type autoScalerManager struct {
 	deps *controller.Dependencies
+
+	// recommendations keeps the replicas recommended by the metrics within the stabilization windows in memory
+	// as the HorizontalPodAutoscaler does, they are kept by the key of the tac and the component
+	recommendations     map[string]map[v1alpha1.MemberType][]calculate.Recommendation
+	recommendationsLock sync.Mutex
 }
 
 func NewAutoScalerManager(deps *controller.Dependencies) *autoScalerManager {
-	return &autoScalerManager{
-		deps: deps,
+	am := &autoScalerManager{
+		deps:            deps,
+		recommendations: map[string]map[v1alpha1.MemberType][]calculate.Recommendation{},
 	}
+	deps.InformerFactory.Pingcap().V1alpha1().TidbClusterAutoScalers().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: am.forgetRecommendations,
+	})
+	return am
 }
 
 func (am *autoScalerManager) Sync(tac *v1alpha1.TidbClusterAutoScaler) error {
@@ -75,13 +97,7 @@ func (am *autoScalerManager) Sync(tac *v1alpha1.TidbClusterAutoScaler) error {
 }
 
 func (am *autoScalerManager) syncExternal(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
-	var cfg *v1alpha1.ExternalConfig
-	switch component {
-	case v1alpha1.TiDBMemberType:
-		cfg = tac.Spec.TiDB.External
-	case v1alpha1.TiKVMemberType:
-		cfg = tac.Spec.TiKV.External
-	}
+	cfg := getBasicAutoScalerSpec(tac, component).External
 
 	targetReplicas, err := query.ExternalService(tc, component, cfg.Endpoint, am.deps.SecretLister)
 	if err != nil {
@@ -89,11 +105,189 @@ func (am *autoScalerManager) syncExternal(tc *v1alpha1.TidbCluster, tac *v1alpha
 		return err
 	}
 
+	window, err := am.getActiveSchedule(tac, component)
+	if err != nil {
+		return err
+	}
+	targetReplicas = boundReplicas(window, targetReplicas)
+
+	if targetReplicas > cfg.MaxReplicas {
+		targetReplicas = cfg.MaxReplicas
+	}
+
+	if component == v1alpha1.TiFlashMemberType {
+		autoReplicas, err := am.getExternalReplicas(tc, component)
+		if err != nil {
+			return err
+		}
+		targetReplicas, err = am.safeTiFlashReplicas(tc, tac, autoReplicas, targetReplicas)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = am.syncExternalResult(tc, tac, component, targetReplicas)
+	updateActiveSchedule(tac, component, window)
+	return err
+}
+
+func (am *autoScalerManager) syncMetrics(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
+	cfg := getBasicAutoScalerSpec(tac, component).Metrics
+	now := time.Now()
+
+	// the replicas are recommended for the whole component, including the replicas of the target TidbCluster
+	baseReplicas := getReplicas(tc, component)
+	autoReplicas, err := am.getExternalReplicas(tc, component)
+	if err != nil {
+		return err
+	}
+	currentReplicas := baseReplicas + autoReplicas
+	if component == v1alpha1.TiCDCMemberType {
+		// the captures alive are taken as the current replicas of ticdc
+		if captures := am.getCaptureCount(tc); captures > 0 {
+			currentReplicas = captures
+		}
+	}
+
+	endpoint := prometheusEndpoint(tac, cfg.Prometheus)
+	var recommendation int32
+	for _, q := range cfg.Queries {
+		value, err := query.Prometheus(endpoint, q.Query, now)
+		if err != nil {
+			klog.Errorf("tac[%s/%s]'s query %s to prometheus for component %s got error: %v", tac.Namespace, tac.Name, q.Name, component.String(), err)
+			return err
+		}
+		replicas := calculate.RecommendedReplicas(currentReplicas, value, q.TargetAverageValue, *cfg.Tolerance)
+		klog.V(4).Infof("tac[%s/%s]'s query %s for component %s got %v, recommended replicas: %d", tac.Namespace, tac.Name, q.Name, component.String(), value, replicas)
+		if replicas > recommendation {
+			recommendation = replicas
+		}
+	}
+	if component == v1alpha1.TiCDCMemberType && tac.Spec.TiCDC.TargetChangefeedLagSeconds != nil {
+		lag, err := query.Prometheus(endpoint, changefeedLagQuery(tc), now)
+		if err != nil {
+			klog.Errorf("tac[%s/%s]'s query of the changefeed lag to prometheus got error: %v", tac.Namespace, tac.Name, err)
+			return err
+		}
+		replicas := calculate.ProportionalReplicas(currentReplicas, lag, float64(*tac.Spec.TiCDC.TargetChangefeedLagSeconds), *cfg.Tolerance)
+		klog.V(4).Infof("tac[%s/%s]'s changefeed lag is %vs with %d captures, recommended replicas: %d", tac.Namespace, tac.Name, lag, currentReplicas, replicas)
+		if replicas > recommendation {
+			recommendation = replicas
+		}
+	}
+
+	targetReplicas := recommendation - baseReplicas
+	if targetReplicas < 0 {
+		targetReplicas = 0
+	}
+	recommendations := am.pruneRecommendations(tac, component, cfg, now)
+	recommendation = targetReplicas
+	targetReplicas = calculate.StabilizeReplicas(autoReplicas, targetReplicas, recommendations, now,
+		time.Duration(*cfg.ScaleOutStabilizationWindowSeconds)*time.Second,
+		time.Duration(*cfg.ScaleInStabilizationWindowSeconds)*time.Second)
+
+	window, err := am.getActiveSchedule(tac, component)
+	if err != nil {
+		return err
+	}
+	targetReplicas = boundReplicas(window, targetReplicas)
+
 	if targetReplicas > cfg.MaxReplicas {
 		targetReplicas = cfg.MaxReplicas
 	}
 
-	return am.syncExternalResult(tc, tac, component, targetReplicas)
+	if component == v1alpha1.TiFlashMemberType {
+		targetReplicas, err = am.safeTiFlashReplicas(tc, tac, autoReplicas, targetReplicas)
+		if err != nil {
+			return err
+		}
+	}
+
+	am.updateRecommendations(tac, component, append(recommendations, calculate.Recommendation{
+		Replicas:  recommendation,
+		Timestamp: now,
+	}))
+	err = am.syncExternalResult(tc, tac, component, targetReplicas)
+	updateActiveSchedule(tac, component, window)
+	return err
+}
+
+// getExternalReplicas returns the replicas of the component in the auto-scaled TidbCluster, 0 if it does not exist
+func (am *autoScalerManager) getExternalReplicas(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType) (int32, error) {
+	externalTc, err := am.deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(externalTcName(tc, component))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return getReplicas(externalTc, component), nil
+}
+
+// getCaptureCount returns the number of the ready captures of the target and the auto-scaled TidbCluster
+func (am *autoScalerManager) getCaptureCount(tc *v1alpha1.TidbCluster) int32 {
+	var count int32
+	countReady := func(captures map[string]v1alpha1.TiCDCCapture) {
+		for _, capture := range captures {
+			if capture.Ready {
+				count++
+			}
+		}
+	}
+	countReady(tc.Status.TiCDC.Captures)
+	if externalTc, err := am.deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(externalTcName(tc, v1alpha1.TiCDCMemberType)); err == nil {
+		countReady(externalTc.Status.TiCDC.Captures)
+	}
+	return count
+}
+
+// changefeedLagQuery returns the PromQL of the largest checkpoint lag of the changefeeds in the cluster,
+// the owner may be a capture of either the target or the auto-scaled TidbCluster
+func changefeedLagQuery(tc *v1alpha1.TidbCluster) string {
+	return fmt.Sprintf(`max(ticdc_owner_checkpoint_ts_lag{tidb_cluster=~"%s-(%s|%s)"})`,
+		tc.Namespace, tc.Name, externalTcName(tc, v1alpha1.TiCDCMemberType))
+}
+
+// safeTiFlashReplicas keeps tiflash from being scaled in below the largest replica count of the tables,
+// otherwise the table replicas can not be placed and the scaling in would never finish
+func (am *autoScalerManager) safeTiFlashReplicas(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, autoReplicas, targetReplicas int32) (int32, error) {
+	if targetReplicas >= autoReplicas {
+		return targetReplicas, nil
+	}
+
+	rules, err := controller.GetPDClient(am.deps.PDControl, tc).GetPlacementRules(tiflashRuleGroup)
+	if err != nil {
+		klog.Errorf("tac[%s/%s] cannot get the placement rules of tiflash, err: %v", tac.Namespace, tac.Name, err)
+		return 0, err
+	}
+	var replicaCount int32
+	for _, rule := range rules {
+		if int32(rule.Count) > replicaCount {
+			replicaCount = int32(rule.Count)
+		}
+	}
+
+	floor := replicaCount - getReplicas(tc, v1alpha1.TiFlashMemberType)
+	if targetReplicas < floor {
+		if floor > autoReplicas {
+			floor = autoReplicas
+		}
+		klog.Infof("tac[%s/%s] keeps %d auto-scaled tiflash replicas instead of %d as tables have %d tiflash replicas", tac.Namespace, tac.Name, floor, targetReplicas, replicaCount)
+		return floor, nil
+	}
+	return targetReplicas, nil
+}
+
+// prometheusEndpoint returns the address of the Prometheus to query
+func prometheusEndpoint(tac *v1alpha1.TidbClusterAutoScaler, source v1alpha1.PrometheusSource) string {
+	if source.Monitor == nil {
+		return source.URL
+	}
+	ns := source.Monitor.Namespace
+	if len(ns) == 0 {
+		ns = tac.Namespace
+	}
+	return fmt.Sprintf("http://%s.%s:9090", monitor.PrometheusName(source.Monitor.Name, 0), ns)
 }
 
 func (am *autoScalerManager) syncPD(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
@@ -105,14 +299,35 @@ func (am *autoScalerManager) syncPD(tc *v1alpha1.TidbCluster, tac *v1alpha1.Tidb
 		return err
 	}
 
+	window, err := am.getActiveSchedule(tac, component)
+	if err != nil {
+		return err
+	}
+	plans = boundPlans(tac, component, plans, window)
+
 	// Apply auto-scaling plans
-	if err := am.syncPlans(tc, tac, plans, component); err != nil {
+	err = am.syncPlans(tc, tac, plans, component)
+	updateActiveSchedule(tac, component, window)
+	if err != nil {
 		klog.Errorf("tac[%s/%s] cannot apply autoscaling plans for component %v err:%v", tac.Namespace, tac.Name, component, err)
 		return err
 	}
 	return nil
 }
 
+// getActiveSchedule returns the active schedule window of the component, nil if no schedule is active
+func (am *autoScalerManager) getActiveSchedule(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) (*v1alpha1.AutoScalerScheduleWindow, error) {
+	window, err := activeScheduleWindow(getBasicAutoScalerSpec(tac, component), time.Now())
+	if err != nil {
+		klog.Errorf("tac[%s/%s] cannot get the active schedule for component %v err:%v", tac.Namespace, tac.Name, component, err)
+		return nil, err
+	}
+	if window != nil {
+		klog.V(4).Infof("tac[%s/%s] schedule %s bounds the replicas of component %v until %s", tac.Namespace, tac.Name, window.Name, component, window.EndTime)
+	}
+	return window, nil
+}
+
 func (am *autoScalerManager) syncAutoScaling(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler) error {
 	var errs []error
 	if tac.Spec.TiDB != nil {
@@ -120,11 +335,21 @@ func (am *autoScalerManager) syncAutoScaling(tc *v1alpha1.TidbCluster, tac *v1al
 			if err := am.syncExternal(tc, tac, v1alpha1.TiDBMemberType); err != nil {
 				errs = append(errs, err)
 			}
-		} else {
+		} else if tac.Spec.TiDB.Metrics != nil {
+			if err := am.syncMetrics(tc, tac, v1alpha1.TiDBMemberType); err != nil {
+				errs = append(errs, err)
+			}
+		} else if tac.Spec.TiDB.Vertical == nil || len(tac.Spec.TiDB.Rules) > 0 {
 			if err := am.syncPD(tc, tac, v1alpha1.TiDBMemberType); err != nil {
 				errs = append(errs, err)
 			}
 		}
+
+		if tac.Spec.TiDB.Vertical != nil {
+			if err := am.syncVertical(tc, tac, v1alpha1.TiDBMemberType); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	}
 
 	if tac.Spec.TiKV != nil {
@@ -132,11 +357,66 @@ func (am *autoScalerManager) syncAutoScaling(tc *v1alpha1.TidbCluster, tac *v1al
 			if err := am.syncExternal(tc, tac, v1alpha1.TiKVMemberType); err != nil {
 				errs = append(errs, err)
 			}
-		} else {
+		} else if tac.Spec.TiKV.Metrics != nil {
+			if err := am.syncMetrics(tc, tac, v1alpha1.TiKVMemberType); err != nil {
+				errs = append(errs, err)
+			}
+		} else if tac.Spec.TiKV.Vertical == nil || len(tac.Spec.TiKV.Rules) > 0 {
 			if err := am.syncPD(tc, tac, v1alpha1.TiKVMemberType); err != nil {
 				errs = append(errs, err)
 			}
 		}
+
+		if tac.Spec.TiKV.Vertical != nil {
+			if err := am.syncVertical(tc, tac, v1alpha1.TiKVMemberType); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	// the auto-scaling plans of PD do not support tiflash and ticdc, so only the external service and the metrics are used
+	if tac.Spec.TiFlash != nil {
+		if tc.Spec.TiFlash == nil {
+			errs = append(errs, fmt.Errorf("tc[%s/%s] has no tiflash to auto-scale for tac[%s/%s]", tc.Namespace, tc.Name, tac.Namespace, tac.Name))
+		} else {
+			if tac.Spec.TiFlash.External != nil {
+				if err := am.syncExternal(tc, tac, v1alpha1.TiFlashMemberType); err != nil {
+					errs = append(errs, err)
+				}
+			} else if tac.Spec.TiFlash.Metrics != nil {
+				if err := am.syncMetrics(tc, tac, v1alpha1.TiFlashMemberType); err != nil {
+					errs = append(errs, err)
+				}
+			}
+
+			if tac.Spec.TiFlash.Vertical != nil {
+				if err := am.syncVertical(tc, tac, v1alpha1.TiFlashMemberType); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	if tac.Spec.TiCDC != nil {
+		if tc.Spec.TiCDC == nil {
+			errs = append(errs, fmt.Errorf("tc[%s/%s] has no ticdc to auto-scale for tac[%s/%s]", tc.Namespace, tc.Name, tac.Namespace, tac.Name))
+		} else {
+			if tac.Spec.TiCDC.External != nil {
+				if err := am.syncExternal(tc, tac, v1alpha1.TiCDCMemberType); err != nil {
+					errs = append(errs, err)
+				}
+			} else if tac.Spec.TiCDC.Metrics != nil {
+				if err := am.syncMetrics(tc, tac, v1alpha1.TiCDCMemberType); err != nil {
+					errs = append(errs, err)
+				}
+			}
+
+			if tac.Spec.TiCDC.Vertical != nil {
+				if err := am.syncVertical(tc, tac, v1alpha1.TiCDCMemberType); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
 	}
 
 	klog.Infof("tc[%s/%s]'s tac[%s/%s] synced", tc.Namespace, tc.Name, tac.Namespace, tac.Name)
@@ -164,6 +444,20 @@ func (am *autoScalerManager) gracefullyDeleteTidbCluster(deleteTc *v1alpha1.Tidb
 		// The TC has scaled in, fall through the code to delete it
 	}
 
+	// TiFlash stores the table replicas too, delete the cluster after the replicas are moved away
+	if deleteTc.Spec.TiFlash != nil {
+		if deleteTc.Spec.TiFlash.Replicas != 0 {
+			cloned := deleteTc.DeepCopy()
+			cloned.Spec.TiFlash.Replicas = 0
+			_, err := am.deps.TiDBClusterControl.UpdateTidbCluster(cloned, &cloned.Status, &deleteTc.Status)
+			return err
+		}
+
+		if deleteTc.Status.TiFlash.StatefulSet != nil && deleteTc.Status.TiFlash.StatefulSet.Replicas != 0 {
+			return nil
+		}
+	}
+
 	return am.deps.Clientset.PingcapV1alpha1().TidbClusters(deleteTc.Namespace).Delete(context.TODO(), deleteTc.Name, metav1.DeleteOptions{})
 }
 
@@ -197,20 +491,64 @@ func (am *autoScalerManager) updateTidbClusterAutoScaler(tac *v1alpha1.TidbClust
 }
 
 func updateLastAutoScalingTimestamp(tac *v1alpha1.TidbClusterAutoScaler, memberType string, group string) {
-	switch memberType {
-	case v1alpha1.TiKVMemberType.String():
-		if tac.Status.TiKV == nil {
-			tac.Status.TiKV = map[string]v1alpha1.TikvAutoScalerStatus{}
-		}
-		status := tac.Status.TiKV[group]
-		status.LastAutoScalingTimestamp = &metav1.Time{Time: time.Now()}
-		tac.Status.TiKV[group] = status
-	case v1alpha1.TiDBMemberType.String():
-		if tac.Status.TiDB == nil {
-			tac.Status.TiDB = map[string]v1alpha1.TidbAutoScalerStatus{}
-		}
-		status := tac.Status.TiDB[group]
-		status.LastAutoScalingTimestamp = &metav1.Time{Time: time.Now()}
-		tac.Status.TiDB[group] = status
+	status, _ := getBasicAutoScalerStatus(tac, v1alpha1.MemberType(memberType), group)
+	status.LastAutoScalingTimestamp = &metav1.Time{Time: time.Now()}
+	setBasicAutoScalerStatus(tac, v1alpha1.MemberType(memberType), group, status)
+}
+
+// updateActiveSchedule records the active schedule window in the status of each group of the component
+func updateActiveSchedule(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, window *v1alpha1.AutoScalerScheduleWindow) {
+	for _, group := range getBasicAutoScalerStatusGroups(tac, component) {
+		status, _ := getBasicAutoScalerStatus(tac, component, group)
+		status.ActiveSchedule = window
+		setBasicAutoScalerStatus(tac, component, group, status)
+	}
+}
+
+// pruneRecommendations returns the recommendations of the component within the stabilization windows
+func (am *autoScalerManager) pruneRecommendations(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, cfg *v1alpha1.MetricsConfig, now time.Time) []calculate.Recommendation {
+	am.recommendationsLock.Lock()
+	defer am.recommendationsLock.Unlock()
+
+	window := *cfg.ScaleInStabilizationWindowSeconds
+	if *cfg.ScaleOutStabilizationWindowSeconds > window {
+		window = *cfg.ScaleOutStabilizationWindowSeconds
+	}
+	cutoff := now.Add(-time.Duration(window) * time.Second)
+	recommendations := am.recommendations[recommendationsKey(tac)][component]
+	result := make([]calculate.Recommendation, 0, len(recommendations))
+	for _, rec := range recommendations {
+		if rec.Timestamp.After(cutoff) {
+			result = append(result, rec)
+		}
+	}
+	return result
+}
+
+func (am *autoScalerManager) updateRecommendations(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, recommendations []calculate.Recommendation) {
+	am.recommendationsLock.Lock()
+	defer am.recommendationsLock.Unlock()
+
+	key := recommendationsKey(tac)
+	if am.recommendations[key] == nil {
+		am.recommendations[key] = map[v1alpha1.MemberType][]calculate.Recommendation{}
 	}
+	am.recommendations[key][component] = recommendations
+}
+
+// forgetRecommendations drops the recommendations of the deleted tac
+func (am *autoScalerManager) forgetRecommendations(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %+v: %v", obj, err))
+		return
+	}
+
+	am.recommendationsLock.Lock()
+	defer am.recommendationsLock.Unlock()
+	delete(am.recommendations, key)
+}
+
+func recommendationsKey(tac *v1alpha1.TidbClusterAutoScaler) string {
+	return fmt.Sprintf("%s/%s", tac.Namespace, tac.Name)
 }
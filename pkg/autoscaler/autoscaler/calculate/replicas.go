@@ -0,0 +1,74 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calculate
+
+import (
+	"math"
+	"time"
+)
+
+// Recommendation describes the replicas recommended at a time
+type Recommendation struct {
+	Replicas  int32
+	Timestamp time.Time
+}
+
+// RecommendedReplicas returns the replicas to bring the average value per replica to the target,
+// the current replicas are kept if the ratio of the average value to the target is within the tolerance
+func RecommendedReplicas(currentReplicas int32, value, targetAverageValue, tolerance float64) int32 {
+	if currentReplicas > 0 {
+		ratio := value / (float64(currentReplicas) * targetAverageValue)
+		if math.Abs(1.0-ratio) <= tolerance {
+			return currentReplicas
+		}
+	}
+	return int32(math.Ceil(value / targetAverageValue))
+}
+
+// ProportionalReplicas returns the replicas scaled in proportion to the ratio of the value of the whole
+// component to the target, the current replicas are kept if the ratio is within the tolerance
+func ProportionalReplicas(currentReplicas int32, value, targetValue, tolerance float64) int32 {
+	if currentReplicas < 1 {
+		currentReplicas = 1
+	}
+	ratio := value / targetValue
+	if math.Abs(1.0-ratio) <= tolerance {
+		return currentReplicas
+	}
+	return int32(math.Ceil(float64(currentReplicas) * ratio))
+}
+
+// StabilizeReplicas stabilizes the recommendation with the past recommendations, the lowest recommendation
+// within the scale-out window and the highest one within the scale-in window bound the change of the replicas
+func StabilizeReplicas(currentReplicas, recommendation int32, recommendations []Recommendation, now time.Time, scaleOutWindow, scaleInWindow time.Duration) int32 {
+	scaleOutRecommendation, scaleInRecommendation := recommendation, recommendation
+	scaleOutCutoff, scaleInCutoff := now.Add(-scaleOutWindow), now.Add(-scaleInWindow)
+	for _, rec := range recommendations {
+		if rec.Timestamp.After(scaleOutCutoff) && rec.Replicas < scaleOutRecommendation {
+			scaleOutRecommendation = rec.Replicas
+		}
+		if rec.Timestamp.After(scaleInCutoff) && rec.Replicas > scaleInRecommendation {
+			scaleInRecommendation = rec.Replicas
+		}
+	}
+
+	replicas := currentReplicas
+	if replicas < scaleOutRecommendation {
+		replicas = scaleOutRecommendation
+	}
+	if replicas > scaleInRecommendation {
+		replicas = scaleInRecommendation
+	}
+	return replicas
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calculate
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRecommendedReplicas(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name            string
+		currentReplicas int32
+		value           float64
+		expected        int32
+	}{
+		{name: "within tolerance", currentReplicas: 3, value: 3200, expected: 3},
+		{name: "scale out", currentReplicas: 3, value: 4500, expected: 5},
+		{name: "scale in", currentReplicas: 3, value: 1500, expected: 2},
+		{name: "scale in to zero", currentReplicas: 3, value: 0, expected: 0},
+		{name: "no current replicas", currentReplicas: 0, value: 900, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Expect(RecommendedReplicas(tt.currentReplicas, tt.value, 1000, 0.1)).Should(Equal(tt.expected))
+		})
+	}
+}
+
+func TestProportionalReplicas(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name            string
+		currentReplicas int32
+		value           float64
+		expected        int32
+	}{
+		{name: "within tolerance", currentReplicas: 2, value: 32, expected: 2},
+		{name: "scale out", currentReplicas: 2, value: 75, expected: 5},
+		{name: "scale in", currentReplicas: 4, value: 10, expected: 2},
+		{name: "no current replicas", currentReplicas: 0, value: 60, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Expect(ProportionalReplicas(tt.currentReplicas, tt.value, 30, 0.1)).Should(Equal(tt.expected))
+		})
+	}
+}
+
+func TestStabilizeReplicas(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	recommendations := []Recommendation{
+		{Replicas: 5, Timestamp: now.Add(-4 * time.Minute)},
+		{Replicas: 2, Timestamp: now.Add(-30 * time.Second)},
+	}
+
+	tests := []struct {
+		name            string
+		currentReplicas int32
+		recommendation  int32
+		scaleOutWindow  time.Duration
+		expected        int32
+	}{
+		{name: "scale in is held by the higher recommendation", currentReplicas: 4, recommendation: 1, expected: 4},
+		{name: "scale in to the highest recommendation", currentReplicas: 6, recommendation: 1, expected: 5},
+		{name: "scale out immediately", currentReplicas: 2, recommendation: 6, expected: 6},
+		{name: "scale out is held by the lower recommendation", currentReplicas: 1, recommendation: 6, scaleOutWindow: time.Minute, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replicas := StabilizeReplicas(tt.currentReplicas, tt.recommendation, recommendations, now, tt.scaleOutWindow, 5*time.Minute)
+			g.Expect(replicas).Should(Equal(tt.expected))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calculate
+
+import (
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const mebibyte = 1024 * 1024
+
+// RecommendedRequest returns the request of the resource recommended from the usage, which is in cores
+// for cpu and in bytes for memory. The change from the current request is limited by the max step ratio,
+// and the recommendation is bounded by the min and max allowed quantity if they are not nil.
+func RecommendedRequest(name corev1.ResourceName, current *resource.Quantity, usage, margin, maxStepRatio float64, min, max *resource.Quantity) resource.Quantity {
+	target := usage * (1 + margin)
+	if current != nil && !current.IsZero() {
+		value := quantityValue(*current)
+		if lower := value * (1 - maxStepRatio); target < lower {
+			target = lower
+		}
+		if upper := value * (1 + maxStepRatio); target > upper {
+			target = upper
+		}
+	}
+
+	recommendation := newQuantity(name, target)
+
+	if min != nil && recommendation.Cmp(*min) < 0 {
+		return min.DeepCopy()
+	}
+	if max != nil && recommendation.Cmp(*max) > 0 {
+		return max.DeepCopy()
+	}
+	return *recommendation
+}
+
+// ProportionalLimit returns the limit scaled in proportion to the ratio of the recommended request to
+// the current request, the limit is never less than the recommended request
+func ProportionalLimit(name corev1.ResourceName, limit, request, recommendation resource.Quantity) resource.Quantity {
+	if request.IsZero() {
+		if limit.Cmp(recommendation) < 0 {
+			return recommendation.DeepCopy()
+		}
+		return limit.DeepCopy()
+	}
+
+	target := quantityValue(limit) * quantityValue(recommendation) / quantityValue(request)
+	scaled := newQuantity(name, target)
+	if scaled.Cmp(recommendation) < 0 {
+		return recommendation.DeepCopy()
+	}
+	return *scaled
+}
+
+// ExceedsTolerance returns whether the recommendation deviates from the current quantity by more than the tolerance
+func ExceedsTolerance(current *resource.Quantity, recommendation resource.Quantity, tolerance float64) bool {
+	if current == nil || current.IsZero() {
+		return true
+	}
+	return math.Abs(quantityValue(recommendation)/quantityValue(*current)-1.0) > tolerance
+}
+
+// quantityValue returns the value of the quantity as a float, in cores for cpu
+func quantityValue(q resource.Quantity) float64 {
+	return float64(q.MilliValue()) / 1000
+}
+
+// newQuantity returns the quantity of the value rounded up, to millicores for cpu and to MiB for the others
+func newQuantity(name corev1.ResourceName, value float64) *resource.Quantity {
+	if name == corev1.ResourceCPU {
+		return resource.NewMilliQuantity(int64(math.Ceil(value*1000)), resource.DecimalSI)
+	}
+	return resource.NewQuantity(int64(math.Ceil(value/mebibyte))*mebibyte, resource.BinarySI)
+}
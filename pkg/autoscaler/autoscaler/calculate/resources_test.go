@@ -0,0 +1,73 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calculate
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRecommendedRequest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	quantity := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+		return &q
+	}
+	tests := []struct {
+		name     string
+		resource corev1.ResourceName
+		current  *resource.Quantity
+		usage    float64
+		min      *resource.Quantity
+		max      *resource.Quantity
+		expected string
+	}{
+		{name: "cpu with margin", resource: corev1.ResourceCPU, current: quantity("2"), usage: 2, expected: "2300m"},
+		{name: "cpu limited by the step", resource: corev1.ResourceCPU, current: quantity("2"), usage: 10, expected: "3"},
+		{name: "cpu limited by the min allowed", resource: corev1.ResourceCPU, current: quantity("2"), usage: 0.5, min: quantity("1500m"), expected: "1500m"},
+		{name: "cpu without the current request", resource: corev1.ResourceCPU, usage: 10, max: quantity("8"), expected: "8"},
+		{name: "memory rounded up to MiB", resource: corev1.ResourceMemory, current: quantity("4Gi"), usage: 3 * 1024 * 1024 * 1024, expected: "3533Mi"},
+		{name: "memory limited by the step", resource: corev1.ResourceMemory, current: quantity("4Gi"), usage: 512 * 1024 * 1024, expected: "2Gi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := RecommendedRequest(tt.resource, tt.current, tt.usage, 0.15, 0.5, tt.min, tt.max)
+			g.Expect(request.Cmp(resource.MustParse(tt.expected))).Should(Equal(0), "got %s", request.String())
+		})
+	}
+}
+
+func TestProportionalLimit(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	limit := ProportionalLimit(corev1.ResourceCPU, resource.MustParse("4"), resource.MustParse("2"), resource.MustParse("3"))
+	g.Expect(limit.Cmp(resource.MustParse("6"))).Should(Equal(0), "got %s", limit.String())
+
+	limit = ProportionalLimit(corev1.ResourceMemory, resource.MustParse("4Gi"), resource.MustParse("0"), resource.MustParse("8Gi"))
+	g.Expect(limit.Cmp(resource.MustParse("8Gi"))).Should(Equal(0), "got %s", limit.String())
+}
+
+func TestExceedsTolerance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	current := resource.MustParse("2")
+	g.Expect(ExceedsTolerance(&current, resource.MustParse("2100m"), 0.1)).Should(BeFalse())
+	g.Expect(ExceedsTolerance(&current, resource.MustParse("2500m"), 0.1)).Should(BeTrue())
+	g.Expect(ExceedsTolerance(nil, resource.MustParse("1"), 0.1)).Should(BeTrue())
+}
@@ -31,8 +31,13 @@ const (
 	specialUseHotRegion   = "hotRegion"
 )
 
+// externalTcName returns the name of the auto-scaled TidbCluster for the external service and the metrics
+func externalTcName(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType) string {
+	return fmt.Sprintf(externalTcNamePattern, tc.ClusterName, component.String())
+}
+
 func (am *autoScalerManager) syncExternalResult(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, targetReplicas int32) error {
-	externalTcName := fmt.Sprintf(externalTcNamePattern, tc.ClusterName, component.String())
+	externalTcName := externalTcName(tc, component)
 	externalTc, err := am.deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(externalTcName)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -53,12 +58,7 @@ func (am *autoScalerManager) syncExternalResult(tc *v1alpha1.TidbCluster, tac *v
 			return err
 		}
 
-		switch component {
-		case v1alpha1.TiDBMemberType:
-			delete(tac.Status.TiDB, externalStatusKey)
-		case v1alpha1.TiKVMemberType:
-			delete(tac.Status.TiKV, externalStatusKey)
-		}
+		deleteBasicAutoScalerStatus(tac, component, externalStatusKey)
 
 		return nil
 	}
@@ -69,11 +69,9 @@ func (am *autoScalerManager) syncExternalResult(tc *v1alpha1.TidbCluster, tac *v
 func (am *autoScalerManager) createExternalAutoCluster(tc *v1alpha1.TidbCluster, externalTcName string, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, targetReplicas int32) error {
 	autoTc := newAutoScalingCluster(tc, tac, externalTcName, component.String())
 
-	switch component {
-	case v1alpha1.TiDBMemberType:
-		autoTc.Spec.TiDB.Replicas = targetReplicas
-	case v1alpha1.TiKVMemberType:
-		autoTc.Spec.TiKV.Replicas = targetReplicas
+	setReplicas(autoTc, component, targetReplicas)
+	// only the tikv scaled out by the external service takes the hot regions
+	if component == v1alpha1.TiKVMemberType && getBasicAutoScalerSpec(tac, component).External != nil {
 		autoTc.Spec.TiKV.Config.Set("server.labels."+specialUseLabelKey, specialUseHotRegion)
 	}
 
@@ -89,26 +87,15 @@ func (am *autoScalerManager) createExternalAutoCluster(tc *v1alpha1.TidbCluster,
 
 func (am *autoScalerManager) updateExternalAutoCluster(externalTc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, targetReplicas int32) error {
 	updated := externalTc.DeepCopy()
-	switch component {
-	case v1alpha1.TiDBMemberType:
-		if updated.Spec.TiDB.Replicas == targetReplicas {
-			return nil
-		}
-
-		if !checkAutoScaling(tac, component, externalStatusKey, updated.Spec.TiDB.Replicas, targetReplicas) {
-			return nil
-		}
-		updated.Spec.TiDB.Replicas = targetReplicas
-	case v1alpha1.TiKVMemberType:
-		if updated.Spec.TiKV.Replicas == targetReplicas {
-			return nil
-		}
+	currentReplicas := getReplicas(updated, component)
+	if currentReplicas == targetReplicas {
+		return nil
+	}
 
-		if !checkAutoScaling(tac, component, externalStatusKey, updated.Spec.TiKV.Replicas, targetReplicas) {
-			return nil
-		}
-		updated.Spec.TiKV.Replicas = targetReplicas
+	if !checkAutoScaling(tac, component, externalStatusKey, currentReplicas, targetReplicas) {
+		return nil
 	}
+	setReplicas(updated, component, targetReplicas)
 
 	_, err := am.deps.TiDBClusterControl.UpdateTidbCluster(updated, &updated.Status, &externalTc.Status)
 	if err != nil {
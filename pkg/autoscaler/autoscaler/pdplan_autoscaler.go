@@ -29,7 +29,11 @@ import (
 	"k8s.io/klog/v2"
 )
 
-const groupLabelKey = "group"
+const (
+	groupLabelKey = "group"
+	// The group planned to reach the floor of a schedule is named with this prefix and the schedule
+	scheduleGroupPrefix = "schedule-"
+)
 
 func (am *autoScalerManager) getAutoScaledClusters(tac *v1alpha1.TidbClusterAutoScaler, components []v1alpha1.MemberType) (tcList []*v1alpha1.TidbCluster, err error) {
 	componentStrings := make([]string, len(components))
@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/autoscaler/autoscaler/calculate"
+)
+
+const prometheusQueryPath = "/api/v1/query"
+
+// Prometheus evaluates the instant query against the Prometheus at endpoint and returns
+// the sum of the values of all the series in the result
+func Prometheus(endpoint, promQL string, ts time.Time) (float64, error) {
+	params := url.Values{}
+	params.Set("query", promQL)
+	params.Set("time", strconv.FormatInt(ts.Unix(), 10))
+	u := fmt.Sprintf("%s%s?%s", strings.TrimSuffix(endpoint, "/"), prometheusQueryPath, params.Encode())
+
+	client := &http.Client{Timeout: defaultTimeout}
+	r, err := client.Get(u)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Body.Close()
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return 0, err
+	}
+	if r.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("query %s from prometheus [%s] failed, response: %v, status code: %v", promQL, endpoint, string(bytes), r.StatusCode)
+	}
+
+	resp := &calculate.Response{}
+	if err := json.Unmarshal(bytes, resp); err != nil {
+		return 0, fmt.Errorf("query %s from prometheus [%s] returns unexpected response %s, the query should return an instant vector: %v", promQL, endpoint, string(bytes), err)
+	}
+	if resp.Status != "success" {
+		return 0, fmt.Errorf("query %s from prometheus [%s] failed, status: %s", promQL, endpoint, resp.Status)
+	}
+	// no series means the metrics are missing, which should not be taken as zero
+	if len(resp.Data.Result) == 0 {
+		return 0, fmt.Errorf("query %s from prometheus [%s] returns no series", promQL, endpoint)
+	}
+
+	var sum float64
+	for _, result := range resp.Data.Result {
+		if len(result.Value) != 2 {
+			return 0, fmt.Errorf("query %s from prometheus [%s] returns unexpected value %v", promQL, endpoint, result.Value)
+		}
+		s, ok := result.Value[1].(string)
+		if !ok {
+			return 0, fmt.Errorf("query %s from prometheus [%s] returns unexpected value %v", promQL, endpoint, result.Value)
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("query %s from prometheus [%s] returns unexpected value %v: %v", promQL, endpoint, result.Value, err)
+		}
+		sum += v
+	}
+	return sum, nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,40 +35,22 @@ var zeroQuantity = resource.MustParse("0")
 
 // checkAutoScaling would check whether an autoscaling for a group is permitted
 func checkAutoScaling(tac *v1alpha1.TidbClusterAutoScaler, memberType v1alpha1.MemberType, group string, beforeReplicas, afterReplicas int32) bool {
+	spec := getBasicAutoScalerSpec(tac, memberType)
 	if beforeReplicas > afterReplicas {
-		switch memberType {
-		case v1alpha1.TiKVMemberType:
-			return checkAutoScalingInterval(tac, *tac.Spec.TiKV.ScaleInIntervalSeconds, memberType, group)
-		case v1alpha1.TiDBMemberType:
-			return checkAutoScalingInterval(tac, *tac.Spec.TiDB.ScaleInIntervalSeconds, memberType, group)
-		}
+		return checkAutoScalingInterval(tac, *spec.ScaleInIntervalSeconds, memberType, group)
 	} else if beforeReplicas < afterReplicas {
-		switch memberType {
-		case v1alpha1.TiKVMemberType:
-			return checkAutoScalingInterval(tac, *tac.Spec.TiKV.ScaleOutIntervalSeconds, memberType, group)
-		case v1alpha1.TiDBMemberType:
-			return checkAutoScalingInterval(tac, *tac.Spec.TiDB.ScaleOutIntervalSeconds, memberType, group)
-		}
+		return checkAutoScalingInterval(tac, *spec.ScaleOutIntervalSeconds, memberType, group)
 	}
 	return true
 }
 
 // checkAutoScalingInterval would check whether there is enough interval duration between every two auto-scaling
 func checkAutoScalingInterval(tac *v1alpha1.TidbClusterAutoScaler, intervalSeconds int32, memberType v1alpha1.MemberType, group string) bool {
-	var lastAutoScalingTimestamp *metav1.Time
-	if memberType == v1alpha1.TiKVMemberType {
-		status, existed := tac.Status.TiKV[group]
-		if !existed {
-			return true
-		}
-		lastAutoScalingTimestamp = status.LastAutoScalingTimestamp
-	} else if memberType == v1alpha1.TiDBMemberType {
-		status, existed := tac.Status.TiDB[group]
-		if !existed {
-			return true
-		}
-		lastAutoScalingTimestamp = status.LastAutoScalingTimestamp
+	status, existed := getBasicAutoScalerStatus(tac, memberType, group)
+	if !existed {
+		return true
 	}
+	lastAutoScalingTimestamp := status.LastAutoScalingTimestamp
 	if lastAutoScalingTimestamp == nil {
 		return true
 	}
@@ -134,10 +117,143 @@ func getBasicAutoScalerSpec(tac *v1alpha1.TidbClusterAutoScaler, component v1alp
 		return &tac.Spec.TiDB.BasicAutoScalerSpec
 	case v1alpha1.TiKVMemberType:
 		return &tac.Spec.TiKV.BasicAutoScalerSpec
+	case v1alpha1.TiFlashMemberType:
+		return &tac.Spec.TiFlash.BasicAutoScalerSpec
+	case v1alpha1.TiCDCMemberType:
+		return &tac.Spec.TiCDC.BasicAutoScalerSpec
 	}
 	return nil
 }
 
+// getBasicAutoScalerStatus returns the status of the group for the component, and whether it exists
+func getBasicAutoScalerStatus(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, group string) (v1alpha1.BasicAutoScalerStatus, bool) {
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		status, existed := tac.Status.TiDB[group]
+		return status.BasicAutoScalerStatus, existed
+	case v1alpha1.TiKVMemberType:
+		status, existed := tac.Status.TiKV[group]
+		return status.BasicAutoScalerStatus, existed
+	case v1alpha1.TiFlashMemberType:
+		status, existed := tac.Status.TiFlash[group]
+		return status.BasicAutoScalerStatus, existed
+	case v1alpha1.TiCDCMemberType:
+		status, existed := tac.Status.TiCDC[group]
+		return status.BasicAutoScalerStatus, existed
+	}
+	return v1alpha1.BasicAutoScalerStatus{}, false
+}
+
+// getBasicAutoScalerStatusGroups returns the groups with status for the component
+func getBasicAutoScalerStatusGroups(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) []string {
+	var groups []string
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		for group := range tac.Status.TiDB {
+			groups = append(groups, group)
+		}
+	case v1alpha1.TiKVMemberType:
+		for group := range tac.Status.TiKV {
+			groups = append(groups, group)
+		}
+	case v1alpha1.TiFlashMemberType:
+		for group := range tac.Status.TiFlash {
+			groups = append(groups, group)
+		}
+	case v1alpha1.TiCDCMemberType:
+		for group := range tac.Status.TiCDC {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func setBasicAutoScalerStatus(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, group string, status v1alpha1.BasicAutoScalerStatus) {
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		if tac.Status.TiDB == nil {
+			tac.Status.TiDB = map[string]v1alpha1.TidbAutoScalerStatus{}
+		}
+		tac.Status.TiDB[group] = v1alpha1.TidbAutoScalerStatus{BasicAutoScalerStatus: status}
+	case v1alpha1.TiKVMemberType:
+		if tac.Status.TiKV == nil {
+			tac.Status.TiKV = map[string]v1alpha1.TikvAutoScalerStatus{}
+		}
+		tac.Status.TiKV[group] = v1alpha1.TikvAutoScalerStatus{BasicAutoScalerStatus: status}
+	case v1alpha1.TiFlashMemberType:
+		if tac.Status.TiFlash == nil {
+			tac.Status.TiFlash = map[string]v1alpha1.TiflashAutoScalerStatus{}
+		}
+		tac.Status.TiFlash[group] = v1alpha1.TiflashAutoScalerStatus{BasicAutoScalerStatus: status}
+	case v1alpha1.TiCDCMemberType:
+		if tac.Status.TiCDC == nil {
+			tac.Status.TiCDC = map[string]v1alpha1.TicdcAutoScalerStatus{}
+		}
+		tac.Status.TiCDC[group] = v1alpha1.TicdcAutoScalerStatus{BasicAutoScalerStatus: status}
+	}
+}
+
+func deleteBasicAutoScalerStatus(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, group string) {
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		delete(tac.Status.TiDB, group)
+	case v1alpha1.TiKVMemberType:
+		delete(tac.Status.TiKV, group)
+	case v1alpha1.TiFlashMemberType:
+		delete(tac.Status.TiFlash, group)
+	case v1alpha1.TiCDCMemberType:
+		delete(tac.Status.TiCDC, group)
+	}
+}
+
+// setComponentAutoScalerStatus sets the status of the component, the empty status is removed
+func setComponentAutoScalerStatus(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, status v1alpha1.ComponentAutoScalerStatus) {
+	if status == (v1alpha1.ComponentAutoScalerStatus{}) {
+		delete(tac.Status.Components, component.String())
+		return
+	}
+	if tac.Status.Components == nil {
+		tac.Status.Components = map[string]v1alpha1.ComponentAutoScalerStatus{}
+	}
+	tac.Status.Components[component.String()] = status
+}
+
+// getReplicas returns the replicas of the component in the TidbCluster, 0 if the component is not defined
+func getReplicas(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType) int32 {
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		if tc.Spec.TiDB != nil {
+			return tc.Spec.TiDB.Replicas
+		}
+	case v1alpha1.TiKVMemberType:
+		if tc.Spec.TiKV != nil {
+			return tc.Spec.TiKV.Replicas
+		}
+	case v1alpha1.TiFlashMemberType:
+		if tc.Spec.TiFlash != nil {
+			return tc.Spec.TiFlash.Replicas
+		}
+	case v1alpha1.TiCDCMemberType:
+		if tc.Spec.TiCDC != nil {
+			return tc.Spec.TiCDC.Replicas
+		}
+	}
+	return 0
+}
+
+func setReplicas(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType, replicas int32) {
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		tc.Spec.TiDB.Replicas = replicas
+	case v1alpha1.TiKVMemberType:
+		tc.Spec.TiKV.Replicas = replicas
+	case v1alpha1.TiFlashMemberType:
+		tc.Spec.TiFlash.Replicas = replicas
+	case v1alpha1.TiCDCMemberType:
+		tc.Spec.TiCDC.Replicas = replicas
+	}
+}
+
 func getSpecResources(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) map[string]v1alpha1.AutoResource {
 	switch component {
 	case v1alpha1.TiDBMemberType:
@@ -162,10 +278,27 @@ func defaultBasicAutoScaler(tac *v1alpha1.TidbClusterAutoScaler, component v1alp
 		spec.ScaleInIntervalSeconds = pointer.Int32Ptr(500)
 	}
 
+	if spec.Vertical != nil {
+		defaultVertical(spec.Vertical)
+	}
+
 	if spec.External != nil {
 		return
 	}
 
+	if spec.Metrics != nil {
+		if spec.Metrics.Tolerance == nil {
+			spec.Metrics.Tolerance = pointer.Float64Ptr(0.1)
+		}
+		if spec.Metrics.ScaleOutStabilizationWindowSeconds == nil {
+			spec.Metrics.ScaleOutStabilizationWindowSeconds = pointer.Int32Ptr(0)
+		}
+		if spec.Metrics.ScaleInStabilizationWindowSeconds == nil {
+			spec.Metrics.ScaleInStabilizationWindowSeconds = pointer.Int32Ptr(300)
+		}
+		return
+	}
+
 	for res := range spec.Rules {
 		rule := spec.Rules[res]
 
@@ -179,6 +312,30 @@ func defaultBasicAutoScaler(tac *v1alpha1.TidbClusterAutoScaler, component v1alp
 	}
 }
 
+func defaultVertical(cfg *v1alpha1.VerticalAutoScalerConfig) {
+	if cfg.Mode == "" {
+		cfg.Mode = v1alpha1.VerticalScalingModeRecommend
+	}
+	if cfg.HistoryWindowSeconds == nil {
+		cfg.HistoryWindowSeconds = pointer.Int32Ptr(86400)
+	}
+	if cfg.CPUPercentile == nil {
+		cfg.CPUPercentile = pointer.Float64Ptr(0.9)
+	}
+	if cfg.Margin == nil {
+		cfg.Margin = pointer.Float64Ptr(0.15)
+	}
+	if cfg.MaxStepRatio == nil {
+		cfg.MaxStepRatio = pointer.Float64Ptr(0.5)
+	}
+	if cfg.Tolerance == nil {
+		cfg.Tolerance = pointer.Float64Ptr(0.1)
+	}
+	if cfg.UpdateIntervalSeconds == nil {
+		cfg.UpdateIntervalSeconds = pointer.Int32Ptr(3600)
+	}
+}
+
 // If the minReplicas not set, the default value would be 1
 // If the Metrics not set, the default metric will be set to 80% average CPU utilization.
 // defaultTAC would default the omitted value
@@ -188,11 +345,11 @@ func defaultTAC(tac *v1alpha1.TidbClusterAutoScaler, tc *v1alpha1.TidbCluster) {
 	}
 
 	// Construct default resource
-	if tac.Spec.TiKV != nil && tac.Spec.TiKV.External == nil && len(tac.Spec.TiKV.Resources) == 0 {
+	if tac.Spec.TiKV != nil && tac.Spec.TiKV.External == nil && tac.Spec.TiKV.Metrics == nil && len(tac.Spec.TiKV.Resources) == 0 {
 		defaultResources(tc, tac, v1alpha1.TiKVMemberType)
 	}
 
-	if tac.Spec.TiDB != nil && tac.Spec.TiDB.External == nil && len(tac.Spec.TiDB.Resources) == 0 {
+	if tac.Spec.TiDB != nil && tac.Spec.TiDB.External == nil && tac.Spec.TiDB.Metrics == nil && len(tac.Spec.TiDB.Resources) == 0 {
 		defaultResources(tc, tac, v1alpha1.TiDBMemberType)
 	}
 
@@ -204,15 +361,88 @@ func defaultTAC(tac *v1alpha1.TidbClusterAutoScaler, tc *v1alpha1.TidbCluster) {
 		defaultBasicAutoScaler(tac, v1alpha1.TiKVMemberType)
 	}
 
+	if tiflash := tac.Spec.TiFlash; tiflash != nil {
+		defaultBasicAutoScaler(tac, v1alpha1.TiFlashMemberType)
+	}
+
+	if ticdc := tac.Spec.TiCDC; ticdc != nil {
+		defaultBasicAutoScaler(tac, v1alpha1.TiCDCMemberType)
+	}
+
+}
+
+func validateSchedules(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
+	spec := getBasicAutoScalerSpec(tac, component)
+	names := map[string]struct{}{}
+
+	for _, schedule := range spec.Schedules {
+		if len(schedule.Name) == 0 {
+			return fmt.Errorf("no name defined for schedule of %s in %s/%s", component.String(), tac.Namespace, tac.Name)
+		}
+		if _, ok := names[schedule.Name]; ok {
+			return fmt.Errorf("duplicated schedule %s of %s in %s/%s", schedule.Name, component.String(), tac.Namespace, tac.Name)
+		}
+		names[schedule.Name] = struct{}{}
+		if _, err := cron.ParseStandard(schedule.Schedule); err != nil {
+			return fmt.Errorf("invalid cron format %s for schedule %s of %s in %s/%s: %v", schedule.Schedule, schedule.Name, component.String(), tac.Namespace, tac.Name, err)
+		}
+		if schedule.DurationSeconds <= 0 || schedule.DurationSeconds > maxScheduleDurationSeconds {
+			return fmt.Errorf("durationSeconds (%d) should be in (0, %d] for schedule %s of %s in %s/%s", schedule.DurationSeconds, maxScheduleDurationSeconds, schedule.Name, component.String(), tac.Namespace, tac.Name)
+		}
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone %s for schedule %s of %s in %s/%s: %v", schedule.TimeZone, schedule.Name, component.String(), tac.Namespace, tac.Name, err)
+		}
+		if schedule.MinReplicas == nil && schedule.MaxReplicas == nil {
+			return fmt.Errorf("neither minReplicas nor maxReplicas is defined for schedule %s of %s in %s/%s", schedule.Name, component.String(), tac.Namespace, tac.Name)
+		}
+		if schedule.MinReplicas != nil && *schedule.MinReplicas < 0 {
+			return fmt.Errorf("minReplicas (%d) should not be negative for schedule %s of %s in %s/%s", *schedule.MinReplicas, schedule.Name, component.String(), tac.Namespace, tac.Name)
+		}
+		if schedule.MaxReplicas != nil && *schedule.MaxReplicas < 0 {
+			return fmt.Errorf("maxReplicas (%d) should not be negative for schedule %s of %s in %s/%s", *schedule.MaxReplicas, schedule.Name, component.String(), tac.Namespace, tac.Name)
+		}
+		if schedule.MinReplicas != nil && schedule.MaxReplicas != nil && *schedule.MinReplicas > *schedule.MaxReplicas {
+			return fmt.Errorf("minReplicas (%d) > maxReplicas (%d) for schedule %s of %s in %s/%s", *schedule.MinReplicas, *schedule.MaxReplicas, schedule.Name, component.String(), tac.Namespace, tac.Name)
+		}
+	}
+
+	return nil
 }
 
 func validateBasicAutoScalerSpec(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
 	spec := getBasicAutoScalerSpec(tac, component)
 
+	if err := validateSchedules(tac, component); err != nil {
+		return err
+	}
+
+	if spec.Vertical != nil {
+		if err := validateVertical(tac, component); err != nil {
+			return err
+		}
+	}
+
 	if spec.External != nil {
+		if spec.Metrics != nil {
+			return fmt.Errorf("both external and metrics are defined for component %s in %s/%s", component.String(), tac.Namespace, tac.Name)
+		}
+		return nil
+	}
+
+	if spec.Metrics != nil {
+		return validateMetrics(tac, component)
+	}
+
+	// only the vertical auto-scaling is enabled
+	if spec.Vertical != nil && len(spec.Rules) == 0 {
 		return nil
 	}
 
+	// the auto-scaling plans of PD only support tidb and tikv
+	if component == v1alpha1.TiFlashMemberType || component == v1alpha1.TiCDCMemberType {
+		return fmt.Errorf("neither external, metrics nor vertical is defined for component %s in %s/%s", component.String(), tac.Namespace, tac.Name)
+	}
+
 	if len(spec.Rules) == 0 {
 		return fmt.Errorf("no rules defined for component %s in %s/%s", component.String(), tac.Namespace, tac.Name)
 	}
@@ -267,12 +497,99 @@ func validateBasicAutoScalerSpec(tac *v1alpha1.TidbClusterAutoScaler, component
 	return nil
 }
 
+func validatePrometheusSource(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, source v1alpha1.PrometheusSource) error {
+	if (source.Monitor == nil) == (len(source.URL) == 0) {
+		return fmt.Errorf("either monitor or url of prometheus should be defined for component %s in %s/%s", component.String(), tac.Namespace, tac.Name)
+	}
+	if source.Monitor != nil && len(source.Monitor.Name) == 0 {
+		return fmt.Errorf("no name defined for the monitor of prometheus for component %s in %s/%s", component.String(), tac.Namespace, tac.Name)
+	}
+	return nil
+}
+
+func validateVertical(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
+	cfg := getBasicAutoScalerSpec(tac, component).Vertical
+
+	if err := validatePrometheusSource(tac, component, cfg.Prometheus); err != nil {
+		return err
+	}
+	if cfg.Mode != v1alpha1.VerticalScalingModeRecommend && cfg.Mode != v1alpha1.VerticalScalingModeApply {
+		return fmt.Errorf("unknown vertical auto-scaling mode %s for component %s in %s/%s", cfg.Mode, component.String(), tac.Namespace, tac.Name)
+	}
+	if *cfg.HistoryWindowSeconds <= 0 {
+		return fmt.Errorf("historyWindowSeconds (%d) should be positive for component %s in %s/%s", *cfg.HistoryWindowSeconds, component.String(), tac.Namespace, tac.Name)
+	}
+	if *cfg.CPUPercentile <= 0.0 || *cfg.CPUPercentile > 1.0 {
+		return fmt.Errorf("cpuPercentile (%v) should be in (0, 1] for component %s in %s/%s", *cfg.CPUPercentile, component.String(), tac.Namespace, tac.Name)
+	}
+	if *cfg.Margin < 0.0 {
+		return fmt.Errorf("margin (%v) should not be negative for component %s in %s/%s", *cfg.Margin, component.String(), tac.Namespace, tac.Name)
+	}
+	if *cfg.MaxStepRatio <= 0.0 || *cfg.MaxStepRatio > 1.0 {
+		return fmt.Errorf("maxStepRatio (%v) should be in (0, 1] for component %s in %s/%s", *cfg.MaxStepRatio, component.String(), tac.Namespace, tac.Name)
+	}
+	if *cfg.Tolerance < 0.0 || *cfg.Tolerance > 1.0 {
+		return fmt.Errorf("tolerance (%v) should be between 0 and 1 for component %s in %s/%s", *cfg.Tolerance, component.String(), tac.Namespace, tac.Name)
+	}
+	if *cfg.UpdateIntervalSeconds < 0 {
+		return fmt.Errorf("updateIntervalSeconds (%d) should not be negative for component %s in %s/%s", *cfg.UpdateIntervalSeconds, component.String(), tac.Namespace, tac.Name)
+	}
+	for _, list := range []corev1.ResourceList{cfg.MinAllowed, cfg.MaxAllowed} {
+		for name := range list {
+			if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+				return fmt.Errorf("unknown resource %s for the vertical auto-scaling of %s in %s/%s", name, component.String(), tac.Namespace, tac.Name)
+			}
+		}
+	}
+	for name, min := range cfg.MinAllowed {
+		if max, ok := cfg.MaxAllowed[name]; ok && min.Cmp(max) > 0 {
+			return fmt.Errorf("minAllowed (%s) > maxAllowed (%s) of %s for component %s in %s/%s", min.String(), max.String(), name, component.String(), tac.Namespace, tac.Name)
+		}
+	}
+
+	return nil
+}
+
+func validateMetrics(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
+	cfg := getBasicAutoScalerSpec(tac, component).Metrics
+
+	if err := validatePrometheusSource(tac, component, cfg.Prometheus); err != nil {
+		return err
+	}
+	if component == v1alpha1.TiCDCMemberType && tac.Spec.TiCDC.TargetChangefeedLagSeconds != nil {
+		if *tac.Spec.TiCDC.TargetChangefeedLagSeconds <= 0 {
+			return fmt.Errorf("targetChangefeedLagSeconds (%d) should be positive for ticdc in %s/%s", *tac.Spec.TiCDC.TargetChangefeedLagSeconds, tac.Namespace, tac.Name)
+		}
+	} else if len(cfg.Queries) == 0 {
+		return fmt.Errorf("no queries defined for component %s in %s/%s", component.String(), tac.Namespace, tac.Name)
+	}
+	for _, q := range cfg.Queries {
+		if len(q.Query) == 0 {
+			return fmt.Errorf("no promql defined for query %s of %s in %s/%s", q.Name, component.String(), tac.Namespace, tac.Name)
+		}
+		if q.TargetAverageValue <= 0 {
+			return fmt.Errorf("targetAverageValue (%v) should be positive for query %s of %s in %s/%s", q.TargetAverageValue, q.Name, component.String(), tac.Namespace, tac.Name)
+		}
+	}
+	if cfg.MaxReplicas < 0 {
+		return fmt.Errorf("maxReplicas (%d) should not be negative for component %s in %s/%s", cfg.MaxReplicas, component.String(), tac.Namespace, tac.Name)
+	}
+	if *cfg.Tolerance < 0.0 || *cfg.Tolerance > 1.0 {
+		return fmt.Errorf("tolerance (%v) should be between 0 and 1 for component %s in %s/%s", *cfg.Tolerance, component.String(), tac.Namespace, tac.Name)
+	}
+	if *cfg.ScaleOutStabilizationWindowSeconds < 0 || *cfg.ScaleInStabilizationWindowSeconds < 0 {
+		return fmt.Errorf("stabilization window seconds should not be negative for component %s in %s/%s", component.String(), tac.Namespace, tac.Name)
+	}
+
+	return nil
+}
+
 func validateTAC(tac *v1alpha1.TidbClusterAutoScaler) error {
-	if tac.Spec.TiDB != nil && tac.Spec.TiDB.External == nil && len(tac.Spec.TiDB.Resources) == 0 {
+	if tac.Spec.TiDB != nil && tac.Spec.TiDB.External == nil && tac.Spec.TiDB.Metrics == nil && len(tac.Spec.TiDB.Resources) == 0 {
 		return fmt.Errorf("no resources provided for tidb in %s/%s", tac.Namespace, tac.Name)
 	}
 
-	if tac.Spec.TiKV != nil && tac.Spec.TiKV.External == nil && len(tac.Spec.TiKV.Resources) == 0 {
+	if tac.Spec.TiKV != nil && tac.Spec.TiKV.External == nil && tac.Spec.TiKV.Metrics == nil && len(tac.Spec.TiKV.Resources) == 0 {
 		return fmt.Errorf("no resources provided for tikv in %s/%s", tac.Namespace, tac.Name)
 	}
 
@@ -290,6 +607,20 @@ func validateTAC(tac *v1alpha1.TidbClusterAutoScaler) error {
 		}
 	}
 
+	if tiflash := tac.Spec.TiFlash; tiflash != nil {
+		err := validateBasicAutoScalerSpec(tac, v1alpha1.TiFlashMemberType)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ticdc := tac.Spec.TiCDC; ticdc != nil {
+		err := validateBasicAutoScalerSpec(tac, v1alpha1.TiCDCMemberType)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -392,25 +723,160 @@ func newAutoScalingCluster(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAu
 		Name:      tc.Name,
 	}
 
-	autoTc.Spec.TiCDC = nil
-	autoTc.Spec.TiFlash = nil
 	autoTc.Spec.PD = nil
 	autoTc.Spec.Pump = nil
 
 	switch component {
 	case v1alpha1.TiDBMemberType.String():
+		autoTc.Spec.TiCDC = nil
+		autoTc.Spec.TiFlash = nil
 		autoTc.Spec.TiKV = nil
 		// Initialize Config
 		if autoTc.Spec.TiDB.Config == nil {
 			autoTc.Spec.TiDB.Config = v1alpha1.NewTiDBConfig()
 		}
 	case v1alpha1.TiKVMemberType.String():
+		autoTc.Spec.TiCDC = nil
+		autoTc.Spec.TiFlash = nil
 		autoTc.Spec.TiDB = nil
 		// Initialize Config
 		if autoTc.Spec.TiKV.Config == nil {
 			autoTc.Spec.TiKV.Config = v1alpha1.NewTiKVConfig()
 		}
+	case v1alpha1.TiFlashMemberType.String():
+		autoTc.Spec.TiCDC = nil
+		autoTc.Spec.TiDB = nil
+		autoTc.Spec.TiKV = nil
+	case v1alpha1.TiCDCMemberType.String():
+		autoTc.Spec.TiFlash = nil
+		autoTc.Spec.TiDB = nil
+		autoTc.Spec.TiKV = nil
 	}
 
 	return autoTc
 }
+
+// activeScheduleWindow returns the window of the first schedule active at now, or nil if there is none
+func activeScheduleWindow(spec *v1alpha1.BasicAutoScalerSpec, now time.Time) (*v1alpha1.AutoScalerScheduleWindow, error) {
+	for _, schedule := range spec.Schedules {
+		sched, err := cron.ParseStandard(schedule.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("parse schedule %s cron format %s failed, err: %v", schedule.Name, schedule.Schedule, err)
+		}
+		// an empty time zone is loaded as UTC, so fall back to the time zone of the operator explicitly
+		loc := time.Local
+		if len(schedule.TimeZone) > 0 {
+			if loc, err = time.LoadLocation(schedule.TimeZone); err != nil {
+				return nil, fmt.Errorf("load time zone %s of schedule %s failed, err: %v", schedule.TimeZone, schedule.Name, err)
+			}
+		}
+
+		// the window is active if it starts within the last duration, take the latest start
+		// in case the windows of the schedule overlap
+		duration := time.Duration(schedule.DurationSeconds) * time.Second
+		localNow := now.In(loc)
+		var start time.Time
+		for t := sched.Next(localNow.Add(-duration)); !t.IsZero() && !t.After(localNow); t = sched.Next(t) {
+			start = t
+		}
+		if start.IsZero() {
+			continue
+		}
+
+		return &v1alpha1.AutoScalerScheduleWindow{
+			Name:        schedule.Name,
+			StartTime:   metav1.NewTime(start),
+			EndTime:     metav1.NewTime(start.Add(duration)),
+			MinReplicas: schedule.MinReplicas,
+			MaxReplicas: schedule.MaxReplicas,
+		}, nil
+	}
+	return nil, nil
+}
+
+// boundReplicas bounds the replicas by the floor and the ceiling of the window
+func boundReplicas(window *v1alpha1.AutoScalerScheduleWindow, replicas int32) int32 {
+	if window == nil {
+		return replicas
+	}
+	if window.MinReplicas != nil && replicas < *window.MinReplicas {
+		replicas = *window.MinReplicas
+	}
+	if window.MaxReplicas != nil && replicas > *window.MaxReplicas {
+		replicas = *window.MaxReplicas
+	}
+	return replicas
+}
+
+// boundPlans adjusts the plans so that their total count is bounded by the window. The missing replicas
+// are added to the first group, and the excess replicas are removed from the last groups. If PD recommends
+// no group, a group of the schedule is planned to reach the floor.
+func boundPlans(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, plans []pdapi.Plan, window *v1alpha1.AutoScalerScheduleWindow) []pdapi.Plan {
+	var total int32
+	for _, plan := range plans {
+		total += int32(plan.Count)
+	}
+	target := boundReplicas(window, total)
+	if target == total {
+		return plans
+	}
+
+	if target > total && len(plans) == 0 {
+		resourceType, ok := scheduleResourceType(tac, component)
+		if !ok {
+			klog.Warningf("tac[%s/%s] has no resource type to scale out %s for schedule %s", tac.Namespace, tac.Name, component.String(), window.Name)
+			return plans
+		}
+		klog.Infof("tac[%s/%s] has no group recommended by PD, scale out %s to %d for schedule %s", tac.Namespace, tac.Name, component.String(), target, window.Name)
+		return []pdapi.Plan{{
+			Component:    component.String(),
+			Count:        uint64(target),
+			ResourceType: resourceType,
+			Labels:       map[string]string{groupLabelKey: scheduleGroupPrefix + window.Name},
+		}}
+	}
+
+	bounded := make([]pdapi.Plan, len(plans))
+	copy(bounded, plans)
+	sort.SliceStable(bounded, func(i, j int) bool {
+		return bounded[i].Labels[groupLabelKey] < bounded[j].Labels[groupLabelKey]
+	})
+
+	if target > total {
+		bounded[0].Count += uint64(target - total)
+		return bounded
+	}
+
+	excess := uint64(total - target)
+	for i := len(bounded) - 1; i >= 0 && excess > 0; i-- {
+		removed := bounded[i].Count
+		if removed > excess {
+			removed = excess
+		}
+		bounded[i].Count -= removed
+		excess -= removed
+	}
+	// the clusters of the groups without replicas are deleted
+	result := make([]pdapi.Plan, 0, len(bounded))
+	for _, plan := range bounded {
+		if plan.Count > 0 {
+			result = append(result, plan)
+		}
+	}
+	return result
+}
+
+// scheduleResourceType returns the resource type of the group planned for a schedule,
+// the first one in order of the resource types of the component
+func scheduleResourceType(tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) (string, bool) {
+	resources := getSpecResources(tac, component)
+	if len(resources) == 0 {
+		return "", false
+	}
+	types := make([]string, 0, len(resources))
+	for typ := range resources {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types[0], true
+}
@@ -14,15 +14,26 @@
 package autoscaler
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/autoscaler/autoscaler/calculate"
+	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/fake"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clitesting "k8s.io/client-go/testing"
 	"k8s.io/utils/pointer"
 )
 
@@ -581,6 +592,638 @@ func TestValidateTidbClusterAutoScaler(t *testing.T) {
 	g.Expect(err).Should(BeNil())
 }
 
+func TestValidateSchedules(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		schedule    v1alpha1.AutoScalerSchedule
+		expectedErr string
+	}{
+		{
+			name:        "invalid cron format",
+			schedule:    v1alpha1.AutoScalerSchedule{Name: "peak", Schedule: "0 8 * *", DurationSeconds: 3600, MinReplicas: pointer.Int32Ptr(1)},
+			expectedErr: "invalid cron format 0 8 * * for schedule peak of tidb in default/tac",
+		},
+		{
+			name:        "non-positive duration",
+			schedule:    v1alpha1.AutoScalerSchedule{Name: "peak", Schedule: "0 8 * * *", MinReplicas: pointer.Int32Ptr(1)},
+			expectedErr: "durationSeconds (0) should be in (0, 604800] for schedule peak of tidb in default/tac",
+		},
+		{
+			name:        "too long duration",
+			schedule:    v1alpha1.AutoScalerSchedule{Name: "peak", Schedule: "* * * * *", DurationSeconds: 8 * 24 * 3600, MinReplicas: pointer.Int32Ptr(1)},
+			expectedErr: "durationSeconds (691200) should be in (0, 604800] for schedule peak of tidb in default/tac",
+		},
+		{
+			name:        "invalid time zone",
+			schedule:    v1alpha1.AutoScalerSchedule{Name: "peak", Schedule: "0 8 * * *", DurationSeconds: 3600, TimeZone: "Mars/Olympus", MinReplicas: pointer.Int32Ptr(1)},
+			expectedErr: "invalid time zone Mars/Olympus for schedule peak of tidb in default/tac",
+		},
+		{
+			name:        "no replicas",
+			schedule:    v1alpha1.AutoScalerSchedule{Name: "peak", Schedule: "0 8 * * *", DurationSeconds: 3600},
+			expectedErr: "neither minReplicas nor maxReplicas is defined for schedule peak of tidb in default/tac",
+		},
+		{
+			name:        "minReplicas > maxReplicas",
+			schedule:    v1alpha1.AutoScalerSchedule{Name: "peak", Schedule: "0 8 * * *", DurationSeconds: 3600, MinReplicas: pointer.Int32Ptr(3), MaxReplicas: pointer.Int32Ptr(2)},
+			expectedErr: "minReplicas (3) > maxReplicas (2) for schedule peak of tidb in default/tac",
+		},
+		{
+			name:     "valid schedule",
+			schedule: v1alpha1.AutoScalerSchedule{Name: "peak", Schedule: "0 8 * * *", DurationSeconds: 3600, TimeZone: "Asia/Shanghai", MinReplicas: pointer.Int32Ptr(2), MaxReplicas: pointer.Int32Ptr(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tac := newTidbClusterAutoScaler()
+			tac.Spec.TiDB.Schedules = []v1alpha1.AutoScalerSchedule{tt.schedule}
+			err := validateSchedules(tac, v1alpha1.TiDBMemberType)
+			if tt.expectedErr == "" {
+				g.Expect(err).Should(BeNil())
+			} else {
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(HavePrefix(tt.expectedErr))
+			}
+		})
+	}
+}
+
+func TestActiveScheduleWindow(t *testing.T) {
+	g := NewGomegaWithT(t)
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	g.Expect(err).Should(BeNil())
+
+	spec := &v1alpha1.BasicAutoScalerSpec{
+		Schedules: []v1alpha1.AutoScalerSchedule{
+			{
+				Name:            "daytime",
+				Schedule:        "0 8 * * *",
+				DurationSeconds: 12 * 3600,
+				TimeZone:        "Asia/Shanghai",
+				MinReplicas:     pointer.Int32Ptr(4),
+			},
+			{
+				Name:            "batch",
+				Schedule:        "0 1 * * *",
+				DurationSeconds: 3 * 3600,
+				TimeZone:        "Asia/Shanghai",
+				MinReplicas:     pointer.Int32Ptr(2),
+				MaxReplicas:     pointer.Int32Ptr(2),
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		expectedName  string
+		expectedStart time.Time
+	}{
+		{
+			name:          "in the daytime window",
+			now:           time.Date(2021, 6, 1, 10, 0, 0, 0, shanghai),
+			expectedName:  "daytime",
+			expectedStart: time.Date(2021, 6, 1, 8, 0, 0, 0, shanghai),
+		},
+		{
+			name:          "at the start of the batch window",
+			now:           time.Date(2021, 6, 1, 1, 0, 0, 0, shanghai),
+			expectedName:  "batch",
+			expectedStart: time.Date(2021, 6, 1, 1, 0, 0, 0, shanghai),
+		},
+		{
+			name: "at the end of the daytime window",
+			now:  time.Date(2021, 6, 1, 20, 0, 0, 0, shanghai),
+		},
+		{
+			name:          "evaluated in the time zone of the schedule",
+			now:           time.Date(2021, 6, 1, 18, 0, 0, 0, time.UTC),
+			expectedName:  "batch",
+			expectedStart: time.Date(2021, 6, 2, 1, 0, 0, 0, shanghai),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := activeScheduleWindow(spec, tt.now)
+			g.Expect(err).Should(BeNil())
+			if tt.expectedName == "" {
+				g.Expect(window).Should(BeNil())
+				return
+			}
+			g.Expect(window).ShouldNot(BeNil())
+			g.Expect(window.Name).Should(Equal(tt.expectedName))
+			g.Expect(window.StartTime.Time.Equal(tt.expectedStart)).Should(BeTrue())
+		})
+	}
+}
+
+func TestBoundPlans(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newPlan := func(group string, count uint64) pdapi.Plan {
+		return pdapi.Plan{
+			Component:    v1alpha1.TiDBMemberType.String(),
+			Count:        count,
+			ResourceType: "compute",
+			Labels:       map[string]string{groupLabelKey: group},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		resources map[string]v1alpha1.AutoResource
+		plans     []pdapi.Plan
+		window    *v1alpha1.AutoScalerScheduleWindow
+		expected  []pdapi.Plan
+	}{
+		{
+			name:     "no active schedule",
+			plans:    []pdapi.Plan{newPlan("a", 1)},
+			expected: []pdapi.Plan{newPlan("a", 1)},
+		},
+		{
+			name:     "within the bounds",
+			plans:    []pdapi.Plan{newPlan("a", 1), newPlan("b", 2)},
+			window:   &v1alpha1.AutoScalerScheduleWindow{MinReplicas: pointer.Int32Ptr(2), MaxReplicas: pointer.Int32Ptr(4)},
+			expected: []pdapi.Plan{newPlan("a", 1), newPlan("b", 2)},
+		},
+		{
+			name:     "scale out the first group to the floor",
+			plans:    []pdapi.Plan{newPlan("b", 1), newPlan("a", 1)},
+			window:   &v1alpha1.AutoScalerScheduleWindow{MinReplicas: pointer.Int32Ptr(5)},
+			expected: []pdapi.Plan{newPlan("a", 4), newPlan("b", 1)},
+		},
+		{
+			name: "plan a group of the schedule to reach the floor if PD returns no plans",
+			resources: map[string]v1alpha1.AutoResource{
+				"storage": {CPU: resource.MustParse("1"), Memory: resource.MustParse("4Gi")},
+				"compute": {CPU: resource.MustParse("2"), Memory: resource.MustParse("4Gi")},
+			},
+			window:   &v1alpha1.AutoScalerScheduleWindow{Name: "peak", MinReplicas: pointer.Int32Ptr(2)},
+			expected: []pdapi.Plan{newPlan("schedule-peak", 2)},
+		},
+		{
+			name:     "no resource type to reach the floor",
+			window:   &v1alpha1.AutoScalerScheduleWindow{Name: "peak", MinReplicas: pointer.Int32Ptr(2)},
+			expected: nil,
+		},
+		{
+			name:     "scale in the last groups to the ceiling",
+			plans:    []pdapi.Plan{newPlan("a", 2), newPlan("b", 1), newPlan("c", 1)},
+			window:   &v1alpha1.AutoScalerScheduleWindow{MaxReplicas: pointer.Int32Ptr(1)},
+			expected: []pdapi.Plan{newPlan("a", 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tac := newTidbClusterAutoScaler()
+			tac.Spec.TiDB.Resources = tt.resources
+			plans := boundPlans(tac, v1alpha1.TiDBMemberType, tt.plans, tt.window)
+			g.Expect(plans).Should(Equal(tt.expected))
+		})
+	}
+}
+
+func TestUpdateActiveSchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tac := newTidbClusterAutoScaler()
+	tac.Status.TiDB = map[string]v1alpha1.TidbAutoScalerStatus{
+		"a": {},
+		"b": {BasicAutoScalerStatus: v1alpha1.BasicAutoScalerStatus{LastAutoScalingTimestamp: &metav1.Time{}}},
+	}
+	window := &v1alpha1.AutoScalerScheduleWindow{Name: "peak", MinReplicas: pointer.Int32Ptr(2)}
+
+	// the window is recorded in the status of each group of the component
+	updateActiveSchedule(tac, v1alpha1.TiDBMemberType, window)
+	g.Expect(tac.Status.TiDB).Should(HaveLen(2))
+	for _, status := range tac.Status.TiDB {
+		g.Expect(status.ActiveSchedule).Should(Equal(window))
+	}
+	g.Expect(tac.Status.TiDB["b"].LastAutoScalingTimestamp).ShouldNot(BeNil())
+	g.Expect(tac.Status.TiKV).Should(BeEmpty())
+
+	updateActiveSchedule(tac, v1alpha1.TiDBMemberType, nil)
+	for _, status := range tac.Status.TiDB {
+		g.Expect(status.ActiveSchedule).Should(BeNil())
+	}
+}
+
+func TestValidateMetrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	validQueries := []v1alpha1.MetricsQuery{{Name: "qps", Query: "sum(rate(tidb_server_query_total[1m]))", TargetAverageValue: 1000}}
+	tests := []struct {
+		name        string
+		metrics     v1alpha1.MetricsConfig
+		expectedErr string
+	}{
+		{
+			name:        "no prometheus",
+			metrics:     v1alpha1.MetricsConfig{Queries: validQueries, MaxReplicas: 3},
+			expectedErr: "either monitor or url of prometheus should be defined for component tidb in default/tac",
+		},
+		{
+			name: "both monitor and url",
+			metrics: v1alpha1.MetricsConfig{
+				Prometheus: v1alpha1.PrometheusSource{Monitor: &v1alpha1.TidbMonitorRef{Name: "monitor"}, URL: "http://prometheus:9090"},
+				Queries:    validQueries,
+			},
+			expectedErr: "either monitor or url of prometheus should be defined for component tidb in default/tac",
+		},
+		{
+			name:        "no queries",
+			metrics:     v1alpha1.MetricsConfig{Prometheus: v1alpha1.PrometheusSource{URL: "http://prometheus:9090"}},
+			expectedErr: "no queries defined for component tidb in default/tac",
+		},
+		{
+			name: "non-positive target",
+			metrics: v1alpha1.MetricsConfig{
+				Prometheus: v1alpha1.PrometheusSource{URL: "http://prometheus:9090"},
+				Queries:    []v1alpha1.MetricsQuery{{Name: "qps", Query: "sum(rate(tidb_server_query_total[1m]))"}},
+			},
+			expectedErr: "targetAverageValue (0) should be positive for query qps of tidb in default/tac",
+		},
+		{
+			name: "invalid tolerance",
+			metrics: v1alpha1.MetricsConfig{
+				Prometheus: v1alpha1.PrometheusSource{URL: "http://prometheus:9090"},
+				Queries:    validQueries,
+				Tolerance:  pointer.Float64Ptr(1.5),
+			},
+			expectedErr: "tolerance (1.5) should be between 0 and 1 for component tidb in default/tac",
+		},
+		{
+			name: "valid metrics",
+			metrics: v1alpha1.MetricsConfig{
+				Prometheus:  v1alpha1.PrometheusSource{Monitor: &v1alpha1.TidbMonitorRef{Name: "monitor"}},
+				Queries:     validQueries,
+				MaxReplicas: 3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tac := newTidbClusterAutoScaler()
+			metrics := tt.metrics
+			tac.Spec.TiDB.Metrics = &metrics
+			defaultBasicAutoScaler(tac, v1alpha1.TiDBMemberType)
+			err := validateBasicAutoScalerSpec(tac, v1alpha1.TiDBMemberType)
+			if tt.expectedErr == "" {
+				g.Expect(err).Should(BeNil())
+			} else {
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(HavePrefix(tt.expectedErr))
+			}
+		})
+	}
+}
+
+func TestPruneRecommendations(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	tac := newTidbClusterAutoScaler()
+	tac.Spec.TiDB.Metrics = &v1alpha1.MetricsConfig{
+		ScaleOutStabilizationWindowSeconds: pointer.Int32Ptr(60),
+		ScaleInStabilizationWindowSeconds:  pointer.Int32Ptr(300),
+	}
+	am := NewAutoScalerManager(controller.NewFakeDependencies())
+	am.updateRecommendations(tac, v1alpha1.TiDBMemberType, []calculate.Recommendation{
+		{Replicas: 1, Timestamp: now.Add(-10 * time.Minute)},
+		{Replicas: 2, Timestamp: now.Add(-4 * time.Minute)},
+		{Replicas: 3, Timestamp: now.Add(-30 * time.Second)},
+	})
+
+	recommendations := am.pruneRecommendations(tac, v1alpha1.TiDBMemberType, tac.Spec.TiDB.Metrics, now)
+	g.Expect(recommendations).Should(HaveLen(2))
+	g.Expect(recommendations[0].Replicas).Should(Equal(int32(2)))
+	g.Expect(recommendations[1].Replicas).Should(Equal(int32(3)))
+	g.Expect(am.pruneRecommendations(tac, v1alpha1.TiKVMemberType, tac.Spec.TiDB.Metrics, now)).Should(BeEmpty())
+
+	// the recommendations are dropped with the tac
+	am.forgetRecommendations(tac)
+	g.Expect(am.pruneRecommendations(tac, v1alpha1.TiDBMemberType, tac.Spec.TiDB.Metrics, now)).Should(BeEmpty())
+}
+
+func TestCreateExternalAutoCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name              string
+		update            func(tac *v1alpha1.TidbClusterAutoScaler)
+		expectedHotRegion bool
+	}{
+		{
+			name: "external service",
+			update: func(tac *v1alpha1.TidbClusterAutoScaler) {
+				tac.Spec.TiKV.External = &v1alpha1.ExternalConfig{MaxReplicas: 3}
+			},
+			expectedHotRegion: true,
+		},
+		{
+			name: "metrics",
+			update: func(tac *v1alpha1.TidbClusterAutoScaler) {
+				tac.Spec.TiKV.Metrics = &v1alpha1.MetricsConfig{MaxReplicas: 3}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tac := newTidbClusterAutoScaler()
+			tt.update(tac)
+			tc := newTidbCluster()
+			deps := controller.NewFakeDependencies()
+			am := NewAutoScalerManager(deps)
+
+			name := externalTcName(tc, v1alpha1.TiKVMemberType)
+			g.Expect(am.createExternalAutoCluster(tc, name, tac, v1alpha1.TiKVMemberType, 2)).Should(Succeed())
+			autoTc, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(autoTc.Spec.TiKV.Replicas).Should(Equal(int32(2)))
+			g.Expect(autoTc.Spec.TiKV.Config.Get("server.labels."+specialUseLabelKey) != nil).Should(Equal(tt.expectedHotRegion))
+		})
+	}
+}
+
+func TestValidateTiFlashAndTiCDC(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	metrics := func() *v1alpha1.MetricsConfig {
+		return &v1alpha1.MetricsConfig{
+			Prometheus:  v1alpha1.PrometheusSource{URL: "http://prometheus:9090"},
+			MaxReplicas: 3,
+		}
+	}
+	tests := []struct {
+		name        string
+		update      func(tac *v1alpha1.TidbClusterAutoScaler)
+		expectedErr string
+	}{
+		{
+			name: "tiflash with pd rules",
+			update: func(tac *v1alpha1.TidbClusterAutoScaler) {
+				tac.Spec.TiFlash = &v1alpha1.TiflashAutoScalerSpec{}
+			},
+			expectedErr: "neither external, metrics nor vertical is defined for component tiflash in default/tac",
+		},
+		{
+			name: "tiflash with metrics",
+			update: func(tac *v1alpha1.TidbClusterAutoScaler) {
+				tac.Spec.TiFlash = &v1alpha1.TiflashAutoScalerSpec{}
+				tac.Spec.TiFlash.Metrics = metrics()
+				tac.Spec.TiFlash.Metrics.Queries = []v1alpha1.MetricsQuery{{Name: "cpu", Query: "sum(rate(process_cpu_seconds_total{component=\"tiflash\"}[1m]))", TargetAverageValue: 4}}
+			},
+		},
+		{
+			name: "ticdc without queries",
+			update: func(tac *v1alpha1.TidbClusterAutoScaler) {
+				tac.Spec.TiCDC = &v1alpha1.TicdcAutoScalerSpec{}
+				tac.Spec.TiCDC.Metrics = metrics()
+			},
+			expectedErr: "no queries defined for component ticdc in default/tac",
+		},
+		{
+			name: "ticdc with non-positive lag",
+			update: func(tac *v1alpha1.TidbClusterAutoScaler) {
+				tac.Spec.TiCDC = &v1alpha1.TicdcAutoScalerSpec{TargetChangefeedLagSeconds: pointer.Int32Ptr(0)}
+				tac.Spec.TiCDC.Metrics = metrics()
+			},
+			expectedErr: "targetChangefeedLagSeconds (0) should be positive for ticdc in default/tac",
+		},
+		{
+			name: "ticdc with changefeed lag",
+			update: func(tac *v1alpha1.TidbClusterAutoScaler) {
+				tac.Spec.TiCDC = &v1alpha1.TicdcAutoScalerSpec{TargetChangefeedLagSeconds: pointer.Int32Ptr(30)}
+				tac.Spec.TiCDC.Metrics = metrics()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tac := newTidbClusterAutoScaler()
+			tac.Spec.TiDB = nil
+			tac.Spec.TiKV = nil
+			tt.update(tac)
+			defaultTAC(tac, newTidbCluster())
+			err := validateTAC(tac)
+			if tt.expectedErr == "" {
+				g.Expect(err).Should(BeNil())
+			} else {
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(HavePrefix(tt.expectedErr))
+			}
+		})
+	}
+}
+
+func TestSafeTiFlashReplicas(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tac := newTidbClusterAutoScaler()
+	tc := newTidbCluster()
+	tc.Spec.TiFlash = &v1alpha1.TiFlashSpec{Replicas: 1}
+	deps := controller.NewFakeDependencies()
+	pdClient := pdapi.NewFakePDClient()
+	deps.PDControl.(*pdapi.FakePDControl).SetPDClient(pdapi.Namespace(tc.Namespace), tc.Name, pdClient)
+	pdClient.AddReaction(pdapi.GetPlacementRulesActionType, func(action *pdapi.Action) (interface{}, error) {
+		g.Expect(action.Name).Should(Equal("tiflash"))
+		return []pdapi.PlacementRule{
+			{GroupID: "tiflash", ID: "table-45-r", Role: "learner", Count: 2},
+			{GroupID: "tiflash", ID: "table-46-r", Role: "learner", Count: 3},
+		}, nil
+	})
+	am := NewAutoScalerManager(deps)
+
+	tests := []struct {
+		name           string
+		autoReplicas   int32
+		targetReplicas int32
+		expected       int32
+	}{
+		{name: "scale out", autoReplicas: 1, targetReplicas: 4, expected: 4},
+		{name: "scale in above the replica count", autoReplicas: 4, targetReplicas: 3, expected: 3},
+		{name: "scale in below the replica count", autoReplicas: 4, targetReplicas: 0, expected: 2},
+		{name: "already below the replica count", autoReplicas: 1, targetReplicas: 0, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replicas, err := am.safeTiFlashReplicas(tc, tac, tt.autoReplicas, tt.targetReplicas)
+			g.Expect(err).Should(BeNil())
+			g.Expect(replicas).Should(Equal(tt.expected))
+		})
+	}
+}
+
+func TestValidateVertical(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		vertical    v1alpha1.VerticalAutoScalerConfig
+		expectedErr string
+	}{
+		{
+			name:        "no prometheus",
+			vertical:    v1alpha1.VerticalAutoScalerConfig{},
+			expectedErr: "either monitor or url of prometheus should be defined for component tidb in default/tac",
+		},
+		{
+			name: "unknown mode",
+			vertical: v1alpha1.VerticalAutoScalerConfig{
+				Prometheus: v1alpha1.PrometheusSource{URL: "http://prometheus:9090"},
+				Mode:       "Auto",
+			},
+			expectedErr: "unknown vertical auto-scaling mode Auto for component tidb in default/tac",
+		},
+		{
+			name: "invalid max step ratio",
+			vertical: v1alpha1.VerticalAutoScalerConfig{
+				Prometheus:   v1alpha1.PrometheusSource{URL: "http://prometheus:9090"},
+				MaxStepRatio: pointer.Float64Ptr(1.5),
+			},
+			expectedErr: "maxStepRatio (1.5) should be in (0, 1] for component tidb in default/tac",
+		},
+		{
+			name: "unknown resource",
+			vertical: v1alpha1.VerticalAutoScalerConfig{
+				Prometheus: v1alpha1.PrometheusSource{URL: "http://prometheus:9090"},
+				MaxAllowed: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")},
+			},
+			expectedErr: "unknown resource storage for the vertical auto-scaling of tidb in default/tac",
+		},
+		{
+			name: "minAllowed > maxAllowed",
+			vertical: v1alpha1.VerticalAutoScalerConfig{
+				Prometheus: v1alpha1.PrometheusSource{URL: "http://prometheus:9090"},
+				MinAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+				MaxAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			expectedErr: "minAllowed (4) > maxAllowed (2) of cpu for component tidb in default/tac",
+		},
+		{
+			name: "vertical only",
+			vertical: v1alpha1.VerticalAutoScalerConfig{
+				Prometheus: v1alpha1.PrometheusSource{Monitor: &v1alpha1.TidbMonitorRef{Name: "monitor"}},
+				Mode:       v1alpha1.VerticalScalingModeApply,
+				MinAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				MaxAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tac := newTidbClusterAutoScaler()
+			tac.Spec.TiKV = nil
+			vertical := tt.vertical
+			tac.Spec.TiDB.Vertical = &vertical
+			defaultTAC(tac, newTidbCluster())
+			err := validateTAC(tac)
+			if tt.expectedErr == "" {
+				g.Expect(err).Should(BeNil())
+			} else {
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(HavePrefix(tt.expectedErr))
+			}
+		})
+	}
+}
+
+func TestSyncVertical(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := "1.5"
+		if strings.Contains(r.URL.Query().Get("query"), "container_memory_working_set_bytes") {
+			value = fmt.Sprintf("%d", 3*1024*1024*1024)
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1690000000,"%s"]}]}}`, value)
+	}))
+	defer svc.Close()
+
+	tests := []struct {
+		name            string
+		mode            v1alpha1.VerticalScalingMode
+		phase           v1alpha1.MemberPhase
+		conflict        bool
+		expectedApplied bool
+	}{
+		{name: "recommend", mode: v1alpha1.VerticalScalingModeRecommend, phase: v1alpha1.NormalPhase},
+		{name: "apply", mode: v1alpha1.VerticalScalingModeApply, phase: v1alpha1.NormalPhase, expectedApplied: true},
+		{name: "apply on conflict", mode: v1alpha1.VerticalScalingModeApply, phase: v1alpha1.NormalPhase, conflict: true, expectedApplied: true},
+		{name: "apply while upgrading", mode: v1alpha1.VerticalScalingModeApply, phase: v1alpha1.UpgradePhase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbCluster()
+			tc.Status.TiDB.Phase = tt.phase
+			tac := newTidbClusterAutoScaler()
+			tac.Spec.TiDB.Vertical = &v1alpha1.VerticalAutoScalerConfig{
+				Prometheus: v1alpha1.PrometheusSource{URL: svc.URL},
+				Mode:       tt.mode,
+			}
+			defaultTAC(tac, tc)
+			deps := controller.NewFakeDependencies()
+			_, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+			g.Expect(err).Should(BeNil())
+			if tt.conflict {
+				// the TidbCluster is changed by others before the first update
+				conflicted := false
+				cli := deps.Clientset.(*fake.Clientset)
+				cli.PrependReactor("update", "tidbclusters", func(action clitesting.Action) (bool, runtime.Object, error) {
+					if conflicted {
+						return false, nil, nil
+					}
+					conflicted = true
+					latest := tc.DeepCopy()
+					latest.Annotations = map[string]string{"changed": "true"}
+					g.Expect(cli.Tracker().Update(v1alpha1.SchemeGroupVersion.WithResource("tidbclusters"), latest, tc.Namespace)).Should(Succeed())
+					return true, nil, apierrors.NewConflict(v1alpha1.Resource("tidbclusters"), tc.Name, fmt.Errorf("the object has been modified"))
+				})
+			}
+			am := NewAutoScalerManager(deps)
+
+			g.Expect(am.syncVertical(tc, tac, v1alpha1.TiDBMemberType)).Should(Succeed())
+			recommendation := tac.Status.Components[v1alpha1.TiDBMemberType.String()].VerticalRecommendation
+			g.Expect(recommendation).ShouldNot(BeNil())
+			g.Expect(recommendation.Applied).Should(Equal(tt.expectedApplied))
+			expected := corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+			}
+			for name, quantity := range expected {
+				request, limit := recommendation.Requests[name], recommendation.Limits[name]
+				g.Expect(request.Cmp(quantity)).Should(Equal(0))
+				g.Expect(limit.Cmp(quantity)).Should(Equal(0))
+			}
+
+			updated, err := deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Get(context.TODO(), tc.Name, metav1.GetOptions{})
+			g.Expect(err).Should(BeNil())
+			if tt.conflict {
+				g.Expect(updated.Annotations).Should(HaveKeyWithValue("changed", "true"))
+			}
+			if tt.expectedApplied {
+				g.Expect(tac.Status.Components[v1alpha1.TiDBMemberType.String()].LastVerticalScalingTimestamp).ShouldNot(BeNil())
+				g.Expect(updated.Spec.TiDB.Requests.Cpu().Cmp(expected[corev1.ResourceCPU])).Should(Equal(0))
+				g.Expect(updated.Spec.TiDB.Limits.Memory().Cmp(expected[corev1.ResourceMemory])).Should(Equal(0))
+			} else {
+				g.Expect(updated.Spec.TiDB.Requests.Cpu().Cmp(resource.MustParse("1000m"))).Should(Equal(0))
+			}
+		})
+	}
+}
+
 func newTidbClusterAutoScaler() *v1alpha1.TidbClusterAutoScaler {
 	tac := &v1alpha1.TidbClusterAutoScaler{}
 	tac.Name = "tac"
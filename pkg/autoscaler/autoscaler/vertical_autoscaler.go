@@ -0,0 +1,203 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/autoscaler/autoscaler/calculate"
+	"github.com/pingcap/tidb-operator/pkg/autoscaler/autoscaler/query"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+var verticalResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+func (am *autoScalerManager) syncVertical(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType) error {
+	cfg := getBasicAutoScalerSpec(tac, component).Vertical
+	now := time.Now()
+	endpoint := prometheusEndpoint(tac, cfg.Prometheus)
+	current := getResourceRequirements(tc, component)
+
+	recommendation := &v1alpha1.VerticalRecommendation{
+		Requests:  corev1.ResourceList{},
+		Timestamp: metav1.NewTime(now),
+	}
+	changed := false
+	for _, name := range verticalResources {
+		usage, err := query.Prometheus(endpoint, usageQuery(tc, component, cfg, name), now)
+		if err != nil {
+			klog.Errorf("tac[%s/%s]'s query of the %s usage to prometheus for component %s got error: %v", tac.Namespace, tac.Name, name, component.String(), err)
+			return err
+		}
+
+		currentRequest := quantityOf(current.Requests, name)
+		request := calculate.RecommendedRequest(name, currentRequest, usage, *cfg.Margin, *cfg.MaxStepRatio,
+			quantityOf(cfg.MinAllowed, name), quantityOf(cfg.MaxAllowed, name))
+		klog.V(4).Infof("tac[%s/%s]'s %s usage of component %s is %v, recommended request: %s", tac.Namespace, tac.Name, name, component.String(), usage, request.String())
+		recommendation.Requests[name] = request
+		if calculate.ExceedsTolerance(currentRequest, request, *cfg.Tolerance) {
+			changed = true
+		}
+
+		if limit, ok := current.Limits[name]; ok {
+			if recommendation.Limits == nil {
+				recommendation.Limits = corev1.ResourceList{}
+			}
+			var req resource.Quantity
+			if currentRequest != nil {
+				req = *currentRequest
+			}
+			recommendation.Limits[name] = calculate.ProportionalLimit(name, limit, req, request)
+		}
+	}
+
+	recommendation.Applied = !changed
+	if changed && cfg.Mode == v1alpha1.VerticalScalingModeApply {
+		applied, err := am.applyVerticalRecommendation(tc, tac, component, recommendation)
+		if err != nil {
+			return err
+		}
+		recommendation.Applied = applied
+	}
+
+	status := tac.Status.Components[component.String()]
+	status.VerticalRecommendation = recommendation
+	setComponentAutoScalerStatus(tac, component, status)
+	return nil
+}
+
+// applyVerticalRecommendation updates the resources of the component in the target TidbCluster,
+// the upgrader of the component then rolling restarts the pods the same way as upgrading
+func (am *autoScalerManager) applyVerticalRecommendation(tc *v1alpha1.TidbCluster, tac *v1alpha1.TidbClusterAutoScaler, component v1alpha1.MemberType, recommendation *v1alpha1.VerticalRecommendation) (bool, error) {
+	if phase := componentPhase(tc, component); phase != v1alpha1.NormalPhase {
+		klog.Infof("tac[%s/%s] waits for component %s in phase %s to apply the recommended resources", tac.Namespace, tac.Name, component.String(), phase)
+		return false, nil
+	}
+	interval := time.Duration(*getBasicAutoScalerSpec(tac, component).Vertical.UpdateIntervalSeconds) * time.Second
+	status := tac.Status.Components[component.String()]
+	if last := status.LastVerticalScalingTimestamp; last != nil && time.Since(last.Time) < interval {
+		return false, nil
+	}
+
+	// the recommended resources are applied to the latest TidbCluster again on conflict,
+	// so that they are not dropped by the retry of the update
+	ns, name := tc.Namespace, tc.Name
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		updated := tc.DeepCopy()
+		setRecommendedResources(updated, component, recommendation)
+		_, updateErr := am.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Update(context.TODO(), updated, metav1.UpdateOptions{})
+		if updateErr == nil {
+			return nil
+		}
+		klog.V(4).Infof("tac[%s/%s] failed to update tc[%s/%s], error: %v", tac.Namespace, tac.Name, ns, name, updateErr)
+		if latest, err := am.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Get(context.TODO(), name, metav1.GetOptions{}); err == nil {
+			tc = latest
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting the latest TidbCluster %s/%s: %v", ns, name, err))
+		}
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("tac[%s/%s] failed to apply the recommended resources to tc[%s/%s], err: %v", tac.Namespace, tac.Name, ns, name, err)
+		return false, err
+	}
+	klog.Infof("tac[%s/%s] applied the recommended resources of component %s to tc[%s/%s], requests: %v, limits: %v",
+		tac.Namespace, tac.Name, component.String(), tc.Namespace, tc.Name, recommendation.Requests, recommendation.Limits)
+
+	status.LastVerticalScalingTimestamp = &metav1.Time{Time: time.Now()}
+	setComponentAutoScalerStatus(tac, component, status)
+	return true, nil
+}
+
+// setRecommendedResources sets the recommended requests and limits to the component of the TidbCluster
+func setRecommendedResources(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType, recommendation *v1alpha1.VerticalRecommendation) {
+	res := getResourceRequirements(tc, component)
+	if res.Requests == nil {
+		res.Requests = corev1.ResourceList{}
+	}
+	for name, quantity := range recommendation.Requests {
+		res.Requests[name] = quantity
+	}
+	if len(recommendation.Limits) > 0 && res.Limits == nil {
+		res.Limits = corev1.ResourceList{}
+	}
+	for name, quantity := range recommendation.Limits {
+		res.Limits[name] = quantity
+	}
+}
+
+// usageQuery returns the PromQL of the usage of the resource by the containers of the component,
+// the percentile of the cpu usage in cores and the peak of the memory working set in bytes
+func usageQuery(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType, cfg *v1alpha1.VerticalAutoScalerConfig, name corev1.ResourceName) string {
+	selector := fmt.Sprintf(`namespace="%s",pod=~"%s-[0-9]+",container="%s"`, tc.Namespace, memberName(tc, component), component.String())
+	if name == corev1.ResourceCPU {
+		return fmt.Sprintf(`max(quantile_over_time(%v, rate(container_cpu_usage_seconds_total{%s}[1m])[%ds:1m]))`,
+			*cfg.CPUPercentile, selector, *cfg.HistoryWindowSeconds)
+	}
+	return fmt.Sprintf(`max(max_over_time(container_memory_working_set_bytes{%s}[%ds]))`, selector, *cfg.HistoryWindowSeconds)
+}
+
+func memberName(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType) string {
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		return controller.TiDBMemberName(tc.Name)
+	case v1alpha1.TiKVMemberType:
+		return controller.TiKVMemberName(tc.Name)
+	case v1alpha1.TiFlashMemberType:
+		return controller.TiFlashMemberName(tc.Name)
+	case v1alpha1.TiCDCMemberType:
+		return controller.TiCDCMemberName(tc.Name)
+	}
+	return ""
+}
+
+// getResourceRequirements returns the resource requirements of the component in the TidbCluster
+func getResourceRequirements(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType) *corev1.ResourceRequirements {
+	switch component {
+	case v1alpha1.TiDBMemberType:
+		return &tc.Spec.TiDB.ResourceRequirements
+	case v1alpha1.TiKVMemberType:
+		return &tc.Spec.TiKV.ResourceRequirements
+	case v1alpha1.TiFlashMemberType:
+		return &tc.Spec.TiFlash.ResourceRequirements
+	case v1alpha1.TiCDCMemberType:
+		return &tc.Spec.TiCDC.ResourceRequirements
+	}
+	return nil
+}
+
+func componentPhase(tc *v1alpha1.TidbCluster, component v1alpha1.MemberType) v1alpha1.MemberPhase {
+	for _, status := range v1alpha1.ComponentStatusFromTC(tc) {
+		if status.GetMemberType() == component {
+			return status.GetPhase()
+		}
+	}
+	return ""
+}
+
+func quantityOf(list corev1.ResourceList, name corev1.ResourceName) *resource.Quantity {
+	if quantity, ok := list[name]; ok {
+		return &quantity
+	}
+	return nil
+}
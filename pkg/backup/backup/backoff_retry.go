@@ -0,0 +1,64 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// syncBackoffRetry retries the failed backup job with backoff by backuputil.SyncBackoffRetry,
+// the failed job is recreated by syncBackupJob after it is deleted.
+func (bm *backupManager) syncBackoffRetry(backup *v1alpha1.Backup) error {
+	if v1alpha1.IsBackupComplete(backup) || v1alpha1.IsBackupFailed(backup) {
+		return nil
+	}
+	selector, err := label.NewBackup().Instance(backup.GetInstanceName()).BackupJob().Backup(backup.GetName()).Selector()
+	if err != nil {
+		return err
+	}
+
+	return backuputil.SyncBackoffRetry(&backuputil.BackoffRetryJob{
+		Kind:        "backup",
+		Owner:       backup,
+		Namespace:   backup.GetNamespace(),
+		Name:        backup.GetName(),
+		JobName:     backup.GetBackupJobName(),
+		PodSelector: selector,
+		Policy:      backup.Spec.BackoffRetryPolicy,
+		Records:     backup.Status.BackoffRetryStatus,
+		Fail: func(reason, message string) error {
+			return bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+				Type:    v1alpha1.BackupFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  reason,
+				Message: message,
+			}, nil)
+		},
+		RetryFailed: func(reason, message string, record *v1alpha1.BackoffRetryRecord) error {
+			return bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+				Type:    v1alpha1.BackupRetryFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  reason,
+				Message: message,
+			}, &controller.BackupUpdateStatus{BackoffRetryRecord: record})
+		},
+		UpdateRecord: func(record *v1alpha1.BackoffRetryRecord) error {
+			return bm.statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{BackoffRetryRecord: record})
+		},
+	}, bm.deps.JobLister, bm.deps.PodLister, bm.deps.JobControl)
+}
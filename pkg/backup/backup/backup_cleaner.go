@@ -14,6 +14,7 @@
 package backup
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
@@ -59,6 +60,10 @@ func (bc *backupCleaner) Clean(backup *v1alpha1.Backup) error {
 	name := backup.GetName()
 	backupJobName := backup.GetBackupJobName()
 
+	if v1alpha1.IsVolumeSnapshotBackup(backup) {
+		return bc.cleanVolumeSnapshots(backup)
+	}
+
 	klog.Infof("start to ensure that backup %s/%s job %s have finished", ns, name, backupJobName)
 
 	finished, err := bc.ensureBackupJobFinished(backup)
@@ -125,6 +130,37 @@ func (bc *backupCleaner) Clean(backup *v1alpha1.Backup) error {
 	}, nil)
 }
 
+// cleanVolumeSnapshots deletes the VolumeSnapshots taken by the volume-snapshot backup,
+// the snapshot data is deleted by the CSI driver according to the deletion policy
+func (bc *backupCleaner) cleanVolumeSnapshots(backup *v1alpha1.Backup) error {
+	ns := backup.GetNamespace()
+	if backup.Spec.BR != nil && backup.Spec.BR.ClusterNamespace != "" {
+		ns = backup.Spec.BR.ClusterNamespace
+	}
+
+	klog.Infof("start to clean volume snapshots of backup %s/%s", backup.Namespace, backup.Name)
+	for _, vs := range backup.Status.VolumeSnapshots {
+		snapshot := backuputil.NewEmptyVolumeSnapshot()
+		snapshot.SetNamespace(ns)
+		snapshot.SetName(vs.SnapshotName)
+		if err := bc.deps.GenericClient.Delete(context.TODO(), snapshot); err != nil && !errors.IsNotFound(err) {
+			bc.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+				Type:    v1alpha1.BackupRetryFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "DeleteVolumeSnapshotFailed",
+				Message: err.Error(),
+			}, nil)
+			return fmt.Errorf("delete volume snapshot %s/%s of backup %s/%s failed, err: %v", ns, vs.SnapshotName, backup.Namespace, backup.Name, err)
+		}
+		klog.Infof("volume snapshot %s/%s of backup %s/%s is deleted", ns, vs.SnapshotName, backup.Namespace, backup.Name)
+	}
+
+	return bc.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupClean,
+		Status: corev1.ConditionTrue,
+	}, nil)
+}
+
 func (bc *backupCleaner) makeCleanJob(backup *v1alpha1.Backup) (*batchv1.Job, string, error) {
 	ns := backup.GetNamespace()
 	name := backup.GetName()
@@ -133,6 +169,11 @@ func (bc *backupCleaner) makeCleanJob(backup *v1alpha1.Backup) (*batchv1.Job, st
 	if err != nil {
 		return nil, reason, err
 	}
+	replicaEnv, reason, err := backuputil.GenerateReplicaStoragesCertEnv(ns, backup, bc.deps.SecretLister)
+	if err != nil {
+		return nil, reason, err
+	}
+	envVars = append(envVars, replicaEnv...)
 
 	// set env vars specified in backup.Spec.Env
 	envVars = util.AppendOverwriteEnv(envVars, backup.Spec.Env)
@@ -154,6 +195,9 @@ func (bc *backupCleaner) makeCleanJob(backup *v1alpha1.Backup) (*batchv1.Job, st
 		volumes = append(volumes, localVolume)
 		volumeMounts = append(volumeMounts, localVolumeMount)
 	}
+	replicaVolumes, replicaVolumeMounts := backuputil.GetReplicaStoragesVolumes(backup)
+	volumes = append(volumes, replicaVolumes...)
+	volumeMounts = append(volumeMounts, replicaVolumeMounts...)
 
 	serviceAccount := constants.DefaultServiceAccountName
 	if backup.Spec.ServiceAccount != "" {
@@ -213,9 +257,24 @@ func (bc *backupCleaner) makeCleanJob(backup *v1alpha1.Backup) (*batchv1.Job, st
 
 // ensureBackupJobDeleted ensure that backup Job have finished, it will delete the job if it is running
 func (bc *backupCleaner) ensureBackupJobFinished(backup *v1alpha1.Backup) (bool, error) {
+	if !v1alpha1.IsLogBackup(backup) {
+		return bc.ensureJobFinished(backup, backup.GetBackupJobName())
+	}
+
+	allFinished := true
+	for _, command := range []v1alpha1.LogSubCommandType{v1alpha1.LogStartCommand, v1alpha1.LogTruncateCommand, v1alpha1.LogStopCommand} {
+		finished, err := bc.ensureJobFinished(backup, backup.GetLogBackupJobName(command))
+		if err != nil {
+			return false, err
+		}
+		allFinished = allFinished && finished
+	}
+	return allFinished, nil
+}
+
+func (bc *backupCleaner) ensureJobFinished(backup *v1alpha1.Backup, backupJobName string) (bool, error) {
 	ns := backup.GetNamespace()
 	name := backup.GetName()
-	backupJobName := backup.GetBackupJobName()
 
 	backupJob, err := bc.deps.JobLister.Jobs(ns).Get(backupJobName)
 	if err != nil {
@@ -231,14 +290,7 @@ func (bc *backupCleaner) ensureBackupJobFinished(backup *v1alpha1.Backup) (bool,
 	}
 
 	// check whether job finish
-	finished := false
-	for _, c := range backupJob.Status.Conditions {
-		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
-			finished = true
-			break
-		}
-	}
-	if finished {
+	if isJobFinished(backupJob) {
 		return true, nil
 	}
 
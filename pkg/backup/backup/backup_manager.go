@@ -33,22 +33,31 @@ import (
 )
 
 type backupManager struct {
-	deps          *controller.Dependencies
-	backupCleaner BackupCleaner
-	statusUpdater controller.BackupConditionUpdaterInterface
+	deps           *controller.Dependencies
+	backupCleaner  BackupCleaner
+	backupVerifier BackupVerifier
+	statusUpdater  controller.BackupConditionUpdaterInterface
 }
 
 // NewBackupManager return backupManager
 func NewBackupManager(deps *controller.Dependencies) backup.BackupManager {
 	statusUpdater := controller.NewRealBackupConditionUpdater(deps.Clientset, deps.BackupLister, deps.Recorder)
 	return &backupManager{
-		deps:          deps,
-		backupCleaner: NewBackupCleaner(deps, statusUpdater),
-		statusUpdater: statusUpdater,
+		deps:           deps,
+		backupCleaner:  NewBackupCleaner(deps, statusUpdater),
+		backupVerifier: NewBackupVerifier(deps, statusUpdater),
+		statusUpdater:  statusUpdater,
 	}
 }
 
 func (bm *backupManager) Sync(backup *v1alpha1.Backup) error {
+	if backup.DeletionTimestamp != nil && v1alpha1.IsCleanCandidate(backup) &&
+		v1alpha1.ParseLogBackupSubcommand(backup) == v1alpha1.LogStopCommand {
+		// the log backup task should be stopped before cleaning its data,
+		// otherwise TiKV keeps writing logs into the storage.
+		return bm.syncBackupJob(backup)
+	}
+
 	// because a finalizer is installed on the backup on creation, when backup is deleted,
 	// backup.DeletionTimestamp will be set, controller will be informed with an onUpdate event,
 	// this is the moment that we can do clean up work.
@@ -61,6 +70,10 @@ func (bm *backupManager) Sync(backup *v1alpha1.Backup) error {
 		return nil
 	}
 
+	if v1alpha1.NeedVerifyBackup(backup) {
+		return bm.backupVerifier.Verify(backup)
+	}
+
 	return bm.syncBackupJob(backup)
 }
 
@@ -74,6 +87,7 @@ func (bm *backupManager) syncBackupJob(backup *v1alpha1.Backup) error {
 	backupJobName := backup.GetBackupJobName()
 
 	var err error
+	var tc *v1alpha1.TidbCluster
 	if backup.Spec.BR == nil {
 		err = backuputil.ValidateBackup(backup, "")
 	} else {
@@ -82,7 +96,6 @@ func (bm *backupManager) syncBackupJob(backup *v1alpha1.Backup) error {
 			backupNamespace = backup.Spec.BR.ClusterNamespace
 		}
 
-		var tc *v1alpha1.TidbCluster
 		tc, err = bm.deps.TiDBClusterLister.TidbClusters(backupNamespace).Get(backup.Spec.BR.Cluster)
 		if err != nil {
 			reason := fmt.Sprintf("failed to fetch tidbcluster %s/%s", backupNamespace, backup.Spec.BR.Cluster)
@@ -110,6 +123,21 @@ func (bm *backupManager) syncBackupJob(backup *v1alpha1.Backup) error {
 		return controller.IgnoreErrorf("invalid backup spec %s/%s cause %s", ns, name, err.Error())
 	}
 
+	if v1alpha1.IsLogBackup(backup) {
+		return bm.syncLogBackupJob(backup)
+	}
+
+	if v1alpha1.IsVolumeSnapshotBackup(backup) {
+		// volume snapshots are taken by the operator directly, the backup job only prepares TiKV for them
+		return bm.syncVolumeSnapshotBackup(backup, tc)
+	}
+
+	if backup.Spec.BackoffRetryPolicy != nil {
+		if err := bm.syncBackoffRetry(backup); err != nil {
+			return err
+		}
+	}
+
 	_, err = bm.deps.JobLister.Jobs(ns).Get(backupJobName)
 	if err == nil {
 		// already have a backup job running，return directly
@@ -177,6 +205,78 @@ func (bm *backupManager) syncBackupJob(backup *v1alpha1.Backup) error {
 	}, nil)
 }
 
+// syncLogBackupJob creates the job for the log backup subcommand which should be run
+// according to the spec and status of the backup.
+func (bm *backupManager) syncLogBackupJob(backup *v1alpha1.Backup) error {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+
+	command := v1alpha1.ParseLogBackupSubcommand(backup)
+	if command == "" {
+		return nil
+	}
+	jobName := backup.GetLogBackupJobName(command)
+
+	oldJob, err := bm.deps.JobLister.Jobs(ns).Get(jobName)
+	if err == nil {
+		if command != v1alpha1.LogTruncateCommand || !isJobFinished(oldJob) {
+			// already have a job of the subcommand running, return directly
+			return nil
+		}
+		// the job of the previous truncate has finished, delete it to truncate to the new ts
+		if err := bm.deps.JobControl.DeleteJob(backup, oldJob); err != nil {
+			return fmt.Errorf("backup %s/%s delete finished job %s failed, err: %v", ns, name, jobName, err)
+		}
+		return controller.RequeueErrorf("backup %s/%s wait for the finished job %s to be deleted", ns, name, jobName)
+	}
+
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("backup %s/%s get job %s failed, err: %v", ns, name, jobName, err)
+	}
+
+	job, reason, err := bm.makeBackupJob(backup)
+	if err != nil {
+		bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+			Type:    v1alpha1.BackupRetryFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  reason,
+			Message: err.Error(),
+		}, nil)
+		return err
+	}
+
+	if err := bm.deps.JobControl.CreateJob(backup, job); err != nil {
+		errMsg := fmt.Errorf("create backup %s/%s job %s failed, err: %v", ns, name, jobName, err)
+		bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+			Type:    v1alpha1.BackupRetryFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "CreateBackupJobFailed",
+			Message: errMsg.Error(),
+		}, nil)
+		return errMsg
+	}
+
+	updateStatus := &controller.BackupUpdateStatus{
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command: command,
+			Phase:   v1alpha1.BackupScheduled,
+		},
+	}
+	if command == v1alpha1.LogTruncateCommand {
+		updateStatus.LogSubCommandStatus.LogTruncatingUntil = backup.Spec.LogTruncateUntil
+	}
+
+	var condition *v1alpha1.BackupCondition
+	if command == v1alpha1.LogStartCommand {
+		// only the start of the log backup task changes the phase of the backup
+		condition = &v1alpha1.BackupCondition{
+			Type:   v1alpha1.BackupScheduled,
+			Status: corev1.ConditionTrue,
+		}
+	}
+	return bm.statusUpdater.Update(backup, condition, updateStatus)
+}
+
 func (bm *backupManager) makeExportJob(backup *v1alpha1.Backup) (*batchv1.Job, string, error) {
 	ns := backup.GetNamespace()
 	name := backup.GetName()
@@ -192,6 +292,12 @@ func (bm *backupManager) makeExportJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 	}
 	envVars = append(envVars, storageEnv...)
 
+	encryptionEnv, reason, err := backuputil.GenerateEncryptionKeyEnv(ns, backup.Spec.Encryption, bm.deps.SecretLister)
+	if err != nil {
+		return nil, reason, fmt.Errorf("backup %s/%s, %v", ns, name, err)
+	}
+	envVars = append(envVars, encryptionEnv...)
+
 	// set env vars specified in backup.Spec.Env
 	envVars = util.AppendOverwriteEnv(envVars, backup.Spec.Env)
 
@@ -354,12 +460,26 @@ func (bm *backupManager) makeBackupJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 		}
 	}
 
-	storageEnv, reason, err := backuputil.GenerateStorageCertEnv(ns, backup.Spec.UseKMS, backup.Spec.StorageProvider, bm.deps.SecretLister)
+	// the job of volume-snapshot backup only prepares TiKV for the snapshots, there is no storage to write
+	if !v1alpha1.IsVolumeSnapshotBackup(backup) {
+		storageEnv, reason, err := backuputil.GenerateStorageCertEnv(ns, backup.Spec.UseKMS, backup.Spec.StorageProvider, bm.deps.SecretLister)
+		if err != nil {
+			return nil, reason, fmt.Errorf("backup %s/%s, %v", ns, name, err)
+		}
+		envVars = append(envVars, storageEnv...)
+	}
+
+	replicaEnv, reason, err := backuputil.GenerateReplicaStoragesCertEnv(ns, backup, bm.deps.SecretLister)
 	if err != nil {
 		return nil, reason, fmt.Errorf("backup %s/%s, %v", ns, name, err)
 	}
+	envVars = append(envVars, replicaEnv...)
 
-	envVars = append(envVars, storageEnv...)
+	encryptionEnv, reason, err := backuputil.GenerateEncryptionKeyEnv(ns, backup.Spec.Encryption, bm.deps.SecretLister)
+	if err != nil {
+		return nil, reason, fmt.Errorf("backup %s/%s, %v", ns, name, err)
+	}
+	envVars = append(envVars, encryptionEnv...)
 	envVars = append(envVars, corev1.EnvVar{
 		Name:  "BR_LOG_TO_TERM",
 		Value: string(rune(1)),
@@ -379,6 +499,13 @@ func (bm *backupManager) makeBackupJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 		args = append(args, fmt.Sprintf("--tikvVersion=%s", tikvVersion))
 	}
 
+	jobName := backup.GetBackupJobName()
+	if v1alpha1.IsLogBackup(backup) {
+		command := v1alpha1.ParseLogBackupSubcommand(backup)
+		args = append(args, fmt.Sprintf("--subcommand=%s", command))
+		jobName = backup.GetLogBackupJobName(command)
+	}
+
 	jobLabels := util.CombineStringMap(label.NewBackup().Instance(backup.GetInstanceName()).BackupJob().Backup(name), backup.Labels)
 	podLabels := jobLabels
 	jobAnnotations := backup.Annotations
@@ -448,6 +575,9 @@ func (bm *backupManager) makeBackupJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 		volumes = append(volumes, backup.Spec.Local.Volume)
 		volumeMounts = append(volumeMounts, backup.Spec.Local.VolumeMount)
 	}
+	replicaVolumes, replicaVolumeMounts := backuputil.GetReplicaStoragesVolumes(backup)
+	volumes = append(volumes, replicaVolumes...)
+	volumeMounts = append(volumeMounts, replicaVolumeMounts...)
 
 	serviceAccount := constants.DefaultServiceAccountName
 	if backup.Spec.ServiceAccount != "" {
@@ -505,7 +635,7 @@ func (bm *backupManager) makeBackupJob(backup *v1alpha1.Backup) (*batchv1.Job, s
 
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        backup.GetBackupJobName(),
+			Name:        jobName,
 			Namespace:   ns,
 			Labels:      jobLabels,
 			Annotations: jobAnnotations,
@@ -576,6 +706,16 @@ func (bm *backupManager) ensureBackupPVCExist(backup *v1alpha1.Backup) (string,
 	return "", nil
 }
 
+// isJobFinished returns true if the job has completed or failed
+func isJobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 var _ backup.BackupManager = &backupManager{}
 
 type FakeBackupManager struct {
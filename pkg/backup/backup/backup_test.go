@@ -22,14 +22,21 @@ import (
 
 	"github.com/onsi/gomega"
 	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/testutils"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type helper struct {
@@ -271,6 +278,44 @@ func TestBackupManagerBR(t *testing.T) {
 	}
 }
 
+func TestBackupManagerLogBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+
+	bm := NewBackupManager(deps).(*backupManager)
+
+	backup := genValidBRBackups()[0]
+	backup.Spec.Type = ""
+	backup.Spec.BR.DB = ""
+	backup.Spec.Mode = v1alpha1.BackupModeLog
+	_, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Create(context.TODO(), backup, metav1.CreateOptions{})
+	g.Expect(err).Should(BeNil())
+	helper.CreateSecret(backup)
+	helper.CreateTC(backup.Spec.BR.ClusterNamespace, backup.Spec.BR.Cluster)
+
+	// the log backup task is started by the log-start job
+	err = bm.syncBackupJob(backup)
+	g.Expect(err).Should(BeNil())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupScheduled, "")
+	jobName := backup.GetLogBackupJobName(v1alpha1.LogStartCommand)
+	g.Expect(jobName).To(Equal(fmt.Sprintf("backup-%s-log-start", backup.Name)))
+	job, err := deps.KubeClientset.BatchV1().Jobs(backup.Namespace).Get(context.TODO(), jobName, metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--subcommand=log-start"))
+
+	get, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Get(context.TODO(), backup.Name, metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	status := v1alpha1.GetLogSubCommandStatus(get, v1alpha1.LogStartCommand)
+	g.Expect(status).ShouldNot(BeNil())
+	g.Expect(status.Phase).To(Equal(v1alpha1.BackupScheduled))
+
+	// the job is not created again while it exists
+	err = bm.syncBackupJob(get)
+	g.Expect(err).Should(BeNil())
+}
+
 func TestClean(t *testing.T) {
 	g := NewGomegaWithT(t)
 	helper := newHelper(t)
@@ -366,3 +411,384 @@ func TestClean(t *testing.T) {
 	}
 
 }
+
+func TestBackupManagerVolumeSnapshot(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+
+	bm := NewBackupManager(deps).(*backupManager)
+
+	backup := genValidBRBackups()[0]
+	backup.Spec.Type = v1alpha1.BackupTypeVolumeSnapshot
+	backup.Spec.StorageProvider = v1alpha1.StorageProvider{}
+	backup.Spec.BR.DB = ""
+	backup.Spec.From = nil
+	backup.Spec.VolumeSnapshotClassName = pointer.StringPtr("csi-snapclass")
+	backup.Spec.CleanPolicy = v1alpha1.CleanPolicyTypeDelete
+	_, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Create(context.TODO(), backup, metav1.CreateOptions{})
+	g.Expect(err).Should(BeNil())
+
+	tc := &v1alpha1.TidbCluster{
+		Spec: v1alpha1.TidbClusterSpec{
+			TiKV: &v1alpha1.TiKVSpec{
+				BaseImage: "pingcap/tikv",
+			},
+		},
+	}
+	tc.Namespace = backup.Spec.BR.ClusterNamespace
+	tc.Name = backup.Spec.BR.Cluster
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() error {
+		_, err := deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(tc.Name)
+		return err
+	}, time.Second*10).Should(BeNil())
+
+	for i := int32(0); i < 3; i++ {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      backuputil.GetTiKVDataPVCName(tc.Name, i),
+				Namespace: tc.Namespace,
+				Labels:    label.New().Instance(tc.Name).TiKV().Labels(),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.StringPtr("ebs-gp3"),
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("100Gi"),
+					},
+				},
+			},
+		}
+		_, err = deps.KubeClientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(context.TODO(), pvc, metav1.CreateOptions{})
+		g.Expect(err).Should(BeNil())
+	}
+	g.Eventually(func() int {
+		pvcs, _ := bm.getTiKVDataPVCs(tc)
+		return len(pvcs)
+	}, time.Second*10).Should(Equal(3))
+
+	pdClient := pdapi.NewFakePDClient()
+	deps.PDControl.(*pdapi.FakePDControl).SetPDClient(pdapi.Namespace(tc.Namespace), tc.Name, pdClient)
+	pdClient.AddReaction(pdapi.GetMinResolvedTSActionType, func(action *pdapi.Action) (interface{}, error) {
+		return uint64(434763491567992834), nil
+	})
+	activeSchedulers := []string{"balance-leader-scheduler", "balance-region-scheduler"}
+	pdClient.AddReaction(pdapi.GetActiveSchedulersActionType, func(action *pdapi.Action) (interface{}, error) {
+		return activeSchedulers, nil
+	})
+	var pauseCount, resumeCount int
+	pdClient.AddReaction(pdapi.PauseSchedulersActionType, func(action *pdapi.Action) (interface{}, error) {
+		g.Expect(action.Schedulers).To(Equal(activeSchedulers))
+		pauseCount++
+		return nil, nil
+	})
+	pdClient.AddReaction(pdapi.ResumeSchedulersActionType, func(action *pdapi.Action) (interface{}, error) {
+		g.Expect(action.Schedulers).To(Equal(activeSchedulers))
+		resumeCount++
+		return nil, nil
+	})
+
+	getBackup := func() *v1alpha1.Backup {
+		get, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Get(context.TODO(), backup.Name, metav1.GetOptions{})
+		g.Expect(err).Should(BeNil())
+		return get
+	}
+
+	// the active schedulers are recorded before they are paused
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupPrepare, "")
+	g.Expect(getBackup().Status.PausedSchedulers).To(Equal(activeSchedulers))
+
+	// the job is created to suspend the writes of TiKV, no snapshot is taken before TiKV is prepared
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(pauseCount).To(Equal(1))
+	g.Expect(getBackup().Status.VolumeSnapshots).To(BeEmpty())
+	g.Eventually(func() error {
+		_, err := deps.JobLister.Jobs(backup.Namespace).Get(backup.GetBackupJobName())
+		return err
+	}, time.Second*10).Should(BeNil())
+
+	statusUpdater := controller.NewRealBackupConditionUpdater(deps.Clientset, deps.BackupLister, deps.Recorder)
+	err = statusUpdater.Update(getBackup(), &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupVolumeSnapshotPrepared,
+		Status: corev1.ConditionTrue,
+	}, nil)
+	g.Expect(err).Should(BeNil())
+
+	// the snapshots are created at the resolved ts
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupRunning, "")
+	get := getBackup()
+	g.Expect(get.Status.CommitTs).To(Equal("434763491567992834"))
+	g.Expect(get.Status.VolumeSnapshots).To(HaveLen(3))
+	g.Expect(pauseCount).To(Equal(2))
+	for i, vs := range get.Status.VolumeSnapshots {
+		g.Expect(vs.Ordinal).To(Equal(int32(i)))
+		g.Expect(vs.Size).To(Equal("100Gi"))
+		g.Expect(vs.SnapshotName).To(Equal(get.GetVolumeSnapshotName(int32(i))))
+
+		snapshot := backuputil.NewEmptyVolumeSnapshot()
+		err = deps.GenericClient.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: vs.SnapshotName}, snapshot)
+		g.Expect(err).Should(BeNil())
+		pvcName, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+		g.Expect(pvcName).To(Equal(vs.PVCName))
+		className, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+		g.Expect(className).To(Equal("csi-snapclass"))
+	}
+
+	// the cluster keeps paused and the job keeps running until all the snapshots are taken
+	err = bm.syncBackupJob(get)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(pauseCount).To(Equal(3))
+	g.Expect(resumeCount).To(Equal(0))
+	_, err = deps.KubeClientset.BatchV1().Jobs(backup.Namespace).Get(context.TODO(), backup.GetBackupJobName(), metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+
+	for _, vs := range get.Status.VolumeSnapshots {
+		snapshot := backuputil.NewEmptyVolumeSnapshot()
+		err = deps.GenericClient.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: vs.SnapshotName}, snapshot)
+		g.Expect(err).Should(BeNil())
+		snapshot.Object["status"] = map[string]interface{}{
+			"creationTime": "2022-06-01T00:00:00Z",
+			"readyToUse":   true,
+			"restoreSize":  "10Gi",
+		}
+		g.Expect(deps.GenericClient.Update(context.TODO(), snapshot)).Should(BeNil())
+	}
+
+	// the job is deleted to resume the writes, and only the recorded schedulers are resumed
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(err).Should(BeNil())
+	g.Expect(resumeCount).To(Equal(1))
+	_, err = deps.KubeClientset.BatchV1().Jobs(backup.Namespace).Get(context.TODO(), backup.GetBackupJobName(), metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupComplete, "")
+	get = getBackup()
+	g.Expect(get.Status.BackupSize).To(Equal(int64(3 * 10 << 30)))
+	for _, vs := range get.Status.VolumeSnapshots {
+		g.Expect(vs.ReadyToUse).To(BeTrue())
+	}
+
+	// the snapshots are deleted with the backup
+	get.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	err = NewBackupCleaner(deps, statusUpdater).Clean(get)
+	g.Expect(err).Should(BeNil())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupClean, "")
+	for _, vs := range get.Status.VolumeSnapshots {
+		err = deps.GenericClient.Get(context.TODO(), client.ObjectKey{Namespace: tc.Namespace, Name: vs.SnapshotName}, backuputil.NewEmptyVolumeSnapshot())
+		g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	}
+}
+
+func TestBackupVerify(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+
+	bm := NewBackupManager(deps).(*backupManager)
+
+	backup := genValidBRBackups()[0]
+	backup.Spec.Verify = &v1alpha1.BackupVerifySpec{TiKVStorageSize: "10Gi"}
+	backup.Status.Conditions = []v1alpha1.BackupCondition{
+		{
+			Type:   v1alpha1.BackupComplete,
+			Status: corev1.ConditionTrue,
+		},
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Create(context.TODO(), backup, metav1.CreateOptions{})
+	g.Expect(err).Should(BeNil())
+	helper.CreateTC(backup.Spec.BR.ClusterNamespace, backup.Spec.BR.Cluster)
+	source, err := deps.Clientset.PingcapV1alpha1().TidbClusters(backup.Spec.BR.ClusterNamespace).Get(context.TODO(), backup.Spec.BR.Cluster, metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	source.Spec.PD = &v1alpha1.PDSpec{BaseImage: "pingcap/pd"}
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters(source.Namespace).Update(context.TODO(), source, metav1.UpdateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() bool {
+		tc, err := deps.TiDBClusterLister.TidbClusters(source.Namespace).Get(source.Name)
+		return err == nil && tc.Spec.PD != nil
+	}, time.Second*10).Should(BeTrue())
+	verifyName := backup.GetVerifyName()
+
+	getBackup := func() *v1alpha1.Backup {
+		get, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Get(context.TODO(), backup.Name, metav1.GetOptions{})
+		g.Expect(err).Should(BeNil())
+		return get
+	}
+
+	// the scratch cluster is created with the version of the backed up cluster
+	err = bm.Sync(getBackup())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupVerified, "Verifying")
+	g.Expect(v1alpha1.NeedVerifyBackup(getBackup())).To(BeTrue())
+	tc, err := deps.Clientset.PingcapV1alpha1().TidbClusters(backup.Namespace).Get(context.TODO(), verifyName, metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Expect(tc.Spec.TiKV.BaseImage).To(Equal("pingcap/tikv"))
+	g.Expect(tc.Spec.TiKV.Replicas).To(Equal(int32(1)))
+	g.Expect(tc.Spec.TiKV.Requests.Storage().String()).To(Equal("10Gi"))
+	g.Expect(tc.Spec.TiDB).To(BeNil())
+	g.Expect(tc.OwnerReferences).To(HaveLen(1))
+
+	// wait for the scratch cluster to be ready
+	g.Eventually(func() error {
+		_, err := deps.TiDBClusterLister.TidbClusters(backup.Namespace).Get(verifyName)
+		return err
+	}, time.Second*10).Should(BeNil())
+	err = bm.Sync(getBackup())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	_, err = deps.Clientset.PingcapV1alpha1().Restores(backup.Namespace).Get(context.TODO(), verifyName, metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{"pd-0": {Health: true}}
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{"1": {State: v1alpha1.TiKVStateUp}}
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters(backup.Namespace).Update(context.TODO(), tc, metav1.UpdateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() bool {
+		tc, err := deps.TiDBClusterLister.TidbClusters(backup.Namespace).Get(verifyName)
+		return err == nil && tc.TiKVAllStoresReady()
+	}, time.Second*10).Should(BeTrue())
+
+	// the backup is restored into the scratch cluster with checksum
+	err = bm.Sync(getBackup())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	restore, err := deps.Clientset.PingcapV1alpha1().Restores(backup.Namespace).Get(context.TODO(), verifyName, metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Expect(restore.Spec.BR.Cluster).To(Equal(verifyName))
+	g.Expect(*restore.Spec.BR.Checksum).To(BeTrue())
+	g.Expect(restore.Spec.StorageProvider).To(Equal(backup.Spec.StorageProvider))
+
+	restore.Status.Conditions = []v1alpha1.RestoreCondition{
+		{
+			Type:   v1alpha1.RestoreComplete,
+			Status: corev1.ConditionTrue,
+		},
+	}
+	_, err = deps.Clientset.PingcapV1alpha1().Restores(backup.Namespace).Update(context.TODO(), restore, metav1.UpdateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() bool {
+		restore, err := deps.RestoreLister.Restores(backup.Namespace).Get(verifyName)
+		return err == nil && v1alpha1.IsRestoreComplete(restore)
+	}, time.Second*10).Should(BeTrue())
+
+	// the result is recorded and the scratch cluster is removed
+	err = bm.Sync(getBackup())
+	g.Expect(err).Should(BeNil())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupVerified, "RestoreComplete")
+	get := getBackup()
+	g.Expect(v1alpha1.IsBackupVerified(get)).To(BeTrue())
+	g.Expect(v1alpha1.NeedVerifyBackup(get)).To(BeFalse())
+	g.Expect(v1alpha1.IsBackupComplete(get)).To(BeTrue())
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters(backup.Namespace).Get(context.TODO(), verifyName, metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	_, err = deps.Clientset.PingcapV1alpha1().Restores(backup.Namespace).Get(context.TODO(), verifyName, metav1.GetOptions{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestBackupManagerBackoffRetry(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+
+	bm := NewBackupManager(deps).(*backupManager)
+
+	backup := genValidBRBackups()[0]
+	backup.Spec.BackoffRetryPolicy = &v1alpha1.BackoffRetryPolicy{
+		MinRetryDuration: pointer.StringPtr("10ms"),
+		MaxRetryTimes:    pointer.Int32Ptr(1),
+	}
+	_, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Create(context.TODO(), backup, metav1.CreateOptions{})
+	g.Expect(err).Should(BeNil())
+	helper.CreateSecret(backup)
+	helper.CreateTC(backup.Spec.BR.ClusterNamespace, backup.Spec.BR.Cluster)
+
+	getBackup := func() *v1alpha1.Backup {
+		get, err := deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Get(context.TODO(), backup.Name, metav1.GetOptions{})
+		g.Expect(err).Should(BeNil())
+		return get
+	}
+	// createFailedPod replaces the pod of the backup job with a failed one
+	createFailedPod := func(status corev1.PodStatus) {
+		job, err := deps.KubeClientset.BatchV1().Jobs(backup.Namespace).Get(context.TODO(), backup.GetBackupJobName(), metav1.GetOptions{})
+		g.Expect(err).Should(BeNil())
+		g.Eventually(func() error {
+			get, err := deps.JobLister.Jobs(job.Namespace).Get(job.Name)
+			if err == nil && !get.CreationTimestamp.Equal(&job.CreationTimestamp) {
+				return fmt.Errorf("job %s is not synced", job.Name)
+			}
+			return err
+		}, time.Second*10).Should(BeNil())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            job.Name + "-pod",
+				Namespace:       job.Namespace,
+				Labels:          label.NewBackup().Instance(backup.GetInstanceName()).BackupJob().Backup(backup.Name),
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(job, batchv1.SchemeGroupVersion.WithKind("Job"))},
+			},
+			Status: status,
+		}
+		pod.Status.Phase = corev1.PodFailed
+		_ = deps.KubeClientset.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+		_, err = deps.KubeClientset.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+		g.Expect(err).Should(BeNil())
+		g.Eventually(func() error {
+			get, err := deps.PodLister.Pods(pod.Namespace).Get(pod.Name)
+			if err == nil && !apiequality.Semantic.DeepEqual(get.Status, pod.Status) {
+				return fmt.Errorf("pod %s is not synced", pod.Name)
+			}
+			return err
+		}, time.Second*10).Should(BeNil())
+	}
+
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(err).Should(BeNil())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupScheduled, "")
+
+	// the OOMKilled pod is retried after the backoff
+	createFailedPod(corev1.PodStatus{
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "backup",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+		}},
+	})
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupRetryFailed, backuputil.PodFailureReasonOOMKilled)
+	records := getBackup().Status.BackoffRetryStatus
+	g.Expect(records).To(HaveLen(1))
+	g.Expect(records[0].RetryNum).To(Equal(int32(1)))
+	g.Expect(records[0].RealRetryAt.IsZero()).To(BeTrue())
+
+	time.Sleep(20 * time.Millisecond)
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	g.Expect(getBackup().Status.BackoffRetryStatus[0].RealRetryAt.IsZero()).To(BeFalse())
+	g.Eventually(func() bool {
+		_, err := deps.JobLister.Jobs(backup.Namespace).Get(backup.GetBackupJobName())
+		return errors.IsNotFound(err)
+	}, time.Second*10).Should(BeTrue())
+
+	// the job is recreated
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(err).Should(BeNil())
+	job, err := deps.KubeClientset.BatchV1().Jobs(backup.Namespace).Get(context.TODO(), backup.GetBackupJobName(), metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	// the fake clientset does not set the creation timestamp
+	job.CreationTimestamp = metav1.Now()
+	_, err = deps.KubeClientset.BatchV1().Jobs(job.Namespace).Update(context.TODO(), job, metav1.UpdateOptions{})
+	g.Expect(err).Should(BeNil())
+
+	// the retries are exhausted
+	createFailedPod(corev1.PodStatus{Reason: "Evicted", Message: "The node was low on resource: memory."})
+	err = bm.syncBackupJob(getBackup())
+	g.Expect(controller.IsIgnoreError(err)).To(BeTrue())
+	helper.hasCondition(backup.Namespace, backup.Name, v1alpha1.BackupFailed, backuputil.PodFailureReasonEvicted)
+	g.Expect(getBackup().Status.BackoffRetryStatus).To(HaveLen(1))
+}
@@ -0,0 +1,309 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+)
+
+var _ BackupVerifier = &backupVerifier{}
+
+// BackupVerifier implements the logic for verifying a completed backup
+type BackupVerifier interface {
+	Verify(backup *v1alpha1.Backup) error
+}
+
+type backupVerifier struct {
+	deps          *controller.Dependencies
+	statusUpdater controller.BackupConditionUpdaterInterface
+}
+
+// NewBackupVerifier returns a BackupVerifier
+func NewBackupVerifier(deps *controller.Dependencies, statusUpdater controller.BackupConditionUpdaterInterface) BackupVerifier {
+	return &backupVerifier{
+		deps:          deps,
+		statusUpdater: statusUpdater,
+	}
+}
+
+// Verify restores the backup into a scratch TidbCluster with checksum enabled, BR
+// fails the restore if the checksums of the restored tables do not match the backup.
+// The scratch TidbCluster and Restore are removed once the result is recorded.
+func (bv *backupVerifier) Verify(backup *v1alpha1.Backup) error {
+	if !v1alpha1.NeedVerifyBackup(backup) {
+		return nil
+	}
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	verifyName := backup.GetVerifyName()
+
+	tc, err := bv.deps.TiDBClusterLister.TidbClusters(ns).Get(verifyName)
+	if errors.IsNotFound(err) {
+		return bv.createVerifyCluster(backup)
+	}
+	if err != nil {
+		return fmt.Errorf("backup %s/%s get verify tidbcluster failed, err: %v", ns, name, err)
+	}
+
+	timeout := constants.DefaultVerifyTimeout
+	if backup.Spec.Verify.Timeout != nil {
+		timeout = *backup.Spec.Verify.Timeout
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return bv.finishVerify(backup, corev1.ConditionFalse, "InvalidVerifyTimeout", err.Error())
+	}
+	// the condition is set to Unknown when the verification starts
+	_, condition := v1alpha1.GetBackupCondition(&backup.Status, v1alpha1.BackupVerified)
+	if condition != nil && time.Since(condition.LastTransitionTime.Time) > duration {
+		return bv.finishVerify(backup, corev1.ConditionFalse, "VerifyTimeout",
+			fmt.Sprintf("backup is not verified in %s", timeout))
+	}
+
+	if !tc.PDAllMembersReady() || !tc.TiKVAllStoresReady() {
+		return controller.RequeueErrorf("backup %s/%s wait for verify tidbcluster %s to be ready", ns, name, verifyName)
+	}
+
+	restore, err := bv.deps.RestoreLister.Restores(ns).Get(verifyName)
+	if errors.IsNotFound(err) {
+		restore = newVerifyRestore(backup)
+		if _, err := bv.deps.Clientset.PingcapV1alpha1().Restores(ns).Create(context.TODO(), restore, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("backup %s/%s create verify restore failed, err: %v", ns, name, err)
+		}
+		klog.Infof("backup %s/%s created verify restore %s", ns, name, verifyName)
+		return controller.RequeueErrorf("backup %s/%s wait for verify restore %s to finish", ns, name, verifyName)
+	}
+	if err != nil {
+		return fmt.Errorf("backup %s/%s get verify restore failed, err: %v", ns, name, err)
+	}
+
+	switch {
+	case v1alpha1.IsRestoreComplete(restore):
+		return bv.finishVerify(backup, corev1.ConditionTrue, "RestoreComplete",
+			fmt.Sprintf("backup is restored into tidbcluster %s and the checksums match", verifyName))
+	case v1alpha1.IsRestoreFailed(restore):
+		_, condition := v1alpha1.GetRestoreCondition(&restore.Status, v1alpha1.RestoreFailed)
+		return bv.finishVerify(backup, corev1.ConditionFalse, "RestoreFailed",
+			fmt.Sprintf("restore %s failed: %s", verifyName, condition.Message))
+	case v1alpha1.IsRestoreInvalid(restore):
+		_, condition := v1alpha1.GetRestoreCondition(&restore.Status, v1alpha1.RestoreInvalid)
+		return bv.finishVerify(backup, corev1.ConditionFalse, "RestoreInvalid",
+			fmt.Sprintf("restore %s is invalid: %s", verifyName, condition.Message))
+	}
+	return controller.RequeueErrorf("backup %s/%s wait for verify restore %s to finish", ns, name, verifyName)
+}
+
+func (bv *backupVerifier) createVerifyCluster(backup *v1alpha1.Backup) error {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+
+	clusterNamespace := ns
+	if backup.Spec.BR.ClusterNamespace != "" {
+		clusterNamespace = backup.Spec.BR.ClusterNamespace
+	}
+	source, err := bv.deps.TiDBClusterLister.TidbClusters(clusterNamespace).Get(backup.Spec.BR.Cluster)
+	if err != nil {
+		return fmt.Errorf("backup %s/%s get tidbcluster %s/%s failed, err: %v", ns, name, clusterNamespace, backup.Spec.BR.Cluster, err)
+	}
+	tc, err := newVerifyTidbCluster(backup, source)
+	if err != nil {
+		return bv.finishVerify(backup, corev1.ConditionFalse, "InvalidVerifyCluster", err.Error())
+	}
+	if _, err := bv.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Create(context.TODO(), tc, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("backup %s/%s create verify tidbcluster failed, err: %v", ns, name, err)
+	}
+	klog.Infof("backup %s/%s created verify tidbcluster %s", ns, name, tc.Name)
+
+	if err := bv.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupVerified,
+		Status: corev1.ConditionUnknown,
+		Reason: "Verifying",
+	}, nil); err != nil {
+		return err
+	}
+	return controller.RequeueErrorf("backup %s/%s wait for verify tidbcluster %s to be ready", ns, name, tc.Name)
+}
+
+// finishVerify tears down the scratch cluster and records the result of the verification
+func (bv *backupVerifier) finishVerify(backup *v1alpha1.Backup, status corev1.ConditionStatus, reason, message string) error {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	verifyName := backup.GetVerifyName()
+
+	err := bv.deps.Clientset.PingcapV1alpha1().Restores(ns).Delete(context.TODO(), verifyName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("backup %s/%s delete verify restore failed, err: %v", ns, name, err)
+	}
+	err = bv.deps.Clientset.PingcapV1alpha1().TidbClusters(ns).Delete(context.TODO(), verifyName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("backup %s/%s delete verify tidbcluster failed, err: %v", ns, name, err)
+	}
+
+	// the PVCs are not owned by the tidbcluster, delete them explicitly
+	selector, err := label.New().Instance(verifyName).Selector()
+	if err != nil {
+		return err
+	}
+	pvcs, err := bv.deps.PVCLister.PersistentVolumeClaims(ns).List(selector)
+	if err != nil {
+		return fmt.Errorf("backup %s/%s list verify pvcs failed, err: %v", ns, name, err)
+	}
+	for _, pvc := range pvcs {
+		err := bv.deps.KubeClientset.CoreV1().PersistentVolumeClaims(ns).Delete(context.TODO(), pvc.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("backup %s/%s delete verify pvc %s failed, err: %v", ns, name, pvc.Name, err)
+		}
+	}
+
+	klog.Infof("backup %s/%s verification finished, verified: %s, reason: %s, message: %s", ns, name, status, reason, message)
+	return bv.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:    v1alpha1.BackupVerified,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}, nil)
+}
+
+// newVerifyTidbCluster returns a minimal TidbCluster with the same version as the backed up cluster
+func newVerifyTidbCluster(backup *v1alpha1.Backup, source *v1alpha1.TidbCluster) (*v1alpha1.TidbCluster, error) {
+	if source.Spec.PD == nil || source.Spec.TiKV == nil {
+		return nil, fmt.Errorf("tidbcluster %s/%s should have both PD and TiKV to be verified", source.Namespace, source.Name)
+	}
+	verify := backup.Spec.Verify
+
+	tikvReplicas := int32(1)
+	if verify.TiKVReplicas != nil {
+		tikvReplicas = *verify.TiKVReplicas
+	}
+	pdStorageClassName := source.Spec.PD.StorageClassName
+	tikvStorageClassName := source.Spec.TiKV.StorageClassName
+	if verify.StorageClassName != nil {
+		pdStorageClassName = verify.StorageClassName
+		tikvStorageClassName = verify.StorageClassName
+	}
+	tikvStorage := source.Spec.TiKV.Requests[corev1.ResourceStorage]
+	if verify.TiKVStorageSize != "" {
+		size, err := resource.ParseQuantity(verify.TiKVStorageSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verify tikvStorageSize %s: %v", verify.TiKVStorageSize, err)
+		}
+		tikvStorage = size
+	}
+
+	pdConfig := v1alpha1.NewPDConfig()
+	if tikvReplicas < 3 {
+		// the regions can not be fully replicated in a smaller cluster
+		pdConfig.Set("replication.max-replicas", int64(tikvReplicas))
+	}
+
+	reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+	tc := &v1alpha1.TidbCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            backup.GetVerifyName(),
+			Namespace:       backup.GetNamespace(),
+			Labels:          label.NewBackup().Instance(backup.GetInstanceName()).Backup(backup.GetName()),
+			OwnerReferences: []metav1.OwnerReference{controller.GetBackupOwnerRef(backup)},
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			Version:          source.Spec.Version,
+			PVReclaimPolicy:  &reclaimPolicy,
+			ImagePullPolicy:  source.Spec.ImagePullPolicy,
+			ImagePullSecrets: source.Spec.ImagePullSecrets,
+			Timezone:         source.Spec.Timezone,
+			PD: &v1alpha1.PDSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{
+					Image:   source.Spec.PD.Image,
+					Version: source.Spec.PD.Version,
+				},
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: storageRequests(source.Spec.PD.Requests[corev1.ResourceStorage]),
+				},
+				Replicas:         1,
+				BaseImage:        source.Spec.PD.BaseImage,
+				StorageClassName: pdStorageClassName,
+				Config:           pdConfig,
+			},
+			TiKV: &v1alpha1.TiKVSpec{
+				ComponentSpec: v1alpha1.ComponentSpec{
+					Image:   source.Spec.TiKV.Image,
+					Version: source.Spec.TiKV.Version,
+				},
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: storageRequests(tikvStorage),
+				},
+				Replicas:         tikvReplicas,
+				BaseImage:        source.Spec.TiKV.BaseImage,
+				StorageClassName: tikvStorageClassName,
+				Config:           v1alpha1.NewTiKVConfig(),
+			},
+		},
+	}
+	return tc, nil
+}
+
+// newVerifyRestore returns a Restore of the backup into the scratch cluster
+func newVerifyRestore(backup *v1alpha1.Backup) *v1alpha1.Restore {
+	verifyName := backup.GetVerifyName()
+	return &v1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            verifyName,
+			Namespace:       backup.GetNamespace(),
+			Labels:          label.NewBackup().Instance(backup.GetInstanceName()).Backup(backup.GetName()),
+			OwnerReferences: []metav1.OwnerReference{controller.GetBackupOwnerRef(backup)},
+		},
+		Spec: v1alpha1.RestoreSpec{
+			ResourceRequirements: backup.Spec.ResourceRequirements,
+			Env:                  backup.Spec.Env,
+			Type:                 backup.Spec.Type,
+			StorageProvider:      *backup.Spec.StorageProvider.DeepCopy(),
+			BR: &v1alpha1.BRConfig{
+				Cluster:        verifyName,
+				DB:             backup.Spec.BR.DB,
+				Table:          backup.Spec.BR.Table,
+				Checksum:       pointer.BoolPtr(true),
+				SendCredToTikv: backup.Spec.BR.SendCredToTikv,
+			},
+			Encryption:         backup.Spec.Encryption.DeepCopy(),
+			Tolerations:        backup.Spec.Tolerations,
+			Affinity:           backup.Spec.Affinity,
+			UseKMS:             backup.Spec.UseKMS,
+			ServiceAccount:     backup.Spec.ServiceAccount,
+			ToolImage:          backup.Spec.ToolImage,
+			ImagePullSecrets:   backup.Spec.ImagePullSecrets,
+			TableFilter:        backup.Spec.TableFilter,
+			PodSecurityContext: backup.Spec.PodSecurityContext,
+			PriorityClassName:  backup.Spec.PriorityClassName,
+		},
+	}
+}
+
+func storageRequests(size resource.Quantity) corev1.ResourceList {
+	if size.IsZero() {
+		return nil
+	}
+	return corev1.ResourceList{corev1.ResourceStorage: size}
+}
@@ -0,0 +1,349 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// volumeSnapshotPauseDuration is how long the PD schedulers are paused each time, it is renewed
+	// on every sync until all the snapshots are taken, and expires by itself if the operator is gone
+	volumeSnapshotPauseDuration = 5 * time.Minute
+	// volumeSnapshotGCSafePointTTL is the ttl in seconds of the service gc safe point kept during the snapshot
+	volumeSnapshotGCSafePointTTL = int64(volumeSnapshotPauseDuration / time.Second)
+)
+
+// syncVolumeSnapshotBackup takes CSI volume snapshots of all the TiKV data volumes.
+// The active PD schedulers are recorded and paused, then a BR job flushes and suspends the writes
+// of all TiKV stores. GC is held at the min resolved ts and the snapshots are created once the stores
+// are prepared. After all the snapshots are cut, the BR job is deleted to resume the writes, and only
+// the recorded schedulers are resumed, so the volumes are consistent at the resolved ts.
+func (bm *backupManager) syncVolumeSnapshotBackup(backup *v1alpha1.Backup, tc *v1alpha1.TidbCluster) error {
+	if v1alpha1.IsBackupComplete(backup) || v1alpha1.IsBackupFailed(backup) {
+		return nil
+	}
+	if !v1alpha1.IsBackupPrepared(backup) {
+		return bm.recordPausedSchedulers(backup, tc)
+	}
+	if len(backup.Status.VolumeSnapshots) == 0 {
+		return bm.createVolumeSnapshots(backup, tc)
+	}
+	return bm.checkVolumeSnapshots(backup, tc)
+}
+
+// recordPausedSchedulers records the schedulers which are active before the backup pauses them,
+// the schedulers paused by others are left untouched when the backup resumes the cluster
+func (bm *backupManager) recordPausedSchedulers(backup *v1alpha1.Backup, tc *v1alpha1.TidbCluster) error {
+	pdClient := controller.GetPDClient(bm.deps.PDControl, tc)
+	schedulers, err := pdClient.GetActiveSchedulers()
+	if err != nil {
+		return bm.retryVolumeSnapshot(backup, "GetActiveSchedulersFailed", err)
+	}
+	if err := bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupPrepare,
+		Status: corev1.ConditionTrue,
+	}, &controller.BackupUpdateStatus{
+		PausedSchedulers: schedulers,
+	}); err != nil {
+		return err
+	}
+	return controller.RequeueErrorf("backup %s/%s wait for TiKV to be prepared for volume snapshots", backup.Namespace, backup.Name)
+}
+
+func (bm *backupManager) createVolumeSnapshots(backup *v1alpha1.Backup, tc *v1alpha1.TidbCluster) error {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	started := time.Now()
+
+	pdClient := controller.GetPDClient(bm.deps.PDControl, tc)
+	if err := pdClient.PauseSchedulers(volumeSnapshotPauseDuration, backup.Status.PausedSchedulers); err != nil {
+		return bm.retryVolumeSnapshot(backup, "PauseSchedulersFailed", err)
+	}
+	if err := bm.syncPrepareJob(backup, tc); err != nil {
+		return err
+	}
+	if !v1alpha1.IsVolumeSnapshotPrepared(backup) {
+		return controller.RequeueErrorf("backup %s/%s wait for TiKV to be prepared for volume snapshots", ns, name)
+	}
+
+	pvcs, err := bm.getTiKVDataPVCs(tc)
+	if err != nil {
+		return bm.retryVolumeSnapshot(backup, "ListTiKVPVCFailed", err)
+	}
+	if len(pvcs) == 0 {
+		return bm.retryVolumeSnapshot(backup, "TiKVPVCNotFound", fmt.Errorf("no TiKV data volume found in tidbcluster %s/%s", tc.Namespace, tc.Name))
+	}
+
+	resolvedTs, err := pdClient.GetMinResolvedTS()
+	if err != nil {
+		return bm.retryVolumeSnapshot(backup, "GetMinResolvedTSFailed", err)
+	}
+	if err := bm.holdGC(pdClient, backup, resolvedTs); err != nil {
+		return bm.retryVolumeSnapshot(backup, "HoldGCFailed", err)
+	}
+
+	snapshots := make([]v1alpha1.TiKVVolumeSnapshot, 0, len(pvcs))
+	for _, item := range pvcs {
+		pvc := item.pvc
+		snapshotName := backup.GetVolumeSnapshotName(item.ordinal)
+		snapshotLabels := util.CombineStringMap(label.NewBackup().Instance(backup.GetInstanceName()).Backup(name), backup.Labels)
+		snapshot := backuputil.NewVolumeSnapshot(pvc.Namespace, snapshotName, pvc.Name, backup.Spec.VolumeSnapshotClassName, snapshotLabels)
+		if err := bm.deps.GenericClient.Create(context.TODO(), snapshot); err != nil && !errors.IsAlreadyExists(err) {
+			return bm.retryVolumeSnapshot(backup, "CreateVolumeSnapshotFailed", err)
+		}
+		klog.Infof("backup %s/%s created volume snapshot %s/%s of pvc %s", ns, name, pvc.Namespace, snapshotName, pvc.Name)
+
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		snapshots = append(snapshots, v1alpha1.TiKVVolumeSnapshot{
+			Ordinal:          item.ordinal,
+			StoreID:          pvc.Labels[label.StoreIDLabelKey],
+			PVCName:          pvc.Name,
+			SnapshotName:     snapshotName,
+			StorageClassName: pvc.Spec.StorageClassName,
+			Size:             size.String(),
+		})
+	}
+
+	ts := strconv.FormatUint(resolvedTs, 10)
+	if err := bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupRunning,
+		Status: corev1.ConditionTrue,
+	}, &controller.BackupUpdateStatus{
+		TimeStarted:     &metav1.Time{Time: started},
+		CommitTs:        &ts,
+		VolumeSnapshots: snapshots,
+	}); err != nil {
+		return err
+	}
+	return controller.RequeueErrorf("backup %s/%s wait for volume snapshots to be ready", ns, name)
+}
+
+func (bm *backupManager) checkVolumeSnapshots(backup *v1alpha1.Backup, tc *v1alpha1.TidbCluster) error {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+
+	allTaken, allReady := true, true
+	var totalSize int64
+	snapshots := make([]v1alpha1.TiKVVolumeSnapshot, len(backup.Status.VolumeSnapshots))
+	copy(snapshots, backup.Status.VolumeSnapshots)
+	for i := range snapshots {
+		snapshot := backuputil.NewEmptyVolumeSnapshot()
+		key := client.ObjectKey{Namespace: tc.Namespace, Name: snapshots[i].SnapshotName}
+		if err := bm.deps.GenericClient.Get(context.TODO(), key, snapshot); err != nil {
+			if errors.IsNotFound(err) {
+				return bm.failVolumeSnapshot(backup, tc, "VolumeSnapshotNotFound", err)
+			}
+			return err
+		}
+		state, err := backuputil.GetVolumeSnapshotState(snapshot)
+		if err != nil {
+			return bm.failVolumeSnapshot(backup, tc, "InvalidVolumeSnapshot", err)
+		}
+		if state.Error != "" {
+			return bm.failVolumeSnapshot(backup, tc, "VolumeSnapshotFailed",
+				fmt.Errorf("volume snapshot %s/%s failed: %s", tc.Namespace, snapshots[i].SnapshotName, state.Error))
+		}
+		allTaken = allTaken && state.Taken
+		allReady = allReady && state.ReadyToUse
+		snapshots[i].ReadyToUse = state.ReadyToUse
+		if state.RestoreSize != nil {
+			totalSize += state.RestoreSize.Value()
+		}
+	}
+
+	pdClient := controller.GetPDClient(bm.deps.PDControl, tc)
+	resolvedTs, err := strconv.ParseUint(backup.Status.CommitTs, 10, 64)
+	if err != nil {
+		return bm.failVolumeSnapshot(backup, tc, "InvalidCommitTs", err)
+	}
+	if !allTaken {
+		// keep the cluster paused and the writes suspended until every volume has been cut
+		if err := pdClient.PauseSchedulers(volumeSnapshotPauseDuration, backup.Status.PausedSchedulers); err != nil {
+			return bm.retryVolumeSnapshot(backup, "PauseSchedulersFailed", err)
+		}
+		if err := bm.holdGC(pdClient, backup, resolvedTs); err != nil {
+			return bm.retryVolumeSnapshot(backup, "HoldGCFailed", err)
+		}
+		if err := bm.syncPrepareJob(backup, tc); err != nil {
+			return err
+		}
+	} else if err := bm.resumeCluster(pdClient, backup); err != nil {
+		return bm.retryVolumeSnapshot(backup, "ResumeClusterFailed", err)
+	}
+
+	if !allReady {
+		if err := bm.statusUpdater.Update(backup, nil, &controller.BackupUpdateStatus{
+			VolumeSnapshots: snapshots,
+		}); err != nil {
+			return err
+		}
+		return controller.RequeueErrorf("backup %s/%s wait for volume snapshots to be ready", ns, name)
+	}
+
+	klog.Infof("backup %s/%s all the %d volume snapshots are ready", ns, name, len(snapshots))
+	backupSizeReadable := humanize.Bytes(uint64(totalSize))
+	return bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupComplete,
+		Status: corev1.ConditionTrue,
+	}, &controller.BackupUpdateStatus{
+		TimeCompleted:      &metav1.Time{Time: time.Now()},
+		BackupSize:         &totalSize,
+		BackupSizeReadable: &backupSizeReadable,
+		VolumeSnapshots:    snapshots,
+	})
+}
+
+// syncPrepareJob makes sure the BR job which keeps the writes of TiKV suspended is running. The job
+// is created before the snapshots, and the backup fails if it exits before all the snapshots are cut.
+func (bm *backupManager) syncPrepareJob(backup *v1alpha1.Backup, tc *v1alpha1.TidbCluster) error {
+	ns := backup.GetNamespace()
+	name := backup.GetName()
+	jobName := backup.GetBackupJobName()
+
+	job, err := bm.deps.JobLister.Jobs(ns).Get(jobName)
+	if err == nil {
+		if isJobFinished(job) {
+			return bm.failVolumeSnapshot(backup, tc, "PrepareJobExited",
+				fmt.Errorf("job %s/%s exited before all the volume snapshots are taken", ns, jobName))
+		}
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("backup %s/%s get job %s failed, err: %v", ns, name, jobName, err)
+	}
+	if v1alpha1.IsVolumeSnapshotPrepared(backup) {
+		return bm.failVolumeSnapshot(backup, tc, "PrepareJobNotFound",
+			fmt.Errorf("job %s/%s is gone before all the volume snapshots are taken", ns, jobName))
+	}
+
+	job, reason, err := bm.makeBackupJob(backup)
+	if err != nil {
+		return bm.retryVolumeSnapshot(backup, reason, err)
+	}
+	if err := bm.deps.JobControl.CreateJob(backup, job); err != nil {
+		return bm.retryVolumeSnapshot(backup, "CreateBackupJobFailed", err)
+	}
+	return nil
+}
+
+func (bm *backupManager) holdGC(pdClient pdapi.PDClient, backup *v1alpha1.Backup, resolvedTs uint64) error {
+	if err := pdClient.UpdateServiceGCSafePoint(volumeSnapshotServiceID(backup), volumeSnapshotGCSafePointTTL, resolvedTs); err != nil {
+		return fmt.Errorf("hold gc at %d failed: %v", resolvedTs, err)
+	}
+	return nil
+}
+
+// resumeCluster deletes the BR job to resume the writes of TiKV, resumes the schedulers paused
+// by the backup and removes the gc safe point
+func (bm *backupManager) resumeCluster(pdClient pdapi.PDClient, backup *v1alpha1.Backup) error {
+	job, err := bm.deps.JobLister.Jobs(backup.Namespace).Get(backup.GetBackupJobName())
+	if err == nil {
+		if err := bm.deps.JobControl.DeleteJob(backup, job); err != nil {
+			return fmt.Errorf("delete job %s/%s failed: %v", job.Namespace, job.Name, err)
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	if err := pdClient.ResumeSchedulers(backup.Status.PausedSchedulers); err != nil {
+		return fmt.Errorf("resume schedulers failed: %v", err)
+	}
+	if err := pdClient.UpdateServiceGCSafePoint(volumeSnapshotServiceID(backup), 0, 0); err != nil {
+		return fmt.Errorf("remove gc safe point failed: %v", err)
+	}
+	return nil
+}
+
+// retryVolumeSnapshot records a retryable failure and requeues the backup
+func (bm *backupManager) retryVolumeSnapshot(backup *v1alpha1.Backup, reason string, err error) error {
+	bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:    v1alpha1.BackupRetryFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: err.Error(),
+	}, nil)
+	return fmt.Errorf("backup %s/%s volume snapshot failed, reason: %s, err: %v", backup.Namespace, backup.Name, reason, err)
+}
+
+// failVolumeSnapshot resumes the cluster and marks the backup as failed
+func (bm *backupManager) failVolumeSnapshot(backup *v1alpha1.Backup, tc *v1alpha1.TidbCluster, reason string, err error) error {
+	if rerr := bm.resumeCluster(controller.GetPDClient(bm.deps.PDControl, tc), backup); rerr != nil {
+		// the pause and the suspension of TiKV expire by themselves, so the backup can still be failed
+		klog.Warningf("backup %s/%s resume the cluster failed, err: %v", backup.Namespace, backup.Name, rerr)
+	}
+	return bm.statusUpdater.Update(backup, &v1alpha1.BackupCondition{
+		Type:    v1alpha1.BackupFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: err.Error(),
+	}, nil)
+}
+
+type tikvDataPVC struct {
+	ordinal int32
+	pvc     *corev1.PersistentVolumeClaim
+}
+
+// getTiKVDataPVCs returns the data PVCs of the TiKV Pods sorted by the ordinal
+func (bm *backupManager) getTiKVDataPVCs(tc *v1alpha1.TidbCluster) ([]tikvDataPVC, error) {
+	selector, err := label.New().Instance(tc.GetInstanceName()).TiKV().Selector()
+	if err != nil {
+		return nil, err
+	}
+	pvcs, err := bm.deps.PVCLister.PersistentVolumeClaims(tc.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// the TiKV Pods may mount other volumes, only snapshot the data volumes
+	prefix := fmt.Sprintf("%s-%s-", v1alpha1.TiKVMemberType, controller.TiKVMemberName(tc.Name))
+	var result []tikvDataPVC
+	for _, pvc := range pvcs {
+		if !strings.HasPrefix(pvc.Name, prefix) || pvc.DeletionTimestamp != nil {
+			continue
+		}
+		ordinal, err := util.GetOrdinalFromPodName(pvc.Name)
+		if err != nil || backuputil.GetTiKVDataPVCName(tc.Name, ordinal) != pvc.Name {
+			continue
+		}
+		result = append(result, tikvDataPVC{ordinal: ordinal, pvc: pvc})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ordinal < result[j].ordinal
+	})
+	return result, nil
+}
+
+// volumeSnapshotServiceID is the service id of the gc safe point kept by the backup
+func volumeSnapshotServiceID(backup *v1alpha1.Backup) string {
+	return fmt.Sprintf("tidb-operator-volume-snapshot-%s-%s", backup.Namespace, backup.Name)
+}
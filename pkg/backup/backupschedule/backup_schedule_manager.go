@@ -24,9 +24,12 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup"
 	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	"github.com/robfig/cron"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -48,6 +51,12 @@ func NewBackupScheduleManager(deps *controller.Dependencies) backup.BackupSchedu
 }
 
 func (bm *backupScheduleManager) Sync(bs *v1alpha1.BackupSchedule) error {
+	defer bm.updateBackupScheduleMetrics(bs)
+
+	if err := backuputil.ValidateBackupSchedule(bs); err != nil {
+		bm.deps.Recorder.Event(bs, corev1.EventTypeWarning, "InvalidSpec", err.Error())
+		return controller.IgnoreErrorf("invalid backup schedule spec %s/%s cause %s", bs.GetNamespace(), bs.GetName(), err.Error())
+	}
 	defer bm.backupGC(bs)
 
 	if bs.Spec.Pause {
@@ -79,6 +88,36 @@ func (bm *backupScheduleManager) Sync(bs *v1alpha1.BackupSchedule) error {
 	return nil
 }
 
+// updateBackupScheduleMetrics updates the last success timestamp and the lag of the backup schedule
+func (bm *backupScheduleManager) updateBackupScheduleMetrics(bs *v1alpha1.BackupSchedule) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		klog.Errorf("backup schedule %s/%s update metrics failed, err: %v", ns, bsName, err)
+		return
+	}
+	var lastSuccess time.Time
+	for _, backup := range backupsList {
+		if v1alpha1.IsBackupComplete(backup) && backup.Status.TimeCompleted.After(lastSuccess) {
+			lastSuccess = backup.Status.TimeCompleted.Time
+		}
+	}
+	if !lastSuccess.IsZero() {
+		metrics.BackupScheduleLastSuccessTimestamp.WithLabelValues(ns, bsName).Set(float64(lastSuccess.Unix()))
+	}
+
+	// the lag is how long the next scheduled backup has been overdue, it is zero if the schedule is paused
+	var lag time.Duration
+	if sched, err := cron.ParseStandard(bs.Spec.Schedule); err == nil && !bs.Spec.Pause {
+		if next := sched.Next(getEarliestScheduleTime(bs)); !next.IsZero() && bm.now().After(next) {
+			lag = bm.now().Sub(next)
+		}
+	}
+	metrics.BackupScheduleLag.WithLabelValues(ns, bsName).Set(lag.Seconds())
+}
+
 func (bm *backupScheduleManager) deleteLastBackupJob(bs *v1alpha1.BackupSchedule) error {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
@@ -124,6 +163,24 @@ func (bm *backupScheduleManager) canPerformNextBackup(bs *v1alpha1.BackupSchedul
 	return controller.RequeueErrorf("backup schedule %s/%s, the last backup %s is still running", ns, bsName, bs.Status.LastBackup)
 }
 
+// getEarliestScheduleTime returns the time from which the next backup is scheduled
+func getEarliestScheduleTime(bs *v1alpha1.BackupSchedule) time.Time {
+	if bs.Status.LastBackupTime != nil {
+		return bs.Status.LastBackupTime.Time
+	}
+	if bs.Status.AllBackupCleanTime != nil {
+		// Recovery from a long paused backup schedule may cause problem like "incorrect clock",
+		// so we introduce AllBackupCleanTime field to solve this problem.
+		return bs.Status.AllBackupCleanTime.Time
+	}
+	// If none found, then this is either a recently created backupSchedule,
+	// or the backupSchedule status info was somehow lost,
+	// or that we have started a backup, but have not update backupSchedule status yet
+	// (distributed systems can have arbitrary delays).
+	// In any case, use the creation time of the backupSchedule as last known start time.
+	return bs.ObjectMeta.CreationTimestamp.Time
+}
+
 // getLastScheduledTime return the newest time need to be scheduled according last backup time.
 // the return time is not before now and return nil if there's no such time.
 func getLastScheduledTime(bs *v1alpha1.BackupSchedule, nowFn nowFn) (*time.Time, error) {
@@ -135,21 +192,7 @@ func getLastScheduledTime(bs *v1alpha1.BackupSchedule, nowFn nowFn) (*time.Time,
 		return nil, fmt.Errorf("parse backup schedule %s/%s cron format %s failed, err: %v", ns, bsName, bs.Spec.Schedule, err)
 	}
 
-	var earliestTime time.Time
-	if bs.Status.LastBackupTime != nil {
-		earliestTime = bs.Status.LastBackupTime.Time
-	} else if bs.Status.AllBackupCleanTime != nil {
-		// Recovery from a long paused backup schedule may cause problem like "incorrect clock",
-		// so we introduce AllBackupCleanTime field to solve this problem.
-		earliestTime = bs.Status.AllBackupCleanTime.Time
-	} else {
-		// If none found, then this is either a recently created backupSchedule,
-		// or the backupSchedule status info was somehow lost,
-		// or that we have started a backup, but have not update backupSchedule status yet
-		// (distributed systems can have arbitrary delays).
-		// In any case, use the creation time of the backupSchedule as last known start time.
-		earliestTime = bs.ObjectMeta.CreationTimestamp.Time
-	}
+	earliestTime := getEarliestScheduleTime(bs)
 
 	now := nowFn()
 	if earliestTime.After(now) {
@@ -196,6 +239,9 @@ func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Bac
 	bsName := bs.GetName()
 
 	backupSpec := *bs.Spec.BackupTemplate.DeepCopy()
+	if tier := getNewBackupTier(bs, timestamp); tier != nil {
+		applyRetentionTier(&backupSpec, tier.spec)
+	}
 	if backupSpec.BR == nil {
 		if backupSpec.StorageClassName == nil || *backupSpec.StorageClassName == "" {
 			backupSpec.StorageClassName = bs.Spec.StorageClassName
@@ -217,15 +263,16 @@ func buildBackup(bs *v1alpha1.BackupSchedule, timestamp time.Time) *v1alpha1.Bac
 		pdAddress = fmt.Sprintf("%s-pd.%s:2379", backupSpec.BR.Cluster, clusterNamespace)
 
 		backupPrefix := strings.ReplaceAll(pdAddress, ":", "-") + "-" + timestamp.UTC().Format(v1alpha1.BackupNameTimeFormat)
-		if backupSpec.S3 != nil {
-			backupSpec.S3.Prefix = path.Join(backupSpec.S3.Prefix, backupPrefix)
-		} else if backupSpec.Gcs != nil {
-			backupSpec.Gcs.Prefix = path.Join(backupSpec.Gcs.Prefix, backupPrefix)
-		} else if backupSpec.Local != nil {
-			backupSpec.Local.Prefix = path.Join(backupSpec.Local.Prefix, backupPrefix)
+		joinBackupPrefix(&backupSpec.StorageProvider, backupPrefix)
+		for i := range backupSpec.ReplicaStorages {
+			joinBackupPrefix(&backupSpec.ReplicaStorages[i], backupPrefix)
 		}
 	}
 
+	if backupSpec.Verify == nil && bs.Spec.Verify != nil {
+		backupSpec.Verify = bs.Spec.Verify.DeepCopy()
+	}
+
 	if bs.Spec.ImagePullSecrets != nil {
 		backupSpec.ImagePullSecrets = bs.Spec.ImagePullSecrets
 	}
@@ -252,10 +299,27 @@ func createBackup(bkController controller.BackupControlInterface, bs *v1alpha1.B
 	return bkController.CreateBackup(bk)
 }
 
+// joinBackupPrefix appends the prefix of a single backup to the prefix of the storage
+func joinBackupPrefix(provider *v1alpha1.StorageProvider, backupPrefix string) {
+	if provider.S3 != nil {
+		provider.S3.Prefix = path.Join(provider.S3.Prefix, backupPrefix)
+	} else if provider.Gcs != nil {
+		provider.Gcs.Prefix = path.Join(provider.Gcs.Prefix, backupPrefix)
+	} else if provider.Local != nil {
+		provider.Local.Prefix = path.Join(provider.Local.Prefix, backupPrefix)
+	}
+}
+
 func (bm *backupScheduleManager) backupGC(bs *v1alpha1.BackupSchedule) {
 	ns := bs.GetNamespace()
 	bsName := bs.GetName()
 
+	// the retention policy can't be set together with MaxBackups and MaxReservedTime.
+	if bs.Spec.RetentionPolicy != nil {
+		bm.backupGCByRetentionPolicy(bs)
+		return
+	}
+
 	// if MaxBackups and MaxReservedTime are set at the same time, MaxReservedTime is preferred.
 	if bs.Spec.MaxReservedTime != nil {
 		bm.backupGCByMaxReservedTime(bs)
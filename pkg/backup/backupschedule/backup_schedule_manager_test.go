@@ -25,6 +25,8 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/constants"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -218,6 +220,196 @@ func TestBuildBackup(t *testing.T) {
 	if diff := cmp.Diff(bk, get); diff != "" {
 		t.Errorf("unexpected (-want, +got): %s", diff)
 	}
+
+	// should use Verify from BackupSchedule if not set in BackupTemplate
+	bs.Spec.Verify = &v1alpha1.BackupVerifySpec{TiKVReplicas: pointer.Int32Ptr(3)}
+	bk.Spec.Verify = bs.Spec.Verify.DeepCopy()
+	get = buildBackup(bs, now)
+	if diff := cmp.Diff(bk, get); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+	bs.Spec.BackupTemplate.Verify = &v1alpha1.BackupVerifySpec{TiKVReplicas: pointer.Int32Ptr(1)}
+	bk.Spec.Verify = bs.Spec.BackupTemplate.Verify.DeepCopy()
+	get = buildBackup(bs, now)
+	if diff := cmp.Diff(bk, get); diff != "" {
+		t.Errorf("unexpected (-want, +got): %s", diff)
+	}
+}
+
+func TestBuildBackupWithRetentionPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Date(2022, 3, 15, 10, 0, 0, 0, time.UTC)
+	backupPrefix := "-pd.ns-2379-" + now.Format(v1alpha1.BackupNameTimeFormat)
+
+	bs := &v1alpha1.BackupSchedule{
+		Spec: v1alpha1.BackupScheduleSpec{
+			BackupTemplate: v1alpha1.BackupSpec{
+				StorageProvider: v1alpha1.StorageProvider{
+					S3: &v1alpha1.S3StorageProvider{
+						StorageClass: "STANDARD",
+						Prefix:       "backup",
+					},
+				},
+				BR: &v1alpha1.BRConfig{},
+			},
+			RetentionPolicy: &v1alpha1.BackupRetentionPolicy{
+				Monthly: &v1alpha1.BackupRetentionTier{Keep: 12, StorageClass: "GLACIER", Prefix: "monthly"},
+				Daily:   &v1alpha1.BackupRetentionTier{Keep: 7, Prefix: "daily"},
+			},
+		},
+	}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+
+	// the first backup of the month
+	bs.Status.LastBackupTime = &metav1.Time{Time: now.AddDate(0, -1, 0)}
+	get := buildBackup(bs, now)
+	g.Expect(get.Spec.S3.StorageClass).Should(Equal("GLACIER"))
+	g.Expect(get.Spec.S3.Prefix).Should(Equal("backup/monthly/" + backupPrefix))
+
+	// the first backup of the day
+	bs.Status.LastBackupTime = &metav1.Time{Time: now.AddDate(0, 0, -1)}
+	get = buildBackup(bs, now)
+	g.Expect(get.Spec.S3.StorageClass).Should(Equal("STANDARD"))
+	g.Expect(get.Spec.S3.Prefix).Should(Equal("backup/daily/" + backupPrefix))
+
+	// not the first backup of any tier
+	bs.Status.LastBackupTime = &metav1.Time{Time: now.Add(-time.Hour)}
+	get = buildBackup(bs, now)
+	g.Expect(get.Spec.S3.StorageClass).Should(Equal("STANDARD"))
+	g.Expect(get.Spec.S3.Prefix).Should(Equal("backup/" + backupPrefix))
+	// the template should not be changed
+	g.Expect(bs.Spec.BackupTemplate.S3.Prefix).Should(Equal("backup"))
+}
+
+func TestBackupGCByRetentionPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	m := NewBackupScheduleManager(helper.deps).(*backupScheduleManager)
+
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+	bs.Spec.RetentionPolicy = &v1alpha1.BackupRetentionPolicy{
+		Monthly: &v1alpha1.BackupRetentionTier{Keep: 1},
+		Daily:   &v1alpha1.BackupRetentionTier{Keep: 2},
+		Hourly:  &v1alpha1.BackupRetentionTier{Keep: 3},
+	}
+
+	// one backup every 6 hours, bk-05 failed, bk-16 failed after the latest
+	// complete backup and bk-17 is still running
+	base := time.Date(2022, 3, 1, 0, 30, 0, 0, time.UTC)
+	for i := 0; i < 18; i++ {
+		bk := &v1alpha1.Backup{}
+		bk.Namespace = bs.Namespace
+		bk.Name = fmt.Sprintf("bk-%02d", i)
+		bk.Labels = label.NewBackupSchedule().Instance(bs.Name).BackupSchedule(bs.Name).Labels()
+		bk.CreationTimestamp = metav1.Time{Time: base.Add(time.Duration(i*6) * time.Hour)}
+		condType := v1alpha1.BackupComplete
+		switch i {
+		case 5, 16:
+			condType = v1alpha1.BackupFailed
+		case 17:
+			condType = v1alpha1.BackupRunning
+		}
+		bk.Status.Conditions = []v1alpha1.BackupCondition{{Type: condType, Status: v1.ConditionTrue}}
+		helper.createBackup(bk)
+	}
+
+	m.backupGC(bs)
+	bks := helper.checkBacklist(bs.Namespace, 8)
+	var names []string
+	for _, bk := range bks.Items {
+		names = append(names, bk.Name)
+	}
+	g.Expect(names).Should(ConsistOf("bk-00", "bk-08", "bk-12", "bk-13", "bk-14", "bk-15", "bk-16", "bk-17"))
+	g.Expect(bs.Status.RetainedBackups).Should(Equal([]v1alpha1.BackupRetentionStatus{
+		{Tier: v1alpha1.BackupRetentionTierMonthly, Backups: []string{"bk-00"}},
+		{Tier: v1alpha1.BackupRetentionTierDaily, Backups: []string{"bk-08", "bk-12"}},
+		{Tier: v1alpha1.BackupRetentionTierHourly, Backups: []string{"bk-13", "bk-14", "bk-15"}},
+	}))
+}
+
+func TestBackupGCByRetentionPolicyScheduledTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	m := NewBackupScheduleManager(helper.deps).(*backupScheduleManager)
+
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "bsname"
+	bs.Spec.RetentionPolicy = &v1alpha1.BackupRetentionPolicy{
+		Daily: &v1alpha1.BackupRetentionTier{Keep: 2},
+	}
+
+	// the backup scheduled at the end of a day is created in the next day,
+	// it is still the first backup of the day it is scheduled in
+	scheduled := []time.Time{
+		time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2022, 3, 2, 23, 59, 0, 0, time.UTC),
+		time.Date(2022, 3, 3, 12, 0, 0, 0, time.UTC),
+	}
+	for _, t := range scheduled {
+		bk := &v1alpha1.Backup{}
+		bk.Namespace = bs.Namespace
+		bk.Name = bs.GetBackupCRDName(t)
+		bk.Labels = label.NewBackupSchedule().Instance(bs.Name).BackupSchedule(bs.Name).Labels()
+		bk.CreationTimestamp = metav1.Time{Time: t.Add(2 * time.Minute)}
+		bk.Status.Conditions = []v1alpha1.BackupCondition{{Type: v1alpha1.BackupComplete, Status: v1.ConditionTrue}}
+		helper.createBackup(bk)
+	}
+
+	m.backupGC(bs)
+	helper.checkBacklist(bs.Namespace, 2)
+	g.Expect(bs.Status.RetainedBackups).Should(Equal([]v1alpha1.BackupRetentionStatus{
+		{Tier: v1alpha1.BackupRetentionTierDaily, Backups: []string{bs.GetBackupCRDName(scheduled[1]), bs.GetBackupCRDName(scheduled[2])}},
+	}))
+}
+
+func TestUpdateBackupScheduleMetrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.close()
+	m := NewBackupScheduleManager(helper.deps).(*backupScheduleManager)
+
+	now := time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	bs := &v1alpha1.BackupSchedule{}
+	bs.Namespace = "ns"
+	bs.Name = "metrics"
+	bs.Spec.Schedule = "0 * * * *"
+	bs.Status.LastBackupTime = &metav1.Time{Time: now.Add(-3 * time.Hour)}
+
+	for i, condType := range []v1alpha1.BackupConditionType{v1alpha1.BackupComplete, v1alpha1.BackupComplete, v1alpha1.BackupFailed} {
+		bk := &v1alpha1.Backup{}
+		bk.Namespace = bs.Namespace
+		bk.Name = fmt.Sprintf("bk-%02d", i)
+		bk.Labels = label.NewBackupSchedule().Instance(bs.Name).BackupSchedule(bs.Name).Labels()
+		bk.Status.TimeCompleted = metav1.Time{Time: now.Add(time.Duration(i-5) * time.Hour)}
+		bk.Status.Conditions = []v1alpha1.BackupCondition{{Type: condType, Status: v1.ConditionTrue}}
+		helper.createBackup(bk)
+	}
+
+	// the last success is bk-01, and the backup scheduled at 08:00 is overdue
+	m.updateBackupScheduleMetrics(bs)
+	lastSuccess := testutil.ToFloat64(metrics.BackupScheduleLastSuccessTimestamp.WithLabelValues(bs.Namespace, bs.Name))
+	g.Expect(lastSuccess).Should(Equal(float64(now.Add(-4 * time.Hour).Unix())))
+	lag := testutil.ToFloat64(metrics.BackupScheduleLag.WithLabelValues(bs.Namespace, bs.Name))
+	g.Expect(lag).Should(Equal((150 * time.Minute).Seconds()))
+
+	// no lag if the next backup is not overdue
+	bs.Status.LastBackupTime = &metav1.Time{Time: now.Add(-10 * time.Minute)}
+	m.updateBackupScheduleMetrics(bs)
+	g.Expect(testutil.ToFloat64(metrics.BackupScheduleLag.WithLabelValues(bs.Namespace, bs.Name))).Should(BeZero())
+
+	// no lag if the schedule is paused
+	bs.Status.LastBackupTime = &metav1.Time{Time: now.Add(-3 * time.Hour)}
+	bs.Spec.Pause = true
+	m.updateBackupScheduleMetrics(bs)
+	g.Expect(testutil.ToFloat64(metrics.BackupScheduleLag.WithLabelValues(bs.Namespace, bs.Name))).Should(BeZero())
 }
 
 type helper struct {
@@ -0,0 +1,205 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupschedule
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/klog/v2"
+)
+
+// retentionTier is a configured tier of the retention policy
+type retentionTier struct {
+	tierType v1alpha1.BackupRetentionTierType
+	spec     *v1alpha1.BackupRetentionTier
+	// period returns the period of the tier which the time is in
+	period func(t time.Time) string
+}
+
+// getRetentionTiers returns the configured tiers from the coarsest to the finest
+func getRetentionTiers(policy *v1alpha1.BackupRetentionPolicy) []retentionTier {
+	if policy == nil {
+		return nil
+	}
+	all := []retentionTier{
+		{
+			tierType: v1alpha1.BackupRetentionTierMonthly,
+			spec:     policy.Monthly,
+			period:   func(t time.Time) string { return t.UTC().Format("2006-01") },
+		},
+		{
+			tierType: v1alpha1.BackupRetentionTierWeekly,
+			spec:     policy.Weekly,
+			period: func(t time.Time) string {
+				year, week := t.UTC().ISOWeek()
+				return fmt.Sprintf("%d-W%02d", year, week)
+			},
+		},
+		{
+			tierType: v1alpha1.BackupRetentionTierDaily,
+			spec:     policy.Daily,
+			period:   func(t time.Time) string { return t.UTC().Format("2006-01-02") },
+		},
+		{
+			tierType: v1alpha1.BackupRetentionTierHourly,
+			spec:     policy.Hourly,
+			period:   func(t time.Time) string { return t.UTC().Format("2006-01-02T15") },
+		},
+	}
+	var tiers []retentionTier
+	for _, tier := range all {
+		if tier.spec != nil {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers
+}
+
+// getNewBackupTier returns the coarsest tier which the backup created at the timestamp is the first
+// backup of the period in, it returns nil if the backup does not belong to any tier.
+func getNewBackupTier(bs *v1alpha1.BackupSchedule, timestamp time.Time) *retentionTier {
+	for _, tier := range getRetentionTiers(bs.Spec.RetentionPolicy) {
+		if bs.Status.LastBackupTime == nil || tier.period(bs.Status.LastBackupTime.Time) != tier.period(timestamp) {
+			return &tier
+		}
+	}
+	return nil
+}
+
+// applyRetentionTier sets the storage class and prefix of the tier to the backup spec
+func applyRetentionTier(backupSpec *v1alpha1.BackupSpec, tier *v1alpha1.BackupRetentionTier) {
+	joinPrefix := func(prefix string) string {
+		if tier.Prefix == "" {
+			return prefix
+		}
+		return path.Join(prefix, tier.Prefix)
+	}
+	if s3 := backupSpec.S3; s3 != nil {
+		if tier.StorageClass != "" {
+			s3.StorageClass = tier.StorageClass
+		}
+		s3.Prefix = joinPrefix(s3.Prefix)
+	} else if gcs := backupSpec.Gcs; gcs != nil {
+		if tier.StorageClass != "" {
+			gcs.StorageClass = tier.StorageClass
+		}
+		gcs.Prefix = joinPrefix(gcs.Prefix)
+	} else if azblob := backupSpec.Azblob; azblob != nil {
+		if tier.StorageClass != "" {
+			azblob.AccessTier = tier.StorageClass
+		}
+		azblob.Prefix = joinPrefix(azblob.Prefix)
+	} else if local := backupSpec.Local; local != nil {
+		local.Prefix = joinPrefix(local.Prefix)
+	}
+}
+
+// getBackupScheduledTime returns the time the backup is scheduled at, which is encoded in the name of
+// the backups created by the schedule, the creation time is used for the other backups.
+// The periods of the tiers are of the scheduled time, the same as the last backup time of the schedule.
+func getBackupScheduledTime(bs *v1alpha1.BackupSchedule, backup *v1alpha1.Backup) time.Time {
+	if suffix := strings.TrimPrefix(backup.Name, bs.GetName()+"-"); suffix != backup.Name {
+		if t, err := time.Parse(v1alpha1.BackupNameTimeFormat, suffix); err == nil {
+			return t
+		}
+	}
+	return backup.CreationTimestamp.Time
+}
+
+// getRetainedBackups returns the names of the completed backups retained by the tier,
+// the backups should be sorted by the scheduled time from the oldest to the newest.
+func getRetainedBackups(bs *v1alpha1.BackupSchedule, backups []*v1alpha1.Backup, tier retentionTier) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, backup := range backups {
+		if !v1alpha1.IsBackupComplete(backup) {
+			continue
+		}
+		period := tier.period(getBackupScheduledTime(bs, backup))
+		if seen[period] {
+			continue
+		}
+		seen[period] = true
+		names = append(names, backup.Name)
+	}
+	if keep := int(tier.spec.Keep); len(names) > keep {
+		names = names[len(names)-keep:]
+	}
+	return names
+}
+
+// backupGCByRetentionPolicy deletes the backups that are not retained by any tier of the retention policy.
+// The backups in progress are kept, and so are the failed backups newer than the latest completed backup.
+func (bm *backupScheduleManager) backupGCByRetentionPolicy(bs *v1alpha1.BackupSchedule) {
+	ns := bs.GetNamespace()
+	bsName := bs.GetName()
+
+	backupsList, err := bm.getBackupList(bs)
+	if err != nil {
+		klog.Errorf("backupGCByRetentionPolicy failed, err: %s", err)
+		return
+	}
+	sort.SliceStable(backupsList, func(i, j int) bool {
+		return getBackupScheduledTime(bs, backupsList[i]).Before(getBackupScheduledTime(bs, backupsList[j]))
+	})
+
+	retained := map[string]bool{}
+	var retainedBackups []v1alpha1.BackupRetentionStatus
+	for _, tier := range getRetentionTiers(bs.Spec.RetentionPolicy) {
+		names := getRetainedBackups(bs, backupsList, tier)
+		for _, name := range names {
+			retained[name] = true
+		}
+		retainedBackups = append(retainedBackups, v1alpha1.BackupRetentionStatus{
+			Tier:    tier.tierType,
+			Backups: names,
+		})
+	}
+	bs.Status.RetainedBackups = retainedBackups
+
+	var latestComplete time.Time
+	for _, backup := range backupsList {
+		if v1alpha1.IsBackupComplete(backup) {
+			latestComplete = getBackupScheduledTime(bs, backup)
+		}
+	}
+
+	var deleteCount int
+	for _, backup := range backupsList {
+		if retained[backup.Name] {
+			continue
+		}
+		finished := v1alpha1.IsBackupComplete(backup) || v1alpha1.IsBackupFailed(backup) || v1alpha1.IsBackupInvalid(backup)
+		if !finished || (!v1alpha1.IsBackupComplete(backup) && getBackupScheduledTime(bs, backup).After(latestComplete)) {
+			continue
+		}
+		// delete the backup not retained by any tier
+		if err := bm.deps.BackupControl.DeleteBackup(backup); err != nil {
+			klog.Errorf("backup schedule %s/%s gc backup %s failed, err %v", ns, bsName, backup.GetName(), err)
+			return
+		}
+		deleteCount += 1
+		klog.Infof("backup schedule %s/%s gc backup %s success", ns, bsName, backup.GetName())
+	}
+
+	if deleteCount == len(backupsList) && deleteCount > 0 {
+		// All backups have been deleted, so the last backup information in the backupSchedule should be reset
+		bm.resetLastBackup(bs)
+	}
+}
@@ -23,12 +23,27 @@ const (
 	// DefaultStorageSize is the default pvc request storage size for backup and restore
 	DefaultStorageSize = "100Gi"
 
+	// DefaultVerifyTimeout is the default timeout of the backup verification
+	DefaultVerifyTimeout = "24h"
+
+	// DefaultBackoffMinRetryDuration is the default delay of the first retry of the failed job
+	DefaultBackoffMinRetryDuration = "300s"
+
+	// DefaultBackoffMaxRetryTimes is the default maximum number of retries of the failed job
+	DefaultBackoffMaxRetryTimes = 2
+
+	// DefaultBackoffRetryTimeout is the default timeout of retrying the failed job
+	DefaultBackoffRetryTimeout = "30m"
+
 	// DefaultBackoffLimit specifies the number of retries before marking this job failed.
 	DefaultBackoffLimit = 6
 
 	// TidbPasswordKey represents the password key in tidb secret
 	TidbPasswordKey = "password"
 
+	// EncryptionDataKey represents the hex encoded data key in the encryption secret
+	EncryptionDataKey = "data_key"
+
 	// S3AccessKey represents the S3 compatible access key id in related secret
 	S3AccessKey = "access_key"
 
@@ -65,6 +80,10 @@ const (
 	// KMS secret env prefix
 	KMSSecretPrefix = "KMS_ENCRYPTED"
 
+	// ReplicaStorageEnvPrefixFmt is the format of the prefix of the env vars to access a replica storage,
+	// the arg is the index of the replica storage
+	ReplicaStorageEnvPrefixFmt = "REPLICA_%d_"
+
 	// RootKey represents the username in tidb secret
 	TidbRootKey = "root"
 )
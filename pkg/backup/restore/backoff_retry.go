@@ -0,0 +1,64 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// syncBackoffRetry retries the failed restore job with backoff by backuputil.SyncBackoffRetry,
+// the failed job is recreated by syncRestoreJob after it is deleted.
+func (rm *restoreManager) syncBackoffRetry(restore *v1alpha1.Restore) error {
+	if v1alpha1.IsRestoreComplete(restore) || v1alpha1.IsRestoreFailed(restore) {
+		return nil
+	}
+	selector, err := label.NewRestore().Instance(restore.GetInstanceName()).RestoreJob().Restore(restore.GetName()).Selector()
+	if err != nil {
+		return err
+	}
+
+	return backuputil.SyncBackoffRetry(&backuputil.BackoffRetryJob{
+		Kind:        "restore",
+		Owner:       restore,
+		Namespace:   restore.GetNamespace(),
+		Name:        restore.GetName(),
+		JobName:     restore.GetRestoreJobName(),
+		PodSelector: selector,
+		Policy:      restore.Spec.BackoffRetryPolicy,
+		Records:     restore.Status.BackoffRetryStatus,
+		Fail: func(reason, message string) error {
+			return rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  reason,
+				Message: message,
+			}, nil)
+		},
+		RetryFailed: func(reason, message string, record *v1alpha1.BackoffRetryRecord) error {
+			return rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreRetryFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  reason,
+				Message: message,
+			}, &controller.RestoreUpdateStatus{BackoffRetryRecord: record})
+		},
+		UpdateRecord: func(record *v1alpha1.BackoffRetryRecord) error {
+			return rm.statusUpdater.Update(restore, nil, &controller.RestoreUpdateStatus{BackoffRetryRecord: record})
+		},
+	}, rm.deps.JobLister, rm.deps.PodLister, rm.deps.JobControl)
+}
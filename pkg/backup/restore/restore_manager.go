@@ -59,7 +59,8 @@ func (rm *restoreManager) syncRestoreJob(restore *v1alpha1.Restore) error {
 	restoreJobName := restore.GetRestoreJobName()
 
 	var err error
-	if restore.Spec.BR == nil {
+	if restore.Spec.BR == nil || restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+		// the tidbcluster of the volume-snapshot restore is created after its volumes are provisioned
 		err = backuputil.ValidateRestore(restore, "")
 	} else {
 		restoreNamespace := restore.GetNamespace()
@@ -95,6 +96,19 @@ func (rm *restoreManager) syncRestoreJob(restore *v1alpha1.Restore) error {
 		return controller.IgnoreErrorf("invalid restore spec %s/%s", ns, name)
 	}
 
+	if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+		// the restore job of BR is created only after the volumes are provisioned and the TiKV stores are up
+		if ready, err := rm.syncVolumeSnapshotRestore(restore); !ready || err != nil {
+			return err
+		}
+	}
+
+	if restore.Spec.BackoffRetryPolicy != nil {
+		if err := rm.syncBackoffRetry(restore); err != nil {
+			return err
+		}
+	}
+
 	_, err = rm.deps.JobLister.Jobs(ns).Get(restoreJobName)
 	if err == nil {
 		// already have a backup job running，return directly
@@ -181,6 +195,12 @@ func (rm *restoreManager) makeImportJob(restore *v1alpha1.Restore) (*batchv1.Job
 	}
 
 	envVars = append(envVars, storageEnv...)
+
+	encryptionEnv, reason, err := backuputil.GenerateEncryptionKeyEnv(ns, restore.Spec.Encryption, rm.deps.SecretLister)
+	if err != nil {
+		return nil, reason, fmt.Errorf("restore %s/%s, %v", ns, name, err)
+	}
+	envVars = append(envVars, encryptionEnv...)
 	// set env vars specified in backup.Spec.Env
 	envVars = util.AppendOverwriteEnv(envVars, restore.Spec.Env)
 
@@ -329,12 +349,20 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 		}
 	}
 
-	storageEnv, reason, err := backuputil.GenerateStorageCertEnv(ns, restore.Spec.UseKMS, restore.Spec.StorageProvider, rm.deps.SecretLister)
+	// the data of the volume-snapshot restore is on the TiKV volumes instead of the remote storage
+	if restore.Spec.Mode != v1alpha1.RestoreModeVolumeSnapshot {
+		storageEnv, reason, err := backuputil.GenerateStorageCertEnv(ns, restore.Spec.UseKMS, restore.Spec.StorageProvider, rm.deps.SecretLister)
+		if err != nil {
+			return nil, reason, fmt.Errorf("restore %s/%s, %v", ns, name, err)
+		}
+		envVars = append(envVars, storageEnv...)
+	}
+
+	encryptionEnv, reason, err := backuputil.GenerateEncryptionKeyEnv(ns, restore.Spec.Encryption, rm.deps.SecretLister)
 	if err != nil {
 		return nil, reason, fmt.Errorf("restore %s/%s, %v", ns, name, err)
 	}
-
-	envVars = append(envVars, storageEnv...)
+	envVars = append(envVars, encryptionEnv...)
 	envVars = append(envVars, corev1.EnvVar{
 		Name:  "BR_LOG_TO_TERM",
 		Value: string(rune(1)),
@@ -421,6 +449,12 @@ func (rm *restoreManager) makeRestoreJob(restore *v1alpha1.Restore) (*batchv1.Jo
 		volumes = append(volumes, restore.Spec.Local.Volume)
 		volumeMounts = append(volumeMounts, restore.Spec.Local.VolumeMount)
 	}
+	// the full backup of pitr restore may be stored in another local volume
+	if fullBackup := restore.Spec.PitrFullBackupStorageProvider.Local; restore.Spec.Mode == v1alpha1.RestoreModePiTR && fullBackup != nil &&
+		(restore.Spec.Local == nil || restore.Spec.Local.Volume.Name != fullBackup.Volume.Name) {
+		volumes = append(volumes, fullBackup.Volume)
+		volumeMounts = append(volumeMounts, fullBackup.VolumeMount)
+	}
 
 	serviceAccount := constants.DefaultServiceAccountName
 	if restore.Spec.ServiceAccount != "" {
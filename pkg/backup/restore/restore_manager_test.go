@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/testutils"
+	"github.com/pingcap/tidb-operator/pkg/controller"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -232,3 +233,170 @@ func TestBRRestore(t *testing.T) {
 		g.Expect(job.Spec.Template.Spec.Containers[0].Env).NotTo(gomega.ContainElement(env2No))
 	}
 }
+
+func TestVolumeSnapshotRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+	helper := newHelper(t)
+	defer helper.Close()
+	deps := helper.Deps
+	var err error
+
+	backup := &v1alpha1.Backup{
+		Spec: v1alpha1.BackupSpec{
+			Type: v1alpha1.BackupTypeVolumeSnapshot,
+			BR: &v1alpha1.BRConfig{
+				Cluster: "source",
+			},
+		},
+		Status: v1alpha1.BackupStatus{
+			CommitTs: "434763491567992834",
+			Conditions: []v1alpha1.BackupCondition{
+				{
+					Type:   v1alpha1.BackupComplete,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	backup.Namespace = "ns"
+	backup.Name = "snapshot"
+	for i := int32(0); i < 3; i++ {
+		backup.Status.VolumeSnapshots = append(backup.Status.VolumeSnapshots, v1alpha1.TiKVVolumeSnapshot{
+			Ordinal:          i,
+			SnapshotName:     backup.GetVolumeSnapshotName(i),
+			StorageClassName: pointer.StringPtr("ebs-gp3"),
+			Size:             "100Gi",
+		})
+	}
+	_, err = deps.Clientset.PingcapV1alpha1().Backups(backup.Namespace).Create(context.TODO(), backup, metav1.CreateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() error {
+		_, err := deps.BackupLister.Backups(backup.Namespace).Get(backup.Name)
+		return err
+	}, time.Second*10).Should(BeNil())
+
+	restore := &v1alpha1.Restore{
+		Spec: v1alpha1.RestoreSpec{
+			Mode:                     v1alpha1.RestoreModeVolumeSnapshot,
+			VolumeSnapshotBackupName: backup.Name,
+			BR: &v1alpha1.BRConfig{
+				Cluster: "target",
+			},
+		},
+	}
+	restore.Namespace = "ns"
+	restore.Name = "restore"
+	helper.createRestore(restore)
+	getRestore := func() *v1alpha1.Restore {
+		get, err := deps.Clientset.PingcapV1alpha1().Restores(restore.Namespace).Get(context.TODO(), restore.Name, metav1.GetOptions{})
+		g.Expect(err).Should(BeNil())
+		return get
+	}
+
+	// the volumes are provisioned before the tidbcluster is created
+	m := NewRestoreManager(deps)
+	err = m.Sync(restore)
+	g.Expect(err).Should(BeNil())
+	helper.hasCondition(restore.Namespace, restore.Name, v1alpha1.RestoreVolumeComplete, "")
+	restore = getRestore()
+	g.Expect(restore.Status.CommitTs).To(Equal(backup.Status.CommitTs))
+	for _, snapshot := range backup.Status.VolumeSnapshots {
+		pvc, err := deps.PVCLister.PersistentVolumeClaims(restore.Namespace).Get(fmt.Sprintf("tikv-target-tikv-%d", snapshot.Ordinal))
+		g.Expect(err).Should(BeNil())
+		g.Expect(pvc.Spec.DataSource).ShouldNot(BeNil())
+		g.Expect(pvc.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+		g.Expect(pvc.Spec.DataSource.Name).To(Equal(snapshot.SnapshotName))
+		g.Expect(pvc.Spec.StorageClassName).To(Equal(snapshot.StorageClassName))
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		g.Expect(size.String()).To(Equal("100Gi"))
+	}
+
+	// wait for the tidbcluster to be created in recovery mode
+	err = m.Sync(restore)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+
+	tc := &v1alpha1.TidbCluster{
+		Spec: v1alpha1.TidbClusterSpec{
+			RecoveryMode: true,
+			TiKV: &v1alpha1.TiKVSpec{
+				BaseImage: "pingcap/tikv",
+			},
+		},
+	}
+	tc.Namespace = restore.Namespace
+	tc.Name = "target"
+	tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
+		"1": {ID: "1", State: v1alpha1.TiKVStateUp},
+		"2": {ID: "2", State: v1alpha1.TiKVStateUp},
+		"3": {ID: "3", State: v1alpha1.TiKVStateDown},
+	}
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Create(context.TODO(), tc, metav1.CreateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() error {
+		_, err := deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(tc.Name)
+		return err
+	}, time.Second*10).Should(BeNil())
+
+	// wait for all the tikv stores to be up
+	err = m.Sync(restore)
+	g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+	_, err = deps.KubeClientset.BatchV1().Jobs(restore.Namespace).Get(context.TODO(), restore.GetRestoreJobName(), metav1.GetOptions{})
+	g.Expect(err).ShouldNot(BeNil())
+
+	tc.Status.TiKV.Stores["3"] = v1alpha1.TiKVStore{ID: "3", State: v1alpha1.TiKVStateUp}
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusters(tc.Namespace).Update(context.TODO(), tc, metav1.UpdateOptions{})
+	g.Expect(err).Should(BeNil())
+	g.Eventually(func() string {
+		get, err := deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(tc.Name)
+		g.Expect(err).Should(BeNil())
+		return get.Status.TiKV.Stores["3"].State
+	}, time.Second*10).Should(Equal(v1alpha1.TiKVStateUp))
+
+	// the data is restored by the BR job
+	err = m.Sync(restore)
+	g.Expect(err).Should(BeNil())
+	helper.hasCondition(restore.Namespace, restore.Name, v1alpha1.RestoreScheduled, "")
+	_, err = deps.KubeClientset.BatchV1().Jobs(restore.Namespace).Get(context.TODO(), restore.GetRestoreJobName(), metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+
+	// the cluster is switched out of recovery mode after the data is restored
+	restore = getRestore()
+	v1alpha1.UpdateRestoreCondition(&restore.Status, &v1alpha1.RestoreCondition{
+		Type:   v1alpha1.RestoreDataComplete,
+		Status: corev1.ConditionTrue,
+	})
+	_, err = deps.Clientset.PingcapV1alpha1().Restores(restore.Namespace).Update(context.TODO(), restore, metav1.UpdateOptions{})
+	g.Expect(err).Should(BeNil())
+	err = m.Sync(restore)
+	g.Expect(err).Should(BeNil())
+	helper.hasCondition(restore.Namespace, restore.Name, v1alpha1.RestoreComplete, "")
+	g.Expect(getRestore().Status.TimeCompleted.IsZero()).To(BeFalse())
+	get, err := deps.TiDBClusterLister.TidbClusters(tc.Namespace).Get(tc.Name)
+	g.Expect(err).Should(BeNil())
+	g.Expect(get.Spec.RecoveryMode).To(BeFalse())
+
+	// the snapshots can not be restored into a cluster out of recovery mode
+	restore = restore.DeepCopy()
+	restore.ResourceVersion = ""
+	restore.Status = v1alpha1.RestoreStatus{}
+	restore.Name = "restore-no-recovery"
+	helper.createRestore(restore)
+	err = m.Sync(restore)
+	g.Expect(err).Should(BeNil())
+	restore, err = deps.Clientset.PingcapV1alpha1().Restores(restore.Namespace).Get(context.TODO(), restore.Name, metav1.GetOptions{})
+	g.Expect(err).Should(BeNil())
+	err = m.Sync(restore)
+	g.Expect(err).ShouldNot(BeNil())
+	helper.hasCondition(restore.Namespace, restore.Name, v1alpha1.RestoreFailed, "RecoveryModeNotEnabled")
+
+	// the snapshots can not be restored to another namespace
+	restore = restore.DeepCopy()
+	restore.ResourceVersion = ""
+	restore.Status = v1alpha1.RestoreStatus{}
+	restore.Name = "restore-other-ns"
+	restore.Spec.BR.ClusterNamespace = "other"
+	helper.createRestore(restore)
+	err = m.Sync(restore)
+	g.Expect(err).ShouldNot(BeNil())
+	helper.hasCondition(restore.Namespace, restore.Name, v1alpha1.RestoreFailed, "NamespaceMismatch")
+}
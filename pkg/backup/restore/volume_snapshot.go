@@ -0,0 +1,236 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// syncVolumeSnapshotRestore restores a fresh cluster from the VolumeSnapshots of a volume-snapshot backup
+// in the phases of BR: the TiKV data PVCs are provisioned from the snapshots first, the TidbCluster should
+// be created afterwards in recovery mode with the same name and TiKV replicas, so that its TiKV Pods bind
+// these PVCs. Once all the TiKV stores are up, a BR job restores the data on the volumes to the commit ts
+// of the backup, and the cluster is switched out of recovery mode at last. It returns true if the restore
+// job of BR should be created.
+func (rm *restoreManager) syncVolumeSnapshotRestore(restore *v1alpha1.Restore) (bool, error) {
+	if v1alpha1.IsRestoreDataComplete(restore) {
+		return false, rm.finishVolumeSnapshotRestore(restore)
+	}
+
+	ns := restore.GetNamespace()
+	name := restore.GetName()
+	clusterNamespace := restore.Spec.BR.ClusterNamespace
+	if clusterNamespace == "" {
+		clusterNamespace = ns
+	}
+
+	backup, err := rm.deps.BackupLister.Backups(ns).Get(restore.Spec.VolumeSnapshotBackupName)
+	if err != nil {
+		rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+			Type:    v1alpha1.RestoreRetryFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "GetVolumeSnapshotBackupFailed",
+			Message: err.Error(),
+		}, nil)
+		return false, fmt.Errorf("restore %s/%s get backup %s failed, err: %v", ns, name, restore.Spec.VolumeSnapshotBackupName, err)
+	}
+
+	if !v1alpha1.IsRestoreVolumeComplete(restore) {
+		return false, rm.provisionVolumes(restore, backup, clusterNamespace)
+	}
+
+	tc, err := rm.deps.TiDBClusterLister.TidbClusters(clusterNamespace).Get(restore.Spec.BR.Cluster)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, controller.RequeueErrorf("restore %s/%s waits for tidbcluster %s/%s to be created in recovery mode", ns, name, clusterNamespace, restore.Spec.BR.Cluster)
+		}
+		return false, fmt.Errorf("restore %s/%s get tidbcluster %s/%s failed, err: %v", ns, name, clusterNamespace, restore.Spec.BR.Cluster, err)
+	}
+	if !tc.Spec.RecoveryMode {
+		// the TiKV stores started out of recovery mode can not be restored to the commit ts
+		return false, rm.failVolumeSnapshotRestore(restore, "RecoveryModeNotEnabled",
+			fmt.Errorf("tidbcluster %s/%s is not in recovery mode", clusterNamespace, tc.Name))
+	}
+
+	var upStores int
+	for _, store := range tc.Status.TiKV.Stores {
+		if store.State == v1alpha1.TiKVStateUp {
+			upStores++
+		}
+	}
+	if upStores < len(backup.Status.VolumeSnapshots) {
+		return false, controller.RequeueErrorf("restore %s/%s waits for %d tikv stores of tidbcluster %s/%s to be up, %d are up",
+			ns, name, len(backup.Status.VolumeSnapshots), clusterNamespace, tc.Name, upStores)
+	}
+	return true, nil
+}
+
+// provisionVolumes creates the TiKV data PVCs of the target cluster from the VolumeSnapshots of the backup
+func (rm *restoreManager) provisionVolumes(restore *v1alpha1.Restore, backup *v1alpha1.Backup, clusterNamespace string) error {
+	ns := restore.GetNamespace()
+	name := restore.GetName()
+	started := time.Now()
+
+	if reason, err := checkVolumeSnapshotBackup(backup, clusterNamespace); err != nil {
+		return rm.failVolumeSnapshotRestore(restore, reason, err)
+	}
+
+	for _, snapshot := range backup.Status.VolumeSnapshots {
+		pvcName := backuputil.GetTiKVDataPVCName(restore.Spec.BR.Cluster, snapshot.Ordinal)
+		pvc, err := rm.deps.PVCLister.PersistentVolumeClaims(clusterNamespace).Get(pvcName)
+		if err == nil {
+			if pvc.Spec.DataSource == nil || pvc.Spec.DataSource.Kind != backuputil.VolumeSnapshotGVK.Kind || pvc.Spec.DataSource.Name != snapshot.SnapshotName {
+				return rm.failVolumeSnapshotRestore(restore, "PVCAlreadyExists",
+					fmt.Errorf("pvc %s/%s already exists and is not restored from volume snapshot %s", clusterNamespace, pvcName, snapshot.SnapshotName))
+			}
+			continue
+		}
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("restore %s/%s get pvc %s/%s failed, err: %v", ns, name, clusterNamespace, pvcName, err)
+		}
+
+		pvc, err = newVolumeSnapshotRestorePVC(restore, clusterNamespace, pvcName, snapshot)
+		if err != nil {
+			return rm.failVolumeSnapshotRestore(restore, "InvalidVolumeSnapshotSize", err)
+		}
+		if err := rm.deps.GeneralPVCControl.CreatePVC(restore, pvc); err != nil && !errors.IsAlreadyExists(err) {
+			rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+				Type:    v1alpha1.RestoreRetryFailed,
+				Status:  corev1.ConditionTrue,
+				Reason:  "CreatePVCFailed",
+				Message: err.Error(),
+			}, nil)
+			return fmt.Errorf("restore %s/%s create pvc %s/%s failed, err: %v", ns, name, clusterNamespace, pvcName, err)
+		}
+	}
+
+	// the data is restored to the commit ts of the backup by BR
+	commitTs := backup.Status.CommitTs
+	return rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+		Type:   v1alpha1.RestoreVolumeComplete,
+		Status: corev1.ConditionTrue,
+	}, &controller.RestoreUpdateStatus{
+		TimeStarted: &metav1.Time{Time: started},
+		CommitTs:    &commitTs,
+	})
+}
+
+// finishVolumeSnapshotRestore switches the cluster out of recovery mode after BR has restored the data,
+// so that TiDB is started
+func (rm *restoreManager) finishVolumeSnapshotRestore(restore *v1alpha1.Restore) error {
+	ns := restore.GetNamespace()
+	name := restore.GetName()
+	clusterNamespace := restore.Spec.BR.ClusterNamespace
+	if clusterNamespace == "" {
+		clusterNamespace = ns
+	}
+
+	tc, err := rm.deps.TiDBClusterLister.TidbClusters(clusterNamespace).Get(restore.Spec.BR.Cluster)
+	if err != nil {
+		return fmt.Errorf("restore %s/%s get tidbcluster %s/%s failed, err: %v", ns, name, clusterNamespace, restore.Spec.BR.Cluster, err)
+	}
+	if tc.Spec.RecoveryMode {
+		updated := tc.DeepCopy()
+		updated.Spec.RecoveryMode = false
+		updated, err = rm.deps.TiDBClusterControl.UpdateTidbCluster(updated, &updated.Status, &tc.Status)
+		if err != nil {
+			return fmt.Errorf("restore %s/%s turn off the recovery mode of tidbcluster %s/%s failed, err: %v", ns, name, clusterNamespace, tc.Name, err)
+		}
+		// the spec change is dropped if the update is retried on conflict
+		if updated.Spec.RecoveryMode {
+			return controller.RequeueErrorf("restore %s/%s waits for the recovery mode of tidbcluster %s/%s to be turned off", ns, name, clusterNamespace, tc.Name)
+		}
+	}
+
+	return rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+		Type:   v1alpha1.RestoreComplete,
+		Status: corev1.ConditionTrue,
+	}, &controller.RestoreUpdateStatus{
+		TimeCompleted: &metav1.Time{Time: time.Now()},
+	})
+}
+
+func (rm *restoreManager) failVolumeSnapshotRestore(restore *v1alpha1.Restore, reason string, err error) error {
+	rm.statusUpdater.Update(restore, &v1alpha1.RestoreCondition{
+		Type:    v1alpha1.RestoreFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: err.Error(),
+	}, nil)
+	return controller.IgnoreErrorf("restore %s/%s failed, reason: %s, err: %v", restore.Namespace, restore.Name, reason, err)
+}
+
+// checkVolumeSnapshotBackup checks the backup can be used to provision volumes in the namespace,
+// the VolumeSnapshots can only be restored in the namespace where they are taken
+func checkVolumeSnapshotBackup(backup *v1alpha1.Backup, clusterNamespace string) (string, error) {
+	if !v1alpha1.IsVolumeSnapshotBackup(backup) {
+		return "NotVolumeSnapshotBackup", fmt.Errorf("backup %s/%s is not a volume-snapshot backup", backup.Namespace, backup.Name)
+	}
+	if !v1alpha1.IsBackupComplete(backup) {
+		return "VolumeSnapshotBackupNotComplete", fmt.Errorf("backup %s/%s is not complete", backup.Namespace, backup.Name)
+	}
+	if len(backup.Status.VolumeSnapshots) == 0 {
+		return "VolumeSnapshotNotFound", fmt.Errorf("backup %s/%s has no volume snapshot", backup.Namespace, backup.Name)
+	}
+	snapshotNamespace := backup.Namespace
+	if backup.Spec.BR.ClusterNamespace != "" {
+		snapshotNamespace = backup.Spec.BR.ClusterNamespace
+	}
+	if snapshotNamespace != clusterNamespace {
+		return "NamespaceMismatch", fmt.Errorf("volume snapshots of backup %s/%s are in namespace %s, can not be restored to namespace %s",
+			backup.Namespace, backup.Name, snapshotNamespace, clusterNamespace)
+	}
+	return "", nil
+}
+
+func newVolumeSnapshotRestorePVC(restore *v1alpha1.Restore, ns, pvcName string, snapshot v1alpha1.TiKVVolumeSnapshot) (*corev1.PersistentVolumeClaim, error) {
+	size, err := resource.ParseQuantity(snapshot.Size)
+	if err != nil {
+		return nil, fmt.Errorf("parse size %s of volume snapshot %s failed, err: %v", snapshot.Size, snapshot.SnapshotName, err)
+	}
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvcName,
+			Namespace: ns,
+			Labels:    label.New().Instance(restore.Spec.BR.Cluster).TiKV(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+			StorageClassName: snapshot.StorageClassName,
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: pointer.StringPtr(backuputil.VolumeSnapshotGVK.Group),
+				Kind:     backuputil.VolumeSnapshotGVK.Kind,
+				Name:     snapshot.SnapshotName,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/backup/constants"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// PodFailureReasonEvicted means the pod was evicted by kubelet or the eviction API
+	PodFailureReasonEvicted = "PodEvicted"
+	// PodFailureReasonOOMKilled means a container of the pod was killed because it ran out of memory
+	PodFailureReasonOOMKilled = "OOMKilled"
+	// PodFailureReasonNodeFailure means the pod was terminated because its node was lost or shut down
+	PodFailureReasonNodeFailure = "NodeFailure"
+	// PodFailureReasonError means the pod exited with an error, such as an invalid config or bad credentials
+	PodFailureReasonError = "PodFailed"
+)
+
+// ClassifyPodFailure returns the reason and message of the failed pod of the backup or restore job,
+// and whether the failure can be retried. Only the failures caused by the environment are retryable,
+// the failures reported by the backup-manager itself are permanent.
+func ClassifyPodFailure(pod *corev1.Pod) (reason, message string, retryable bool) {
+	switch pod.Status.Reason {
+	case "Evicted", "Preempting":
+		return PodFailureReasonEvicted, fmt.Sprintf("pod %s was evicted: %s", pod.Name, pod.Status.Message), true
+	case "NodeLost", "Shutdown", "Terminated", "UnexpectedAdmissionError":
+		return PodFailureReasonNodeFailure, fmt.Sprintf("pod %s was terminated by %s: %s", pod.Name, pod.Status.Reason, pod.Status.Message), true
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.Reason == "OOMKilled" {
+			return PodFailureReasonOOMKilled, fmt.Sprintf("container %s of pod %s was OOMKilled", status.Name, pod.Name), true
+		}
+	}
+	return PodFailureReasonError, fmt.Sprintf("Pod %s has failed", pod.Name), false
+}
+
+// NextBackoffRetry returns the record of the next retry for the failure detected at now according to
+// the policy and the previous retry records, an error is returned if the retries are exhausted.
+func NextBackoffRetry(policy *v1alpha1.BackoffRetryPolicy, records []v1alpha1.BackoffRetryRecord, now time.Time) (*v1alpha1.BackoffRetryRecord, error) {
+	minRetryDuration, maxRetryTimes, retryTimeout, err := getBackoffRetryPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	retryNum := int32(len(records)) + 1
+	if retryNum > maxRetryTimes {
+		return nil, fmt.Errorf("the job has been retried %d times, which reaches maxRetryTimes %d", len(records), maxRetryTimes)
+	}
+	if len(records) > 0 && now.Sub(records[0].DetectFailedAt.Time) > retryTimeout {
+		return nil, fmt.Errorf("the job has been retried since %s, which exceeds retryTimeout %s", records[0].DetectFailedAt.Format(time.RFC3339), retryTimeout)
+	}
+	return &v1alpha1.BackoffRetryRecord{
+		RetryNum:        retryNum,
+		DetectFailedAt:  metav1.NewTime(now),
+		ExpectedRetryAt: metav1.NewTime(now.Add(minRetryDuration << (retryNum - 1))),
+	}, nil
+}
+
+// getBackoffRetryPolicy returns the min retry duration, max retry times and retry timeout of the policy
+func getBackoffRetryPolicy(policy *v1alpha1.BackoffRetryPolicy) (time.Duration, int32, time.Duration, error) {
+	minRetryDuration := constants.DefaultBackoffMinRetryDuration
+	if policy.MinRetryDuration != nil {
+		minRetryDuration = *policy.MinRetryDuration
+	}
+	minRetry, err := time.ParseDuration(minRetryDuration)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid minRetryDuration %s: %v", minRetryDuration, err)
+	}
+
+	maxRetryTimes := int32(constants.DefaultBackoffMaxRetryTimes)
+	if policy.MaxRetryTimes != nil {
+		maxRetryTimes = *policy.MaxRetryTimes
+	}
+
+	retryTimeout := constants.DefaultBackoffRetryTimeout
+	if policy.RetryTimeout != nil {
+		retryTimeout = *policy.RetryTimeout
+	}
+	timeout, err := time.ParseDuration(retryTimeout)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid retryTimeout %s: %v", retryTimeout, err)
+	}
+	return minRetry, maxRetryTimes, timeout, nil
+}
+
+// validateBackoffRetryPolicy validates the durations and the retry times of the policy
+func validateBackoffRetryPolicy(policy *v1alpha1.BackoffRetryPolicy) error {
+	minRetryDuration, maxRetryTimes, retryTimeout, err := getBackoffRetryPolicy(policy)
+	if err != nil {
+		return err
+	}
+	if minRetryDuration <= 0 {
+		return fmt.Errorf("minRetryDuration should be positive")
+	}
+	if maxRetryTimes < 0 {
+		return fmt.Errorf("maxRetryTimes should not be negative")
+	}
+	if retryTimeout <= 0 {
+		return fmt.Errorf("retryTimeout should be positive")
+	}
+	return nil
+}
+
+// BackoffRetryJob is the job of a backup or restore to be retried by SyncBackoffRetry
+type BackoffRetryJob struct {
+	// Kind is the kind of the owner of the job used in the messages, such as "backup"
+	Kind string
+	// Owner is the backup or restore which owns the job
+	Owner     runtime.Object
+	Namespace string
+	Name      string
+	JobName   string
+	// PodSelector selects the pods of the job
+	PodSelector labels.Selector
+	Policy      *v1alpha1.BackoffRetryPolicy
+	Records     []v1alpha1.BackoffRetryRecord
+
+	// Fail marks the owner as failed by the failure which can't be retried
+	Fail func(reason, message string) error
+	// RetryFailed marks the owner as retry failed and records the retry of the failure
+	RetryFailed func(reason, message string, record *v1alpha1.BackoffRetryRecord) error
+	// UpdateRecord updates the retry record in the status of the owner
+	UpdateRecord func(record *v1alpha1.BackoffRetryRecord) error
+}
+
+// SyncBackoffRetry checks whether the job has a failed pod. If the failure can be retried
+// according to the backoff retry policy, the failed job is deleted after the backoff so that it
+// is recreated by the owner, otherwise the owner is marked as failed.
+func SyncBackoffRetry(j *BackoffRetryJob, jobLister batchlisters.JobLister, podLister corelisterv1.PodLister, jobControl controller.JobControlInterface) error {
+	job, err := jobLister.Jobs(j.Namespace).Get(j.JobName)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s %s/%s get job %s failed, err: %v", j.Kind, j.Namespace, j.Name, j.JobName, err)
+	}
+
+	var last *v1alpha1.BackoffRetryRecord
+	if len(j.Records) > 0 {
+		last = &j.Records[len(j.Records)-1]
+	}
+	if job.DeletionTimestamp != nil || (last != nil && !last.RealRetryAt.IsZero() && job.CreationTimestamp.Before(&last.RealRetryAt)) {
+		return controller.RequeueErrorf("%s %s/%s is waiting for the failed job %s to be deleted", j.Kind, j.Namespace, j.Name, job.Name)
+	}
+
+	pod, err := getFailedJobPod(j, job, podLister)
+	if err != nil || pod == nil {
+		return err
+	}
+
+	if last != nil && last.RealRetryAt.IsZero() {
+		// the failure has been recorded, retry it after the backoff
+		return retryJob(j, job, last, jobControl)
+	}
+
+	reason, message, retryable := ClassifyPodFailure(pod)
+	var record *v1alpha1.BackoffRetryRecord
+	if retryable {
+		record, err = NextBackoffRetry(j.Policy, j.Records, time.Now())
+		if err != nil {
+			message = fmt.Sprintf("%s, %v", message, err)
+		}
+	}
+	if record == nil {
+		klog.Infof("%s %s/%s job %s failed and can not be retried: %s", j.Kind, j.Namespace, j.Name, job.Name, message)
+		if err := j.Fail(reason, message); err != nil {
+			return err
+		}
+		return controller.IgnoreErrorf("%s %s/%s job %s failed: %s", j.Kind, j.Namespace, j.Name, job.Name, message)
+	}
+
+	record.Reason = reason
+	record.Message = message
+	if err := j.RetryFailed(reason, message, record); err != nil {
+		return err
+	}
+	return controller.RequeueErrorf("%s %s/%s job %s failed by %s, retry %d at %s", j.Kind, j.Namespace, j.Name, job.Name, reason, record.RetryNum, record.ExpectedRetryAt.Format(time.RFC3339))
+}
+
+// retryJob deletes the failed job once the expected retry time of the record is reached
+func retryJob(j *BackoffRetryJob, job *batchv1.Job, record *v1alpha1.BackoffRetryRecord, jobControl controller.JobControlInterface) error {
+	if wait := time.Until(record.ExpectedRetryAt.Time); wait > 0 {
+		return controller.RequeueErrorf("%s %s/%s job %s will be retried in %s", j.Kind, j.Namespace, j.Name, job.Name, wait.Round(time.Second))
+	}
+
+	if err := jobControl.DeleteJob(j.Owner, job); err != nil {
+		return fmt.Errorf("%s %s/%s delete failed job %s to retry failed, err: %v", j.Kind, j.Namespace, j.Name, job.Name, err)
+	}
+	retried := record.DeepCopy()
+	retried.RealRetryAt = metav1.Now()
+	if err := j.UpdateRecord(retried); err != nil {
+		return err
+	}
+	klog.Infof("%s %s/%s job %s is deleted for retry %d", j.Kind, j.Namespace, j.Name, job.Name, retried.RetryNum)
+	return controller.RequeueErrorf("%s %s/%s is waiting for job %s to be recreated", j.Kind, j.Namespace, j.Name, job.Name)
+}
+
+// getFailedJobPod returns the failed pod controlled by the job, or nil if there is none
+func getFailedJobPod(j *BackoffRetryJob, job *batchv1.Job, podLister corelisterv1.PodLister) (*corev1.Pod, error) {
+	pods, err := podLister.Pods(j.Namespace).List(j.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s/%s list pods of job %s failed, err: %v", j.Kind, j.Namespace, j.Name, job.Name, err)
+	}
+	for _, pod := range pods {
+		if owner := metav1.GetControllerOf(pod); owner == nil || owner.UID != job.UID {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodFailed {
+			return pod, nil
+		}
+	}
+	return nil, nil
+}
@@ -16,12 +16,15 @@ package util
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/constants"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 )
@@ -30,6 +33,15 @@ var (
 	// the first version which allows skipping setting tikv_gc_life_time
 	// https://github.com/pingcap/br/pull/553
 	tikvLessThanV408, _ = semver.NewConstraint("<v4.0.8-0")
+	// the first version which supports log backup and PiTR
+	tikvLessThanV620, _ = semver.NewConstraint("<v6.2.0-0")
+	// the first version which supports suspending the writes of TiKV by `br operator prepare-for-snapshot-backup`
+	tikvLessThanV750, _ = semver.NewConstraint("<v7.5.0-0")
+)
+
+const (
+	// physicalShiftBits is the shift bits of the physical part in TSO
+	physicalShiftBits = 18
 )
 
 // CheckAllKeysExistInSecret check if all keys are included in the specific secret
@@ -366,6 +378,37 @@ func GenerateTidbPasswordEnv(ns, tcName, tidbSecretName string, useKMS bool, sec
 	return certEnv, "", nil
 }
 
+// GenerateEncryptionKeyEnv generates the EnvVar of the data key to encrypt or decrypt the backup data,
+// no EnvVar is generated if the encryption is not enabled
+func GenerateEncryptionKeyEnv(ns string, encryption *v1alpha1.BackupEncryption, secretLister corelisterv1.SecretLister) ([]corev1.EnvVar, string, error) {
+	if encryption == nil {
+		return nil, "", nil
+	}
+	secret, err := secretLister.Secrets(ns).Get(encryption.SecretName)
+	if err != nil {
+		err = fmt.Errorf("get encryption secret %s/%s failed, err: %v", ns, encryption.SecretName, err)
+		return nil, "GetEncryptionSecretFailed", err
+	}
+
+	keyStr, exist := CheckAllKeysExistInSecret(secret, constants.EncryptionDataKey)
+	if !exist {
+		err = fmt.Errorf("encryption secret %s/%s missing data key %s", ns, encryption.SecretName, keyStr)
+		return nil, "EncryptionKeyNotExist", err
+	}
+
+	return []corev1.EnvVar{
+		{
+			Name: fmt.Sprintf("%s_%s", constants.BackupManagerEnvVarPrefix, strings.ToUpper(constants.EncryptionDataKey)),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: encryption.SecretName},
+					Key:                  constants.EncryptionDataKey,
+				},
+			},
+		},
+	}, "", nil
+}
+
 // GetBackupBucketName return the bucket name for remote storage
 func GetBackupBucketName(backup *v1alpha1.Backup) (string, string, error) {
 	ns := backup.GetNamespace()
@@ -456,11 +499,66 @@ func validateAccessConfig(config *v1alpha1.TiDBAccessConfig) string {
 	return ""
 }
 
+// ValidateBackupSchedule validates the spec of a backup schedule
+func ValidateBackupSchedule(bs *v1alpha1.BackupSchedule) error {
+	ns := bs.Namespace
+	name := bs.Name
+
+	policy := bs.Spec.RetentionPolicy
+	if policy == nil {
+		return nil
+	}
+	if bs.Spec.MaxBackups != nil || bs.Spec.MaxReservedTime != nil {
+		return fmt.Errorf("retentionPolicy can't be set together with maxBackups or maxReservedTime in spec of %s/%s", ns, name)
+	}
+	tiers := []struct {
+		tierType v1alpha1.BackupRetentionTierType
+		spec     *v1alpha1.BackupRetentionTier
+	}{
+		{v1alpha1.BackupRetentionTierHourly, policy.Hourly},
+		{v1alpha1.BackupRetentionTierDaily, policy.Daily},
+		{v1alpha1.BackupRetentionTierWeekly, policy.Weekly},
+		{v1alpha1.BackupRetentionTierMonthly, policy.Monthly},
+	}
+	for _, tier := range tiers {
+		if tier.spec != nil && tier.spec.Keep < 0 {
+			return fmt.Errorf("keep %d of the %s retention tier should not be negative in spec of %s/%s", tier.spec.Keep, tier.tierType, ns, name)
+		}
+	}
+	return nil
+}
+
 // ValidateBackup validates backup sepc
 func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 	ns := backup.Namespace
 	name := backup.Name
 
+	if err := validateBackupVerify(backup); err != nil {
+		return err
+	}
+
+	if err := validateReplicaStorages(backup); err != nil {
+		return err
+	}
+
+	if backup.Spec.Encryption != nil {
+		if v1alpha1.IsLogBackup(backup) || v1alpha1.IsVolumeSnapshotBackup(backup) {
+			return fmt.Errorf("encryption is only supported by snapshot backup with BR or Dumpling in spec of %s/%s", ns, name)
+		}
+		if err := validateEncryption(backup.Spec.Encryption); err != nil {
+			return fmt.Errorf("%v in spec of %s/%s", err, ns, name)
+		}
+	}
+
+	if backup.Spec.BackoffRetryPolicy != nil {
+		if v1alpha1.IsLogBackup(backup) || v1alpha1.IsVolumeSnapshotBackup(backup) {
+			return fmt.Errorf("backoffRetryPolicy is only supported by snapshot backup with BR or Dumpling in spec of %s/%s", ns, name)
+		}
+		if err := validateBackoffRetryPolicy(backup.Spec.BackoffRetryPolicy); err != nil {
+			return fmt.Errorf("%v in spec of %s/%s", err, ns, name)
+		}
+	}
+
 	if backup.Spec.BR == nil {
 		if reason := validateAccessConfig(backup.Spec.From); reason != "" {
 			return fmt.Errorf(reason, ns, name)
@@ -469,6 +567,9 @@ func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 			return fmt.Errorf("missing StorageSize config in spec of %s/%s", ns, name)
 		}
 	} else {
+		if v1alpha1.IsVolumeSnapshotBackup(backup) {
+			return validateVolumeSnapshotBackup(backup, tikvImage)
+		}
 		if !canSkipSetGCLifeTime(tikvImage) {
 			if reason := validateAccessConfig(backup.Spec.From); reason != "" {
 				return fmt.Errorf(reason, ns, name)
@@ -478,6 +579,10 @@ func ValidateBackup(backup *v1alpha1.Backup, tikvImage string) error {
 			return fmt.Errorf("cluster should be configured for BR in spec of %s/%s", ns, name)
 		}
 
+		if err := validateLogBackup(backup, tikvImage); err != nil {
+			return err
+		}
+
 		if backup.Spec.Type != "" &&
 			backup.Spec.Type != v1alpha1.BackupTypeFull &&
 			backup.Spec.Type != v1alpha1.BackupTypeDB &&
@@ -516,6 +621,24 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 	ns := restore.Namespace
 	name := restore.Name
 
+	if restore.Spec.Encryption != nil {
+		if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+			return fmt.Errorf("encryption is not supported by volume-snapshot restore in spec of %s/%s", ns, name)
+		}
+		if err := validateEncryption(restore.Spec.Encryption); err != nil {
+			return fmt.Errorf("%v in spec of %s/%s", err, ns, name)
+		}
+	}
+
+	if restore.Spec.BackoffRetryPolicy != nil {
+		if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+			return fmt.Errorf("backoffRetryPolicy is not supported by volume-snapshot restore in spec of %s/%s", ns, name)
+		}
+		if err := validateBackoffRetryPolicy(restore.Spec.BackoffRetryPolicy); err != nil {
+			return fmt.Errorf("%v in spec of %s/%s", err, ns, name)
+		}
+	}
+
 	if restore.Spec.BR == nil {
 		if reason := validateAccessConfig(restore.Spec.To); reason != "" {
 			return fmt.Errorf(reason, ns, name)
@@ -524,6 +647,9 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 			return fmt.Errorf("missing StorageSize config in spec of %s/%s", ns, name)
 		}
 	} else {
+		if restore.Spec.Mode == v1alpha1.RestoreModeVolumeSnapshot {
+			return validateVolumeSnapshotRestore(restore)
+		}
 		if !canSkipSetGCLifeTime(tikvImage) {
 			if reason := validateAccessConfig(restore.Spec.To); reason != "" {
 				return fmt.Errorf(reason, ns, name)
@@ -533,6 +659,10 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 			return fmt.Errorf("cluster should be configured for BR in spec of %s/%s", ns, name)
 		}
 
+		if err := validatePiTRRestore(restore, tikvImage); err != nil {
+			return err
+		}
+
 		if restore.Spec.Type != "" &&
 			restore.Spec.Type != v1alpha1.BackupTypeFull &&
 			restore.Spec.Type != v1alpha1.BackupTypeDB &&
@@ -566,6 +696,254 @@ func ValidateRestore(restore *v1alpha1.Restore, tikvImage string) error {
 	return nil
 }
 
+// validateLogBackup validates the log backup related fields of the backup spec
+func validateLogBackup(backup *v1alpha1.Backup, tikvImage string) error {
+	ns := backup.Namespace
+	name := backup.Name
+
+	switch backup.Spec.Mode {
+	case "", v1alpha1.BackupModeSnapshot:
+		return nil
+	case v1alpha1.BackupModeLog:
+	default:
+		return fmt.Errorf("invalid backup mode %s for BR in spec of %s/%s", backup.Spec.Mode, ns, name)
+	}
+
+	if tikvImage != "" && !supportLogBackup(tikvImage) {
+		return fmt.Errorf("log backup is not supported by tikv image %s in spec of %s/%s", tikvImage, ns, name)
+	}
+	if _, err := ParseTSString(backup.Spec.CommitTs); err != nil {
+		return fmt.Errorf("invalid commitTs %s for log backup in spec of %s/%s, err: %v", backup.Spec.CommitTs, ns, name, err)
+	}
+	if _, err := ParseTSString(backup.Spec.LogTruncateUntil); err != nil {
+		return fmt.Errorf("invalid logTruncateUntil %s for log backup in spec of %s/%s, err: %v", backup.Spec.LogTruncateUntil, ns, name, err)
+	}
+	return nil
+}
+
+// validatePiTRRestore validates the pitr related fields of the restore spec
+func validatePiTRRestore(restore *v1alpha1.Restore, tikvImage string) error {
+	ns := restore.Namespace
+	name := restore.Name
+
+	switch restore.Spec.Mode {
+	case "", v1alpha1.RestoreModeSnapshot:
+		return nil
+	case v1alpha1.RestoreModePiTR:
+	default:
+		return fmt.Errorf("invalid restore mode %s for BR in spec of %s/%s", restore.Spec.Mode, ns, name)
+	}
+
+	if tikvImage != "" && !supportLogBackup(tikvImage) {
+		return fmt.Errorf("pitr restore is not supported by tikv image %s in spec of %s/%s", tikvImage, ns, name)
+	}
+	if restore.Spec.PitrRestoredTs == "" {
+		return fmt.Errorf("pitrRestoredTs should be configured for pitr restore in spec of %s/%s", ns, name)
+	}
+	if _, err := ParseTSString(restore.Spec.PitrRestoredTs); err != nil {
+		return fmt.Errorf("invalid pitrRestoredTs %s for pitr restore in spec of %s/%s, err: %v", restore.Spec.PitrRestoredTs, ns, name, err)
+	}
+
+	fullBackup := restore.Spec.PitrFullBackupStorageProvider
+	switch GetStorageType(fullBackup) {
+	case v1alpha1.BackupStorageTypeS3:
+		return validateS3(ns, name, fullBackup.S3)
+	case v1alpha1.BackupStorageTypeGcs:
+		return validateGcs(ns, name, fullBackup.Gcs)
+	case v1alpha1.BackupStorageTypeAzblob:
+		return nil
+	case v1alpha1.BackupStorageTypeLocal:
+		return validateLocal(ns, name, fullBackup.Local)
+	default:
+		return fmt.Errorf("pitrFullBackupStorageProvider should be configured for pitr restore in spec of %s/%s", ns, name)
+	}
+}
+
+// validateBackupVerify validates the verify spec of the backup, only the snapshot backup
+// by BR can be verified by restoring it into a scratch cluster
+func validateBackupVerify(backup *v1alpha1.Backup) error {
+	ns := backup.Namespace
+	name := backup.Name
+	verify := backup.Spec.Verify
+	if verify == nil {
+		return nil
+	}
+
+	if backup.Spec.BR == nil || v1alpha1.IsLogBackup(backup) || v1alpha1.IsVolumeSnapshotBackup(backup) {
+		return fmt.Errorf("verify is only supported by snapshot backup with BR in spec of %s/%s", ns, name)
+	}
+	if verify.Timeout != nil {
+		if _, err := time.ParseDuration(*verify.Timeout); err != nil {
+			return fmt.Errorf("invalid verify timeout %s in spec of %s/%s: %v", *verify.Timeout, ns, name, err)
+		}
+	}
+	if verify.TiKVReplicas != nil && *verify.TiKVReplicas < 1 {
+		return fmt.Errorf("verify tikvReplicas should be at least 1 in spec of %s/%s", ns, name)
+	}
+	if verify.TiKVStorageSize != "" {
+		if _, err := resource.ParseQuantity(verify.TiKVStorageSize); err != nil {
+			return fmt.Errorf("invalid verify tikvStorageSize %s in spec of %s/%s: %v", verify.TiKVStorageSize, ns, name, err)
+		}
+	}
+	return nil
+}
+
+// validateEncryption validates the method and the secret of the encryption
+func validateEncryption(encryption *v1alpha1.BackupEncryption) error {
+	if encryption.GetMethod().KeySize() == 0 {
+		return fmt.Errorf("invalid encryption method %s", encryption.Method)
+	}
+	if encryption.SecretName == "" {
+		return fmt.Errorf("secretName should be configured for encryption")
+	}
+	return nil
+}
+
+// validateReplicaStorages validates the replica storages of the backup
+func validateReplicaStorages(backup *v1alpha1.Backup) error {
+	ns := backup.Namespace
+	name := backup.Name
+	if len(backup.Spec.ReplicaStorages) == 0 {
+		return nil
+	}
+
+	if backup.Spec.BR == nil || v1alpha1.IsLogBackup(backup) || v1alpha1.IsVolumeSnapshotBackup(backup) {
+		return fmt.Errorf("replicaStorages is only supported by snapshot backup with BR in spec of %s/%s", ns, name)
+	}
+	for i, replica := range backup.Spec.ReplicaStorages {
+		var err error
+		switch GetStorageType(replica) {
+		case v1alpha1.BackupStorageTypeS3:
+			err = validateS3(ns, name, replica.S3)
+		case v1alpha1.BackupStorageTypeGcs:
+			err = validateGcs(ns, name, replica.Gcs)
+		case v1alpha1.BackupStorageTypeAzblob:
+			err = validateAzblob(ns, name, replica.Azblob)
+		case v1alpha1.BackupStorageTypeLocal:
+			err = validateLocal(ns, name, replica.Local)
+		default:
+			err = fmt.Errorf("storage of replicaStorages[%d] is not supported in spec of %s/%s", i, ns, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplicaStorageEnvPrefix returns the prefix of the env vars to access the replica storage with the index
+func ReplicaStorageEnvPrefix(index int) string {
+	return fmt.Sprintf(constants.ReplicaStorageEnvPrefixFmt, index)
+}
+
+// GenerateReplicaStoragesCertEnv generates the env info to access the replica storages of the backup,
+// the env vars of each replica storage are prefixed by ReplicaStorageEnvPrefix so that every replica
+// storage can use its own secret.
+func GenerateReplicaStoragesCertEnv(ns string, backup *v1alpha1.Backup, secretLister corelisterv1.SecretLister) ([]corev1.EnvVar, string, error) {
+	var envVars []corev1.EnvVar
+	for i, replica := range backup.Spec.ReplicaStorages {
+		if GetStorageType(replica) == v1alpha1.BackupStorageTypeLocal {
+			continue
+		}
+		certEnv, reason, err := GenerateStorageCertEnv(ns, backup.Spec.UseKMS, replica, secretLister)
+		if err != nil {
+			return nil, reason, err
+		}
+		prefix := ReplicaStorageEnvPrefix(i)
+		for _, env := range certEnv {
+			env.Name = prefix + env.Name
+			envVars = append(envVars, env)
+		}
+	}
+	return envVars, "", nil
+}
+
+// GetReplicaStoragesVolumes returns the volumes and volume mounts of the local replica storages of the backup,
+// the volumes with the same name as the primary local storage or a former one are mounted only once.
+func GetReplicaStoragesVolumes(backup *v1alpha1.Backup) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	mounted := map[string]bool{}
+	if backup.Spec.Local != nil {
+		mounted[backup.Spec.Local.Volume.Name] = true
+	}
+	for _, replica := range backup.Spec.ReplicaStorages {
+		if replica.Local == nil || mounted[replica.Local.Volume.Name] {
+			continue
+		}
+		mounted[replica.Local.Volume.Name] = true
+		volumes = append(volumes, replica.Local.Volume)
+		volumeMounts = append(volumeMounts, replica.Local.VolumeMount)
+	}
+	return volumes, volumeMounts
+}
+
+// validateVolumeSnapshotRestore validates the spec of the volume-snapshot restore, which
+// only needs the cluster to restore and the backup of the snapshots
+func validateVolumeSnapshotRestore(restore *v1alpha1.Restore) error {
+	ns := restore.Namespace
+	name := restore.Name
+
+	if restore.Spec.BR.Cluster == "" {
+		return fmt.Errorf("cluster should be configured for BR in spec of %s/%s", ns, name)
+	}
+	if restore.Spec.VolumeSnapshotBackupName == "" {
+		return fmt.Errorf("volumeSnapshotBackupName should be configured for volume-snapshot restore in spec of %s/%s", ns, name)
+	}
+	return nil
+}
+
+// validateVolumeSnapshotBackup validates the spec of the volume-snapshot backup, which
+// only needs the cluster to snapshot
+func validateVolumeSnapshotBackup(backup *v1alpha1.Backup, tikvImage string) error {
+	ns := backup.Namespace
+	name := backup.Name
+
+	if backup.Spec.BR.Cluster == "" {
+		return fmt.Errorf("cluster should be configured for BR in spec of %s/%s", ns, name)
+	}
+	if backup.Spec.Mode != "" && backup.Spec.Mode != v1alpha1.BackupModeSnapshot {
+		return fmt.Errorf("backup mode %s is not supported by volume-snapshot backup in spec of %s/%s", backup.Spec.Mode, ns, name)
+	}
+	if tikvImage != "" && !supportVolumeSnapshotBackup(tikvImage) {
+		return fmt.Errorf("volume-snapshot backup is not supported by tikv image %s in spec of %s/%s", tikvImage, ns, name)
+	}
+	return nil
+}
+
+// ParseTSString parses a TSO or datetime string to TSO, e.g. '400036290571534337', '2018-05-11 01:42:23'.
+// The datetime can also carry a zone offset, e.g. '2018-05-11 01:42:23 +0800', otherwise it is parsed in local time.
+// An empty string is parsed to 0.
+func ParseTSString(ts string) (uint64, error) {
+	if ts == "" {
+		return 0, nil
+	}
+	if tso, err := strconv.ParseUint(ts, 10, 64); err == nil {
+		return tso, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02 15:04:05 -0700", ts, time.Local)
+	if err != nil {
+		t, err = time.ParseInLocation("2006-01-02 15:04:05", ts, time.Local)
+		if err != nil {
+			return 0, fmt.Errorf("%s is neither a TSO nor a datetime", ts)
+		}
+	}
+	return GoTimeToTS(t), nil
+}
+
+// GoTimeToTS converts a Go time to TSO.
+func GoTimeToTS(t time.Time) uint64 {
+	physical := t.UnixNano() / int64(time.Millisecond)
+	return uint64(physical) << physicalShiftBits
+}
+
+// TSToGoTime converts a TSO to Go time, the logical part of the TSO is ignored.
+func TSToGoTime(ts uint64) time.Time {
+	physical := int64(ts >> physicalShiftBits)
+	return time.Unix(0, physical*int64(time.Millisecond))
+}
+
 func validateS3(ns, name string, s3 *v1alpha1.S3StorageProvider) error {
 	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
 	if s3.Bucket == "" {
@@ -598,6 +976,17 @@ func validateGcs(ns, name string, gcs *v1alpha1.GcsStorageProvider) error {
 	return nil
 }
 
+func validateAzblob(ns, name string, azblob *v1alpha1.AzblobStorageProvider) error {
+	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
+	if azblob.Container == "" {
+		return fmt.Errorf("container should be %s", configuredForBR)
+	}
+	if azblob.Path != "" && !strings.HasPrefix(strings.TrimLeft(azblob.Path, "/"), azblob.Container+"/") {
+		return fmt.Errorf("path %s should start with the container %s %s", azblob.Path, azblob.Container, configuredForBR)
+	}
+	return nil
+}
+
 func validateLocal(ns, name string, local *v1alpha1.LocalStorageProvider) error {
 	configuredForBR := fmt.Sprintf("configured for BR in spec of %s/%s", ns, name)
 	if local.VolumeMount.Name != local.Volume.Name {
@@ -625,6 +1014,28 @@ func ParseImage(image string) (string, string) {
 	return name, tag
 }
 
+// supportLogBackup returns if log backup and pitr restore are supported based on the TiKV version
+func supportLogBackup(image string) bool {
+	_, version := ParseImage(image)
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		klog.Errorf("Parse version %s failure, error: %v", version, err)
+		return true
+	}
+	return !tikvLessThanV620.Check(v)
+}
+
+// supportVolumeSnapshotBackup returns if the TiKV writes can be suspended for the volume snapshots based on the TiKV version
+func supportVolumeSnapshotBackup(image string) bool {
+	_, version := ParseImage(image)
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		klog.Errorf("Parse version %s failure, error: %v", version, err)
+		return true
+	}
+	return !tikvLessThanV750.Check(v)
+}
+
 // canSkipSetGCLifeTime returns if setting tikv_gc_life_time can be skipped based on the TiKV version
 func canSkipSetGCLifeTime(image string) bool {
 	_, version := ParseImage(image)
@@ -17,6 +17,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -25,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 )
 
 func TestCheckAllKeysExistInSecret(t *testing.T) {
@@ -523,6 +525,377 @@ func TestValidateBackup(t *testing.T) {
 	match("")
 }
 
+func TestValidateLogBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := new(v1alpha1.Backup)
+	backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb"}
+	backup.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket"}
+	match := func(tikvImage, sub string) {
+		t.Helper()
+		err := ValidateBackup(backup, tikvImage)
+		if sub == "" {
+			g.Expect(err).Should(BeNil())
+		} else {
+			g.Expect(err).ShouldNot(BeNil())
+			g.Expect(err.Error()).Should(MatchRegexp(".*" + sub + ".*"))
+		}
+	}
+
+	backup.Spec.Mode = v1alpha1.BackupMode("invalid")
+	match("tikv:v6.2.0", "invalid backup mode")
+
+	backup.Spec.Mode = v1alpha1.BackupModeLog
+	match("tikv:v6.1.0", "log backup is not supported")
+	match("tikv:v6.2.0", "")
+
+	backup.Spec.CommitTs = "invalid"
+	match("tikv:v6.2.0", "invalid commitTs")
+
+	backup.Spec.CommitTs = "2022-10-10 10:00:00"
+	backup.Spec.LogTruncateUntil = "invalid"
+	match("tikv:v6.2.0", "invalid logTruncateUntil")
+
+	backup.Spec.LogTruncateUntil = "436225385564323842"
+	match("tikv:v6.2.0", "")
+}
+
+func TestValidateBackupSchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	bs := new(v1alpha1.BackupSchedule)
+	g.Expect(ValidateBackupSchedule(bs)).Should(BeNil())
+
+	bs.Spec.RetentionPolicy = &v1alpha1.BackupRetentionPolicy{
+		Daily:   &v1alpha1.BackupRetentionTier{Keep: 7},
+		Monthly: &v1alpha1.BackupRetentionTier{Keep: 0},
+	}
+	g.Expect(ValidateBackupSchedule(bs)).Should(BeNil())
+
+	bs.Spec.RetentionPolicy.Daily.Keep = -1
+	err := ValidateBackupSchedule(bs)
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("daily retention tier should not be negative"))
+
+	bs.Spec.RetentionPolicy.Daily.Keep = 7
+	bs.Spec.MaxBackups = pointer.Int32Ptr(10)
+	err = ValidateBackupSchedule(bs)
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("can't be set together"))
+
+	bs.Spec.MaxBackups = nil
+	bs.Spec.MaxReservedTime = pointer.StringPtr("72h")
+	err = ValidateBackupSchedule(bs)
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("can't be set together"))
+}
+
+func TestValidateVolumeSnapshot(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := new(v1alpha1.Backup)
+	backup.Spec.Type = v1alpha1.BackupTypeVolumeSnapshot
+	backup.Spec.BR = &v1alpha1.BRConfig{}
+	err := ValidateBackup(backup, "tikv:v7.5.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("cluster should be configured"))
+
+	// the storage provider is not required
+	backup.Spec.BR.Cluster = "tidb"
+	g.Expect(ValidateBackup(backup, "tikv:v7.5.0")).Should(BeNil())
+	err = ValidateBackup(backup, "tikv:v7.1.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("volume-snapshot backup is not supported"))
+
+	backup.Spec.Mode = v1alpha1.BackupModeLog
+	err = ValidateBackup(backup, "tikv:v7.5.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("is not supported by volume-snapshot backup"))
+
+	restore := new(v1alpha1.Restore)
+	restore.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+	restore.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb"}
+	err = ValidateRestore(restore, "")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("volumeSnapshotBackupName should be configured"))
+
+	restore.Spec.VolumeSnapshotBackupName = "snapshot"
+	g.Expect(ValidateRestore(restore, "")).Should(BeNil())
+}
+
+func TestValidateBackupVerify(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := new(v1alpha1.Backup)
+	backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb"}
+	backup.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket"}
+	backup.Spec.Verify = &v1alpha1.BackupVerifySpec{}
+	g.Expect(ValidateBackup(backup, "tikv:v6.2.0")).Should(BeNil())
+
+	backup.Spec.Verify.Timeout = pointer.StringPtr("1 day")
+	err := ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("invalid verify timeout"))
+
+	backup.Spec.Verify.Timeout = pointer.StringPtr("12h")
+	backup.Spec.Verify.TiKVReplicas = pointer.Int32Ptr(0)
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("tikvReplicas"))
+
+	backup.Spec.Verify.TiKVReplicas = pointer.Int32Ptr(3)
+	backup.Spec.Mode = v1alpha1.BackupModeLog
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("verify is only supported"))
+}
+
+func TestValidateReplicaStorages(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := new(v1alpha1.Backup)
+	backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb"}
+	backup.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket", SecretName: "s3-secret"}
+	backup.Spec.ReplicaStorages = []v1alpha1.StorageProvider{
+		{S3: &v1alpha1.S3StorageProvider{Bucket: "bucket-dr", Region: "us-west-2", SecretName: "s3-secret"}},
+		{Gcs: &v1alpha1.GcsStorageProvider{ProjectId: "project", Bucket: "bucket-gcs", SecretName: "gcs-secret"}},
+	}
+	g.Expect(ValidateBackup(backup, "tikv:v6.2.0")).Should(BeNil())
+
+	// each replica storage can use its own secret
+	backup.Spec.ReplicaStorages[0].S3.SecretName = "s3-dr-secret"
+	g.Expect(ValidateBackup(backup, "tikv:v6.2.0")).Should(BeNil())
+
+	backup.Spec.ReplicaStorages[1].Gcs.ProjectId = ""
+	err := ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("projectId should be configured"))
+
+	backup.Spec.ReplicaStorages[1] = v1alpha1.StorageProvider{Azblob: &v1alpha1.AzblobStorageProvider{Prefix: "dr"}}
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("container should be configured"))
+
+	backup.Spec.ReplicaStorages[1].Azblob.Container = "container"
+	backup.Spec.ReplicaStorages[1].Azblob.Path = "other/dr"
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("should start with the container"))
+
+	backup.Spec.ReplicaStorages[1].Azblob.Path = "container/dr"
+	g.Expect(ValidateBackup(backup, "tikv:v6.2.0")).Should(BeNil())
+
+	backup.Spec.ReplicaStorages[1] = v1alpha1.StorageProvider{}
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("storage of replicaStorages[1] is not supported"))
+
+	backup.Spec.ReplicaStorages = backup.Spec.ReplicaStorages[:1]
+	backup.Spec.Mode = v1alpha1.BackupModeLog
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("replicaStorages is only supported"))
+}
+
+func TestGenerateReplicaStoragesCertEnv(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := new(v1alpha1.Backup)
+	backup.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket"}
+	backup.Spec.ReplicaStorages = []v1alpha1.StorageProvider{
+		{S3: &v1alpha1.S3StorageProvider{Bucket: "bucket-dr", Region: "us-west-2"}},
+		{Local: &v1alpha1.LocalStorageProvider{}},
+		{Gcs: &v1alpha1.GcsStorageProvider{ProjectId: "project", Bucket: "bucket-gcs"}},
+	}
+	client := fake.NewSimpleClientset()
+	informer := kubeinformers.NewSharedInformerFactory(client, 0)
+	envs, _, err := GenerateReplicaStoragesCertEnv("ns", backup, informer.Core().V1().Secrets().Lister())
+	g.Expect(err).Should(BeNil())
+
+	names := map[string]string{}
+	for _, env := range envs {
+		names[env.Name] = env.Value
+	}
+	g.Expect(names).Should(HaveKeyWithValue("REPLICA_0_AWS_REGION", "us-west-2"))
+	g.Expect(names).Should(HaveKeyWithValue("REPLICA_2_GCS_PROJECT_ID", "project"))
+	for name := range names {
+		g.Expect(name).ShouldNot(HavePrefix("REPLICA_1_"))
+	}
+}
+
+func TestValidateEncryption(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := new(v1alpha1.Backup)
+	backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb"}
+	backup.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket"}
+	backup.Spec.Encryption = &v1alpha1.BackupEncryption{SecretName: "encryption"}
+	g.Expect(ValidateBackup(backup, "tikv:v6.2.0")).Should(BeNil())
+
+	backup.Spec.Encryption.Method = v1alpha1.EncryptionMethod("sm4-ctr")
+	err := ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("invalid encryption method"))
+
+	backup.Spec.Encryption.Method = v1alpha1.EncryptionMethodAES128CTR
+	backup.Spec.Encryption.SecretName = ""
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("secretName should be configured for encryption"))
+
+	backup.Spec.Encryption.SecretName = "encryption"
+	backup.Spec.Mode = v1alpha1.BackupModeLog
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("encryption is only supported"))
+
+	restore := new(v1alpha1.Restore)
+	restore.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb"}
+	restore.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket"}
+	restore.Spec.Encryption = &v1alpha1.BackupEncryption{SecretName: "encryption"}
+	g.Expect(ValidateRestore(restore, "tikv:v6.2.0")).Should(BeNil())
+
+	restore.Spec.Mode = v1alpha1.RestoreModeVolumeSnapshot
+	restore.Spec.VolumeSnapshotBackupName = "snapshot"
+	err = ValidateRestore(restore, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("encryption is not supported"))
+}
+
+func TestValidateBackoffRetryPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	backup := new(v1alpha1.Backup)
+	backup.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb"}
+	backup.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "bucket"}
+	backup.Spec.BackoffRetryPolicy = &v1alpha1.BackoffRetryPolicy{}
+	g.Expect(ValidateBackup(backup, "tikv:v6.2.0")).Should(BeNil())
+
+	backup.Spec.BackoffRetryPolicy.MinRetryDuration = pointer.StringPtr("5 minutes")
+	err := ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("invalid minRetryDuration"))
+
+	backup.Spec.BackoffRetryPolicy.MinRetryDuration = pointer.StringPtr("5m")
+	backup.Spec.BackoffRetryPolicy.RetryTimeout = pointer.StringPtr("0s")
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("retryTimeout should be positive"))
+
+	backup.Spec.BackoffRetryPolicy.RetryTimeout = nil
+	backup.Spec.Mode = v1alpha1.BackupModeLog
+	err = ValidateBackup(backup, "tikv:v6.2.0")
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("backoffRetryPolicy is only supported"))
+}
+
+func TestNextBackoffRetry(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	policy := &v1alpha1.BackoffRetryPolicy{MaxRetryTimes: pointer.Int32Ptr(3)}
+	var records []v1alpha1.BackoffRetryRecord
+	for i := 1; i <= 3; i++ {
+		record, err := NextBackoffRetry(policy, records, now)
+		g.Expect(err).Should(BeNil())
+		g.Expect(record.RetryNum).Should(Equal(int32(i)))
+		// the retry duration is doubled each time from the default 300s
+		g.Expect(record.ExpectedRetryAt.Sub(now)).Should(Equal(300 * time.Second << (i - 1)))
+		records = append(records, *record)
+	}
+	_, err := NextBackoffRetry(policy, records, now)
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("reaches maxRetryTimes 3"))
+
+	// the retry timeout is counted from the first failure
+	policy.MaxRetryTimes = pointer.Int32Ptr(5)
+	_, err = NextBackoffRetry(policy, records, now.Add(31*time.Minute))
+	g.Expect(err).ShouldNot(BeNil())
+	g.Expect(err.Error()).Should(ContainSubstring("exceeds retryTimeout"))
+}
+
+func TestClassifyPodFailure(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := &corev1.Pod{}
+	pod.Name = "backup"
+	pod.Status.Reason = "Evicted"
+	reason, _, retryable := ClassifyPodFailure(pod)
+	g.Expect(reason).Should(Equal(PodFailureReasonEvicted))
+	g.Expect(retryable).Should(BeTrue())
+
+	pod.Status.Reason = ""
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"}},
+	}}
+	reason, _, retryable = ClassifyPodFailure(pod)
+	g.Expect(reason).Should(Equal(PodFailureReasonOOMKilled))
+	g.Expect(retryable).Should(BeTrue())
+
+	// the backup-manager exits with an error
+	pod.Status.ContainerStatuses[0].State.Terminated.Reason = "Error"
+	reason, _, retryable = ClassifyPodFailure(pod)
+	g.Expect(reason).Should(Equal(PodFailureReasonError))
+	g.Expect(retryable).Should(BeFalse())
+}
+
+func TestValidatePiTRRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	restore := new(v1alpha1.Restore)
+	restore.Spec.BR = &v1alpha1.BRConfig{Cluster: "tidb"}
+	restore.Spec.S3 = &v1alpha1.S3StorageProvider{Bucket: "log-bucket"}
+	match := func(tikvImage, sub string) {
+		t.Helper()
+		err := ValidateRestore(restore, tikvImage)
+		if sub == "" {
+			g.Expect(err).Should(BeNil())
+		} else {
+			g.Expect(err).ShouldNot(BeNil())
+			g.Expect(err.Error()).Should(MatchRegexp(".*" + sub + ".*"))
+		}
+	}
+
+	restore.Spec.Mode = v1alpha1.RestoreMode("invalid")
+	match("tikv:v6.2.0", "invalid restore mode")
+
+	restore.Spec.Mode = v1alpha1.RestoreModePiTR
+	match("tikv:v5.4.0", "pitr restore is not supported")
+	match("tikv:v6.2.0", "pitrRestoredTs should be configured")
+
+	restore.Spec.PitrRestoredTs = "invalid"
+	match("tikv:v6.2.0", "invalid pitrRestoredTs")
+
+	restore.Spec.PitrRestoredTs = "2022-10-10 10:00:00 +0800"
+	match("tikv:v6.2.0", "pitrFullBackupStorageProvider should be configured")
+
+	restore.Spec.PitrFullBackupStorageProvider.S3 = &v1alpha1.S3StorageProvider{}
+	match("tikv:v6.2.0", "bucket should be configured for BR")
+
+	restore.Spec.PitrFullBackupStorageProvider.S3.Bucket = "full-bucket"
+	match("tikv:v6.2.0", "")
+}
+
+func TestParseTSString(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ts, err := ParseTSString("")
+	g.Expect(err).Should(BeNil())
+	g.Expect(ts).Should(Equal(uint64(0)))
+
+	ts, err = ParseTSString("400036290571534337")
+	g.Expect(err).Should(BeNil())
+	g.Expect(ts).Should(Equal(uint64(400036290571534337)))
+
+	ts, err = ParseTSString("2018-05-11 01:42:23 +0000")
+	g.Expect(err).Should(BeNil())
+	g.Expect(ts).Should(Equal(uint64(1526002943000) << 18))
+	g.Expect(TSToGoTime(ts).UTC()).Should(Equal(time.Date(2018, 5, 11, 1, 42, 23, 0, time.UTC)))
+
+	_, err = ParseTSString("2018/05/11")
+	g.Expect(err).ShouldNot(BeNil())
+}
+
 func TestValidateRestore(t *testing.T) {
 	g := NewGomegaWithT(t)
 
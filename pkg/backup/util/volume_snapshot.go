@@ -0,0 +1,104 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VolumeSnapshotGVK is the kind of CSI volume snapshots, the snapshot CRDs are not
+// vendored so the snapshots are managed as unstructured objects
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// VolumeSnapshotState is the state of a CSI volume snapshot
+type VolumeSnapshotState struct {
+	// Taken means the point-in-time snapshot has been cut, the data may still be uploading
+	Taken bool
+	// ReadyToUse means the snapshot can be used to provision a new volume
+	ReadyToUse bool
+	// RestoreSize is the minimum size of the volume to restore the snapshot, nil if unknown
+	RestoreSize *resource.Quantity
+	// Error is the error message reported by the snapshot controller
+	Error string
+}
+
+// NewVolumeSnapshot returns a VolumeSnapshot of the PVC
+func NewVolumeSnapshot(ns, name, pvcName string, className *string, labels map[string]string) *unstructured.Unstructured {
+	snapshot := NewEmptyVolumeSnapshot()
+	snapshot.SetNamespace(ns)
+	snapshot.SetName(name)
+	snapshot.SetLabels(labels)
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvcName,
+		},
+	}
+	if className != nil {
+		spec["volumeSnapshotClassName"] = *className
+	}
+	snapshot.Object["spec"] = spec
+	return snapshot
+}
+
+// NewEmptyVolumeSnapshot returns a VolumeSnapshot with only the kind set, used to get or delete a snapshot
+func NewEmptyVolumeSnapshot() *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	return snapshot
+}
+
+// GetVolumeSnapshotState parses the status of the VolumeSnapshot
+func GetVolumeSnapshotState(snapshot *unstructured.Unstructured) (*VolumeSnapshotState, error) {
+	state := &VolumeSnapshotState{}
+	creationTime, _, err := unstructured.NestedString(snapshot.Object, "status", "creationTime")
+	if err != nil {
+		return nil, err
+	}
+	state.Taken = creationTime != ""
+	state.ReadyToUse, _, err = unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	if err != nil {
+		return nil, err
+	}
+	restoreSize, found, err := unstructured.NestedString(snapshot.Object, "status", "restoreSize")
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		size, err := resource.ParseQuantity(restoreSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid restore size %s of volume snapshot %s/%s: %v", restoreSize, snapshot.GetNamespace(), snapshot.GetName(), err)
+		}
+		state.RestoreSize = &size
+	}
+	state.Error, _, err = unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// GetTiKVDataPVCName returns the name of the data PVC of the TiKV Pod with the ordinal
+func GetTiKVDataPVCName(tcName string, ordinal int32) string {
+	return fmt.Sprintf("%s-%s-%d", v1alpha1.TiKVMemberType, controller.TiKVMemberName(tcName), ordinal)
+}
@@ -139,8 +139,8 @@ func (c *Client) PumpNodeStatus(ctx context.Context) (status []*v1alpha1.PumpNod
 	return c.nodeStatus(ctx, "pumps")
 }
 
-// nolint (unused)
-func (c *Client) drainerNodeStatus(ctx context.Context) (status []*v1alpha1.PumpNodeStatus, err error) {
+// DrainerNodeStatus returns the status of all drainers registered in PD.
+func (c *Client) DrainerNodeStatus(ctx context.Context) (status []*v1alpha1.PumpNodeStatus, err error) {
 	return c.nodeStatus(ctx, "drainers")
 }
 
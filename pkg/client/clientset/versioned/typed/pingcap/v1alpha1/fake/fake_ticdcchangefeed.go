@@ -0,0 +1,139 @@
+// Copyright PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTiCDCChangefeeds implements TiCDCChangefeedInterface
+type FakeTiCDCChangefeeds struct {
+	Fake *FakePingcapV1alpha1
+	ns   string
+}
+
+var ticdcchangefeedsResource = schema.GroupVersionResource{Group: "pingcap.com", Version: "v1alpha1", Resource: "ticdcchangefeeds"}
+
+var ticdcchangefeedsKind = schema.GroupVersionKind{Group: "pingcap.com", Version: "v1alpha1", Kind: "TiCDCChangefeed"}
+
+// Get takes name of the tiCDCChangefeed, and returns the corresponding tiCDCChangefeed object, and an error if there is any.
+func (c *FakeTiCDCChangefeeds) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.TiCDCChangefeed, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(ticdcchangefeedsResource, c.ns, name), &v1alpha1.TiCDCChangefeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TiCDCChangefeed), err
+}
+
+// List takes label and field selectors, and returns the list of TiCDCChangefeeds that match those selectors.
+func (c *FakeTiCDCChangefeeds) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TiCDCChangefeedList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(ticdcchangefeedsResource, ticdcchangefeedsKind, c.ns, opts), &v1alpha1.TiCDCChangefeedList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.TiCDCChangefeedList{ListMeta: obj.(*v1alpha1.TiCDCChangefeedList).ListMeta}
+	for _, item := range obj.(*v1alpha1.TiCDCChangefeedList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested tiCDCChangefeeds.
+func (c *FakeTiCDCChangefeeds) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(ticdcchangefeedsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a tiCDCChangefeed and creates it.  Returns the server's representation of the tiCDCChangefeed, and an error, if there is any.
+func (c *FakeTiCDCChangefeeds) Create(ctx context.Context, tiCDCChangefeed *v1alpha1.TiCDCChangefeed, opts v1.CreateOptions) (result *v1alpha1.TiCDCChangefeed, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(ticdcchangefeedsResource, c.ns, tiCDCChangefeed), &v1alpha1.TiCDCChangefeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TiCDCChangefeed), err
+}
+
+// Update takes the representation of a tiCDCChangefeed and updates it. Returns the server's representation of the tiCDCChangefeed, and an error, if there is any.
+func (c *FakeTiCDCChangefeeds) Update(ctx context.Context, tiCDCChangefeed *v1alpha1.TiCDCChangefeed, opts v1.UpdateOptions) (result *v1alpha1.TiCDCChangefeed, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(ticdcchangefeedsResource, c.ns, tiCDCChangefeed), &v1alpha1.TiCDCChangefeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TiCDCChangefeed), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeTiCDCChangefeeds) UpdateStatus(ctx context.Context, tiCDCChangefeed *v1alpha1.TiCDCChangefeed, opts v1.UpdateOptions) (*v1alpha1.TiCDCChangefeed, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(ticdcchangefeedsResource, "status", c.ns, tiCDCChangefeed), &v1alpha1.TiCDCChangefeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TiCDCChangefeed), err
+}
+
+// Delete takes name of the tiCDCChangefeed and deletes it. Returns an error if one occurs.
+func (c *FakeTiCDCChangefeeds) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(ticdcchangefeedsResource, c.ns, name), &v1alpha1.TiCDCChangefeed{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTiCDCChangefeeds) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(ticdcchangefeedsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.TiCDCChangefeedList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched tiCDCChangefeed.
+func (c *FakeTiCDCChangefeeds) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TiCDCChangefeed, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(ticdcchangefeedsResource, c.ns, name, pt, data, subresources...), &v1alpha1.TiCDCChangefeed{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TiCDCChangefeed), err
+}
@@ -0,0 +1,139 @@
+// Copyright PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTidbClusterReplications implements TidbClusterReplicationInterface
+type FakeTidbClusterReplications struct {
+	Fake *FakePingcapV1alpha1
+	ns   string
+}
+
+var tidbclusterreplicationsResource = schema.GroupVersionResource{Group: "pingcap.com", Version: "v1alpha1", Resource: "tidbclusterreplications"}
+
+var tidbclusterreplicationsKind = schema.GroupVersionKind{Group: "pingcap.com", Version: "v1alpha1", Kind: "TidbClusterReplication"}
+
+// Get takes name of the tidbClusterReplication, and returns the corresponding tidbClusterReplication object, and an error if there is any.
+func (c *FakeTidbClusterReplications) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.TidbClusterReplication, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(tidbclusterreplicationsResource, c.ns, name), &v1alpha1.TidbClusterReplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TidbClusterReplication), err
+}
+
+// List takes label and field selectors, and returns the list of TidbClusterReplications that match those selectors.
+func (c *FakeTidbClusterReplications) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TidbClusterReplicationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(tidbclusterreplicationsResource, tidbclusterreplicationsKind, c.ns, opts), &v1alpha1.TidbClusterReplicationList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.TidbClusterReplicationList{ListMeta: obj.(*v1alpha1.TidbClusterReplicationList).ListMeta}
+	for _, item := range obj.(*v1alpha1.TidbClusterReplicationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested tidbClusterReplications.
+func (c *FakeTidbClusterReplications) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(tidbclusterreplicationsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a tidbClusterReplication and creates it.  Returns the server's representation of the tidbClusterReplication, and an error, if there is any.
+func (c *FakeTidbClusterReplications) Create(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.CreateOptions) (result *v1alpha1.TidbClusterReplication, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(tidbclusterreplicationsResource, c.ns, tidbClusterReplication), &v1alpha1.TidbClusterReplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TidbClusterReplication), err
+}
+
+// Update takes the representation of a tidbClusterReplication and updates it. Returns the server's representation of the tidbClusterReplication, and an error, if there is any.
+func (c *FakeTidbClusterReplications) Update(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.UpdateOptions) (result *v1alpha1.TidbClusterReplication, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(tidbclusterreplicationsResource, c.ns, tidbClusterReplication), &v1alpha1.TidbClusterReplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TidbClusterReplication), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeTidbClusterReplications) UpdateStatus(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.UpdateOptions) (*v1alpha1.TidbClusterReplication, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(tidbclusterreplicationsResource, "status", c.ns, tidbClusterReplication), &v1alpha1.TidbClusterReplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TidbClusterReplication), err
+}
+
+// Delete takes name of the tidbClusterReplication and deletes it. Returns an error if one occurs.
+func (c *FakeTidbClusterReplications) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(tidbclusterreplicationsResource, c.ns, name), &v1alpha1.TidbClusterReplication{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTidbClusterReplications) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(tidbclusterreplicationsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.TidbClusterReplicationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched tidbClusterReplication.
+func (c *FakeTidbClusterReplications) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TidbClusterReplication, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(tidbclusterreplicationsResource, c.ns, name, pt, data, subresources...), &v1alpha1.TidbClusterReplication{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.TidbClusterReplication), err
+}
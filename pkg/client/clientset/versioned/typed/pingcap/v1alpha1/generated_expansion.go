@@ -25,10 +25,14 @@ type DataResourceExpansion interface{}
 
 type RestoreExpansion interface{}
 
+type TiCDCChangefeedExpansion interface{}
+
 type TidbClusterExpansion interface{}
 
 type TidbClusterAutoScalerExpansion interface{}
 
+type TidbClusterReplicationExpansion interface{}
+
 type TidbInitializerExpansion interface{}
 
 type TidbMonitorExpansion interface{}
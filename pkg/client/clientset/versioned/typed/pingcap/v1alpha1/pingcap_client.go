@@ -28,8 +28,10 @@ type PingcapV1alpha1Interface interface {
 	DMClustersGetter
 	DataResourcesGetter
 	RestoresGetter
+	TiCDCChangefeedsGetter
 	TidbClustersGetter
 	TidbClusterAutoScalersGetter
+	TidbClusterReplicationsGetter
 	TidbInitializersGetter
 	TidbMonitorsGetter
 	TidbNGMonitoringsGetter
@@ -60,6 +62,10 @@ func (c *PingcapV1alpha1Client) Restores(namespace string) RestoreInterface {
 	return newRestores(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) TiCDCChangefeeds(namespace string) TiCDCChangefeedInterface {
+	return newTiCDCChangefeeds(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) TidbClusters(namespace string) TidbClusterInterface {
 	return newTidbClusters(c, namespace)
 }
@@ -68,6 +74,10 @@ func (c *PingcapV1alpha1Client) TidbClusterAutoScalers(namespace string) TidbClu
 	return newTidbClusterAutoScalers(c, namespace)
 }
 
+func (c *PingcapV1alpha1Client) TidbClusterReplications(namespace string) TidbClusterReplicationInterface {
+	return newTidbClusterReplications(c, namespace)
+}
+
 func (c *PingcapV1alpha1Client) TidbInitializers(namespace string) TidbInitializerInterface {
 	return newTidbInitializers(c, namespace)
 }
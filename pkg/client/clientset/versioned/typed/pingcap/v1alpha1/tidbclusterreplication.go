@@ -0,0 +1,192 @@
+// Copyright PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	scheme "github.com/pingcap/tidb-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// TidbClusterReplicationsGetter has a method to return a TidbClusterReplicationInterface.
+// A group's client should implement this interface.
+type TidbClusterReplicationsGetter interface {
+	TidbClusterReplications(namespace string) TidbClusterReplicationInterface
+}
+
+// TidbClusterReplicationInterface has methods to work with TidbClusterReplication resources.
+type TidbClusterReplicationInterface interface {
+	Create(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.CreateOptions) (*v1alpha1.TidbClusterReplication, error)
+	Update(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.UpdateOptions) (*v1alpha1.TidbClusterReplication, error)
+	UpdateStatus(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.UpdateOptions) (*v1alpha1.TidbClusterReplication, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.TidbClusterReplication, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.TidbClusterReplicationList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TidbClusterReplication, err error)
+	TidbClusterReplicationExpansion
+}
+
+// tidbClusterReplications implements TidbClusterReplicationInterface
+type tidbClusterReplications struct {
+	client rest.Interface
+	ns     string
+}
+
+// newTidbClusterReplications returns a TidbClusterReplications
+func newTidbClusterReplications(c *PingcapV1alpha1Client, namespace string) *tidbClusterReplications {
+	return &tidbClusterReplications{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the tidbClusterReplication, and returns the corresponding tidbClusterReplication object, and an error if there is any.
+func (c *tidbClusterReplications) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.TidbClusterReplication, err error) {
+	result = &v1alpha1.TidbClusterReplication{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of TidbClusterReplications that match those selectors.
+func (c *tidbClusterReplications) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TidbClusterReplicationList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.TidbClusterReplicationList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested tidbClusterReplications.
+func (c *tidbClusterReplications) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a tidbClusterReplication and creates it.  Returns the server's representation of the tidbClusterReplication, and an error, if there is any.
+func (c *tidbClusterReplications) Create(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.CreateOptions) (result *v1alpha1.TidbClusterReplication, err error) {
+	result = &v1alpha1.TidbClusterReplication{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(tidbClusterReplication).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a tidbClusterReplication and updates it. Returns the server's representation of the tidbClusterReplication, and an error, if there is any.
+func (c *tidbClusterReplications) Update(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.UpdateOptions) (result *v1alpha1.TidbClusterReplication, err error) {
+	result = &v1alpha1.TidbClusterReplication{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		Name(tidbClusterReplication.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(tidbClusterReplication).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *tidbClusterReplications) UpdateStatus(ctx context.Context, tidbClusterReplication *v1alpha1.TidbClusterReplication, opts v1.UpdateOptions) (result *v1alpha1.TidbClusterReplication, err error) {
+	result = &v1alpha1.TidbClusterReplication{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		Name(tidbClusterReplication.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(tidbClusterReplication).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the tidbClusterReplication and deletes it. Returns an error if one occurs.
+func (c *tidbClusterReplications) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *tidbClusterReplications) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched tidbClusterReplication.
+func (c *tidbClusterReplications) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TidbClusterReplication, err error) {
+	result = &v1alpha1.TidbClusterReplication{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("tidbclusterreplications").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
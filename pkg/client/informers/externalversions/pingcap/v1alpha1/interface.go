@@ -31,10 +31,14 @@ type Interface interface {
 	DataResources() DataResourceInformer
 	// Restores returns a RestoreInformer.
 	Restores() RestoreInformer
+	// TiCDCChangefeeds returns a TiCDCChangefeedInformer.
+	TiCDCChangefeeds() TiCDCChangefeedInformer
 	// TidbClusters returns a TidbClusterInformer.
 	TidbClusters() TidbClusterInformer
 	// TidbClusterAutoScalers returns a TidbClusterAutoScalerInformer.
 	TidbClusterAutoScalers() TidbClusterAutoScalerInformer
+	// TidbClusterReplications returns a TidbClusterReplicationInformer.
+	TidbClusterReplications() TidbClusterReplicationInformer
 	// TidbInitializers returns a TidbInitializerInformer.
 	TidbInitializers() TidbInitializerInformer
 	// TidbMonitors returns a TidbMonitorInformer.
@@ -79,6 +83,11 @@ func (v *version) Restores() RestoreInformer {
 	return &restoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TiCDCChangefeeds returns a TiCDCChangefeedInformer.
+func (v *version) TiCDCChangefeeds() TiCDCChangefeedInformer {
+	return &tiCDCChangefeedInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TidbClusters returns a TidbClusterInformer.
 func (v *version) TidbClusters() TidbClusterInformer {
 	return &tidbClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -89,6 +98,11 @@ func (v *version) TidbClusterAutoScalers() TidbClusterAutoScalerInformer {
 	return &tidbClusterAutoScalerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TidbClusterReplications returns a TidbClusterReplicationInformer.
+func (v *version) TidbClusterReplications() TidbClusterReplicationInformer {
+	return &tidbClusterReplicationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TidbInitializers returns a TidbInitializerInformer.
 func (v *version) TidbInitializers() TidbInitializerInformer {
 	return &tidbInitializerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
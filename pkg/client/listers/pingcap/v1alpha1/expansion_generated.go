@@ -55,6 +55,14 @@ type RestoreListerExpansion interface{}
 // RestoreNamespaceLister.
 type RestoreNamespaceListerExpansion interface{}
 
+// TiCDCChangefeedListerExpansion allows custom methods to be added to
+// TiCDCChangefeedLister.
+type TiCDCChangefeedListerExpansion interface{}
+
+// TiCDCChangefeedNamespaceListerExpansion allows custom methods to be added to
+// TiCDCChangefeedNamespaceLister.
+type TiCDCChangefeedNamespaceListerExpansion interface{}
+
 // TidbClusterListerExpansion allows custom methods to be added to
 // TidbClusterLister.
 type TidbClusterListerExpansion interface{}
@@ -71,6 +79,14 @@ type TidbClusterAutoScalerListerExpansion interface{}
 // TidbClusterAutoScalerNamespaceLister.
 type TidbClusterAutoScalerNamespaceListerExpansion interface{}
 
+// TidbClusterReplicationListerExpansion allows custom methods to be added to
+// TidbClusterReplicationLister.
+type TidbClusterReplicationListerExpansion interface{}
+
+// TidbClusterReplicationNamespaceListerExpansion allows custom methods to be added to
+// TidbClusterReplicationNamespaceLister.
+type TidbClusterReplicationNamespaceListerExpansion interface{}
+
 // TidbInitializerListerExpansion allows custom methods to be added to
 // TidbInitializerLister.
 type TidbInitializerListerExpansion interface{}
@@ -0,0 +1,96 @@
+// Copyright PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TiCDCChangefeedLister helps list TiCDCChangefeeds.
+// All objects returned here must be treated as read-only.
+type TiCDCChangefeedLister interface {
+	// List lists all TiCDCChangefeeds in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.TiCDCChangefeed, err error)
+	// TiCDCChangefeeds returns an object that can list and get TiCDCChangefeeds.
+	TiCDCChangefeeds(namespace string) TiCDCChangefeedNamespaceLister
+	TiCDCChangefeedListerExpansion
+}
+
+// tiCDCChangefeedLister implements the TiCDCChangefeedLister interface.
+type tiCDCChangefeedLister struct {
+	indexer cache.Indexer
+}
+
+// NewTiCDCChangefeedLister returns a new TiCDCChangefeedLister.
+func NewTiCDCChangefeedLister(indexer cache.Indexer) TiCDCChangefeedLister {
+	return &tiCDCChangefeedLister{indexer: indexer}
+}
+
+// List lists all TiCDCChangefeeds in the indexer.
+func (s *tiCDCChangefeedLister) List(selector labels.Selector) (ret []*v1alpha1.TiCDCChangefeed, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.TiCDCChangefeed))
+	})
+	return ret, err
+}
+
+// TiCDCChangefeeds returns an object that can list and get TiCDCChangefeeds.
+func (s *tiCDCChangefeedLister) TiCDCChangefeeds(namespace string) TiCDCChangefeedNamespaceLister {
+	return tiCDCChangefeedNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// TiCDCChangefeedNamespaceLister helps list and get TiCDCChangefeeds.
+// All objects returned here must be treated as read-only.
+type TiCDCChangefeedNamespaceLister interface {
+	// List lists all TiCDCChangefeeds in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.TiCDCChangefeed, err error)
+	// Get retrieves the TiCDCChangefeed from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.TiCDCChangefeed, error)
+	TiCDCChangefeedNamespaceListerExpansion
+}
+
+// tiCDCChangefeedNamespaceLister implements the TiCDCChangefeedNamespaceLister
+// interface.
+type tiCDCChangefeedNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all TiCDCChangefeeds in the indexer for a given namespace.
+func (s tiCDCChangefeedNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.TiCDCChangefeed, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.TiCDCChangefeed))
+	})
+	return ret, err
+}
+
+// Get retrieves the TiCDCChangefeed from the indexer for a given namespace and name.
+func (s tiCDCChangefeedNamespaceLister) Get(name string) (*v1alpha1.TiCDCChangefeed, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("ticdcchangefeed"), name)
+	}
+	return obj.(*v1alpha1.TiCDCChangefeed), nil
+}
@@ -0,0 +1,96 @@
+// Copyright PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TidbClusterReplicationLister helps list TidbClusterReplications.
+// All objects returned here must be treated as read-only.
+type TidbClusterReplicationLister interface {
+	// List lists all TidbClusterReplications in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.TidbClusterReplication, err error)
+	// TidbClusterReplications returns an object that can list and get TidbClusterReplications.
+	TidbClusterReplications(namespace string) TidbClusterReplicationNamespaceLister
+	TidbClusterReplicationListerExpansion
+}
+
+// tidbClusterReplicationLister implements the TidbClusterReplicationLister interface.
+type tidbClusterReplicationLister struct {
+	indexer cache.Indexer
+}
+
+// NewTidbClusterReplicationLister returns a new TidbClusterReplicationLister.
+func NewTidbClusterReplicationLister(indexer cache.Indexer) TidbClusterReplicationLister {
+	return &tidbClusterReplicationLister{indexer: indexer}
+}
+
+// List lists all TidbClusterReplications in the indexer.
+func (s *tidbClusterReplicationLister) List(selector labels.Selector) (ret []*v1alpha1.TidbClusterReplication, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.TidbClusterReplication))
+	})
+	return ret, err
+}
+
+// TidbClusterReplications returns an object that can list and get TidbClusterReplications.
+func (s *tidbClusterReplicationLister) TidbClusterReplications(namespace string) TidbClusterReplicationNamespaceLister {
+	return tidbClusterReplicationNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// TidbClusterReplicationNamespaceLister helps list and get TidbClusterReplications.
+// All objects returned here must be treated as read-only.
+type TidbClusterReplicationNamespaceLister interface {
+	// List lists all TidbClusterReplications in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.TidbClusterReplication, err error)
+	// Get retrieves the TidbClusterReplication from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.TidbClusterReplication, error)
+	TidbClusterReplicationNamespaceListerExpansion
+}
+
+// tidbClusterReplicationNamespaceLister implements the TidbClusterReplicationNamespaceLister
+// interface.
+type tidbClusterReplicationNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all TidbClusterReplications in the indexer for a given namespace.
+func (s tidbClusterReplicationNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.TidbClusterReplication, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.TidbClusterReplication))
+	})
+	return ret, err
+}
+
+// Get retrieves the TidbClusterReplication from the indexer for a given namespace and name.
+func (s tidbClusterReplicationNamespaceLister) Get(name string) (*v1alpha1.TidbClusterReplication, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("tidbclusterreplication"), name)
+	}
+	return obj.(*v1alpha1.TidbClusterReplication), nil
+}
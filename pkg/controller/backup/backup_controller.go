@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/backup"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -59,9 +60,15 @@ func NewController(deps *controller.Dependencies) *Controller {
 	backupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: c.updateBackup,
 		UpdateFunc: func(old, cur interface{}) {
+			observeBackupStatusChange(old.(*v1alpha1.Backup), cur.(*v1alpha1.Backup))
 			c.updateBackup(cur)
 		},
-		DeleteFunc: c.updateBackup,
+		DeleteFunc: func(obj interface{}) {
+			if backup, ok := obj.(*v1alpha1.Backup); ok {
+				deleteBackupProgressMetrics(backup)
+			}
+			c.updateBackup(obj)
+		},
 	})
 	jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		DeleteFunc: c.deleteJob,
@@ -116,10 +123,11 @@ func (c *Controller) processNextWorkItem() bool {
 }
 
 // sync syncs the given backup.
-func (c *Controller) sync(key string) error {
+func (c *Controller) sync(key string) (err error) {
 	startTime := time.Now()
 	defer func() {
 		klog.V(4).Infof("Finished syncing Backup %q (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("Backup", startTime, err)
 	}()
 
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
@@ -146,6 +154,7 @@ func (c *Controller) updateBackup(cur interface{}) {
 	newBackup := cur.(*v1alpha1.Backup)
 	ns := newBackup.GetNamespace()
 	name := newBackup.GetName()
+	observeBackupProgress(newBackup)
 
 	if newBackup.DeletionTimestamp != nil {
 		// the backup is being deleted, we need to do some cleanup work, enqueue backup.
@@ -159,6 +168,32 @@ func (c *Controller) updateBackup(cur interface{}) {
 		return
 	}
 
+	if v1alpha1.IsLogBackup(newBackup) {
+		// log backup keeps running until it is stopped, the phase can not tell
+		// whether there is a subcommand to run, so check the subcommand directly.
+		if v1alpha1.ParseLogBackupSubcommand(newBackup) == "" {
+			klog.V(4).Infof("log backup %s/%s has no subcommand to run, skipping.", ns, name)
+			return
+		}
+		klog.V(4).Infof("log backup object %s/%s enqueue", ns, name)
+		c.enqueueBackup(newBackup)
+		return
+	}
+
+	if v1alpha1.IsVolumeSnapshotBackup(newBackup) && !v1alpha1.IsBackupComplete(newBackup) && !v1alpha1.IsBackupFailed(newBackup) {
+		// volume-snapshot backup runs in the controller without a job, so it
+		// must be synced until the snapshots are ready.
+		klog.V(4).Infof("volume-snapshot backup object %s/%s enqueue", ns, name)
+		c.enqueueBackup(newBackup)
+		return
+	}
+
+	if v1alpha1.NeedVerifyBackup(newBackup) {
+		klog.V(4).Infof("backup %s/%s is Complete and need to be verified, enqueue", ns, name)
+		c.enqueueBackup(newBackup)
+		return
+	}
+
 	if v1alpha1.IsBackupComplete(newBackup) {
 		klog.V(4).Infof("backup %s/%s is Complete, skipping.", ns, name)
 		return
@@ -184,6 +219,11 @@ func (c *Controller) updateBackup(cur interface{}) {
 		for _, pod := range pods {
 			if pod.Status.Phase == corev1.PodFailed {
 				klog.Infof("backup %s/%s has failed pod %s.", ns, name, pod.Name)
+				if newBackup.Spec.BackoffRetryPolicy != nil {
+					// the failure is classified and retried by the backup manager
+					c.enqueueBackup(newBackup)
+					break
+				}
 				err = c.control.UpdateCondition(newBackup, &v1alpha1.BackupCondition{
 					Type:    v1alpha1.BackupFailed,
 					Status:  corev1.ConditionTrue,
@@ -203,6 +243,78 @@ func (c *Controller) updateBackup(cur interface{}) {
 	c.enqueueBackup(newBackup)
 }
 
+// observeBackupProgress exports the progress reported by BR as metrics while the backup is running,
+// the processed bytes are kept after the backup finishes as BR reports them at the end
+func observeBackupProgress(backup *v1alpha1.Backup) {
+	progress := backup.Status.Progress
+	if backup.DeletionTimestamp != nil || progress == nil {
+		deleteBackupProgressMetrics(backup)
+		return
+	}
+	ns, name := backup.GetNamespace(), backup.GetName()
+	if progress.ProcessedBytes > 0 {
+		metrics.BackupProcessedBytes.WithLabelValues(ns, name).Set(float64(progress.ProcessedBytes))
+	}
+	if backup.Status.Phase != v1alpha1.BackupRunning {
+		metrics.BackupProgress.DeleteLabelValues(ns, name)
+		metrics.BackupProgressRemainingSeconds.DeleteLabelValues(ns, name)
+		return
+	}
+	metrics.BackupProgress.WithLabelValues(ns, name).Set(float64(progress.Percentage))
+	if remaining, err := time.ParseDuration(progress.Remaining); err == nil {
+		metrics.BackupProgressRemainingSeconds.WithLabelValues(ns, name).Set(remaining.Seconds())
+	} else {
+		metrics.BackupProgressRemainingSeconds.DeleteLabelValues(ns, name)
+	}
+}
+
+// observeBackupStatusChange counts the phase transitions of the backup. Most transitions are
+// updated by the backup jobs, so they are observed by the informer instead of the status updater.
+func observeBackupStatusChange(old, cur *v1alpha1.Backup) {
+	ns := cur.GetNamespace()
+	backupType := getBackupMetricsType(cur)
+	if cur.Status.Phase != "" && old.Status.Phase != cur.Status.Phase {
+		metrics.BackupPhaseTransitions.WithLabelValues(ns, backupType, string(cur.Status.Phase)).Inc()
+
+		switch {
+		case cur.Status.Phase == v1alpha1.BackupComplete:
+			if !cur.Status.TimeStarted.IsZero() && !cur.Status.TimeCompleted.IsZero() {
+				duration := cur.Status.TimeCompleted.Sub(cur.Status.TimeStarted.Time)
+				metrics.BackupDuration.WithLabelValues(ns, backupType).Observe(duration.Seconds())
+			}
+			if cur.Status.BackupSize > 0 {
+				metrics.BackupSize.WithLabelValues(ns, backupType).Observe(float64(cur.Status.BackupSize))
+			}
+		case cur.Status.Phase == v1alpha1.BackupFailed && cur.DeletionTimestamp != nil:
+			// the clean job marks the deleted backup as failed if it fails to clean the data
+			metrics.BackupCleanResults.WithLabelValues(ns, "failed").Inc()
+		}
+	}
+	if !v1alpha1.IsBackupClean(old) && v1alpha1.IsBackupClean(cur) {
+		metrics.BackupCleanResults.WithLabelValues(ns, "success").Inc()
+	}
+}
+
+// getBackupMetricsType returns the type label of the backup metrics, which tells how the backup is taken
+func getBackupMetricsType(backup *v1alpha1.Backup) string {
+	switch {
+	case backup.Spec.BR == nil:
+		return "dumpling"
+	case v1alpha1.IsLogBackup(backup):
+		return "log"
+	case v1alpha1.IsVolumeSnapshotBackup(backup):
+		return "volume-snapshot"
+	default:
+		return "snapshot"
+	}
+}
+
+func deleteBackupProgressMetrics(backup *v1alpha1.Backup) {
+	metrics.BackupProgress.DeleteLabelValues(backup.GetNamespace(), backup.GetName())
+	metrics.BackupProgressRemainingSeconds.DeleteLabelValues(backup.GetNamespace(), backup.GetName())
+	metrics.BackupProcessedBytes.DeleteLabelValues(backup.GetNamespace(), backup.GetName())
+}
+
 func (c *Controller) deleteJob(obj interface{}) {
 	job, ok := obj.(*batchv1.Job)
 	if !ok {
@@ -24,6 +24,8 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -287,6 +289,66 @@ func TestBackupControllerSync(t *testing.T) {
 
 }
 
+func TestObserveBackupStatusChange(t *testing.T) {
+	g := NewGomegaWithT(t)
+	old := newBackup()
+	old.Namespace = "observe-status"
+	old.Status.Phase = v1alpha1.BackupRunning
+
+	cur := old.DeepCopy()
+	cur.Status.Phase = v1alpha1.BackupComplete
+	cur.Status.TimeStarted = metav1.Now()
+	cur.Status.TimeCompleted = metav1.NewTime(cur.Status.TimeStarted.Add(10 * time.Minute))
+	cur.Status.BackupSize = 1 << 30
+	observeBackupStatusChange(old, cur)
+	// the status is updated again without phase transition
+	observeBackupStatusChange(cur, cur.DeepCopy())
+
+	g.Expect(testutil.ToFloat64(metrics.BackupPhaseTransitions.WithLabelValues(cur.Namespace, "dumpling", string(v1alpha1.BackupComplete)))).To(Equal(float64(1)))
+	g.Expect(testutil.CollectAndCount(metrics.BackupDuration)).To(Equal(1))
+	g.Expect(testutil.CollectAndCount(metrics.BackupSize)).To(Equal(1))
+
+	// the backup data is cleaned after the backup is deleted
+	old = cur
+	cur = old.DeepCopy()
+	cur.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	cur.Status.Phase = v1alpha1.BackupClean
+	cur.Status.Conditions = append(cur.Status.Conditions, v1alpha1.BackupCondition{Type: v1alpha1.BackupClean, Status: corev1.ConditionTrue})
+	observeBackupStatusChange(old, cur)
+	g.Expect(testutil.ToFloat64(metrics.BackupCleanResults.WithLabelValues(cur.Namespace, "success"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(metrics.BackupCleanResults.WithLabelValues(cur.Namespace, "failed"))).To(BeZero())
+}
+
+func TestObserveBackupProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+	backup := newBackup()
+	backup.Namespace = "observe-progress"
+	backup.Status.Phase = v1alpha1.BackupRunning
+	backup.Status.Progress = &v1alpha1.BRProgress{Step: "Full Backup", Percentage: 50, Remaining: "1m30s"}
+	ns, name := backup.Namespace, backup.Name
+
+	observeBackupProgress(backup)
+	g.Expect(testutil.ToFloat64(metrics.BackupProgress.WithLabelValues(ns, name))).To(Equal(float64(50)))
+	g.Expect(testutil.ToFloat64(metrics.BackupProgressRemainingSeconds.WithLabelValues(ns, name))).To(Equal(float64(90)))
+	g.Expect(metrics.BackupProcessedBytes.DeleteLabelValues(ns, name)).To(BeFalse())
+
+	// the processed bytes are reported in the success summary of BR
+	backup.Status.Progress = &v1alpha1.BRProgress{Step: "Full Backup", Percentage: 100, Remaining: "0s", ProcessedBytes: 1 << 20}
+	observeBackupProgress(backup)
+	g.Expect(testutil.ToFloat64(metrics.BackupProcessedBytes.WithLabelValues(ns, name))).To(Equal(float64(1 << 20)))
+
+	// the processed bytes are kept after the backup is complete
+	backup.Status.Phase = v1alpha1.BackupComplete
+	observeBackupProgress(backup)
+	g.Expect(metrics.BackupProgress.DeleteLabelValues(ns, name)).To(BeFalse())
+	g.Expect(metrics.BackupProgressRemainingSeconds.DeleteLabelValues(ns, name)).To(BeFalse())
+	g.Expect(testutil.ToFloat64(metrics.BackupProcessedBytes.WithLabelValues(ns, name))).To(Equal(float64(1 << 20)))
+
+	backup.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	observeBackupProgress(backup)
+	g.Expect(metrics.BackupProcessedBytes.DeleteLabelValues(ns, name)).To(BeFalse())
+}
+
 func newFakeBackupController() (*Controller, cache.Indexer, *FakeBackupControl) {
 	fakeDeps := controller.NewFakeDependencies()
 	bkc := NewController(fakeDeps)
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions/pingcap/v1alpha1"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -47,9 +48,28 @@ type BackupUpdateStatus struct {
 	BackupSize *int64
 	// CommitTs is the snapshot time point of tidb cluster.
 	CommitTs *string
+	// LogCheckpointTs is the checkpoint ts of the log backup task.
+	LogCheckpointTs *string
+	// LogSuccessTruncateUntil is the ts before which the log backup data has been truncated successfully.
+	LogSuccessTruncateUntil *string
+	// LogSubCommandStatus is the status of the log backup subcommand.
+	LogSubCommandStatus *v1alpha1.LogSubCommandStatus
+	// LogProgress is the range of the logs that have been backed up.
+	LogProgress *v1alpha1.LogBackupProgress
+	// VolumeSnapshots are the snapshots of the TiKV volumes, nil means no change.
+	VolumeSnapshots []v1alpha1.TiKVVolumeSnapshot
+	// PausedSchedulers are the PD schedulers paused by volume-snapshot backup, nil means no change.
+	PausedSchedulers []string
+	// ReplicaStatus is the status of copying the backup data to a replica storage.
+	ReplicaStatus *v1alpha1.BackupReplicaStatus
+	// Progress is the progress of the running backup reported by BR.
+	Progress *v1alpha1.BRProgress
+	// BackoffRetryRecord is the record of a retry of the failed backup job.
+	BackoffRetryRecord *v1alpha1.BackoffRetryRecord
 }
 
 // BackupConditionUpdaterInterface enables updating Backup conditions.
+// The condition can be nil if only the status fields need to be updated.
 type BackupConditionUpdaterInterface interface {
 	Update(backup *v1alpha1.Backup, condition *v1alpha1.BackupCondition, newStatus *BackupUpdateStatus) error
 }
@@ -78,9 +98,9 @@ func (u *realBackupConditionUpdater) Update(backup *v1alpha1.Backup, condition *
 	var isUpdate bool
 	// try best effort to guarantee backup is updated.
 	err := retry.OnError(retry.DefaultRetry, func(e error) bool { return e != nil }, func() error {
-		updateBackupStatus(&backup.Status, newStatus)
-		isUpdate = v1alpha1.UpdateBackupCondition(&backup.Status, condition)
-		if isUpdate {
+		isStatusUpdate := updateBackupStatus(&backup.Status, newStatus)
+		isUpdate = condition != nil && v1alpha1.UpdateBackupCondition(&backup.Status, condition)
+		if isUpdate || isStatusUpdate {
 			_, updateErr := u.cli.PingcapV1alpha1().Backups(ns).Update(context.TODO(), backup, metav1.UpdateOptions{})
 			if updateErr == nil {
 				klog.Infof("Backup: [%s/%s] updated successfully", ns, backupName)
@@ -102,28 +122,131 @@ func (u *realBackupConditionUpdater) Update(backup *v1alpha1.Backup, condition *
 
 // updateBackupStatus updates existing Backup status
 // from the fields in BackupUpdateStatus.
-func updateBackupStatus(status *v1alpha1.BackupStatus, newStatus *BackupUpdateStatus) {
+// Returns true if any of the status fields has changed.
+func updateBackupStatus(status *v1alpha1.BackupStatus, newStatus *BackupUpdateStatus) bool {
 	if newStatus == nil {
-		return
+		return false
 	}
-	if newStatus.BackupPath != nil {
+	isUpdate := false
+	if newStatus.BackupPath != nil && status.BackupPath != *newStatus.BackupPath {
 		status.BackupPath = *newStatus.BackupPath
+		isUpdate = true
 	}
-	if newStatus.TimeStarted != nil {
+	if newStatus.TimeStarted != nil && !status.TimeStarted.Equal(newStatus.TimeStarted) {
 		status.TimeStarted = *newStatus.TimeStarted
+		isUpdate = true
 	}
-	if newStatus.TimeCompleted != nil {
+	if newStatus.TimeCompleted != nil && !status.TimeCompleted.Equal(newStatus.TimeCompleted) {
 		status.TimeCompleted = *newStatus.TimeCompleted
+		isUpdate = true
 	}
-	if newStatus.BackupSizeReadable != nil {
+	if newStatus.BackupSizeReadable != nil && status.BackupSizeReadable != *newStatus.BackupSizeReadable {
 		status.BackupSizeReadable = *newStatus.BackupSizeReadable
+		isUpdate = true
 	}
-	if newStatus.BackupSize != nil {
+	if newStatus.BackupSize != nil && status.BackupSize != *newStatus.BackupSize {
 		status.BackupSize = *newStatus.BackupSize
+		isUpdate = true
 	}
-	if newStatus.CommitTs != nil {
+	if newStatus.CommitTs != nil && status.CommitTs != *newStatus.CommitTs {
 		status.CommitTs = *newStatus.CommitTs
+		isUpdate = true
 	}
+	if newStatus.LogCheckpointTs != nil && status.LogCheckpointTs != *newStatus.LogCheckpointTs {
+		status.LogCheckpointTs = *newStatus.LogCheckpointTs
+		isUpdate = true
+	}
+	if newStatus.LogSuccessTruncateUntil != nil && status.LogSuccessTruncateUntil != *newStatus.LogSuccessTruncateUntil {
+		status.LogSuccessTruncateUntil = *newStatus.LogSuccessTruncateUntil
+		isUpdate = true
+	}
+	if newStatus.LogSubCommandStatus != nil && updateLogSubCommandStatus(status, newStatus.LogSubCommandStatus) {
+		isUpdate = true
+	}
+	if newStatus.LogProgress != nil && !apiequality.Semantic.DeepEqual(status.LogProgress, newStatus.LogProgress) {
+		status.LogProgress = newStatus.LogProgress
+		isUpdate = true
+	}
+	if newStatus.VolumeSnapshots != nil && !apiequality.Semantic.DeepEqual(status.VolumeSnapshots, newStatus.VolumeSnapshots) {
+		status.VolumeSnapshots = newStatus.VolumeSnapshots
+		isUpdate = true
+	}
+	if newStatus.PausedSchedulers != nil && !apiequality.Semantic.DeepEqual(status.PausedSchedulers, newStatus.PausedSchedulers) {
+		status.PausedSchedulers = newStatus.PausedSchedulers
+		isUpdate = true
+	}
+	if newStatus.ReplicaStatus != nil && updateBackupReplicaStatus(status, newStatus.ReplicaStatus) {
+		isUpdate = true
+	}
+	if newStatus.Progress != nil && !apiequality.Semantic.DeepEqual(status.Progress, newStatus.Progress) {
+		status.Progress = newStatus.Progress
+		isUpdate = true
+	}
+	if newStatus.BackoffRetryRecord != nil && updateBackoffRetryStatus(&status.BackoffRetryStatus, newStatus.BackoffRetryRecord) {
+		isUpdate = true
+	}
+	return isUpdate
+}
+
+// updateBackupReplicaStatus replaces the status of the replica storage with the same index in the backup status.
+func updateBackupReplicaStatus(status *v1alpha1.BackupStatus, newStatus *v1alpha1.BackupReplicaStatus) bool {
+	for i := range status.ReplicaStatuses {
+		if status.ReplicaStatuses[i].Index != newStatus.Index {
+			continue
+		}
+		if apiequality.Semantic.DeepEqual(status.ReplicaStatuses[i], *newStatus) {
+			return false
+		}
+		status.ReplicaStatuses[i] = *newStatus
+		return true
+	}
+	status.ReplicaStatuses = append(status.ReplicaStatuses, *newStatus)
+	return true
+}
+
+// updateBackoffRetryStatus replaces the record with the same retry number in the retry records,
+// or appends it as a new retry.
+func updateBackoffRetryStatus(records *[]v1alpha1.BackoffRetryRecord, record *v1alpha1.BackoffRetryRecord) bool {
+	for i := range *records {
+		if (*records)[i].RetryNum != record.RetryNum {
+			continue
+		}
+		if apiequality.Semantic.DeepEqual((*records)[i], *record) {
+			return false
+		}
+		(*records)[i] = *record
+		return true
+	}
+	*records = append(*records, *record)
+	return true
+}
+
+// updateLogSubCommandStatus merges the new status of a log backup subcommand into the backup status.
+// A Scheduled subcommand starts a new round, so its previous status is replaced entirely.
+func updateLogSubCommandStatus(status *v1alpha1.BackupStatus, newStatus *v1alpha1.LogSubCommandStatus) bool {
+	if status.LogSubCommandStatuses == nil {
+		status.LogSubCommandStatuses = map[v1alpha1.LogSubCommandType]v1alpha1.LogSubCommandStatus{}
+	}
+	old, ok := status.LogSubCommandStatuses[newStatus.Command]
+	if !ok || newStatus.Phase == v1alpha1.BackupScheduled {
+		status.LogSubCommandStatuses[newStatus.Command] = *newStatus
+		return !ok || !apiequality.Semantic.DeepEqual(old, *newStatus)
+	}
+
+	merged := old
+	merged.Phase = newStatus.Phase
+	merged.Message = newStatus.Message
+	if !newStatus.TimeStarted.IsZero() {
+		merged.TimeStarted = newStatus.TimeStarted
+	}
+	if !newStatus.TimeCompleted.IsZero() {
+		merged.TimeCompleted = newStatus.TimeCompleted
+	}
+	if newStatus.LogTruncatingUntil != "" {
+		merged.LogTruncatingUntil = newStatus.LogTruncatingUntil
+	}
+	status.LogSubCommandStatuses[newStatus.Command] = merged
+	return !apiequality.Semantic.DeepEqual(old, merged)
 }
 
 var _ BackupConditionUpdaterInterface = &realBackupConditionUpdater{}
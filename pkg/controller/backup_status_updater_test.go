@@ -57,6 +57,107 @@ func TestUpdateBackupStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateLogSubCommandStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	start, _ := time.Parse(time.RFC3339, "2022-10-10T10:00:00Z")
+	end, _ := time.Parse(time.RFC3339, "2022-10-10T10:05:00Z")
+	status := &v1alpha1.BackupStatus{}
+
+	// schedule a truncate
+	updated := updateBackupStatus(status, &BackupUpdateStatus{
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:            v1alpha1.LogTruncateCommand,
+			LogTruncatingUntil: "100",
+			Phase:              v1alpha1.BackupScheduled,
+		},
+	})
+	g.Expect(updated).Should(BeTrue())
+	g.Expect(status.LogSubCommandStatuses[v1alpha1.LogTruncateCommand].Phase).Should(Equal(v1alpha1.BackupScheduled))
+
+	// the same status should not be updated again
+	updated = updateBackupStatus(status, &BackupUpdateStatus{
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:            v1alpha1.LogTruncateCommand,
+			LogTruncatingUntil: "100",
+			Phase:              v1alpha1.BackupScheduled,
+		},
+	})
+	g.Expect(updated).Should(BeFalse())
+
+	// running and complete are merged into the scheduled status
+	updateBackupStatus(status, &BackupUpdateStatus{
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:     v1alpha1.LogTruncateCommand,
+			TimeStarted: metav1.Time{Time: start},
+			Phase:       v1alpha1.BackupRunning,
+		},
+	})
+	truncateUntil := "100"
+	updated = updateBackupStatus(status, &BackupUpdateStatus{
+		LogSuccessTruncateUntil: &truncateUntil,
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:       v1alpha1.LogTruncateCommand,
+			TimeCompleted: metav1.Time{Time: end},
+			Phase:         v1alpha1.BackupComplete,
+		},
+	})
+	g.Expect(updated).Should(BeTrue())
+	g.Expect(status.LogSuccessTruncateUntil).Should(Equal("100"))
+	g.Expect(status.LogSubCommandStatuses[v1alpha1.LogTruncateCommand]).Should(Equal(v1alpha1.LogSubCommandStatus{
+		Command:            v1alpha1.LogTruncateCommand,
+		TimeStarted:        metav1.Time{Time: start},
+		TimeCompleted:      metav1.Time{Time: end},
+		LogTruncatingUntil: "100",
+		Phase:              v1alpha1.BackupComplete,
+	}))
+
+	// a new truncate replaces the previous one
+	updateBackupStatus(status, &BackupUpdateStatus{
+		LogSubCommandStatus: &v1alpha1.LogSubCommandStatus{
+			Command:            v1alpha1.LogTruncateCommand,
+			LogTruncatingUntil: "200",
+			Phase:              v1alpha1.BackupScheduled,
+		},
+	})
+	g.Expect(status.LogSubCommandStatuses[v1alpha1.LogTruncateCommand]).Should(Equal(v1alpha1.LogSubCommandStatus{
+		Command:            v1alpha1.LogTruncateCommand,
+		LogTruncatingUntil: "200",
+		Phase:              v1alpha1.BackupScheduled,
+	}))
+}
+
+func TestUpdateBackupReplicaStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+	status := &v1alpha1.BackupStatus{}
+
+	updated := updateBackupStatus(status, &BackupUpdateStatus{
+		ReplicaStatus: &v1alpha1.BackupReplicaStatus{Index: 0, Phase: v1alpha1.BackupRunning, TotalObjects: 10},
+	})
+	g.Expect(updated).Should(BeTrue())
+	updated = updateBackupStatus(status, &BackupUpdateStatus{
+		ReplicaStatus: &v1alpha1.BackupReplicaStatus{Index: 1, Phase: v1alpha1.BackupRunning, TotalObjects: 10},
+	})
+	g.Expect(updated).Should(BeTrue())
+	g.Expect(status.ReplicaStatuses).Should(HaveLen(2))
+
+	// the same status should not be updated again
+	updated = updateBackupStatus(status, &BackupUpdateStatus{
+		ReplicaStatus: &v1alpha1.BackupReplicaStatus{Index: 0, Phase: v1alpha1.BackupRunning, TotalObjects: 10},
+	})
+	g.Expect(updated).Should(BeFalse())
+
+	// the status of the replica with the same index is replaced
+	updated = updateBackupStatus(status, &BackupUpdateStatus{
+		ReplicaStatus: &v1alpha1.BackupReplicaStatus{Index: 0, Phase: v1alpha1.BackupComplete, TotalObjects: 10, CopiedObjects: 10},
+	})
+	g.Expect(updated).Should(BeTrue())
+	g.Expect(status.ReplicaStatuses).Should(Equal([]v1alpha1.BackupReplicaStatus{
+		{Index: 0, Phase: v1alpha1.BackupComplete, TotalObjects: 10, CopiedObjects: 10},
+		{Index: 1, Phase: v1alpha1.BackupRunning, TotalObjects: 10},
+	}))
+}
+
 func newUpdateBackupStatus() *BackupUpdateStatus {
 	ts := "421762809912885269"
 	start, _ := time.Parse(time.RFC3339, "2020-12-25T21:46:59Z")
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/backupschedule"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -108,10 +109,11 @@ func (c *Controller) processNextWorkItem() bool {
 }
 
 // sync syncs the given backupSchedule.
-func (c *Controller) sync(key string) error {
+func (c *Controller) sync(key string) (err error) {
 	startTime := time.Now()
 	defer func() {
 		klog.V(4).Infof("Finished syncing BackupSchedule %q (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("BackupSchedule", startTime, err)
 	}()
 
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
@@ -121,6 +123,8 @@ func (c *Controller) sync(key string) error {
 	bs, err := c.deps.BackupScheduleLister.BackupSchedules(ns).Get(name)
 	if errors.IsNotFound(err) {
 		klog.Infof("BackupSchedule has been deleted %v", key)
+		metrics.BackupScheduleLastSuccessTimestamp.DeleteLabelValues(ns, name)
+		metrics.BackupScheduleLag.DeleteLabelValues(ns, name)
 		return nil
 	}
 	if err != nil {
@@ -18,9 +18,12 @@ import (
 	stderrs "errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/dustin/go-humanize"
+	perrors "github.com/pingcap/errors"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	"github.com/pingcap/tidb-operator/pkg/scheme"
 	"github.com/pingcap/tidb-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
@@ -61,6 +64,9 @@ var (
 
 	// tidbNGMonitoringKind cotnains the schema.GroupVersionKind for TidbNGMonitoring controller type.
 	tidbNGMonitoringKind = v1alpha1.SchemeGroupVersion.WithKind("TidbNGMonitoring")
+
+	// tidbClusterReplicationKind cotnains the schema.GroupVersionKind for TidbClusterReplication controller type.
+	tidbClusterReplicationKind = v1alpha1.SchemeGroupVersion.WithKind("TidbClusterReplication")
 )
 
 // RequeueError is used to requeue the item, this error type should't be considered as a real error
@@ -103,6 +109,26 @@ func IsIgnoreError(err error) bool {
 	return ok
 }
 
+// GetReconcileErrorType returns the type of the error returned by a reconcile, which is one of
+// requeue, ignore and error
+func GetReconcileErrorType(err error) string {
+	if perrors.Find(err, IsRequeueError) != nil {
+		return "requeue"
+	}
+	if perrors.Find(err, IsIgnoreError) != nil {
+		return "ignore"
+	}
+	return "error"
+}
+
+// ObserveReconcile records the duration and the error of a reconcile of the controller
+func ObserveReconcile(controllerName string, startTime time.Time, err error) {
+	metrics.ReconcileDuration.WithLabelValues(controllerName).Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		metrics.ReconcileErrors.WithLabelValues(controllerName, GetReconcileErrorType(err)).Inc()
+	}
+}
+
 // GetOwnerRef returns TidbCluster's OwnerReference
 func GetOwnerRef(tc *v1alpha1.TidbCluster) metav1.OwnerReference {
 	controller := true
@@ -212,6 +238,19 @@ func GetTiDBNGMonitoringOwnerRef(tngm *v1alpha1.TidbNGMonitoring) metav1.OwnerRe
 	}
 }
 
+func GetTidbClusterReplicationOwnerRef(tcr *v1alpha1.TidbClusterReplication) metav1.OwnerReference {
+	controller := true
+	blockOwnerDeletion := true
+	return metav1.OwnerReference{
+		APIVersion:         tidbClusterReplicationKind.GroupVersion().String(),
+		Kind:               tidbClusterReplicationKind.Kind,
+		Name:               tcr.GetName(),
+		UID:                tcr.GetUID(),
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
+
 // GetServiceType returns member's service type
 func GetServiceType(services []v1alpha1.Service, serviceName string) corev1.ServiceType {
 	for _, svc := range services {
@@ -265,6 +304,16 @@ func PDPeerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-pd-peer", clusterName)
 }
 
+// PDMSMemberName returns the member name of the PD microservice
+func PDMSMemberName(clusterName, name string) string {
+	return fmt.Sprintf("%s-%s", clusterName, name)
+}
+
+// PDMSPeerMemberName returns the peer service name of the PD microservice
+func PDMSPeerMemberName(clusterName, name string) string {
+	return fmt.Sprintf("%s-%s-peer", clusterName, name)
+}
+
 // TiKVMemberName returns tikv member name
 func TiKVMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tikv", clusterName)
@@ -295,6 +344,16 @@ func TiCDCPeerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-ticdc-peer", clusterName)
 }
 
+// TiProxyMemberName returns tiproxy member name
+func TiProxyMemberName(clusterName string) string {
+	return fmt.Sprintf("%s-tiproxy", clusterName)
+}
+
+// TiProxyPeerMemberName returns tiproxy peer service name
+func TiProxyPeerMemberName(clusterName string) string {
+	return fmt.Sprintf("%s-tiproxy-peer", clusterName)
+}
+
 // TiDBMemberName returns tidb member name
 func TiDBMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tidb", clusterName)
@@ -310,6 +369,11 @@ func PumpMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-pump", clusterName)
 }
 
+// DrainerMemberName returns drainer member name
+func DrainerMemberName(clusterName string) string {
+	return fmt.Sprintf("%s-drainer", clusterName)
+}
+
 // TiDBInitializerMemberName returns TiDBInitializer member name
 func TiDBInitializerMemberName(clusterName string) string {
 	return fmt.Sprintf("%s-tidb-initializer", clusterName)
@@ -48,6 +48,15 @@ func TestIgnoreError(t *testing.T) {
 	g.Expect(IsIgnoreError(fmt.Errorf("i am not an ignore error"))).To(BeFalse())
 }
 
+func TestGetReconcileErrorType(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(GetReconcileErrorType(RequeueErrorf("requeue"))).To(Equal("requeue"))
+	g.Expect(GetReconcileErrorType(fmt.Errorf("wrapped: %w", RequeueErrorf("requeue")))).To(Equal("requeue"))
+	g.Expect(GetReconcileErrorType(IgnoreErrorf("ignore"))).To(Equal("ignore"))
+	g.Expect(GetReconcileErrorType(fmt.Errorf("failed"))).To(Equal("error"))
+}
+
 func TestGetOwnerRef(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -170,6 +170,8 @@ type Controls struct {
 	TiDBClusterControl TidbClusterControlInterface
 	DMClusterControl   DMClusterControlInterface
 	CDCControl         TiCDCControlInterface
+	ChangefeedControl  TiCDCChangefeedControlInterface
+	TiProxyControl     TiProxyControlInterface
 	TiDBControl        TiDBControlInterface
 	BackupControl      BackupControlInterface
 }
@@ -190,29 +192,31 @@ type Dependencies struct {
 	Recorder                       record.EventRecorder
 
 	// Listers
-	ServiceLister               corelisterv1.ServiceLister
-	EndpointLister              corelisterv1.EndpointsLister
-	PVCLister                   corelisterv1.PersistentVolumeClaimLister
-	PVLister                    corelisterv1.PersistentVolumeLister
-	PodLister                   corelisterv1.PodLister
-	NodeLister                  corelisterv1.NodeLister
-	SecretLister                corelisterv1.SecretLister
-	ConfigMapLister             corelisterv1.ConfigMapLister
-	StatefulSetLister           appslisters.StatefulSetLister
-	DeploymentLister            appslisters.DeploymentLister
-	JobLister                   batchlisters.JobLister
-	IngressLister               networklister.IngressLister
-	IngressV1Beta1Lister        extensionslister.IngressLister // in order to be compatibility with kubernetes which less than v1.19
-	StorageClassLister          storagelister.StorageClassLister
-	TiDBClusterLister           listers.TidbClusterLister
-	TiDBClusterAutoScalerLister listers.TidbClusterAutoScalerLister
-	DMClusterLister             listers.DMClusterLister
-	BackupLister                listers.BackupLister
-	RestoreLister               listers.RestoreLister
-	BackupScheduleLister        listers.BackupScheduleLister
-	TiDBInitializerLister       listers.TidbInitializerLister
-	TiDBMonitorLister           listers.TidbMonitorLister
-	TiDBNGMonitoringLister      listers.TidbNGMonitoringLister
+	ServiceLister                corelisterv1.ServiceLister
+	EndpointLister               corelisterv1.EndpointsLister
+	PVCLister                    corelisterv1.PersistentVolumeClaimLister
+	PVLister                     corelisterv1.PersistentVolumeLister
+	PodLister                    corelisterv1.PodLister
+	NodeLister                   corelisterv1.NodeLister
+	SecretLister                 corelisterv1.SecretLister
+	ConfigMapLister              corelisterv1.ConfigMapLister
+	StatefulSetLister            appslisters.StatefulSetLister
+	DeploymentLister             appslisters.DeploymentLister
+	JobLister                    batchlisters.JobLister
+	IngressLister                networklister.IngressLister
+	IngressV1Beta1Lister         extensionslister.IngressLister // in order to be compatibility with kubernetes which less than v1.19
+	StorageClassLister           storagelister.StorageClassLister
+	TiDBClusterLister            listers.TidbClusterLister
+	TiDBClusterAutoScalerLister  listers.TidbClusterAutoScalerLister
+	DMClusterLister              listers.DMClusterLister
+	BackupLister                 listers.BackupLister
+	RestoreLister                listers.RestoreLister
+	BackupScheduleLister         listers.BackupScheduleLister
+	TiDBInitializerLister        listers.TidbInitializerLister
+	TiDBMonitorLister            listers.TidbMonitorLister
+	TiDBNGMonitoringLister       listers.TidbNGMonitoringLister
+	TiCDCChangefeedLister        listers.TiCDCChangefeedLister
+	TiDBClusterReplicationLister listers.TidbClusterReplicationLister
 
 	// Controls
 	Controls
@@ -264,6 +268,8 @@ func newRealControls(
 		TiDBClusterControl: NewRealTidbClusterControl(clientset, tidbClusterLister, recorder),
 		DMClusterControl:   NewRealDMClusterControl(clientset, dmClusterLister, recorder),
 		CDCControl:         NewDefaultTiCDCControl(secretLister),
+		ChangefeedControl:  NewDefaultTiCDCChangefeedControl(secretLister),
+		TiProxyControl:     NewDefaultTiProxyControl(secretLister),
 		TiDBControl:        NewDefaultTiDBControl(secretLister),
 		BackupControl:      NewRealBackupControl(clientset, recorder),
 	}
@@ -323,29 +329,31 @@ func newDependencies(
 		Recorder:                       recorder,
 
 		// Listers
-		ServiceLister:               kubeInformerFactory.Core().V1().Services().Lister(),
-		EndpointLister:              kubeInformerFactory.Core().V1().Endpoints().Lister(),
-		PVCLister:                   kubeInformerFactory.Core().V1().PersistentVolumeClaims().Lister(),
-		PVLister:                    pvLister,
-		PodLister:                   kubeInformerFactory.Core().V1().Pods().Lister(),
-		NodeLister:                  nodeLister,
-		SecretLister:                kubeInformerFactory.Core().V1().Secrets().Lister(),
-		ConfigMapLister:             labelFilterKubeInformerFactory.Core().V1().ConfigMaps().Lister(),
-		StatefulSetLister:           kubeInformerFactory.Apps().V1().StatefulSets().Lister(),
-		DeploymentLister:            kubeInformerFactory.Apps().V1().Deployments().Lister(),
-		StorageClassLister:          scLister,
-		JobLister:                   kubeInformerFactory.Batch().V1().Jobs().Lister(),
-		IngressLister:               ingLister,
-		IngressV1Beta1Lister:        ingv1beta1Lister,
-		TiDBClusterLister:           informerFactory.Pingcap().V1alpha1().TidbClusters().Lister(),
-		TiDBClusterAutoScalerLister: informerFactory.Pingcap().V1alpha1().TidbClusterAutoScalers().Lister(),
-		DMClusterLister:             informerFactory.Pingcap().V1alpha1().DMClusters().Lister(),
-		BackupLister:                informerFactory.Pingcap().V1alpha1().Backups().Lister(),
-		RestoreLister:               informerFactory.Pingcap().V1alpha1().Restores().Lister(),
-		BackupScheduleLister:        informerFactory.Pingcap().V1alpha1().BackupSchedules().Lister(),
-		TiDBInitializerLister:       informerFactory.Pingcap().V1alpha1().TidbInitializers().Lister(),
-		TiDBMonitorLister:           informerFactory.Pingcap().V1alpha1().TidbMonitors().Lister(),
-		TiDBNGMonitoringLister:      informerFactory.Pingcap().V1alpha1().TidbNGMonitorings().Lister(),
+		ServiceLister:                kubeInformerFactory.Core().V1().Services().Lister(),
+		EndpointLister:               kubeInformerFactory.Core().V1().Endpoints().Lister(),
+		PVCLister:                    kubeInformerFactory.Core().V1().PersistentVolumeClaims().Lister(),
+		PVLister:                     pvLister,
+		PodLister:                    kubeInformerFactory.Core().V1().Pods().Lister(),
+		NodeLister:                   nodeLister,
+		SecretLister:                 kubeInformerFactory.Core().V1().Secrets().Lister(),
+		ConfigMapLister:              labelFilterKubeInformerFactory.Core().V1().ConfigMaps().Lister(),
+		StatefulSetLister:            kubeInformerFactory.Apps().V1().StatefulSets().Lister(),
+		DeploymentLister:             kubeInformerFactory.Apps().V1().Deployments().Lister(),
+		StorageClassLister:           scLister,
+		JobLister:                    kubeInformerFactory.Batch().V1().Jobs().Lister(),
+		IngressLister:                ingLister,
+		IngressV1Beta1Lister:         ingv1beta1Lister,
+		TiDBClusterLister:            informerFactory.Pingcap().V1alpha1().TidbClusters().Lister(),
+		TiDBClusterAutoScalerLister:  informerFactory.Pingcap().V1alpha1().TidbClusterAutoScalers().Lister(),
+		DMClusterLister:              informerFactory.Pingcap().V1alpha1().DMClusters().Lister(),
+		BackupLister:                 informerFactory.Pingcap().V1alpha1().Backups().Lister(),
+		RestoreLister:                informerFactory.Pingcap().V1alpha1().Restores().Lister(),
+		BackupScheduleLister:         informerFactory.Pingcap().V1alpha1().BackupSchedules().Lister(),
+		TiDBInitializerLister:        informerFactory.Pingcap().V1alpha1().TidbInitializers().Lister(),
+		TiDBMonitorLister:            informerFactory.Pingcap().V1alpha1().TidbMonitors().Lister(),
+		TiDBNGMonitoringLister:       informerFactory.Pingcap().V1alpha1().TidbNGMonitorings().Lister(),
+		TiCDCChangefeedLister:        informerFactory.Pingcap().V1alpha1().TiCDCChangefeeds().Lister(),
+		TiDBClusterReplicationLister: informerFactory.Pingcap().V1alpha1().TidbClusterReplications().Lister(),
 	}, nil
 }
 
@@ -413,6 +421,8 @@ func newFakeControl(kubeClientset kubernetes.Interface, informerFactory informer
 		TiFlashControl:     tiflashapi.NewFakeTiFlashControl(kubeInformerFactory.Core().V1().Secrets().Lister()),
 		TiDBClusterControl: NewFakeTidbClusterControl(informerFactory.Pingcap().V1alpha1().TidbClusters()),
 		CDCControl:         NewFakeTiCDCControl(),
+		ChangefeedControl:  NewFakeTiCDCChangefeedControl(),
+		TiProxyControl:     NewFakeTiProxyControl(),
 		TiDBControl:        NewFakeTiDBControl(kubeInformerFactory.Core().V1().Secrets().Lister()),
 		BackupControl:      NewFakeBackupControl(informerFactory.Pingcap().V1alpha1().Backups()),
 	}
@@ -126,10 +126,11 @@ func (c *Controller) processNextWorkItem() bool {
 }
 
 // sync syncs the given dmcluster.
-func (c *Controller) sync(key string) error {
+func (c *Controller) sync(key string) (err error) {
 	startTime := time.Now()
 	defer func() {
 		klog.V(4).Infof("Finished syncing DMCluster %q (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("DMCluster", startTime, err)
 	}()
 
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
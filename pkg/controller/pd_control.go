@@ -58,6 +58,11 @@ func GetPDClient(pdControl pdapi.PDControlInterface, tc *v1alpha1.TidbCluster) p
 	return pdClient
 }
 
+// GetPDMSClient returns the PDMSClient of the pd microservice of the TidbCluster
+func GetPDMSClient(pdControl pdapi.PDControlInterface, tc *v1alpha1.TidbCluster, serviceName string) pdapi.PDMSClient {
+	return pdControl.GetPDMSClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), serviceName, tc.IsTLSClusterEnabled())
+}
+
 // NewFakePDClient creates a fake pdclient that is set as the pd client
 func NewFakePDClient(pdControl *pdapi.FakePDControl, tc *v1alpha1.TidbCluster) *pdapi.FakePDClient {
 	pdClient := pdapi.NewFakePDClient()
@@ -75,3 +80,10 @@ func NewFakePDClientWithAddress(pdControl *pdapi.FakePDControl, peerURL string)
 	pdControl.SetPDClientWithAddress(peerURL, pdClient)
 	return pdClient
 }
+
+// NewFakePDMSClient creates a fake pd microservice client that is set as the client of the service
+func NewFakePDMSClient(pdControl *pdapi.FakePDControl, tc *v1alpha1.TidbCluster, serviceName string) *pdapi.FakePDMSClient {
+	pdMSClient := pdapi.NewFakePDMSClient()
+	pdControl.SetPDMSClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), serviceName, pdMSClient)
+	return pdMSClient
+}
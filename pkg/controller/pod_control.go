@@ -33,6 +33,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 )
 
 // PodControlInterface manages Pods used in TidbCluster
@@ -41,6 +42,7 @@ type PodControlInterface interface {
 	UpdateMetaInfo(*v1alpha1.TidbCluster, *corev1.Pod) (*corev1.Pod, error)
 	DeletePod(runtime.Object, *corev1.Pod) error
 	UpdatePod(runtime.Object, *corev1.Pod) (*corev1.Pod, error)
+	UpdatePodStatus(runtime.Object, *corev1.Pod) (*corev1.Pod, error)
 }
 
 type realPodControl struct {
@@ -103,6 +105,45 @@ func (c *realPodControl) UpdatePod(controller runtime.Object, pod *corev1.Pod) (
 	return updatePod, err
 }
 
+func (c *realPodControl) UpdatePodStatus(controller runtime.Object, pod *corev1.Pod) (*corev1.Pod, error) {
+	controllerMo, ok := controller.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a metav1.Object, cannot call setControllerReference", controller)
+	}
+	kind := controller.GetObjectKind().GroupVersionKind().Kind
+	name := controllerMo.GetName()
+	namespace := controllerMo.GetNamespace()
+	podName := pod.GetName()
+
+	conditions := pod.Status.Conditions
+
+	var updatePod *corev1.Pod
+	// don't wait due to limited number of clients, but backoff after the default number of steps
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+		updatePod, updateErr = c.kubeCli.CoreV1().Pods(namespace).UpdateStatus(context.TODO(), pod, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.Infof("Pod: [%s/%s] status updated successfully, %s: [%s/%s]", namespace, podName, kind, namespace, name)
+			return nil
+		}
+		klog.Errorf("failed to update status of Pod: [%s/%s], error: %v", namespace, podName, updateErr)
+
+		if updated, err := c.podLister.Pods(namespace).Get(podName); err == nil {
+			// make a copy so we don't mutate the shared cache
+			pod = updated.DeepCopy()
+			// only the conditions are managed by the operator, the others are kept as reported by kubelet
+			for i := range conditions {
+				podutil.UpdatePodCondition(&pod.Status, &conditions[i])
+			}
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated Pod %s/%s from lister: %v", namespace, podName, err))
+		}
+
+		return updateErr
+	})
+	return updatePod, err
+}
+
 func (c *realPodControl) UpdateMetaInfo(tc *v1alpha1.TidbCluster, pod *corev1.Pod) (*corev1.Pod, error) {
 	ns := pod.GetNamespace()
 	podName := pod.GetName()
@@ -348,4 +389,14 @@ func (c *FakePodControl) UpdatePod(_ runtime.Object, pod *corev1.Pod) (*corev1.P
 	return pod, c.PodIndexer.Update(pod)
 }
 
+func (c *FakePodControl) UpdatePodStatus(_ runtime.Object, pod *corev1.Pod) (*corev1.Pod, error) {
+	defer c.updatePodTracker.Inc()
+	if c.updatePodTracker.ErrorReady() {
+		defer c.updatePodTracker.Reset()
+		return nil, c.updatePodTracker.GetError()
+	}
+
+	return pod, c.PodIndexer.Update(pod)
+}
+
 var _ PodControlInterface = &FakePodControl{}
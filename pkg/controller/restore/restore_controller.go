@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/backup/restore"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -56,9 +57,15 @@ func NewController(deps *controller.Dependencies) *Controller {
 	restoreInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: c.updateRestore,
 		UpdateFunc: func(old, cur interface{}) {
+			observeRestoreStatusChange(old.(*v1alpha1.Restore), cur.(*v1alpha1.Restore))
 			c.updateRestore(cur)
 		},
-		DeleteFunc: c.enqueueRestore,
+		DeleteFunc: func(obj interface{}) {
+			if restore, ok := obj.(*v1alpha1.Restore); ok {
+				deleteRestoreProgressMetrics(restore)
+			}
+			c.enqueueRestore(obj)
+		},
 	})
 	return c
 }
@@ -109,10 +116,11 @@ func (c *Controller) processNextWorkItem() bool {
 }
 
 // sync syncs the given restore.
-func (c *Controller) sync(key string) error {
+func (c *Controller) sync(key string) (err error) {
 	startTime := time.Now()
 	defer func() {
 		klog.V(4).Infof("Finished syncing Restore %q (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("Restore", startTime, err)
 	}()
 
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
@@ -139,6 +147,7 @@ func (c *Controller) updateRestore(cur interface{}) {
 	newRestore := cur.(*v1alpha1.Restore)
 	ns := newRestore.GetNamespace()
 	name := newRestore.GetName()
+	observeRestoreProgress(newRestore)
 
 	if v1alpha1.IsRestoreInvalid(newRestore) {
 		klog.V(4).Infof("restore %s/%s is Invalid, skipping.", ns, name)
@@ -155,6 +164,13 @@ func (c *Controller) updateRestore(cur interface{}) {
 		return
 	}
 
+	if v1alpha1.IsRestoreDataComplete(newRestore) {
+		// the volume-snapshot restore is finished by the restore manager after BR restores the data
+		klog.V(4).Infof("restore object %s/%s data complete, enqueue", ns, name)
+		c.enqueueRestore(newRestore)
+		return
+	}
+
 	if v1alpha1.IsRestoreScheduled(newRestore) || v1alpha1.IsRestoreRunning(newRestore) {
 		selector, err := label.NewRestore().Instance(newRestore.GetInstanceName()).RestoreJob().Restore(name).Selector()
 		if err != nil {
@@ -169,6 +185,11 @@ func (c *Controller) updateRestore(cur interface{}) {
 		for _, pod := range pods {
 			if pod.Status.Phase == corev1.PodFailed {
 				klog.Infof("restore %s/%s has failed pod %s.", ns, name, pod.Name)
+				if newRestore.Spec.BackoffRetryPolicy != nil {
+					// the failure is classified and retried by the restore manager
+					c.enqueueRestore(newRestore)
+					break
+				}
 				err = c.control.UpdateCondition(newRestore, &v1alpha1.RestoreCondition{
 					Type:    v1alpha1.RestoreFailed,
 					Status:  corev1.ConditionTrue,
@@ -189,6 +210,51 @@ func (c *Controller) updateRestore(cur interface{}) {
 	c.enqueueRestore(newRestore)
 }
 
+// observeRestoreProgress exports the progress reported by BR as metrics while the restore is running,
+// the processed bytes are kept after the restore finishes as BR reports them at the end
+func observeRestoreProgress(restore *v1alpha1.Restore) {
+	progress := restore.Status.Progress
+	if restore.DeletionTimestamp != nil || progress == nil {
+		deleteRestoreProgressMetrics(restore)
+		return
+	}
+	ns, name := restore.GetNamespace(), restore.GetName()
+	if progress.ProcessedBytes > 0 {
+		metrics.RestoreProcessedBytes.WithLabelValues(ns, name).Set(float64(progress.ProcessedBytes))
+	}
+	if restore.Status.Phase != v1alpha1.RestoreRunning {
+		metrics.RestoreProgress.DeleteLabelValues(ns, name)
+		metrics.RestoreProgressRemainingSeconds.DeleteLabelValues(ns, name)
+		return
+	}
+	metrics.RestoreProgress.WithLabelValues(ns, name).Set(float64(progress.Percentage))
+	if remaining, err := time.ParseDuration(progress.Remaining); err == nil {
+		metrics.RestoreProgressRemainingSeconds.WithLabelValues(ns, name).Set(remaining.Seconds())
+	} else {
+		metrics.RestoreProgressRemainingSeconds.DeleteLabelValues(ns, name)
+	}
+}
+
+// observeRestoreStatusChange counts the phase transitions of the restore. Most transitions are
+// updated by the restore jobs, so they are observed by the informer instead of the status updater.
+func observeRestoreStatusChange(old, cur *v1alpha1.Restore) {
+	ns := cur.GetNamespace()
+	if cur.Status.Phase == "" || old.Status.Phase == cur.Status.Phase {
+		return
+	}
+	metrics.RestorePhaseTransitions.WithLabelValues(ns, string(cur.Status.Phase)).Inc()
+	if cur.Status.Phase == v1alpha1.RestoreComplete && !cur.Status.TimeStarted.IsZero() && !cur.Status.TimeCompleted.IsZero() {
+		duration := cur.Status.TimeCompleted.Sub(cur.Status.TimeStarted.Time)
+		metrics.RestoreDuration.WithLabelValues(ns).Observe(duration.Seconds())
+	}
+}
+
+func deleteRestoreProgressMetrics(restore *v1alpha1.Restore) {
+	metrics.RestoreProgress.DeleteLabelValues(restore.GetNamespace(), restore.GetName())
+	metrics.RestoreProgressRemainingSeconds.DeleteLabelValues(restore.GetNamespace(), restore.GetName())
+	metrics.RestoreProcessedBytes.DeleteLabelValues(restore.GetNamespace(), restore.GetName())
+}
+
 // enqueueRestore enqueues the given restore in the work queue.
 func (c *Controller) enqueueRestore(obj interface{}) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
@@ -18,11 +18,14 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
@@ -101,6 +104,13 @@ func TestRestoreControllerUpdateRestore(t *testing.T) {
 				g.Expect(rtc.queue.Len()).To(Equal(0))
 			},
 		},
+		{
+			name:          "restore has restored the data of volume snapshots",
+			conditionType: v1alpha1.RestoreDataComplete,
+			expectFn: func(g *GomegaWithT, rtc *Controller) {
+				g.Expect(rtc.queue.Len()).To(Equal(1))
+			},
+		},
 		{
 			name:          "restore is newly created",
 			conditionType: "", // no condition
@@ -284,3 +294,29 @@ func newRestore() *v1alpha1.Restore {
 		},
 	}
 }
+
+func TestObserveRestoreProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+	restore := newRestore()
+	restore.Namespace = "observe-progress"
+	restore.Status.Phase = v1alpha1.RestoreRunning
+	restore.Status.Progress = &v1alpha1.BRProgress{Step: "Full Restore", Percentage: 50, Remaining: "1m30s"}
+	ns, name := restore.Namespace, restore.Name
+
+	observeRestoreProgress(restore)
+	g.Expect(testutil.ToFloat64(metrics.RestoreProgress.WithLabelValues(ns, name))).To(Equal(float64(50)))
+	g.Expect(testutil.ToFloat64(metrics.RestoreProgressRemainingSeconds.WithLabelValues(ns, name))).To(Equal(float64(90)))
+	g.Expect(metrics.RestoreProcessedBytes.DeleteLabelValues(ns, name)).To(BeFalse())
+
+	// the processed bytes are reported in the success summary of BR and kept after the restore is complete
+	restore.Status.Progress = &v1alpha1.BRProgress{Step: "Full Restore", Percentage: 100, Remaining: "0s", ProcessedBytes: 1 << 20}
+	restore.Status.Phase = v1alpha1.RestoreComplete
+	observeRestoreProgress(restore)
+	g.Expect(metrics.RestoreProgress.DeleteLabelValues(ns, name)).To(BeFalse())
+	g.Expect(metrics.RestoreProgressRemainingSeconds.DeleteLabelValues(ns, name)).To(BeFalse())
+	g.Expect(testutil.ToFloat64(metrics.RestoreProcessedBytes.WithLabelValues(ns, name))).To(Equal(float64(1 << 20)))
+
+	restore.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	observeRestoreProgress(restore)
+	g.Expect(metrics.RestoreProcessedBytes.DeleteLabelValues(ns, name)).To(BeFalse())
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/client/clientset/versioned"
 	informers "github.com/pingcap/tidb-operator/pkg/client/informers/externalversions/pingcap/v1alpha1"
 	listers "github.com/pingcap/tidb-operator/pkg/client/listers/pingcap/v1alpha1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -39,9 +40,14 @@ type RestoreUpdateStatus struct {
 	TimeCompleted *metav1.Time
 	// CommitTs is the snapshot time point of tidb cluster.
 	CommitTs *string
+	// Progress is the progress of the running restore reported by BR.
+	Progress *v1alpha1.BRProgress
+	// BackoffRetryRecord is the record of a retry of the failed restore job.
+	BackoffRetryRecord *v1alpha1.BackoffRetryRecord
 }
 
 // RestoreConditionUpdaterInterface enables updating Restore conditions.
+// The condition can be nil if only the status fields need to be updated.
 type RestoreConditionUpdaterInterface interface {
 	Update(restore *v1alpha1.Restore, condition *v1alpha1.RestoreCondition, newStatus *RestoreUpdateStatus) error
 }
@@ -70,9 +76,9 @@ func (u *realRestoreConditionUpdater) Update(restore *v1alpha1.Restore, conditio
 	var isUpdate bool
 	// try best effort to guarantee restore is updated.
 	err := retry.OnError(retry.DefaultRetry, func(e error) bool { return e != nil }, func() error {
-		updateRestoreStatus(&restore.Status, newStatus)
-		isUpdate = v1alpha1.UpdateRestoreCondition(&restore.Status, condition)
-		if isUpdate {
+		isStatusUpdate := updateRestoreStatus(&restore.Status, newStatus)
+		isUpdate = condition != nil && v1alpha1.UpdateRestoreCondition(&restore.Status, condition)
+		if isUpdate || isStatusUpdate {
 			_, updateErr := u.cli.PingcapV1alpha1().Restores(ns).Update(context.TODO(), restore, metav1.UpdateOptions{})
 			if updateErr == nil {
 				klog.Infof("Restore: [%s/%s] updated successfully", ns, restoreName)
@@ -94,19 +100,32 @@ func (u *realRestoreConditionUpdater) Update(restore *v1alpha1.Restore, conditio
 
 // updateRestoreStatus updates existing Restore status
 // from the fields in RestoreUpdateStatus.
-func updateRestoreStatus(status *v1alpha1.RestoreStatus, newStatus *RestoreUpdateStatus) {
+// Returns true if any of the status fields has changed.
+func updateRestoreStatus(status *v1alpha1.RestoreStatus, newStatus *RestoreUpdateStatus) bool {
 	if newStatus == nil {
-		return
+		return false
 	}
-	if newStatus.TimeStarted != nil {
+	isUpdate := false
+	if newStatus.TimeStarted != nil && !status.TimeStarted.Equal(newStatus.TimeStarted) {
 		status.TimeStarted = *newStatus.TimeStarted
+		isUpdate = true
 	}
-	if newStatus.TimeCompleted != nil {
+	if newStatus.TimeCompleted != nil && !status.TimeCompleted.Equal(newStatus.TimeCompleted) {
 		status.TimeCompleted = *newStatus.TimeCompleted
+		isUpdate = true
 	}
-	if newStatus.CommitTs != nil {
+	if newStatus.CommitTs != nil && status.CommitTs != *newStatus.CommitTs {
 		status.CommitTs = *newStatus.CommitTs
+		isUpdate = true
 	}
+	if newStatus.Progress != nil && !apiequality.Semantic.DeepEqual(status.Progress, newStatus.Progress) {
+		status.Progress = newStatus.Progress
+		isUpdate = true
+	}
+	if newStatus.BackoffRetryRecord != nil && updateBackoffRetryStatus(&status.BackoffRetryStatus, newStatus.BackoffRetryRecord) {
+		isUpdate = true
+	}
+	return isUpdate
 }
 
 var _ RestoreConditionUpdaterInterface = &realRestoreConditionUpdater{}
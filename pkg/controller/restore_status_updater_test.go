@@ -57,6 +57,22 @@ func TestUpdateRestoreStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateRestoreProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+	status := newRestoreStatus()
+
+	progress := &v1alpha1.BRProgress{Step: "Full Restore", Percentage: 30, Completed: 3, Total: 10}
+	g.Expect(updateRestoreStatus(status, &RestoreUpdateStatus{Progress: progress})).Should(BeTrue())
+	g.Expect(status.Progress).Should(Equal(progress))
+
+	// the same progress should not be updated again
+	g.Expect(updateRestoreStatus(status, &RestoreUpdateStatus{Progress: progress.DeepCopy()})).Should(BeFalse())
+
+	progress = &v1alpha1.BRProgress{Step: "Checksum", Percentage: 10, Completed: 1, Total: 10}
+	g.Expect(updateRestoreStatus(status, &RestoreUpdateStatus{Progress: progress})).Should(BeTrue())
+	g.Expect(status.Progress.Step).Should(Equal("Checksum"))
+}
+
 func newUpdateRestoreStatus() *RestoreUpdateStatus {
 	ts := "421762809912885269"
 	start, _ := time.Parse(time.RFC3339, "2020-12-25T21:46:59Z")
@@ -0,0 +1,308 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
+)
+
+// errChangefeedNotExists is the error code returned by ticdc if the changefeed does not exist
+const errChangefeedNotExists = "ErrChangeFeedNotExists"
+
+// ChangefeedConfig is the config to create or update a changefeed by the open API v2 of ticdc
+type ChangefeedConfig struct {
+	ID            string                 `json:"changefeed_id,omitempty"`
+	StartTS       uint64                 `json:"start_ts,omitempty"`
+	TargetTS      uint64                 `json:"target_ts,omitempty"`
+	SinkURI       string                 `json:"sink_uri,omitempty"`
+	ReplicaConfig map[string]interface{} `json:"replica_config,omitempty"`
+}
+
+// ChangefeedError is the error of a changefeed returned by the open API v2 of ticdc
+type ChangefeedError struct {
+	Addr    string `json:"addr"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ChangefeedInfo is the changefeed returned by the open API v2 of ticdc
+type ChangefeedInfo struct {
+	ID           string           `json:"id"`
+	State        string           `json:"state"`
+	CheckpointTS uint64           `json:"checkpoint_ts"`
+	Error        *ChangefeedError `json:"error,omitempty"`
+}
+
+// TiCDCChangefeedControlInterface is the interface that knows how to manage the changefeeds of ticdc
+type TiCDCChangefeedControlInterface interface {
+	// GetChangefeed returns the changefeed, it returns nil if the changefeed does not exist
+	GetChangefeed(tc *v1alpha1.TidbCluster, id string) (*ChangefeedInfo, error)
+	// CreateChangefeed creates a changefeed
+	CreateChangefeed(tc *v1alpha1.TidbCluster, cfg *ChangefeedConfig) error
+	// UpdateChangefeed updates a changefeed, the changefeed must be paused before it is updated
+	UpdateChangefeed(tc *v1alpha1.TidbCluster, cfg *ChangefeedConfig) error
+	// PauseChangefeed pauses a changefeed
+	PauseChangefeed(tc *v1alpha1.TidbCluster, id string) error
+	// ResumeChangefeed resumes a paused changefeed
+	ResumeChangefeed(tc *v1alpha1.TidbCluster, id string) error
+	// RemoveChangefeed removes a changefeed, it succeeds if the changefeed does not exist
+	RemoveChangefeed(tc *v1alpha1.TidbCluster, id string) error
+}
+
+// defaultTiCDCChangefeedControl is default implementation of TiCDCChangefeedControlInterface.
+type defaultTiCDCChangefeedControl struct {
+	httpClient
+	// for unit test only
+	testURL string
+}
+
+// NewDefaultTiCDCChangefeedControl returns a defaultTiCDCChangefeedControl instance
+func NewDefaultTiCDCChangefeedControl(secretLister corelisterv1.SecretLister) *defaultTiCDCChangefeedControl {
+	return &defaultTiCDCChangefeedControl{httpClient: httpClient{secretLister: secretLister}}
+}
+
+func (c *defaultTiCDCChangefeedControl) GetChangefeed(tc *v1alpha1.TidbCluster, id string) (*ChangefeedInfo, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/api/v2/changefeeds/%s", c.getBaseURL(tc), id)
+	res, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer httputil.DeferClose(res.Body)
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 400 {
+		if strings.Contains(string(body), errChangefeedNotExists) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get ticdc changefeed %s failed, response %s:%v URL %s", id, string(body), res.StatusCode, url)
+	}
+
+	info := &ChangefeedInfo{}
+	if err := json.Unmarshal(body, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (c *defaultTiCDCChangefeedControl) CreateChangefeed(tc *v1alpha1.TidbCluster, cfg *ChangefeedConfig) error {
+	payload, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/api/v2/changefeeds", c.getBaseURL(tc))
+	return c.doRequest(tc, http.MethodPost, url, bytes.NewReader(payload))
+}
+
+func (c *defaultTiCDCChangefeedControl) UpdateChangefeed(tc *v1alpha1.TidbCluster, cfg *ChangefeedConfig) error {
+	// the ID is in the URL, and the start TS can not be updated
+	update := *cfg
+	update.ID = ""
+	update.StartTS = 0
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/api/v2/changefeeds/%s", c.getBaseURL(tc), cfg.ID)
+	return c.doRequest(tc, http.MethodPut, url, bytes.NewReader(payload))
+}
+
+func (c *defaultTiCDCChangefeedControl) PauseChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	url := fmt.Sprintf("%s/api/v2/changefeeds/%s/pause", c.getBaseURL(tc), id)
+	return c.doRequest(tc, http.MethodPost, url, nil)
+}
+
+func (c *defaultTiCDCChangefeedControl) ResumeChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	url := fmt.Sprintf("%s/api/v2/changefeeds/%s/resume", c.getBaseURL(tc), id)
+	return c.doRequest(tc, http.MethodPost, url, nil)
+}
+
+func (c *defaultTiCDCChangefeedControl) RemoveChangefeed(tc *v1alpha1.TidbCluster, id string) error {
+	url := fmt.Sprintf("%s/api/v2/changefeeds/%s", c.getBaseURL(tc), id)
+	err := c.doRequest(tc, http.MethodDelete, url, nil)
+	if err != nil && strings.Contains(err.Error(), errChangefeedNotExists) {
+		return nil
+	}
+	return err
+}
+
+func (c *defaultTiCDCChangefeedControl) doRequest(tc *v1alpha1.TidbCluster, method, url string, body io.Reader) error {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("%s ticdc changefeed failed, response %s:%v URL %s", method, string(resBody), res.StatusCode, url)
+	}
+	return nil
+}
+
+// getBaseURL returns the URL of the peer service of ticdc, any capture forwards the
+// request of the open API to the owner.
+func (c *defaultTiCDCChangefeedControl) getBaseURL(tc *v1alpha1.TidbCluster) string {
+	if c.testURL != "" {
+		return c.testURL
+	}
+
+	return fmt.Sprintf("%s://%s.%s:8301", tc.Scheme(), TiCDCPeerMemberName(tc.GetName()), tc.GetNamespace())
+}
+
+// FakeTiCDCChangefeedControl is a fake implementation of TiCDCChangefeedControlInterface,
+// it keeps the changefeeds in memory.
+type FakeTiCDCChangefeedControl struct {
+	mu          sync.Mutex
+	changefeeds map[string]*ChangefeedInfo
+	configs     map[string]*ChangefeedConfig
+	err         error
+}
+
+// NewFakeTiCDCChangefeedControl returns a FakeTiCDCChangefeedControl instance
+func NewFakeTiCDCChangefeedControl() *FakeTiCDCChangefeedControl {
+	return &FakeTiCDCChangefeedControl{
+		changefeeds: map[string]*ChangefeedInfo{},
+		configs:     map[string]*ChangefeedConfig{},
+	}
+}
+
+// SetError sets the error returned by all the requests
+func (c *FakeTiCDCChangefeedControl) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// SetChangefeed sets the changefeed returned by GetChangefeed
+func (c *FakeTiCDCChangefeedControl) SetChangefeed(info *ChangefeedInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changefeeds[info.ID] = info
+}
+
+// GetConfig returns the config the changefeed is created or updated with
+func (c *FakeTiCDCChangefeedControl) GetConfig(id string) *ChangefeedConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.configs[id]
+}
+
+func (c *FakeTiCDCChangefeedControl) GetChangefeed(_ *v1alpha1.TidbCluster, id string) (*ChangefeedInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	if info, ok := c.changefeeds[id]; ok {
+		copied := *info
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (c *FakeTiCDCChangefeedControl) CreateChangefeed(_ *v1alpha1.TidbCluster, cfg *ChangefeedConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	if _, ok := c.changefeeds[cfg.ID]; ok {
+		return fmt.Errorf("changefeed %s already exists", cfg.ID)
+	}
+	c.changefeeds[cfg.ID] = &ChangefeedInfo{ID: cfg.ID, State: string(v1alpha1.TiCDCChangefeedStateNormal), CheckpointTS: cfg.StartTS}
+	c.configs[cfg.ID] = cfg
+	return nil
+}
+
+func (c *FakeTiCDCChangefeedControl) UpdateChangefeed(_ *v1alpha1.TidbCluster, cfg *ChangefeedConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	info, ok := c.changefeeds[cfg.ID]
+	if !ok {
+		return fmt.Errorf("changefeed %s does not exist", cfg.ID)
+	}
+	if info.State != string(v1alpha1.TiCDCChangefeedStateStopped) {
+		return fmt.Errorf("changefeed %s is not paused", cfg.ID)
+	}
+	c.configs[cfg.ID] = cfg
+	return nil
+}
+
+func (c *FakeTiCDCChangefeedControl) PauseChangefeed(_ *v1alpha1.TidbCluster, id string) error {
+	return c.setState(id, v1alpha1.TiCDCChangefeedStateStopped)
+}
+
+func (c *FakeTiCDCChangefeedControl) ResumeChangefeed(_ *v1alpha1.TidbCluster, id string) error {
+	return c.setState(id, v1alpha1.TiCDCChangefeedStateNormal)
+}
+
+func (c *FakeTiCDCChangefeedControl) RemoveChangefeed(_ *v1alpha1.TidbCluster, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	delete(c.changefeeds, id)
+	delete(c.configs, id)
+	return nil
+}
+
+func (c *FakeTiCDCChangefeedControl) setState(id string, state v1alpha1.TiCDCChangefeedState) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	info, ok := c.changefeeds[id]
+	if !ok {
+		return fmt.Errorf("changefeed %s does not exist", id)
+	}
+	info.State = string(state)
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTiCDCChangefeedControl(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var requests []string
+	changefeeds := map[string]*ChangefeedInfo{}
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		requests = append(requests, request.Method+" "+request.URL.Path)
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		switch request.Method + " " + request.URL.Path {
+		case "POST /api/v2/changefeeds":
+			cfg := ChangefeedConfig{}
+			g.Expect(json.NewDecoder(request.Body).Decode(&cfg)).To(Succeed())
+			changefeeds[cfg.ID] = &ChangefeedInfo{ID: cfg.ID, State: "normal", CheckpointTS: cfg.StartTS}
+		case "PUT /api/v2/changefeeds/cf":
+			cfg := ChangefeedConfig{}
+			g.Expect(json.NewDecoder(request.Body).Decode(&cfg)).To(Succeed())
+			g.Expect(cfg.ID).To(BeEmpty())
+			g.Expect(cfg.StartTS).To(BeZero())
+			g.Expect(cfg.SinkURI).To(Equal("blackhole://new"))
+		case "GET /api/v2/changefeeds/cf", "DELETE /api/v2/changefeeds/cf":
+			info, ok := changefeeds["cf"]
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error_msg":"changefeed not exists","error_code":"CDC:ErrChangeFeedNotExists"}`))
+				return
+			}
+			if request.Method == http.MethodDelete {
+				delete(changefeeds, "cf")
+				return
+			}
+			data, err := json.Marshal(info)
+			g.Expect(err).NotTo(HaveOccurred())
+			w.Write(data)
+		case "POST /api/v2/changefeeds/cf/pause", "POST /api/v2/changefeeds/cf/resume":
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	defer svc.Close()
+
+	fakeClient := &fake.Clientset{}
+	informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+	control := NewDefaultTiCDCChangefeedControl(informer.Core().V1().Secrets().Lister())
+	control.testURL = svc.URL
+	tc := getTidbCluster()
+
+	info, err := control.GetChangefeed(tc, "cf")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info).To(BeNil())
+
+	g.Expect(control.CreateChangefeed(tc, &ChangefeedConfig{ID: "cf", StartTS: 100, SinkURI: "blackhole://"})).To(Succeed())
+	info, err = control.GetChangefeed(tc, "cf")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info).To(Equal(&ChangefeedInfo{ID: "cf", State: "normal", CheckpointTS: 100}))
+
+	g.Expect(control.PauseChangefeed(tc, "cf")).To(Succeed())
+	g.Expect(control.UpdateChangefeed(tc, &ChangefeedConfig{ID: "cf", StartTS: 100, SinkURI: "blackhole://new"})).To(Succeed())
+	g.Expect(control.ResumeChangefeed(tc, "cf")).To(Succeed())
+	g.Expect(control.RemoveChangefeed(tc, "cf")).To(Succeed())
+	// removing a changefeed that does not exist succeeds
+	g.Expect(control.RemoveChangefeed(tc, "cf")).To(Succeed())
+	g.Expect(control.PauseChangefeed(tc, "other")).NotTo(Succeed())
+
+	g.Expect(requests).To(Equal([]string{
+		"GET /api/v2/changefeeds/cf",
+		"POST /api/v2/changefeeds",
+		"GET /api/v2/changefeeds/cf",
+		"POST /api/v2/changefeeds/cf/pause",
+		"PUT /api/v2/changefeeds/cf",
+		"POST /api/v2/changefeeds/cf/resume",
+		"DELETE /api/v2/changefeeds/cf",
+		"DELETE /api/v2/changefeeds/cf",
+		"POST /api/v2/changefeeds/other/pause",
+	}))
+}
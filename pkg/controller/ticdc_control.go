@@ -14,11 +14,16 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
 )
 
 type CaptureStatus struct {
@@ -27,10 +32,32 @@ type CaptureStatus struct {
 	IsOwner bool   `json:"is_owner"`
 }
 
+// CaptureInfo is the capture returned by the open API of ticdc
+type CaptureInfo struct {
+	ID            string `json:"id"`
+	IsOwner       bool   `json:"is_owner"`
+	AdvertiseAddr string `json:"address"`
+}
+
+type drainCaptureRequest struct {
+	CaptureID string `json:"capture_id"`
+}
+
+type drainCaptureResp struct {
+	CurrentTableCount int `json:"current_table_count"`
+}
+
 // TiCDCControlInterface is the interface that knows how to manage ticdc captures
 type TiCDCControlInterface interface {
 	// GetStatus returns ticdc's status
 	GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*CaptureStatus, error)
+	// DrainCapture moves the tables of the capture to the other captures, it returns the count of the tables
+	// left in the capture, and whether the caller should retry later because the cluster is not ready to drain.
+	DrainCapture(tc *v1alpha1.TidbCluster, ordinal int32) (tableCount int, retry bool, err error)
+	// ResignOwner resigns the ownership of the capture, it returns true if the capture is not the owner.
+	ResignOwner(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)
+	// IsHealthy returns whether the ticdc cluster is healthy
+	IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)
 }
 
 // defaultTiCDCControl is default implementation of TiCDCControlInterface.
@@ -63,6 +90,137 @@ func (c *defaultTiCDCControl) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32)
 	return &status, err
 }
 
+func (c *defaultTiCDCControl) DrainCapture(tc *v1alpha1.TidbCluster, ordinal int32) (int, bool, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return 0, false, err
+	}
+
+	baseURL := c.getBaseURL(tc, ordinal)
+	this, captures, err := c.getCaptures(httpClient, tc, ordinal)
+	if err != nil {
+		return 0, false, err
+	}
+	if this == nil {
+		// the capture has gone, there is nothing to drain
+		klog.Infof("ticdc capture %d of tidbcluster %s/%s is not found, skip draining", ordinal, tc.Namespace, tc.Name)
+		return 0, false, nil
+	}
+	if len(captures) == 1 {
+		// there is no other capture to move the tables to
+		klog.Infof("ticdc capture %s of tidbcluster %s/%s is the only capture, skip draining", this.ID, tc.Namespace, tc.Name)
+		return 0, false, nil
+	}
+	if this.IsOwner {
+		// the owner can not be drained, it should resign the ownership first
+		return 0, true, nil
+	}
+
+	payload, err := json.Marshal(drainCaptureRequest{CaptureID: this.ID})
+	if err != nil {
+		return 0, false, err
+	}
+	url := fmt.Sprintf("%s/api/v1/captures/drain", baseURL)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer httputil.DeferClose(res.Body)
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, false, err
+	}
+	if res.StatusCode == http.StatusServiceUnavailable {
+		// the owner is not ready, or another capture is being drained
+		klog.Infof("ticdc is not ready to drain capture %s of tidbcluster %s/%s, response: %s", this.ID, tc.Namespace, tc.Name, string(body))
+		return 0, true, nil
+	}
+	if res.StatusCode >= 400 {
+		return 0, false, fmt.Errorf("drain ticdc capture %s failed, response %s:%v URL %s", this.ID, string(body), res.StatusCode, url)
+	}
+
+	resp := drainCaptureResp{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, false, err
+	}
+	return resp.CurrentTableCount, false, nil
+}
+
+func (c *defaultTiCDCControl) ResignOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return false, err
+	}
+
+	this, captures, err := c.getCaptures(httpClient, tc, ordinal)
+	if err != nil {
+		return false, err
+	}
+	if this == nil || !this.IsOwner {
+		return true, nil
+	}
+	if len(captures) == 1 {
+		// there is no other capture to take over the ownership
+		klog.Infof("ticdc capture %s of tidbcluster %s/%s is the only capture, skip resigning owner", this.ID, tc.Namespace, tc.Name)
+		return true, nil
+	}
+
+	url := fmt.Sprintf("%s/api/v1/owner/resign", c.getBaseURL(tc, ordinal))
+	if _, err := httputil.PostBodyOK(httpClient, url, nil); err != nil {
+		return false, err
+	}
+	// wait for another capture to be elected as the owner
+	return false, nil
+}
+
+func (c *defaultTiCDCControl) IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return false, err
+	}
+
+	url := fmt.Sprintf("%s/api/v1/health", c.getBaseURL(tc, ordinal))
+	res, err := httpClient.Get(url)
+	if err != nil {
+		return false, err
+	}
+	defer httputil.DeferClose(res.Body)
+	return res.StatusCode == http.StatusOK, nil
+}
+
+// getCaptures returns the capture of the ordinal and all the captures of the ticdc cluster
+func (c *defaultTiCDCControl) getCaptures(httpClient *http.Client, tc *v1alpha1.TidbCluster, ordinal int32) (*CaptureInfo, []CaptureInfo, error) {
+	baseURL := c.getBaseURL(tc, ordinal)
+	body, err := getBodyOK(httpClient, fmt.Sprintf("%s/status", baseURL))
+	if err != nil {
+		return nil, nil, err
+	}
+	status := CaptureStatus{}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, nil, err
+	}
+
+	body, err = getBodyOK(httpClient, fmt.Sprintf("%s/api/v1/captures", baseURL))
+	if err != nil {
+		return nil, nil, err
+	}
+	var captures []CaptureInfo
+	if err := json.Unmarshal(body, &captures); err != nil {
+		return nil, nil, err
+	}
+	for i := range captures {
+		if captures[i].ID == status.ID {
+			return &captures[i], captures, nil
+		}
+	}
+	return nil, captures, nil
+}
+
 func (c *defaultTiCDCControl) getBaseURL(tc *v1alpha1.TidbCluster, ordinal int32) string {
 	if c.testURL != "" {
 		return c.testURL
@@ -78,7 +236,10 @@ func (c *defaultTiCDCControl) getBaseURL(tc *v1alpha1.TidbCluster, ordinal int32
 
 // FakeTiCDCControl is a fake implementation of TiCDCControlInterface.
 type FakeTiCDCControl struct {
-	getStatus func(tc *v1alpha1.TidbCluster, ordinal int32) (*CaptureStatus, error)
+	getStatus    func(tc *v1alpha1.TidbCluster, ordinal int32) (*CaptureStatus, error)
+	drainCapture func(tc *v1alpha1.TidbCluster, ordinal int32) (tableCount int, retry bool, err error)
+	resignOwner  func(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)
+	isHealthy    func(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)
 }
 
 // NewFakeTiCDCControl returns a FakeTiCDCControl instance
@@ -97,3 +258,39 @@ func (c *FakeTiCDCControl) GetStatus(tc *v1alpha1.TidbCluster, ordinal int32) (*
 	}
 	return c.getStatus(tc, ordinal)
 }
+
+func (c *FakeTiCDCControl) MockDrainCapture(mockfunc func(tc *v1alpha1.TidbCluster, ordinal int32) (tableCount int, retry bool, err error)) {
+	c.drainCapture = mockfunc
+}
+
+// DrainCapture returns that there is no table left if it is not mocked
+func (c *FakeTiCDCControl) DrainCapture(tc *v1alpha1.TidbCluster, ordinal int32) (int, bool, error) {
+	if c.drainCapture == nil {
+		return 0, false, nil
+	}
+	return c.drainCapture(tc, ordinal)
+}
+
+func (c *FakeTiCDCControl) MockResignOwner(mockfunc func(tc *v1alpha1.TidbCluster, ordinal int32) (ok bool, err error)) {
+	c.resignOwner = mockfunc
+}
+
+// ResignOwner returns that the capture is not the owner if it is not mocked
+func (c *FakeTiCDCControl) ResignOwner(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	if c.resignOwner == nil {
+		return true, nil
+	}
+	return c.resignOwner(tc, ordinal)
+}
+
+func (c *FakeTiCDCControl) MockIsHealthy(mockfunc func(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)) {
+	c.isHealthy = mockfunc
+}
+
+// IsHealthy returns healthy if it is not mocked
+func (c *FakeTiCDCControl) IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	if c.isHealthy == nil {
+		return true, nil
+	}
+	return c.isHealthy(tc, ordinal)
+}
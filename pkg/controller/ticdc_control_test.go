@@ -0,0 +1,182 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func getTiCDCServer(g *GomegaWithT, status CaptureStatus, captures []CaptureInfo, drainStatus int, resigned *bool) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, request *http.Request) {
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		var resp interface{}
+		switch request.URL.Path {
+		case "/status":
+			resp = status
+		case "/api/v1/captures":
+			resp = captures
+		case "/api/v1/captures/drain":
+			g.Expect(request.Method).To(Equal(http.MethodPut))
+			req := drainCaptureRequest{}
+			g.Expect(json.NewDecoder(request.Body).Decode(&req)).To(Succeed())
+			g.Expect(req.CaptureID).To(Equal(status.ID))
+			w.WriteHeader(drainStatus)
+			resp = drainCaptureResp{CurrentTableCount: 3}
+		case "/api/v1/owner/resign":
+			g.Expect(request.Method).To(Equal(http.MethodPost))
+			*resigned = true
+			w.WriteHeader(http.StatusAccepted)
+			return
+		case "/api/v1/health":
+			w.WriteHeader(http.StatusOK)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data, err := json.Marshal(resp)
+		g.Expect(err).NotTo(HaveOccurred())
+		w.Write(data)
+	}
+}
+
+func TestTiCDCDrainCapture(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName      string
+		status        CaptureStatus
+		captures      []CaptureInfo
+		drainStatus   int
+		expectCount   int
+		expectRetry   bool
+		expectFailure bool
+	}{
+		{
+			caseName:    "drain capture",
+			status:      CaptureStatus{ID: "a"},
+			captures:    []CaptureInfo{{ID: "a"}, {ID: "b", IsOwner: true}},
+			drainStatus: http.StatusAccepted,
+			expectCount: 3,
+		},
+		{
+			caseName:    "capture is the owner",
+			status:      CaptureStatus{ID: "a", IsOwner: true},
+			captures:    []CaptureInfo{{ID: "a", IsOwner: true}, {ID: "b"}},
+			expectRetry: true,
+		},
+		{
+			caseName: "capture is the only capture",
+			status:   CaptureStatus{ID: "a", IsOwner: true},
+			captures: []CaptureInfo{{ID: "a", IsOwner: true}},
+		},
+		{
+			caseName: "capture is not found",
+			status:   CaptureStatus{ID: "c"},
+			captures: []CaptureInfo{{ID: "a", IsOwner: true}, {ID: "b"}},
+		},
+		{
+			caseName:    "owner is not ready to drain",
+			status:      CaptureStatus{ID: "a"},
+			captures:    []CaptureInfo{{ID: "a"}, {ID: "b", IsOwner: true}},
+			drainStatus: http.StatusServiceUnavailable,
+			expectRetry: true,
+		},
+		{
+			caseName:      "drain capture failed",
+			status:        CaptureStatus{ID: "a"},
+			captures:      []CaptureInfo{{ID: "a"}, {ID: "b", IsOwner: true}},
+			drainStatus:   http.StatusInternalServerError,
+			expectFailure: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Log(c.caseName)
+		var resigned bool
+		svc := getClientServer(getTiCDCServer(g, c.status, c.captures, c.drainStatus, &resigned))
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+		control := NewDefaultTiCDCControl(informer.Core().V1().Secrets().Lister())
+		control.testURL = svc.URL
+		count, retry, err := control.DrainCapture(getTidbCluster(), 0)
+		if c.expectFailure {
+			g.Expect(err).To(HaveOccurred())
+			continue
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(count).To(Equal(c.expectCount))
+		g.Expect(retry).To(Equal(c.expectRetry))
+	}
+}
+
+func TestTiCDCResignOwner(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName       string
+		status         CaptureStatus
+		captures       []CaptureInfo
+		expectOK       bool
+		expectResigned bool
+	}{
+		{
+			caseName:       "resign owner",
+			status:         CaptureStatus{ID: "a", IsOwner: true},
+			captures:       []CaptureInfo{{ID: "a", IsOwner: true}, {ID: "b"}},
+			expectOK:       false,
+			expectResigned: true,
+		},
+		{
+			caseName: "capture is not the owner",
+			status:   CaptureStatus{ID: "a"},
+			captures: []CaptureInfo{{ID: "a"}, {ID: "b", IsOwner: true}},
+			expectOK: true,
+		},
+		{
+			caseName: "capture is the only capture",
+			status:   CaptureStatus{ID: "a", IsOwner: true},
+			captures: []CaptureInfo{{ID: "a", IsOwner: true}},
+			expectOK: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Log(c.caseName)
+		var resigned bool
+		svc := getClientServer(getTiCDCServer(g, c.status, c.captures, http.StatusAccepted, &resigned))
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+		control := NewDefaultTiCDCControl(informer.Core().V1().Secrets().Lister())
+		control.testURL = svc.URL
+		ok, err := control.ResignOwner(getTidbCluster(), 0)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ok).To(Equal(c.expectOK))
+		g.Expect(resigned).To(Equal(c.expectResigned))
+
+		healthy, err := control.IsHealthy(getTidbCluster(), 0)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(healthy).To(BeTrue())
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ticdcchangefeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/util/slice"
+)
+
+// removeChangefeedTimeout is how long it keeps trying to remove the changefeed from TiCDC before
+// the finalizer of a deleted TiCDCChangefeed is released
+const removeChangefeedTimeout = 10 * time.Minute
+
+// ControlInterface provide function about control TiCDCChangefeed
+type ControlInterface interface {
+	// Reconcile a TiCDCChangefeed
+	Reconcile(*v1alpha1.TiCDCChangefeed) error
+
+	// Update the status of a TiCDCChangefeed
+	Update(*v1alpha1.TiCDCChangefeed) (*v1alpha1.TiCDCChangefeed, error)
+}
+
+// NewDefaultTiCDCChangefeedControl returns a defaultTiCDCChangefeedControl instance
+func NewDefaultTiCDCChangefeedControl(deps *controller.Dependencies, recorder record.EventRecorder) *defaultTiCDCChangefeedControl {
+	return &defaultTiCDCChangefeedControl{
+		deps:     deps,
+		recorder: recorder,
+	}
+}
+
+type defaultTiCDCChangefeedControl struct {
+	deps     *controller.Dependencies
+	recorder record.EventRecorder
+}
+
+func (c *defaultTiCDCChangefeedControl) Reconcile(cf *v1alpha1.TiCDCChangefeed) error {
+	c.defaulting(cf)
+
+	if isDeletionCandidate(cf) {
+		return c.removeChangefeed(cf)
+	}
+	if cf.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if !c.validate(cf) {
+		return nil // fatal error, no need to retry on invalid object
+	}
+
+	if needToAddFinalizer(cf) {
+		updated, err := c.addProtectionFinalizer(cf)
+		if err != nil {
+			return err
+		}
+		cf = updated
+		c.defaulting(cf)
+	}
+
+	var errs []error
+
+	oldStatus := cf.Status.DeepCopy()
+
+	err := c.reconcile(cf)
+	if err != nil {
+		errs = append(errs, err)
+		setSyncedCondition(cf, metav1.ConditionFalse, "Failed", err.Error())
+	} else if cf.Status.ObservedGeneration == cf.Generation && !cf.IsSynced() {
+		// recovered from the failure of the last round
+		setSyncedCondition(cf, metav1.ConditionTrue, "Synced", "")
+	}
+
+	if apiequality.Semantic.DeepEqual(&cf.Status, oldStatus) {
+		return errorutils.NewAggregate(errs)
+	}
+
+	_, err = c.Update(cf.DeepCopy())
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return errorutils.NewAggregate(errs)
+}
+
+func (c *defaultTiCDCChangefeedControl) reconcile(cf *v1alpha1.TiCDCChangefeed) error {
+	tc, err := c.getTidbCluster(cf)
+	if err != nil {
+		return err
+	}
+
+	cdc := c.deps.ChangefeedControl
+	id := cf.GetChangefeedID()
+	info, err := cdc.GetChangefeed(tc, id)
+	if err != nil {
+		return fmt.Errorf("get changefeed %s of tidbcluster %s/%s failed: %v", id, tc.Namespace, tc.Name, err)
+	}
+
+	if info == nil {
+		if cf.Status.ChangefeedID != "" {
+			// do not recreate the changefeed that is removed out of band, which may replicate
+			// the data that has been replicated again
+			c.recorder.Eventf(cf, corev1.EventTypeWarning, "ChangefeedNotFound", "changefeed %s is not found in TiCDC", id)
+			return fmt.Errorf("changefeed %s of tidbcluster %s/%s is not found", id, tc.Namespace, tc.Name)
+		}
+
+		cfg, err := c.changefeedConfig(cf)
+		if err != nil {
+			return err
+		}
+		if err := cdc.CreateChangefeed(tc, cfg); err != nil {
+			return fmt.Errorf("create changefeed %s of tidbcluster %s/%s failed: %v", id, tc.Namespace, tc.Name, err)
+		}
+		klog.Infof("TiCDCChangefeed: [%s/%s] changefeed %s is created", cf.Namespace, cf.Name, id)
+		c.recorder.Eventf(cf, corev1.EventTypeNormal, "Created", "changefeed %s is created", id)
+		if cf.Spec.Paused {
+			if err := cdc.PauseChangefeed(tc, id); err != nil {
+				return fmt.Errorf("pause changefeed %s of tidbcluster %s/%s failed: %v", id, tc.Namespace, tc.Name, err)
+			}
+		}
+		cf.Status.ChangefeedID = id
+		cf.Status.ObservedGeneration = cf.Generation
+		cf.Status.ObservedSpecHash = specHash(cf)
+		setSyncedCondition(cf, metav1.ConditionTrue, "Created", "")
+		return c.syncStatus(tc, cf)
+	}
+
+	state := v1alpha1.TiCDCChangefeedState(info.State)
+	pausedForUpdate := false
+	if cf.Status.ObservedGeneration != cf.Generation {
+		// the changefeed is not updated if only Paused is changed, which is handled below
+		hash := specHash(cf)
+		if hash != cf.Status.ObservedSpecHash {
+			cfg, err := c.changefeedConfig(cf)
+			if err != nil {
+				return err
+			}
+			// the changefeed must be paused before it is updated
+			if isRunning(state) {
+				if err := cdc.PauseChangefeed(tc, id); err != nil {
+					return fmt.Errorf("pause changefeed %s of tidbcluster %s/%s failed: %v", id, tc.Namespace, tc.Name, err)
+				}
+				state = v1alpha1.TiCDCChangefeedStateStopped
+				pausedForUpdate = true
+			}
+			if err := cdc.UpdateChangefeed(tc, cfg); err != nil {
+				return fmt.Errorf("update changefeed %s of tidbcluster %s/%s failed: %v", id, tc.Namespace, tc.Name, err)
+			}
+			klog.Infof("TiCDCChangefeed: [%s/%s] changefeed %s is updated", cf.Namespace, cf.Name, id)
+			c.recorder.Eventf(cf, corev1.EventTypeNormal, "Updated", "changefeed %s is updated", id)
+			setSyncedCondition(cf, metav1.ConditionTrue, "Updated", "")
+		}
+		cf.Status.ChangefeedID = id
+		cf.Status.ObservedGeneration = cf.Generation
+		cf.Status.ObservedSpecHash = hash
+	}
+
+	switch {
+	case cf.Spec.Paused && isRunning(state):
+		if err := cdc.PauseChangefeed(tc, id); err != nil {
+			return fmt.Errorf("pause changefeed %s of tidbcluster %s/%s failed: %v", id, tc.Namespace, tc.Name, err)
+		}
+		c.recorder.Eventf(cf, corev1.EventTypeNormal, "Paused", "changefeed %s is paused", id)
+	case cf.Spec.Paused && pausedForUpdate:
+		c.recorder.Eventf(cf, corev1.EventTypeNormal, "Paused", "changefeed %s is paused", id)
+	case !cf.Spec.Paused && state == v1alpha1.TiCDCChangefeedStateStopped:
+		if err := cdc.ResumeChangefeed(tc, id); err != nil {
+			return fmt.Errorf("resume changefeed %s of tidbcluster %s/%s failed: %v", id, tc.Namespace, tc.Name, err)
+		}
+		c.recorder.Eventf(cf, corev1.EventTypeNormal, "Resumed", "changefeed %s is resumed", id)
+	}
+
+	return c.syncStatus(tc, cf)
+}
+
+// syncStatus mirrors the state and the checkpoint of the changefeed into the status
+func (c *defaultTiCDCChangefeedControl) syncStatus(tc *v1alpha1.TidbCluster, cf *v1alpha1.TiCDCChangefeed) error {
+	info, err := c.deps.ChangefeedControl.GetChangefeed(tc, cf.Status.ChangefeedID)
+	if err != nil {
+		return fmt.Errorf("get changefeed %s of tidbcluster %s/%s failed: %v", cf.Status.ChangefeedID, tc.Namespace, tc.Name, err)
+	}
+	if info == nil {
+		return nil
+	}
+
+	cf.Status.State = v1alpha1.TiCDCChangefeedState(info.State)
+	cf.Status.CheckpointTS = info.CheckpointTS
+	cf.Status.CheckpointTime = nil
+	cf.Status.LagSeconds = 0
+	if info.CheckpointTS > 0 {
+		checkpoint := backuputil.TSToGoTime(info.CheckpointTS)
+		cf.Status.CheckpointTime = &metav1.Time{Time: checkpoint}
+		if lag := time.Since(checkpoint); lag > 0 {
+			cf.Status.LagSeconds = int64(lag.Seconds())
+		}
+	}
+	cf.Status.Error = nil
+	if info.Error != nil {
+		cf.Status.Error = &v1alpha1.TiCDCChangefeedError{Code: info.Error.Code, Message: info.Error.Message}
+	}
+	return nil
+}
+
+// removeChangefeed removes the changefeed from TiCDC and then the finalizer of the TiCDCChangefeed
+func (c *defaultTiCDCChangefeedControl) removeChangefeed(cf *v1alpha1.TiCDCChangefeed) error {
+	ns := cf.GetNamespace()
+	name := cf.GetName()
+
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(cf.Spec.Cluster.Namespace).Get(cf.Spec.Cluster.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	// the changefeed has gone with the tidbcluster or the ticdc
+	if err == nil && tc.Spec.TiCDC != nil && tc.Spec.TiCDC.Replicas > 0 && cf.Status.ChangefeedID != "" {
+		if err := c.deps.ChangefeedControl.RemoveChangefeed(tc, cf.Status.ChangefeedID); err != nil {
+			err = fmt.Errorf("remove changefeed %s of tidbcluster %s/%s failed: %v", cf.Status.ChangefeedID, tc.Namespace, tc.Name, err)
+			// do not block the deletion forever if ticdc keeps unreachable
+			if time.Since(cf.DeletionTimestamp.Time) < removeChangefeedTimeout {
+				return err
+			}
+			klog.Warningf("TiCDCChangefeed: [%s/%s] gives up removing changefeed %s after %v: %v", ns, name, cf.Status.ChangefeedID, removeChangefeedTimeout, err)
+			c.recorder.Eventf(cf, corev1.EventTypeWarning, "FailedRemove", "give up removing changefeed %s after %v, it should be removed manually: %v",
+				cf.Status.ChangefeedID, removeChangefeedTimeout, err)
+		} else {
+			klog.Infof("TiCDCChangefeed: [%s/%s] changefeed %s is removed", ns, name, cf.Status.ChangefeedID)
+		}
+	}
+
+	cf.Finalizers = slice.RemoveString(cf.Finalizers, label.TiCDCChangefeedFinalizer, nil)
+	_, err = c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(ns).Update(context.TODO(), cf, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("remove TiCDCChangefeed %s/%s protection finalizer failed, err: %v", ns, name, err)
+	}
+	klog.Infof("remove TiCDCChangefeed %s/%s protection finalizer success", ns, name)
+	return nil
+}
+
+func (c *defaultTiCDCChangefeedControl) addProtectionFinalizer(cf *v1alpha1.TiCDCChangefeed) (*v1alpha1.TiCDCChangefeed, error) {
+	ns := cf.GetNamespace()
+	name := cf.GetName()
+
+	cf.Finalizers = append(cf.Finalizers, label.TiCDCChangefeedFinalizer)
+	updated, err := c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(ns).Update(context.TODO(), cf, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("add TiCDCChangefeed %s/%s protection finalizer failed, err: %v", ns, name, err)
+	}
+	return updated, nil
+}
+
+func (c *defaultTiCDCChangefeedControl) getTidbCluster(cf *v1alpha1.TiCDCChangefeed) (*v1alpha1.TidbCluster, error) {
+	ref := cf.Spec.Cluster
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(ref.Namespace).Get(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("get tc %s/%s failed: %s", ref.Namespace, ref.Name, err)
+	}
+	if tc.Spec.TiCDC == nil {
+		return nil, fmt.Errorf("tc %s/%s has no ticdc", ref.Namespace, ref.Name)
+	}
+	return tc, nil
+}
+
+// changefeedConfig returns the config of the changefeed to create or update it with
+func (c *defaultTiCDCChangefeedControl) changefeedConfig(cf *v1alpha1.TiCDCChangefeed) (*controller.ChangefeedConfig, error) {
+	sinkURI, err := c.sinkURI(cf)
+	if err != nil {
+		return nil, err
+	}
+
+	replicaConfig := map[string]interface{}{}
+	if cf.Spec.Config != nil {
+		replicaConfig = cf.Spec.Config.DeepCopy().Inner()
+	}
+	if f := cf.Spec.Filter; f != nil {
+		filter, ok := replicaConfig["filter"].(map[string]interface{})
+		if !ok {
+			filter = map[string]interface{}{}
+		}
+		if len(f.Rules) > 0 {
+			filter["rules"] = f.Rules
+		}
+		if len(f.IgnoreTxnStartTS) > 0 {
+			filter["ignore_txn_start_ts"] = f.IgnoreTxnStartTS
+		}
+		replicaConfig["filter"] = filter
+	}
+	if len(replicaConfig) == 0 {
+		replicaConfig = nil
+	}
+
+	return &controller.ChangefeedConfig{
+		ID:            cf.GetChangefeedID(),
+		StartTS:       cf.Spec.StartTS,
+		TargetTS:      cf.Spec.TargetTS,
+		SinkURI:       sinkURI,
+		ReplicaConfig: replicaConfig,
+	}, nil
+}
+
+// sinkURI returns the sink URI with the credentials in the Secret referenced by SinkSecretRef
+func (c *defaultTiCDCChangefeedControl) sinkURI(cf *v1alpha1.TiCDCChangefeed) (string, error) {
+	ref := cf.Spec.SinkSecretRef
+	if ref == nil {
+		return cf.Spec.SinkURI, nil
+	}
+
+	secret, err := c.deps.SecretLister.Secrets(cf.Namespace).Get(ref.Name)
+	if err != nil {
+		return "", fmt.Errorf("get sink secret %s/%s failed: %v", cf.Namespace, ref.Name, err)
+	}
+	user, ok := secret.Data[ref.UserKey]
+	if !ok {
+		return "", fmt.Errorf("key %s is not found in sink secret %s/%s", ref.UserKey, cf.Namespace, ref.Name)
+	}
+	u, err := url.Parse(cf.Spec.SinkURI)
+	if err != nil {
+		return "", fmt.Errorf("parse sink URI of TiCDCChangefeed %s/%s failed", cf.Namespace, cf.Name)
+	}
+	if password, ok := secret.Data[ref.PasswordKey]; ok {
+		u.User = url.UserPassword(string(user), string(password))
+	} else {
+		u.User = url.User(string(user))
+	}
+	return u.String(), nil
+}
+
+func (c *defaultTiCDCChangefeedControl) Update(cf *v1alpha1.TiCDCChangefeed) (*v1alpha1.TiCDCChangefeed, error) {
+	var (
+		ns     string                          = cf.GetNamespace()
+		name   string                          = cf.GetName()
+		status *v1alpha1.TiCDCChangefeedStatus = cf.Status.DeepCopy()
+		update *v1alpha1.TiCDCChangefeed
+	)
+
+	// don't wait due to limited number of clients, but backoff after the default number of steps
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(ns).UpdateStatus(context.TODO(), cf, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.V(4).Infof("TiCDCChangefeed: [%s/%s] updated successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("failed to update TiCDCChangefeed: [%s/%s], error: %v", ns, name, updateErr)
+
+		if updated, err := c.deps.TiCDCChangefeedLister.TiCDCChangefeeds(ns).Get(name); err == nil {
+			// make a copy so we don't mutate the shared cache
+			cf = updated.DeepCopy()
+			cf.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated TiCDCChangefeed %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("failed to update TiCDCChangefeed: [%s/%s], error: %v", ns, name, err)
+	}
+	return update, err
+}
+
+func (c *defaultTiCDCChangefeedControl) defaulting(cf *v1alpha1.TiCDCChangefeed) {
+	defaulting.SetTiCDCChangefeedDefault(cf)
+}
+
+func (c *defaultTiCDCChangefeedControl) validate(cf *v1alpha1.TiCDCChangefeed) bool {
+	errs := v1alpha1validation.ValidateTiCDCChangefeed(cf)
+	if len(errs) > 0 {
+		aggregatedErr := errs.ToAggregate()
+		klog.Errorf("ticdc changefeed %s/%s is not valid and must be fixed first, aggregated error: %v", cf.GetNamespace(), cf.GetName(), aggregatedErr)
+		c.recorder.Event(cf, corev1.EventTypeWarning, "FailedValidation", aggregatedErr.Error())
+		return false
+	}
+	return true
+}
+
+func setSyncedCondition(cf *v1alpha1.TiCDCChangefeed, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&cf.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.TiCDCChangefeedSynced,
+		Status:             status,
+		ObservedGeneration: cf.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// isRunning returns whether the changefeed can be paused
+func isRunning(state v1alpha1.TiCDCChangefeedState) bool {
+	return state == v1alpha1.TiCDCChangefeedStateNormal ||
+		state == v1alpha1.TiCDCChangefeedStateWarning ||
+		state == v1alpha1.TiCDCChangefeedStateError
+}
+
+// specHash returns the hash of the spec except Paused, which can be changed without updating the changefeed
+func specHash(cf *v1alpha1.TiCDCChangefeed) string {
+	spec := cf.Spec.DeepCopy()
+	spec.Paused = false
+	data, _ := json.Marshal(spec)
+	return v1alpha1.HashContents(data)
+}
+
+func needToAddFinalizer(cf *v1alpha1.TiCDCChangefeed) bool {
+	return cf.DeletionTimestamp == nil && !slice.ContainsString(cf.Finalizers, label.TiCDCChangefeedFinalizer, nil)
+}
+
+func isDeletionCandidate(cf *v1alpha1.TiCDCChangefeed) bool {
+	return cf.DeletionTimestamp != nil && slice.ContainsString(cf.Finalizers, label.TiCDCChangefeedFinalizer, nil)
+}
+
+// FakeTiCDCChangefeedControl is a fake implementation of ControlInterface
+type FakeTiCDCChangefeedControl struct {
+	reconcile func(*v1alpha1.TiCDCChangefeed) error
+}
+
+func (c *FakeTiCDCChangefeedControl) MockReconcile(reconcile func(*v1alpha1.TiCDCChangefeed) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakeTiCDCChangefeedControl) Reconcile(cf *v1alpha1.TiCDCChangefeed) error {
+	if c.reconcile != nil {
+		return c.reconcile(cf)
+	}
+	return nil
+}
+
+func (c *FakeTiCDCChangefeedControl) Update(cf *v1alpha1.TiCDCChangefeed) (*v1alpha1.TiCDCChangefeed, error) {
+	return cf, nil
+}
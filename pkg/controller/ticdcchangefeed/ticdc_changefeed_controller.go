@@ -0,0 +1,145 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ticdcchangefeed
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	perrors "github.com/pingcap/errors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// Controller syncs TiCDCChangefeed
+type Controller struct {
+	deps    *controller.Dependencies
+	control ControlInterface
+	queue   workqueue.RateLimitingInterface
+}
+
+// NewController creates a TiCDCChangefeed controller
+func NewController(deps *controller.Dependencies) *Controller {
+	c := &Controller{
+		deps:    deps,
+		control: NewDefaultTiCDCChangefeedControl(deps, deps.Recorder),
+		queue: workqueue.NewNamedRateLimitingQueue(
+			controller.NewControllerRateLimiter(1*time.Second, 100*time.Second),
+			"ticdc-changefeed",
+		),
+	}
+
+	cfInformer := deps.InformerFactory.Pingcap().V1alpha1().TiCDCChangefeeds()
+	cfInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueue,
+		UpdateFunc: func(old, cur interface{}) {
+			oldCf := old.(*v1alpha1.TiCDCChangefeed)
+			curCf := cur.(*v1alpha1.TiCDCChangefeed)
+			// the status is refreshed on every sync, skip the updates of the status only,
+			// the periodic resync refreshes the checkpoint of the changefeed instead
+			if oldCf.ResourceVersion != curCf.ResourceVersion &&
+				oldCf.Generation == curCf.Generation &&
+				curCf.DeletionTimestamp == nil {
+				return
+			}
+			c.enqueue(cur)
+		},
+		DeleteFunc: c.enqueue,
+	})
+
+	return c
+}
+
+// Run runs the TiCDCChangefeed controller.
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting ticdc changefeed controller")
+	defer klog.Info("Shutting down ticdc changefeed controller")
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	keyIface, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(keyIface)
+
+	key := keyIface.(string)
+	err := c.sync(key)
+	if err != nil {
+		if perrors.Find(err, controller.IsRequeueError) != nil {
+			klog.Infof("TiCDCChangefeed %v still need sync: %v, requeuing", key, err)
+		} else {
+			utilruntime.HandleError(fmt.Errorf("TiCDCChangefeed %v sync failed, err: %v", key, err))
+		}
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(keyIface)
+	}
+
+	return true
+}
+
+func (c *Controller) sync(key string) (err error) {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).Infof("Finished syncing TiCDCChangefeed %s (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("TiCDCChangefeed", startTime, err)
+	}()
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	cf, err := c.deps.TiCDCChangefeedLister.TiCDCChangefeeds(ns).Get(name)
+	if errors.IsNotFound(err) {
+		klog.Infof("TiCDCChangefeed %s has been deleted", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.control.Reconcile(cf.DeepCopy())
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("cound't get key for object %+v: %v", obj, err))
+		return
+	}
+	c.queue.Add(key)
+}
@@ -37,6 +37,13 @@ type DBInfo struct {
 	IsOwner bool `json:"is_owner"`
 }
 
+// DBStatus is the response of the status api of tidb
+type DBStatus struct {
+	Connections int    `json:"connections"`
+	Version     string `json:"version"`
+	GitHash     string `json:"git_hash"`
+}
+
 // TiDBControlInterface is the interface that knows how to manage tidb peers
 type TiDBControlInterface interface {
 	// GetHealth returns tidb's health info
@@ -45,6 +52,8 @@ type TiDBControlInterface interface {
 	GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*DBInfo, error)
 	// GetSettings return the TiDB instance settings
 	GetSettings(tc *v1alpha1.TidbCluster, ordinal int32) (*config.Config, error)
+	// GetConnections returns the count of the active client connections of the TiDB instance
+	GetConnections(tc *v1alpha1.TidbCluster, ordinal int32) (int, error)
 }
 
 // defaultTiDBControl is default implementation of TiDBControlInterface.
@@ -137,6 +146,26 @@ func (c *defaultTiDBControl) GetSettings(tc *v1alpha1.TidbCluster, ordinal int32
 	return &info, nil
 }
 
+func (c *defaultTiDBControl) GetConnections(tc *v1alpha1.TidbCluster, ordinal int32) (int, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return 0, err
+	}
+
+	baseURL := c.getBaseURL(tc, ordinal)
+	url := fmt.Sprintf("%s/status", baseURL)
+	body, err := getBodyOK(httpClient, url)
+	if err != nil {
+		return 0, err
+	}
+	status := DBStatus{}
+	err = json.Unmarshal(body, &status)
+	if err != nil {
+		return 0, err
+	}
+	return status.Connections, nil
+}
+
 func getBodyOK(httpClient *http.Client, apiURL string) ([]byte, error) {
 	res, err := httpClient.Get(apiURL)
 	if err != nil {
@@ -174,6 +203,7 @@ type FakeTiDBControl struct {
 	tiDBInfo     *DBInfo
 	getInfoError error
 	tidbConfig   *config.Config
+	connections  map[string]int
 }
 
 // NewFakeTiDBControl returns a FakeTiDBControl instance
@@ -197,6 +227,16 @@ func (c *FakeTiDBControl) GetHealth(tc *v1alpha1.TidbCluster, ordinal int32) (bo
 	return false, nil
 }
 
+// SetConnections set the active connections for FakeTiDBControl
+func (c *FakeTiDBControl) SetConnections(connections map[string]int) {
+	c.connections = connections
+}
+
+func (c *FakeTiDBControl) GetConnections(tc *v1alpha1.TidbCluster, ordinal int32) (int, error) {
+	podName := fmt.Sprintf("%s-%d", TiDBMemberName(tc.GetName()), ordinal)
+	return c.connections[podName], c.getInfoError
+}
+
 func (c *FakeTiDBControl) GetInfo(tc *v1alpha1.TidbCluster, ordinal int32) (*DBInfo, error) {
 	return c.tiDBInfo, c.getInfoError
 }
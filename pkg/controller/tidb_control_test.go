@@ -268,6 +268,60 @@ func TestSettings(t *testing.T) {
 	}
 }
 
+func TestConnections(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName string
+		failed   bool
+		resp     DBStatus
+		expected int
+	}{
+		{
+			caseName: "GetConnections",
+			failed:   false,
+			resp:     DBStatus{Connections: 3, Version: "5.7.25-TiDB-v6.1.0"},
+			expected: 3,
+		},
+		{
+			caseName: "GetConnections failed",
+			failed:   true,
+			resp:     DBStatus{Connections: 3},
+			expected: 0,
+		},
+	}
+
+	for _, c := range cases {
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal("GET"), "check method")
+			g.Expect(request.URL.Path).To(Equal("/status"), "check url")
+
+			w.Header().Set("Content-Type", ContentTypeJSON)
+			if c.failed {
+				w.WriteHeader(http.StatusInternalServerError)
+			} else {
+				data, err := json.Marshal(c.resp)
+				g.Expect(err).NotTo(HaveOccurred())
+				w.Write(data)
+			}
+		})
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+		control := NewDefaultTiDBControl(informer.Core().V1().Secrets().Lister())
+		control.testURL = svc.URL
+		tc := getTidbCluster()
+		result, err := control.GetConnections(tc, 0)
+		if c.failed {
+			g.Expect(err).To(HaveOccurred())
+		} else {
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		g.Expect(result).To(Equal(c.expected))
+	}
+}
+
 func TestGetHTTPClient(t *testing.T) {
 	g := NewGomegaWithT(t)
 
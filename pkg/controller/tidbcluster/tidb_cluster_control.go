@@ -14,6 +14,8 @@
 package tidbcluster
 
 import (
+	"time"
+
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
 	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
@@ -23,6 +25,7 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/metrics"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
@@ -41,6 +44,7 @@ type ControlInterface interface {
 func NewDefaultTidbClusterControl(
 	tcControl controller.TidbClusterControlInterface,
 	pdMemberManager manager.Manager,
+	pdMSMemberManager manager.Manager,
 	tikvMemberManager manager.Manager,
 	tidbMemberManager manager.Manager,
 	reclaimPolicyManager manager.Manager,
@@ -49,15 +53,19 @@ func NewDefaultTidbClusterControl(
 	pvcCleaner member.PVCCleanerInterface,
 	pvcResizer member.PVCResizerInterface,
 	pumpMemberManager manager.Manager,
+	drainerMemberManager manager.Manager,
 	tiflashMemberManager manager.Manager,
 	ticdcMemberManager manager.Manager,
+	tiproxyMemberManager manager.Manager,
 	discoveryManager member.TidbDiscoveryManager,
 	tidbClusterStatusManager manager.Manager,
+	upgradePreflightManager manager.Manager,
 	conditionUpdater TidbClusterConditionUpdater,
 	recorder record.EventRecorder) ControlInterface {
 	return &defaultTidbClusterControl{
 		tcControl:                tcControl,
 		pdMemberManager:          pdMemberManager,
+		pdMSMemberManager:        pdMSMemberManager,
 		tikvMemberManager:        tikvMemberManager,
 		tidbMemberManager:        tidbMemberManager,
 		reclaimPolicyManager:     reclaimPolicyManager,
@@ -66,10 +74,13 @@ func NewDefaultTidbClusterControl(
 		pvcCleaner:               pvcCleaner,
 		pvcResizer:               pvcResizer,
 		pumpMemberManager:        pumpMemberManager,
+		drainerMemberManager:     drainerMemberManager,
 		tiflashMemberManager:     tiflashMemberManager,
 		ticdcMemberManager:       ticdcMemberManager,
+		tiproxyMemberManager:     tiproxyMemberManager,
 		discoveryManager:         discoveryManager,
 		tidbClusterStatusManager: tidbClusterStatusManager,
+		upgradePreflightManager:  upgradePreflightManager,
 		conditionUpdater:         conditionUpdater,
 		recorder:                 recorder,
 	}
@@ -78,6 +89,7 @@ func NewDefaultTidbClusterControl(
 type defaultTidbClusterControl struct {
 	tcControl                controller.TidbClusterControlInterface
 	pdMemberManager          manager.Manager
+	pdMSMemberManager        manager.Manager
 	tikvMemberManager        manager.Manager
 	tidbMemberManager        manager.Manager
 	reclaimPolicyManager     manager.Manager
@@ -86,10 +98,13 @@ type defaultTidbClusterControl struct {
 	pvcCleaner               member.PVCCleanerInterface
 	pvcResizer               member.PVCResizerInterface
 	pumpMemberManager        manager.Manager
+	drainerMemberManager     manager.Manager
 	tiflashMemberManager     manager.Manager
 	ticdcMemberManager       manager.Manager
+	tiproxyMemberManager     manager.Manager
 	discoveryManager         member.TidbDiscoveryManager
 	tidbClusterStatusManager manager.Manager
+	upgradePreflightManager  manager.Manager
 	conditionUpdater         TidbClusterConditionUpdater
 	recorder                 record.EventRecorder
 }
@@ -101,7 +116,12 @@ func (c *defaultTidbClusterControl) UpdateTidbCluster(tc *v1alpha1.TidbCluster)
 		return nil // fatal error, no need to retry on invalid object
 	}
 
+	startTime := time.Now()
 	var errs []error
+	defer func() {
+		c.recordReconcileMetrics(tc, startTime, errorutils.NewAggregate(errs))
+		c.recordStatusMetrics(tc)
+	}()
 	oldStatus := tc.Status.DeepCopy()
 
 	if err := c.updateTidbCluster(tc); err != nil {
@@ -161,6 +181,12 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// run the pre-flight checks before changing the version of the components, the version change
+	// is blocked by the upgraders if the checks fail
+	if err := c.upgradePreflightManager.Sync(tc); err != nil {
+		return err
+	}
+
 	// works that should be done to make the pd cluster current state match the desired state:
 	//   - create or update the pd service
 	//   - create or update the pd headless service
@@ -176,6 +202,17 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// works that should be done to make the pd microservices current state match the desired state:
+	//   - waiting for the pd cluster available
+	//   - create or update the services of the pd microservices
+	//   - create the statefulsets of the pd microservices
+	//   - sync the members and the primary of the pd microservices from pd to TidbCluster object
+	//   - upgrade the pd microservices after pd, tso before scheduling
+	//   - scale out/in the pd microservices
+	if err := c.pdMSMemberManager.Sync(tc); err != nil {
+		return err
+	}
+
 	// works that should be done to make the tiflash cluster current state match the desired state:
 	//   - waiting for the tidb cluster available
 	//   - create or update tiflash headless service
@@ -207,6 +244,11 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// syncing the drainer cluster
+	if err := c.drainerMemberManager.Sync(tc); err != nil {
+		return err
+	}
+
 	// works that should be done to make the tidb cluster current state match the desired state:
 	//   - waiting for the tikv cluster available(at least one peer works)
 	//   - create or update tidb headless service
@@ -219,6 +261,16 @@ func (c *defaultTidbClusterControl) updateTidbCluster(tc *v1alpha1.TidbCluster)
 		return err
 	}
 
+	// works that should be done to make the tiproxy cluster current state match the desired state:
+	//   - waiting for the tidb cluster available
+	//   - create or update tiproxy headless service, client service and statefulset
+	//   - sync tiproxy cluster status from the tiproxy servers to TidbCluster object
+	//   - upgrade the tiproxy cluster
+	//   - scale out/in the tiproxy cluster
+	if err := c.tiproxyMemberManager.Sync(tc); err != nil {
+		return err
+	}
+
 	//   - waiting for the pd cluster available(pd cluster is in quorum)
 	//   - create or update ticdc deployment
 	//   - sync ticdc cluster status from pd to TidbCluster object
@@ -261,6 +313,9 @@ func (c *defaultTidbClusterControl) recordMetrics(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.PD != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "pd").Set(float64(tc.Spec.PD.Replicas))
 	}
+	for _, spec := range tc.Spec.PDMS {
+		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, spec.Name).Set(float64(spec.Replicas))
+	}
 	if tc.Spec.TiKV != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tikv").Set(float64(tc.Spec.TiKV.Replicas))
 	}
@@ -273,9 +328,108 @@ func (c *defaultTidbClusterControl) recordMetrics(tc *v1alpha1.TidbCluster) {
 	if tc.Spec.TiCDC != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "ticdc").Set(float64(tc.Spec.TiCDC.Replicas))
 	}
+	if tc.Spec.TiProxy != nil {
+		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "tiproxy").Set(float64(tc.Spec.TiProxy.Replicas))
+	}
 	if tc.Spec.Pump != nil {
 		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "pump").Set(float64(tc.Spec.Pump.Replicas))
 	}
+	if tc.Spec.Drainer != nil {
+		metrics.ClusterSpecReplicas.WithLabelValues(ns, tcName, "drainer").Set(float64(tc.Spec.Drainer.Replicas))
+	}
+}
+
+func (c *defaultTidbClusterControl) recordReconcileMetrics(tc *v1alpha1.TidbCluster, startTime time.Time, err error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	metrics.ClusterReconcileDuration.WithLabelValues(ns, tcName).Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		metrics.ClusterReconcileErrors.WithLabelValues(ns, tcName, controller.GetReconcileErrorType(err)).Inc()
+	}
+}
+
+// recordStatusMetrics records the health of the components according to the status updated by the member managers
+func (c *defaultTidbClusterControl) recordStatusMetrics(tc *v1alpha1.TidbCluster) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	for _, status := range v1alpha1.ComponentStatusFromTC(tc) {
+		component := status.GetMemberType().String()
+		for _, phase := range memberPhases {
+			var value float64
+			if status.GetPhase() == phase {
+				value = 1
+			}
+			metrics.ClusterComponentPhase.WithLabelValues(ns, tcName, component, string(phase)).Set(value)
+		}
+		var resizing float64
+		if meta.IsStatusConditionTrue(status.GetConditions(), v1alpha1.ComponentVolumeResizing) {
+			resizing = 1
+		}
+		metrics.ClusterVolumeResizing.WithLabelValues(ns, tcName, component).Set(resizing)
+	}
+
+	if tc.Spec.PD != nil {
+		metrics.ClusterFailureMembers.WithLabelValues(ns, tcName, v1alpha1.PDMemberType.String()).Set(float64(len(tc.Status.PD.FailureMembers)))
+
+		var total, healthy int
+		for _, members := range []map[string]v1alpha1.PDMember{tc.Status.PD.Members, tc.Status.PD.PeerMembers} {
+			for _, member := range members {
+				total++
+				if member.Health {
+					healthy++
+				}
+			}
+		}
+		var quorumHealthy float64
+		if total > 0 && healthy*2 > total {
+			quorumHealthy = 1
+		}
+		metrics.ClusterPDQuorumHealthy.WithLabelValues(ns, tcName).Set(quorumHealthy)
+	}
+	if tc.Spec.TiDB != nil {
+		metrics.ClusterFailureMembers.WithLabelValues(ns, tcName, v1alpha1.TiDBMemberType.String()).Set(float64(len(tc.Status.TiDB.FailureMembers)))
+	}
+	if tc.Spec.TiKV != nil {
+		metrics.ClusterFailureMembers.WithLabelValues(ns, tcName, v1alpha1.TiKVMemberType.String()).Set(float64(len(tc.Status.TiKV.FailureStores)))
+		metrics.ClusterEvictLeaderStores.WithLabelValues(ns, tcName).Set(float64(len(tc.Status.TiKV.EvictLeader)))
+	}
+	if tc.Spec.TiFlash != nil {
+		metrics.ClusterFailureMembers.WithLabelValues(ns, tcName, v1alpha1.TiFlashMemberType.String()).Set(float64(len(tc.Status.TiFlash.FailureStores)))
+	}
+}
+
+var memberPhases = []v1alpha1.MemberPhase{v1alpha1.NormalPhase, v1alpha1.UpgradePhase, v1alpha1.ScalePhase}
+
+// deleteClusterMetrics deletes all the metrics of the deleted TidbCluster
+func deleteClusterMetrics(ns, tcName string) {
+	metrics.ClusterReconcileDuration.DeleteLabelValues(ns, tcName)
+	for _, errType := range []string{"requeue", "ignore", "error"} {
+		metrics.ClusterReconcileErrors.DeleteLabelValues(ns, tcName, errType)
+	}
+	metrics.ClusterEvictLeaderStores.DeleteLabelValues(ns, tcName)
+	metrics.ClusterPDQuorumHealthy.DeleteLabelValues(ns, tcName)
+
+	components := []v1alpha1.MemberType{
+		v1alpha1.PDMemberType,
+		v1alpha1.PDMSTSOMemberType,
+		v1alpha1.PDMSSchedulingMemberType,
+		v1alpha1.TiDBMemberType,
+		v1alpha1.TiKVMemberType,
+		v1alpha1.TiFlashMemberType,
+		v1alpha1.TiCDCMemberType,
+		v1alpha1.TiProxyMemberType,
+		v1alpha1.PumpMemberType,
+		v1alpha1.DrainerMemberType,
+	}
+	for _, memberType := range components {
+		component := memberType.String()
+		metrics.ClusterSpecReplicas.DeleteLabelValues(ns, tcName, component)
+		metrics.ClusterFailureMembers.DeleteLabelValues(ns, tcName, component)
+		metrics.ClusterVolumeResizing.DeleteLabelValues(ns, tcName, component)
+		for _, phase := range memberPhases {
+			metrics.ClusterComponentPhase.DeleteLabelValues(ns, tcName, component, string(phase))
+		}
+	}
 }
 
 var _ ControlInterface = &defaultTidbClusterControl{}
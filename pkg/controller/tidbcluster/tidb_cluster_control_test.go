@@ -25,6 +25,8 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	mm "github.com/pingcap/tidb-operator/pkg/manager/member"
 	"github.com/pingcap/tidb-operator/pkg/manager/meta"
+	"github.com/pingcap/tidb-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -294,6 +296,49 @@ func TestTidbClusterStatusEquality(t *testing.T) {
 	g.Expect(apiequality.Semantic.DeepEqual(&tcStatus, tcStatusCopy)).To(Equal(false))
 }
 
+func TestTidbClusterControlRecordStatusMetrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTidbClusterControl()
+	tc.Name = "test-metrics"
+	tc.Status.PD.Phase = v1alpha1.UpgradePhase
+	tc.Status.PD.Members = map[string]v1alpha1.PDMember{
+		"pd-0": {Name: "pd-0", Health: true},
+		"pd-1": {Name: "pd-1", Health: false},
+	}
+	tc.Status.PD.PeerMembers = map[string]v1alpha1.PDMember{
+		"peer-pd-0": {Name: "peer-pd-0", Health: true},
+	}
+	tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+	tc.Status.TiKV.FailureStores = map[string]v1alpha1.TiKVFailureStore{"1": {StoreID: "1"}}
+	tc.Status.TiKV.EvictLeader = map[string]*v1alpha1.EvictLeaderStatus{"test-tikv-0": {}}
+	tc.Status.TiKV.Conditions = []metav1.Condition{{Type: v1alpha1.ComponentVolumeResizing, Status: metav1.ConditionTrue}}
+
+	c := &defaultTidbClusterControl{}
+	c.recordStatusMetrics(tc)
+	ns, name := tc.Namespace, tc.Name
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentPhase.WithLabelValues(ns, name, "pd", "Upgrade"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentPhase.WithLabelValues(ns, name, "pd", "Normal"))).To(BeZero())
+	g.Expect(testutil.ToFloat64(metrics.ClusterComponentPhase.WithLabelValues(ns, name, "tikv", "Normal"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(metrics.ClusterFailureMembers.WithLabelValues(ns, name, "tikv"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(metrics.ClusterFailureMembers.WithLabelValues(ns, name, "pd"))).To(BeZero())
+	g.Expect(testutil.ToFloat64(metrics.ClusterEvictLeaderStores.WithLabelValues(ns, name))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(metrics.ClusterVolumeResizing.WithLabelValues(ns, name, "tikv"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(metrics.ClusterVolumeResizing.WithLabelValues(ns, name, "pd"))).To(BeZero())
+	g.Expect(testutil.ToFloat64(metrics.ClusterPDQuorumHealthy.WithLabelValues(ns, name))).To(Equal(float64(1)))
+
+	// the quorum is lost if the majority of the PD members are unhealthy
+	tc.Status.PD.PeerMembers["peer-pd-0"] = v1alpha1.PDMember{Name: "peer-pd-0", Health: false}
+	c.recordStatusMetrics(tc)
+	g.Expect(testutil.ToFloat64(metrics.ClusterPDQuorumHealthy.WithLabelValues(ns, name))).To(BeZero())
+
+	// nothing is left to delete after the metrics of the cluster are deleted
+	deleteClusterMetrics(ns, name)
+	g.Expect(metrics.ClusterPDQuorumHealthy.DeleteLabelValues(ns, name)).To(BeFalse())
+	g.Expect(metrics.ClusterComponentPhase.DeleteLabelValues(ns, name, "pd", "Upgrade")).To(BeFalse())
+	g.Expect(metrics.ClusterFailureMembers.DeleteLabelValues(ns, name, "tikv")).To(BeFalse())
+}
+
 func newFakeTidbClusterControl() (
 	ControlInterface,
 	*meta.FakeReclaimPolicyManager,
@@ -310,6 +355,7 @@ func newFakeTidbClusterControl() (
 
 	tcUpdater := controller.NewFakeTidbClusterControl(tcInformer)
 	pdMemberManager := mm.NewFakePDMemberManager()
+	pdMSMemberManager := mm.NewFakePDMSMemberManager()
 	tikvMemberManager := mm.NewFakeTiKVMemberManager()
 	tidbMemberManager := mm.NewFakeTiDBMemberManager()
 	reclaimPolicyManager := meta.NewFakeReclaimPolicyManager()
@@ -317,14 +363,18 @@ func newFakeTidbClusterControl() (
 	orphanPodCleaner := mm.NewFakeOrphanPodsCleaner()
 	pvcCleaner := mm.NewFakePVCCleaner()
 	pumpMemberManager := mm.NewFakePumpMemberManager()
+	drainerMemberManager := mm.NewFakeDrainerMemberManager()
 	tiflashMemberManager := mm.NewFakeTiFlashMemberManager()
 	ticdcMemberManager := mm.NewFakeTiCDCMemberManager()
+	tiproxyMemberManager := mm.NewFakeTiProxyMemberManager()
 	discoveryManager := mm.NewFakeDiscoveryManger()
 	statusManager := mm.NewFakeTidbClusterStatusManager()
+	upgradePreflightManager := mm.NewFakeUpgradePreflightManager()
 	pvcResizer := mm.NewFakePVCResizer()
 	control := NewDefaultTidbClusterControl(
 		tcUpdater,
 		pdMemberManager,
+		pdMSMemberManager,
 		tikvMemberManager,
 		tidbMemberManager,
 		reclaimPolicyManager,
@@ -333,10 +383,13 @@ func newFakeTidbClusterControl() (
 		pvcCleaner,
 		pvcResizer,
 		pumpMemberManager,
+		drainerMemberManager,
 		tiflashMemberManager,
 		ticdcMemberManager,
+		tiproxyMemberManager,
 		discoveryManager,
 		statusManager,
+		upgradePreflightManager,
 		&tidbClusterConditionUpdater{},
 		recorder,
 	)
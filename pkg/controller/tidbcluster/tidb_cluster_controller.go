@@ -49,6 +49,7 @@ func NewController(deps *controller.Dependencies) *Controller {
 		control: NewDefaultTidbClusterControl(
 			deps.TiDBClusterControl,
 			mm.NewPDMemberManager(deps, mm.NewPDScaler(deps), mm.NewPDUpgrader(deps), mm.NewPDFailover(deps)),
+			mm.NewPDMSMemberManager(deps, mm.NewPDMSScaler(deps), mm.NewPDMSUpgrader(deps)),
 			mm.NewTiKVMemberManager(deps, mm.NewTiKVFailover(deps), mm.NewTiKVScaler(deps), mm.NewTiKVUpgrader(deps)),
 			mm.NewTiDBMemberManager(deps, mm.NewTiDBScaler(deps), mm.NewTiDBUpgrader(deps), mm.NewTiDBFailover(deps)),
 			meta.NewReclaimPolicyManager(deps),
@@ -57,10 +58,13 @@ func NewController(deps *controller.Dependencies) *Controller {
 			mm.NewRealPVCCleaner(deps),
 			mm.NewPVCResizer(deps),
 			mm.NewPumpMemberManager(deps, mm.NewPumpScaler(deps)),
+			mm.NewDrainerMemberManager(deps, mm.NewDrainerScaler(deps)),
 			mm.NewTiFlashMemberManager(deps, mm.NewTiFlashFailover(deps), mm.NewTiFlashScaler(deps), mm.NewTiFlashUpgrader(deps)),
 			mm.NewTiCDCMemberManager(deps, mm.NewTiCDCScaler(deps), mm.NewTiCDCUpgrader(deps)),
+			mm.NewTiProxyMemberManager(deps, mm.NewTiProxyScaler(deps), mm.NewTiProxyUpgrader(deps)),
 			mm.NewTidbDiscoveryManager(deps),
 			mm.NewTidbClusterStatusManager(deps),
+			mm.NewUpgradePreflightManager(deps),
 			&tidbClusterConditionUpdater{},
 			deps.Recorder,
 		),
@@ -133,10 +137,11 @@ func (c *Controller) processNextWorkItem() bool {
 }
 
 // sync syncs the given tidbcluster.
-func (c *Controller) sync(key string) error {
+func (c *Controller) sync(key string) (err error) {
 	startTime := time.Now()
 	defer func() {
 		klog.V(4).Infof("Finished syncing TidbCluster %q (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("TidbCluster", startTime, err)
 	}()
 
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
@@ -146,6 +151,7 @@ func (c *Controller) sync(key string) error {
 	tc, err := c.deps.TiDBClusterLister.TidbClusters(ns).Get(name)
 	if errors.IsNotFound(err) {
 		klog.Infof("TidbCluster has been deleted %v", key)
+		deleteClusterMetrics(ns, name)
 		return nil
 	}
 	if err != nil {
@@ -0,0 +1,620 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterreplication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/defaulting"
+	v1alpha1validation "github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1/validation"
+	backuputil "github.com/pingcap/tidb-operator/pkg/backup/util"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// bootstrapGCSafePointTTL is the ttl in seconds of the service gc safe point kept on the primary during
+// the bootstrap, it is renewed on every sync until the changefeed is created
+const bootstrapGCSafePointTTL = int64(time.Hour / time.Second)
+
+// ControlInterface provide function about control TidbClusterReplication
+type ControlInterface interface {
+	// Reconcile a TidbClusterReplication
+	Reconcile(*v1alpha1.TidbClusterReplication) error
+
+	// Update the status of a TidbClusterReplication
+	Update(*v1alpha1.TidbClusterReplication) (*v1alpha1.TidbClusterReplication, error)
+}
+
+// NewDefaultTidbClusterReplicationControl returns a defaultTidbClusterReplicationControl instance
+func NewDefaultTidbClusterReplicationControl(deps *controller.Dependencies, sqlControl TiDBSQLControlInterface, recorder record.EventRecorder) *defaultTidbClusterReplicationControl {
+	return &defaultTidbClusterReplicationControl{
+		deps:       deps,
+		sqlControl: sqlControl,
+		recorder:   recorder,
+	}
+}
+
+type defaultTidbClusterReplicationControl struct {
+	deps       *controller.Dependencies
+	sqlControl TiDBSQLControlInterface
+	recorder   record.EventRecorder
+}
+
+func (c *defaultTidbClusterReplicationControl) Reconcile(tcr *v1alpha1.TidbClusterReplication) error {
+	c.defaulting(tcr)
+
+	if tcr.DeletionTimestamp != nil {
+		// the Backup, Restore and TiCDCChangefeed are garbage collected with the owner
+		return nil
+	}
+
+	if !c.validate(tcr) {
+		return nil // fatal error, no need to retry on invalid object
+	}
+
+	var errs []error
+
+	oldStatus := tcr.Status.DeepCopy()
+
+	err := c.reconcile(tcr)
+	if err != nil {
+		errs = append(errs, err)
+		// waiting for the Backup, Restore or TiCDCChangefeed is not a failure
+		if !controller.IsRequeueError(err) {
+			setSyncedCondition(tcr, metav1.ConditionFalse, "Failed", err.Error())
+		}
+	} else {
+		setSyncedCondition(tcr, metav1.ConditionTrue, "Synced", "")
+	}
+
+	if apiequality.Semantic.DeepEqual(&tcr.Status, oldStatus) {
+		return errorutils.NewAggregate(errs)
+	}
+
+	_, err = c.Update(tcr.DeepCopy())
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return errorutils.NewAggregate(errs)
+}
+
+func (c *defaultTidbClusterReplicationControl) reconcile(tcr *v1alpha1.TidbClusterReplication) error {
+	if tcr.Status.Primary == "" {
+		tcr.Status.Primary = tcr.Spec.Primary.Key()
+	}
+
+	if tcr.IsSwitchingOver() || tcr.Status.Primary != tcr.Spec.Primary.Key() {
+		done, err := c.switchover(tcr)
+		if err != nil || !done {
+			return err
+		}
+	}
+
+	if tcr.Status.ChangefeedName == "" && tcr.Spec.Bootstrap != nil {
+		done, err := c.bootstrap(tcr)
+		if err != nil || !done {
+			return err
+		}
+	}
+
+	return c.syncChangefeed(tcr, &tcr.Spec.Primary, &tcr.Spec.Secondary)
+}
+
+// switchover stops the writes to the old primary, waits for the changefeed to catch up with the
+// stopped writes and then removes the changefeed, the changefeed in the reversed direction is
+// created by syncChangefeed after the switchover is done.
+func (c *defaultTidbClusterReplicationControl) switchover(tcr *v1alpha1.TidbClusterReplication) (bool, error) {
+	ns := tcr.GetNamespace()
+	name := tcr.GetName()
+
+	if tcr.Status.Primary == tcr.Spec.Primary.Key() {
+		// the changefeed may have been removed, it is recreated from where the writes are stopped
+		if tcr.Status.SwitchoverStep == v1alpha1.SwitchoverStepReverse {
+			tcr.Status.StartTS = tcr.Status.StoppedTS
+		}
+		// the primary is swapped back before the switchover is done, resume the writes to it
+		if err := c.setReadOnly(tcr, &tcr.Spec.Primary, false); err != nil {
+			return false, err
+		}
+		klog.Infof("TidbClusterReplication: [%s/%s] switchover is cancelled at step %s", ns, name, tcr.Status.SwitchoverStep)
+		c.recorder.Eventf(tcr, corev1.EventTypeNormal, "SwitchoverCancelled", "switchover is cancelled at step %s", tcr.Status.SwitchoverStep)
+		tcr.Status.SwitchoverStep = ""
+		tcr.Status.StoppedTS = 0
+		return true, nil
+	}
+
+	if tcr.Status.ChangefeedName == "" {
+		return false, fmt.Errorf("tcr %s/%s can not switch over before the replication starts", ns, name)
+	}
+
+	// the old primary is the secondary in the spec during the switchover
+	oldPrimary := &tcr.Spec.Secondary
+	newPrimary := &tcr.Spec.Primary
+	tcr.Status.Phase = v1alpha1.TidbClusterReplicationSwitchingOver
+
+	switch tcr.Status.SwitchoverStep {
+	case "":
+		tcr.Status.SwitchoverStep = v1alpha1.SwitchoverStepStopWrites
+		c.recorder.Eventf(tcr, corev1.EventTypeNormal, "SwitchoverStarted", "switch over from %s to %s", oldPrimary.Key(), newPrimary.Key())
+		fallthrough
+	case v1alpha1.SwitchoverStepStopWrites:
+		if err := c.setReadOnly(tcr, oldPrimary, true); err != nil {
+			return false, err
+		}
+		tso, err := c.currentTSO(tcr, oldPrimary)
+		if err != nil {
+			return false, err
+		}
+		klog.Infof("TidbClusterReplication: [%s/%s] writes to %s are stopped at %d", ns, name, oldPrimary.Key(), tso)
+		tcr.Status.StoppedTS = tso
+		tcr.Status.SwitchoverStep = v1alpha1.SwitchoverStepCatchUp
+		fallthrough
+	case v1alpha1.SwitchoverStepCatchUp:
+		cf, err := c.deps.TiCDCChangefeedLister.TiCDCChangefeeds(ns).Get(tcr.Status.ChangefeedName)
+		if err != nil {
+			return false, fmt.Errorf("get changefeed %s/%s failed: %v", ns, tcr.Status.ChangefeedName, err)
+		}
+		c.syncCheckpoint(tcr, cf)
+		if cf.Status.CheckpointTS < tcr.Status.StoppedTS {
+			return false, controller.RequeueErrorf("checkpoint %d of changefeed %s/%s has not caught up with %d", cf.Status.CheckpointTS, ns, cf.Name, tcr.Status.StoppedTS)
+		}
+		tcr.Status.SwitchoverStep = v1alpha1.SwitchoverStepReverse
+		fallthrough
+	case v1alpha1.SwitchoverStepReverse:
+		removed, err := c.removeChangefeed(tcr, tcr.Status.ChangefeedName)
+		if err != nil || !removed {
+			return false, err
+		}
+		// the old primary is kept read only, the changefeed in the reversed direction writes to it
+		// with the privilege to bypass the read only
+		if err := c.grantReplicaWriter(tcr, oldPrimary); err != nil {
+			return false, err
+		}
+		// the changefeed in the reversed direction starts from the TSO of the new primary, which is
+		// taken before the writes to it are resumed so that no write is missed
+		tso, err := c.currentTSO(tcr, newPrimary)
+		if err != nil {
+			return false, err
+		}
+		tcr.Status.StartTS = tso
+		if err := c.setReadOnly(tcr, newPrimary, false); err != nil {
+			return false, err
+		}
+	default:
+		return false, fmt.Errorf("unknown switchover step %s of tcr %s/%s", tcr.Status.SwitchoverStep, ns, name)
+	}
+
+	klog.Infof("TidbClusterReplication: [%s/%s] switched over from %s to %s", ns, name, oldPrimary.Key(), newPrimary.Key())
+	c.recorder.Eventf(tcr, corev1.EventTypeNormal, "SwitchedOver", "switched over from %s to %s", oldPrimary.Key(), newPrimary.Key())
+	tcr.Status.Primary = newPrimary.Key()
+	tcr.Status.ChangefeedName = tcr.GetChangefeedName(newPrimary)
+	tcr.Status.SwitchoverStep = ""
+	tcr.Status.StoppedTS = 0
+	return true, nil
+}
+
+// bootstrap restores the secondary from a full backup of the primary, the changefeed starts from
+// the commit TS of the backup. GC of the primary is held at the commit TS until the changefeed is
+// created, the failed Backup and Restore are recreated to retry.
+func (c *defaultTidbClusterReplicationControl) bootstrap(tcr *v1alpha1.TidbClusterReplication) (bool, error) {
+	ns := tcr.GetNamespace()
+	name := tcr.GetBootstrapName()
+	tcr.Status.Phase = v1alpha1.TidbClusterReplicationBootstrapping
+
+	backup, err := c.deps.BackupLister.Backups(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		backup, err = c.deps.Clientset.PingcapV1alpha1().Backups(ns).Create(context.TODO(), c.newBackup(tcr), metav1.CreateOptions{})
+		if err != nil {
+			return false, fmt.Errorf("create backup %s/%s failed: %v", ns, name, err)
+		}
+		klog.Infof("TidbClusterReplication: [%s/%s] backup %s is created", ns, tcr.Name, name)
+		c.recorder.Eventf(tcr, corev1.EventTypeNormal, "BackupCreated", "backup %s of %s is created", name, tcr.Spec.Primary.Key())
+	} else if err != nil {
+		return false, fmt.Errorf("get backup %s/%s failed: %v", ns, name, err)
+	}
+	tcr.Status.BackupName = name
+
+	if v1alpha1.IsBackupFailed(backup) {
+		if backup.DeletionTimestamp == nil {
+			if err := c.deps.Clientset.PingcapV1alpha1().Backups(ns).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return false, fmt.Errorf("delete failed backup %s/%s failed: %v", ns, name, err)
+			}
+			klog.Infof("TidbClusterReplication: [%s/%s] backup %s failed and is deleted to retry", ns, tcr.Name, name)
+			c.recorder.Eventf(tcr, corev1.EventTypeWarning, "BootstrapFailed", "backup %s failed, it is recreated to retry", name)
+		}
+		return false, controller.RequeueErrorf("backup %s/%s failed and is being recreated", ns, name)
+	}
+	if !v1alpha1.IsBackupComplete(backup) {
+		return false, nil
+	}
+	if tcr.Status.StartTS == 0 {
+		ts, err := backuputil.ParseTSString(backup.Status.CommitTs)
+		if err != nil {
+			return false, fmt.Errorf("parse commit ts %q of backup %s/%s failed: %v", backup.Status.CommitTs, ns, name, err)
+		}
+		tcr.Status.StartTS = ts
+	}
+	if err := c.holdGC(tcr, bootstrapGCSafePointTTL); err != nil {
+		return false, err
+	}
+
+	restore, err := c.deps.RestoreLister.Restores(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		restore, err = c.deps.Clientset.PingcapV1alpha1().Restores(ns).Create(context.TODO(), c.newRestore(tcr), metav1.CreateOptions{})
+		if err != nil {
+			return false, fmt.Errorf("create restore %s/%s failed: %v", ns, name, err)
+		}
+		klog.Infof("TidbClusterReplication: [%s/%s] restore %s is created", ns, tcr.Name, name)
+		c.recorder.Eventf(tcr, corev1.EventTypeNormal, "RestoreCreated", "restore %s to %s is created", name, tcr.Spec.Secondary.Key())
+	} else if err != nil {
+		return false, fmt.Errorf("get restore %s/%s failed: %v", ns, name, err)
+	}
+	tcr.Status.RestoreName = name
+
+	if v1alpha1.IsRestoreFailed(restore) {
+		if restore.DeletionTimestamp == nil {
+			if err := c.deps.Clientset.PingcapV1alpha1().Restores(ns).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return false, fmt.Errorf("delete failed restore %s/%s failed: %v", ns, name, err)
+			}
+			klog.Infof("TidbClusterReplication: [%s/%s] restore %s failed and is deleted to retry", ns, tcr.Name, name)
+			c.recorder.Eventf(tcr, corev1.EventTypeWarning, "BootstrapFailed", "restore %s failed, it is recreated to retry", name)
+		}
+		return false, controller.RequeueErrorf("restore %s/%s failed and is being recreated", ns, name)
+	}
+	return v1alpha1.IsRestoreComplete(restore), nil
+}
+
+// holdGC keeps GC of the primary from advancing beyond the start TS of the changefeed during the ttl (in seconds),
+// the service gc safe point is removed if ttl is 0
+func (c *defaultTidbClusterReplicationControl) holdGC(tcr *v1alpha1.TidbClusterReplication, ttl int64) error {
+	primary := &tcr.Spec.Primary
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(primary.Namespace).Get(primary.Name)
+	if err != nil {
+		return fmt.Errorf("get tc %s failed: %s", primary.Key(), err)
+	}
+	safePoint := tcr.Status.StartTS
+	if ttl == 0 {
+		safePoint = 0
+	}
+	if err := controller.GetPDClient(c.deps.PDControl, tc).UpdateServiceGCSafePoint(bootstrapServiceID(tcr), ttl, safePoint); err != nil {
+		return fmt.Errorf("update gc safe point of tc %s to %d failed: %v", primary.Key(), safePoint, err)
+	}
+	return nil
+}
+
+func (c *defaultTidbClusterReplicationControl) newBackup(tcr *v1alpha1.TidbClusterReplication) *v1alpha1.Backup {
+	bootstrap := tcr.Spec.Bootstrap
+	return &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            tcr.GetBootstrapName(),
+			Namespace:       tcr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{controller.GetTidbClusterReplicationOwnerRef(tcr)},
+		},
+		Spec: v1alpha1.BackupSpec{
+			Type:            v1alpha1.BackupTypeFull,
+			StorageProvider: *bootstrap.StorageProvider.DeepCopy(),
+			BR:              brConfig(bootstrap, &tcr.Spec.Primary),
+			ToolImage:       bootstrap.ToolImage,
+			ServiceAccount:  bootstrap.ServiceAccount,
+		},
+	}
+}
+
+func (c *defaultTidbClusterReplicationControl) newRestore(tcr *v1alpha1.TidbClusterReplication) *v1alpha1.Restore {
+	bootstrap := tcr.Spec.Bootstrap
+	return &v1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            tcr.GetBootstrapName(),
+			Namespace:       tcr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{controller.GetTidbClusterReplicationOwnerRef(tcr)},
+		},
+		Spec: v1alpha1.RestoreSpec{
+			Type:            v1alpha1.BackupTypeFull,
+			StorageProvider: *bootstrap.StorageProvider.DeepCopy(),
+			BR:              brConfig(bootstrap, &tcr.Spec.Secondary),
+			ToolImage:       bootstrap.ToolImage,
+			ServiceAccount:  bootstrap.ServiceAccount,
+		},
+	}
+}
+
+// syncChangefeed ensures the TiCDCChangefeed replicating the data from one TidbCluster to the other
+func (c *defaultTidbClusterReplicationControl) syncChangefeed(tcr *v1alpha1.TidbClusterReplication, from, to *v1alpha1.TidbClusterReplicationMember) error {
+	ns := tcr.GetNamespace()
+
+	tc, err := c.deps.TiDBClusterLister.TidbClusters(from.Namespace).Get(from.Name)
+	if err != nil {
+		return fmt.Errorf("get tc %s failed: %s", from.Key(), err)
+	}
+	if tc.Spec.TiCDC == nil {
+		return fmt.Errorf("tc %s has no ticdc", from.Key())
+	}
+	if tc.Status.TiCDC.Phase != v1alpha1.NormalPhase || len(tc.Status.TiCDC.Captures) == 0 {
+		return controller.RequeueErrorf("ticdc of tc %s is not ready", from.Key())
+	}
+
+	name := tcr.GetChangefeedName(from)
+	spec := v1alpha1.TiCDCChangefeedSpec{
+		Cluster:       from.TidbClusterRef,
+		SinkURI:       fmt.Sprintf("mysql://%s/", c.tidbAddr(to)),
+		SinkSecretRef: to.SecretRef.DeepCopy(),
+		StartTS:       tcr.Status.StartTS,
+		Filter:        tcr.Spec.Filter.DeepCopy(),
+		Config:        tcr.Spec.Config.DeepCopy(),
+	}
+
+	cf, err := c.deps.TiCDCChangefeedLister.TiCDCChangefeeds(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		// only the changefeed writes to the secondary
+		if err := c.grantReplicaWriter(tcr, to); err != nil {
+			return err
+		}
+		if err := c.setReadOnly(tcr, to, true); err != nil {
+			return err
+		}
+		cf = &v1alpha1.TiCDCChangefeed{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       ns,
+				OwnerReferences: []metav1.OwnerReference{controller.GetTidbClusterReplicationOwnerRef(tcr)},
+			},
+			Spec: spec,
+		}
+		if _, err := c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(ns).Create(context.TODO(), cf, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("create changefeed %s/%s failed: %v", ns, name, err)
+		}
+		klog.Infof("TidbClusterReplication: [%s/%s] changefeed %s is created", ns, tcr.Name, name)
+		c.recorder.Eventf(tcr, corev1.EventTypeNormal, "ChangefeedCreated", "changefeed %s from %s to %s is created", name, from.Key(), to.Key())
+		tcr.Status.ChangefeedName = name
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get changefeed %s/%s failed: %v", ns, name, err)
+	}
+	if cf.DeletionTimestamp != nil {
+		// the changefeed removed by the cancelled switchover is recreated after it is gone
+		return controller.RequeueErrorf("changefeed %s/%s is being deleted", ns, name)
+	}
+
+	// the changefeed keeps the cluster and the start ts it is created with
+	if !apiequality.Semantic.DeepEqual(cf.Spec.SinkURI, spec.SinkURI) ||
+		!apiequality.Semantic.DeepEqual(cf.Spec.SinkSecretRef, spec.SinkSecretRef) ||
+		!apiequality.Semantic.DeepEqual(cf.Spec.Filter, spec.Filter) ||
+		!apiequality.Semantic.DeepEqual(cf.Spec.Config, spec.Config) {
+		update := cf.DeepCopy()
+		update.Spec.SinkURI = spec.SinkURI
+		update.Spec.SinkSecretRef = spec.SinkSecretRef
+		update.Spec.Filter = spec.Filter
+		update.Spec.Config = spec.Config
+		if _, err := c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(ns).Update(context.TODO(), update, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("update changefeed %s/%s failed: %v", ns, name, err)
+		}
+		klog.Infof("TidbClusterReplication: [%s/%s] changefeed %s is updated", ns, tcr.Name, name)
+	}
+
+	tcr.Status.ChangefeedName = name
+	c.syncCheckpoint(tcr, cf)
+	if cf.Status.ChangefeedID == "" {
+		return controller.RequeueErrorf("changefeed %s/%s has not been created in ticdc", ns, name)
+	}
+	if tcr.Status.Phase == v1alpha1.TidbClusterReplicationBootstrapping {
+		// ticdc holds GC of the primary since the changefeed is created
+		if err := c.holdGC(tcr, 0); err != nil {
+			return err
+		}
+	}
+	tcr.Status.Phase = v1alpha1.TidbClusterReplicationReplicating
+	return nil
+}
+
+// syncCheckpoint mirrors the checkpoint and the lag of the changefeed into the status
+func (c *defaultTidbClusterReplicationControl) syncCheckpoint(tcr *v1alpha1.TidbClusterReplication, cf *v1alpha1.TiCDCChangefeed) {
+	tcr.Status.CheckpointTS = cf.Status.CheckpointTS
+	tcr.Status.RPOSeconds = cf.Status.LagSeconds
+}
+
+// removeChangefeed deletes the TiCDCChangefeed and returns whether it is gone
+func (c *defaultTidbClusterReplicationControl) removeChangefeed(tcr *v1alpha1.TidbClusterReplication, name string) (bool, error) {
+	ns := tcr.GetNamespace()
+
+	cf, err := c.deps.TiCDCChangefeedLister.TiCDCChangefeeds(ns).Get(name)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get changefeed %s/%s failed: %v", ns, name, err)
+	}
+	if cf.DeletionTimestamp == nil {
+		err := c.deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("delete changefeed %s/%s failed: %v", ns, name, err)
+		}
+		klog.Infof("TidbClusterReplication: [%s/%s] changefeed %s is deleted", ns, tcr.Name, name)
+	}
+	// wait for the changefeed to be removed from TiCDC
+	return false, controller.RequeueErrorf("changefeed %s/%s is being deleted", ns, name)
+}
+
+func (c *defaultTidbClusterReplicationControl) setReadOnly(tcr *v1alpha1.TidbClusterReplication, m *v1alpha1.TidbClusterReplicationMember, readOnly bool) error {
+	addr, user, password, err := c.connectionInfo(tcr, m)
+	if err != nil {
+		return err
+	}
+	if err := c.sqlControl.SetReadOnly(addr, user, password, readOnly); err != nil {
+		return fmt.Errorf("set tidb_restricted_read_only of tc %s to %t failed: %v", m.Key(), readOnly, err)
+	}
+	return nil
+}
+
+// grantReplicaWriter grants the privilege to write to the read only member to the user of the member
+func (c *defaultTidbClusterReplicationControl) grantReplicaWriter(tcr *v1alpha1.TidbClusterReplication, m *v1alpha1.TidbClusterReplicationMember) error {
+	addr, user, password, err := c.connectionInfo(tcr, m)
+	if err != nil {
+		return err
+	}
+	if err := c.sqlControl.GrantReplicaWriter(addr, user, password); err != nil {
+		return fmt.Errorf("grant RESTRICTED_REPLICA_WRITER_ADMIN to the user of tc %s failed: %v", m.Key(), err)
+	}
+	return nil
+}
+
+func (c *defaultTidbClusterReplicationControl) currentTSO(tcr *v1alpha1.TidbClusterReplication, m *v1alpha1.TidbClusterReplicationMember) (uint64, error) {
+	addr, user, password, err := c.connectionInfo(tcr, m)
+	if err != nil {
+		return 0, err
+	}
+	tso, err := c.sqlControl.GetCurrentTSO(addr, user, password)
+	if err != nil {
+		return 0, fmt.Errorf("get current tso of tc %s failed: %v", m.Key(), err)
+	}
+	return tso, nil
+}
+
+// connectionInfo returns the address of the TiDB service of the member and the credentials in the Secret
+func (c *defaultTidbClusterReplicationControl) connectionInfo(tcr *v1alpha1.TidbClusterReplication, m *v1alpha1.TidbClusterReplicationMember) (string, string, string, error) {
+	ref := m.SecretRef
+	secret, err := c.deps.SecretLister.Secrets(tcr.Namespace).Get(ref.Name)
+	if err != nil {
+		return "", "", "", fmt.Errorf("get secret %s/%s failed: %v", tcr.Namespace, ref.Name, err)
+	}
+	user, ok := secret.Data[ref.UserKey]
+	if !ok {
+		return "", "", "", fmt.Errorf("key %s is not found in secret %s/%s", ref.UserKey, tcr.Namespace, ref.Name)
+	}
+	password, ok := secret.Data[ref.PasswordKey]
+	if !ok {
+		return "", "", "", fmt.Errorf("key %s is not found in secret %s/%s", ref.PasswordKey, tcr.Namespace, ref.Name)
+	}
+	return c.tidbAddr(m), string(user), string(password), nil
+}
+
+// tidbAddr returns the address of the TiDB service of the member, the default port is used
+// if the TidbCluster is not found by the lister
+func (c *defaultTidbClusterReplicationControl) tidbAddr(m *v1alpha1.TidbClusterReplicationMember) string {
+	port := v1alpha1.DefaultTiDBServicePort
+	if tc, err := c.deps.TiDBClusterLister.TidbClusters(m.Namespace).Get(m.Name); err == nil && tc.Spec.TiDB != nil {
+		port = tc.Spec.TiDB.GetServicePort()
+	}
+	return fmt.Sprintf("%s-tidb.%s.svc:%d", m.Name, m.Namespace, port)
+}
+
+func (c *defaultTidbClusterReplicationControl) Update(tcr *v1alpha1.TidbClusterReplication) (*v1alpha1.TidbClusterReplication, error) {
+	var (
+		ns     string                                 = tcr.GetNamespace()
+		name   string                                 = tcr.GetName()
+		status *v1alpha1.TidbClusterReplicationStatus = tcr.Status.DeepCopy()
+		update *v1alpha1.TidbClusterReplication
+	)
+
+	// don't wait due to limited number of clients, but backoff after the default number of steps
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var updateErr error
+
+		update, updateErr = c.deps.Clientset.PingcapV1alpha1().TidbClusterReplications(ns).UpdateStatus(context.TODO(), tcr, metav1.UpdateOptions{})
+		if updateErr == nil {
+			klog.V(4).Infof("TidbClusterReplication: [%s/%s] updated successfully", ns, name)
+			return nil
+		}
+
+		klog.V(4).Infof("failed to update TidbClusterReplication: [%s/%s], error: %v", ns, name, updateErr)
+
+		if updated, err := c.deps.TiDBClusterReplicationLister.TidbClusterReplications(ns).Get(name); err == nil {
+			// make a copy so we don't mutate the shared cache
+			tcr = updated.DeepCopy()
+			tcr.Status = *status
+		} else {
+			utilruntime.HandleError(fmt.Errorf("error getting updated TidbClusterReplication %s/%s from lister: %v", ns, name, err))
+		}
+
+		return updateErr
+	})
+	if err != nil {
+		klog.Errorf("failed to update TidbClusterReplication: [%s/%s], error: %v", ns, name, err)
+	}
+	return update, err
+}
+
+func (c *defaultTidbClusterReplicationControl) defaulting(tcr *v1alpha1.TidbClusterReplication) {
+	defaulting.SetTidbClusterReplicationDefault(tcr)
+}
+
+func (c *defaultTidbClusterReplicationControl) validate(tcr *v1alpha1.TidbClusterReplication) bool {
+	errs := v1alpha1validation.ValidateTidbClusterReplication(tcr)
+	if len(errs) > 0 {
+		aggregatedErr := errs.ToAggregate()
+		klog.Errorf("tidb cluster replication %s/%s is not valid and must be fixed first, aggregated error: %v", tcr.GetNamespace(), tcr.GetName(), aggregatedErr)
+		c.recorder.Event(tcr, corev1.EventTypeWarning, "FailedValidation", aggregatedErr.Error())
+		return false
+	}
+	return true
+}
+
+// brConfig returns the BR config of the bootstrap targeting the member
+func brConfig(bootstrap *v1alpha1.TidbClusterReplicationBootstrap, m *v1alpha1.TidbClusterReplicationMember) *v1alpha1.BRConfig {
+	br := &v1alpha1.BRConfig{}
+	if bootstrap.BR != nil {
+		br = bootstrap.BR.DeepCopy()
+	}
+	br.Cluster = m.Name
+	br.ClusterNamespace = m.Namespace
+	return br
+}
+
+// bootstrapServiceID returns the id of the service gc safe point kept during the bootstrap
+func bootstrapServiceID(tcr *v1alpha1.TidbClusterReplication) string {
+	return fmt.Sprintf("tidb-operator-replication-%s-%s", tcr.Namespace, tcr.Name)
+}
+
+func setSyncedCondition(tcr *v1alpha1.TidbClusterReplication, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&tcr.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.TidbClusterReplicationSynced,
+		Status:             status,
+		ObservedGeneration: tcr.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+type FakeTidbClusterReplicationControl struct {
+	reconcile func(*v1alpha1.TidbClusterReplication) error
+}
+
+func (c *FakeTidbClusterReplicationControl) MockReconcile(reconcile func(*v1alpha1.TidbClusterReplication) error) {
+	c.reconcile = reconcile
+}
+
+func (c *FakeTidbClusterReplicationControl) Reconcile(tcr *v1alpha1.TidbClusterReplication) error {
+	if c.reconcile != nil {
+		return c.reconcile(tcr)
+	}
+	return nil
+}
+
+func (c *FakeTidbClusterReplicationControl) Update(tcr *v1alpha1.TidbClusterReplication) (*v1alpha1.TidbClusterReplication, error) {
+	return tcr, nil
+}
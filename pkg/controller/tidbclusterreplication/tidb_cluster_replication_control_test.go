@@ -0,0 +1,334 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterreplication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+
+	. "github.com/onsi/gomega"
+	perrors "github.com/pingcap/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestControl(g *GomegaWithT) (*defaultTidbClusterReplicationControl, *FakeTiDBSQLControl, *controller.Dependencies) {
+	deps := controller.NewFakeDependencies()
+	for _, name := range []string{"primary", "secondary"} {
+		tc := &v1alpha1.TidbCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1alpha1.TidbClusterSpec{
+				TiDB:  &v1alpha1.TiDBSpec{},
+				TiCDC: &v1alpha1.TiCDCSpec{},
+			},
+			Status: v1alpha1.TidbClusterStatus{
+				TiCDC: v1alpha1.TiCDCStatus{
+					Phase:    v1alpha1.NormalPhase,
+					Captures: map[string]v1alpha1.TiCDCCapture{"capture": {}},
+				},
+			},
+		}
+		g.Expect(deps.InformerFactory.Pingcap().V1alpha1().TidbClusters().Informer().GetIndexer().Add(tc)).To(Succeed())
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Data:       map[string][]byte{"user": []byte("root"), "password": []byte("secret")},
+		}
+		g.Expect(deps.KubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer().Add(secret)).To(Succeed())
+	}
+
+	sqlControl := NewFakeTiDBSQLControl()
+	return NewDefaultTidbClusterReplicationControl(deps, sqlControl, deps.Recorder), sqlControl, deps
+}
+
+func newTidbClusterReplication() *v1alpha1.TidbClusterReplication {
+	return &v1alpha1.TidbClusterReplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "tcr", Namespace: "ns", Generation: 1},
+		Spec: v1alpha1.TidbClusterReplicationSpec{
+			Primary: v1alpha1.TidbClusterReplicationMember{
+				TidbClusterRef: v1alpha1.TidbClusterRef{Name: "primary"},
+				SecretRef:      v1alpha1.TiCDCChangefeedSinkSecretRef{Name: "primary"},
+			},
+			Secondary: v1alpha1.TidbClusterReplicationMember{
+				TidbClusterRef: v1alpha1.TidbClusterRef{Name: "secondary"},
+				SecretRef:      v1alpha1.TiCDCChangefeedSinkSecretRef{Name: "secondary"},
+			},
+			Bootstrap: &v1alpha1.TidbClusterReplicationBootstrap{
+				StorageProvider: v1alpha1.StorageProvider{
+					S3: &v1alpha1.S3StorageProvider{Provider: v1alpha1.S3StorageProviderTypeAWS, Bucket: "bucket", Prefix: "dr"},
+				},
+			},
+		},
+	}
+}
+
+// reconcile reconciles the replication and returns the latest one in the api server
+func reconcile(g *GomegaWithT, control *defaultTidbClusterReplicationControl, deps *controller.Dependencies, tcr *v1alpha1.TidbClusterReplication) (*v1alpha1.TidbClusterReplication, error) {
+	indexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterReplications().Informer().GetIndexer()
+	g.Expect(indexer.Update(tcr)).To(Succeed())
+	err := control.Reconcile(tcr.DeepCopy())
+	updated, getErr := deps.Clientset.PingcapV1alpha1().TidbClusterReplications(tcr.Namespace).Get(context.TODO(), tcr.Name, metav1.GetOptions{})
+	g.Expect(getErr).NotTo(HaveOccurred())
+	return updated, err
+}
+
+// syncIndexer adds the objects created by the control to the listers
+func syncIndexer(g *GomegaWithT, deps *controller.Dependencies) {
+	ctx := context.TODO()
+	informers := deps.InformerFactory.Pingcap().V1alpha1()
+
+	backups, err := deps.Clientset.PingcapV1alpha1().Backups("ns").List(ctx, metav1.ListOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := range backups.Items {
+		g.Expect(informers.Backups().Informer().GetIndexer().Update(&backups.Items[i])).To(Succeed())
+	}
+	restores, err := deps.Clientset.PingcapV1alpha1().Restores("ns").List(ctx, metav1.ListOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := range restores.Items {
+		g.Expect(informers.Restores().Informer().GetIndexer().Update(&restores.Items[i])).To(Succeed())
+	}
+
+	indexer := informers.TiCDCChangefeeds().Informer().GetIndexer()
+	for _, obj := range indexer.List() {
+		g.Expect(indexer.Delete(obj)).To(Succeed())
+	}
+	cfs, err := deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds("ns").List(ctx, metav1.ListOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	for i := range cfs.Items {
+		g.Expect(indexer.Add(&cfs.Items[i])).To(Succeed())
+	}
+}
+
+// setCheckpoint sets the checkpoint of the changefeed as the changefeed controller does
+func setCheckpoint(g *GomegaWithT, deps *controller.Dependencies, name string, checkpointTS uint64, lag int64) {
+	client := deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds("ns")
+	cf, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	cf.Status.ChangefeedID = name
+	cf.Status.CheckpointTS = checkpointTS
+	cf.Status.LagSeconds = lag
+	_, err = client.UpdateStatus(context.TODO(), cf, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	syncIndexer(g, deps)
+}
+
+func TestTidbClusterReplicationControlReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+	control, sqlControl, deps := newTestControl(g)
+	ctx := context.TODO()
+
+	tc, err := deps.TiDBClusterLister.TidbClusters("ns").Get("primary")
+	g.Expect(err).NotTo(HaveOccurred())
+	var safePoint, safePointTTL int64 = -1, -1
+	pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+	pdClient.AddReaction(pdapi.UpdateServiceGCSafePointActionType, func(action *pdapi.Action) (interface{}, error) {
+		safePoint, safePointTTL = int64(action.SafePoint), action.TTL
+		return nil, nil
+	})
+
+	tcr := newTidbClusterReplication()
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusterReplications("ns").Create(ctx, tcr, metav1.CreateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// back up the primary
+	tcr, err = reconcile(g, control, deps, tcr)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcr.Status.Phase).To(Equal(v1alpha1.TidbClusterReplicationBootstrapping))
+	g.Expect(tcr.Status.Primary).To(Equal("ns/primary"))
+	g.Expect(tcr.Status.BackupName).To(Equal("tcr-bootstrap"))
+	backup, err := deps.Clientset.PingcapV1alpha1().Backups("ns").Get(ctx, "tcr-bootstrap", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(backup.Spec.BR.Cluster).To(Equal("primary"))
+	g.Expect(backup.Spec.S3.Bucket).To(Equal("bucket"))
+
+	// restore the secondary from the completed backup
+	v1alpha1.UpdateBackupCondition(&backup.Status, &v1alpha1.BackupCondition{Type: v1alpha1.BackupComplete, Status: corev1.ConditionTrue})
+	backup.Status.CommitTs = "435000000000000000"
+	_, err = deps.Clientset.PingcapV1alpha1().Backups("ns").UpdateStatus(ctx, backup, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	syncIndexer(g, deps)
+	tcr, err = reconcile(g, control, deps, tcr)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcr.Status.StartTS).To(Equal(uint64(435000000000000000)))
+	g.Expect(safePoint).To(Equal(int64(435000000000000000)))
+	g.Expect(safePointTTL).To(Equal(bootstrapGCSafePointTTL))
+	g.Expect(tcr.Status.RestoreName).To(Equal("tcr-bootstrap"))
+	restore, err := deps.Clientset.PingcapV1alpha1().Restores("ns").Get(ctx, "tcr-bootstrap", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(restore.Spec.BR.Cluster).To(Equal("secondary"))
+
+	// start the changefeed from the commit ts of the backup
+	v1alpha1.UpdateRestoreCondition(&restore.Status, &v1alpha1.RestoreCondition{Type: v1alpha1.RestoreComplete, Status: corev1.ConditionTrue})
+	_, err = deps.Clientset.PingcapV1alpha1().Restores("ns").UpdateStatus(ctx, restore, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	syncIndexer(g, deps)
+	tcr, err = reconcile(g, control, deps, tcr)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcr.Status.Phase).To(Equal(v1alpha1.TidbClusterReplicationBootstrapping))
+	g.Expect(tcr.Status.ChangefeedName).To(Equal("tcr-from-primary"))
+	g.Expect(sqlControl.ReadOnly["secondary-tidb.ns.svc:4000"]).To(BeTrue())
+	g.Expect(sqlControl.ReplicaWriters["secondary-tidb.ns.svc:4000"]).To(Equal("root"))
+	cf, err := deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds("ns").Get(ctx, "tcr-from-primary", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cf.Spec.Cluster.Name).To(Equal("primary"))
+	g.Expect(cf.Spec.SinkURI).To(Equal("mysql://secondary-tidb.ns.svc:4000/"))
+	g.Expect(cf.Spec.SinkSecretRef.Name).To(Equal("secondary"))
+	g.Expect(cf.Spec.StartTS).To(Equal(uint64(435000000000000000)))
+
+	// the gc safe point is removed after the changefeed is created in ticdc, and the rpo is reported
+	setCheckpoint(g, deps, "tcr-from-primary", 435000000000000100, 3)
+	tcr, err = reconcile(g, control, deps, tcr)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcr.Status.Phase).To(Equal(v1alpha1.TidbClusterReplicationReplicating))
+	g.Expect(safePoint).To(Equal(int64(0)))
+	g.Expect(safePointTTL).To(Equal(int64(0)))
+	g.Expect(tcr.Status.CheckpointTS).To(Equal(uint64(435000000000000100)))
+	g.Expect(tcr.Status.RPOSeconds).To(Equal(int64(3)))
+
+	// switch over, the writes to the old primary are stopped and the checkpoint has not caught up
+	tcr.Spec.Primary, tcr.Spec.Secondary = tcr.Spec.Secondary, tcr.Spec.Primary
+	tcr.Generation = 2
+	_, err = deps.Clientset.PingcapV1alpha1().TidbClusterReplications("ns").Update(ctx, tcr, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	sqlControl.TSO = 435000000000000200
+	tcr, err = reconcile(g, control, deps, tcr)
+	g.Expect(perrors.Find(err, controller.IsRequeueError)).NotTo(BeNil())
+	g.Expect(tcr.Status.Phase).To(Equal(v1alpha1.TidbClusterReplicationSwitchingOver))
+	g.Expect(tcr.Status.SwitchoverStep).To(Equal(v1alpha1.SwitchoverStepCatchUp))
+	g.Expect(tcr.Status.StoppedTS).To(Equal(uint64(435000000000000200)))
+	g.Expect(sqlControl.ReadOnly["primary-tidb.ns.svc:4000"]).To(BeTrue())
+
+	// the checkpoint catches up and the changefeed is removed
+	setCheckpoint(g, deps, "tcr-from-primary", 435000000000000200, 0)
+	tcr, err = reconcile(g, control, deps, tcr)
+	g.Expect(perrors.Find(err, controller.IsRequeueError)).NotTo(BeNil())
+	g.Expect(tcr.Status.SwitchoverStep).To(Equal(v1alpha1.SwitchoverStepReverse))
+	syncIndexer(g, deps)
+
+	// reverse the direction of the replication from the tso of the new primary, the old primary is kept read only
+	sqlControl.TSO = 435000000000000300
+	tcr, err = reconcile(g, control, deps, tcr)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcr.Status.Primary).To(Equal("ns/secondary"))
+	g.Expect(tcr.Status.SwitchoverStep).To(BeEmpty())
+	g.Expect(tcr.Status.StoppedTS).To(BeZero())
+	g.Expect(tcr.Status.StartTS).To(Equal(uint64(435000000000000300)))
+	g.Expect(tcr.Status.ChangefeedName).To(Equal("tcr-from-secondary"))
+	g.Expect(sqlControl.ReadOnly["primary-tidb.ns.svc:4000"]).To(BeTrue())
+	g.Expect(sqlControl.ReplicaWriters["primary-tidb.ns.svc:4000"]).To(Equal("root"))
+	g.Expect(sqlControl.ReadOnly["secondary-tidb.ns.svc:4000"]).To(BeFalse())
+	cf, err = deps.Clientset.PingcapV1alpha1().TiCDCChangefeeds("ns").Get(ctx, "tcr-from-secondary", metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cf.Spec.Cluster.Name).To(Equal("secondary"))
+	g.Expect(cf.Spec.SinkURI).To(Equal("mysql://primary-tidb.ns.svc:4000/"))
+	g.Expect(cf.Spec.StartTS).To(Equal(uint64(435000000000000300)))
+
+	syncIndexer(g, deps)
+	setCheckpoint(g, deps, "tcr-from-secondary", 435000000000000300, 0)
+	tcr, err = reconcile(g, control, deps, tcr)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tcr.Status.Phase).To(Equal(v1alpha1.TidbClusterReplicationReplicating))
+}
+
+func TestTidbClusterReplicationControlReconcileFailed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		name      string
+		modifyTcr func(tcr *v1alpha1.TidbClusterReplication)
+		prepare   func(deps *controller.Dependencies)
+		expectFn  func(tcr *v1alpha1.TidbClusterReplication, deps *controller.Dependencies, err error)
+	}{
+		{
+			name: "invalid replication",
+			modifyTcr: func(tcr *v1alpha1.TidbClusterReplication) {
+				tcr.Spec.Secondary.Name = "primary"
+			},
+			expectFn: func(tcr *v1alpha1.TidbClusterReplication, deps *controller.Dependencies, err error) {
+				// should return nil when validation is failed
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(tcr.Status.Phase).To(BeEmpty())
+			},
+		},
+		{
+			name: "primary is not found",
+			modifyTcr: func(tcr *v1alpha1.TidbClusterReplication) {
+				tcr.Spec.Bootstrap = nil
+				tcr.Spec.Primary.Name = "other"
+			},
+			expectFn: func(tcr *v1alpha1.TidbClusterReplication, deps *controller.Dependencies, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(tcr.Status.ChangefeedName).To(BeEmpty())
+			},
+		},
+		{
+			name: "switch over before the replication starts",
+			modifyTcr: func(tcr *v1alpha1.TidbClusterReplication) {
+				tcr.Status.Primary = "ns/secondary"
+			},
+			expectFn: func(tcr *v1alpha1.TidbClusterReplication, deps *controller.Dependencies, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(perrors.Find(err, controller.IsRequeueError)).To(BeNil())
+				g.Expect(tcr.Status.SwitchoverStep).To(BeEmpty())
+			},
+		},
+		{
+			name:      "backup failed",
+			modifyTcr: func(tcr *v1alpha1.TidbClusterReplication) {},
+			prepare: func(deps *controller.Dependencies) {
+				backup := &v1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "tcr-bootstrap", Namespace: "ns"}}
+				v1alpha1.UpdateBackupCondition(&backup.Status, &v1alpha1.BackupCondition{Type: v1alpha1.BackupFailed, Status: corev1.ConditionTrue})
+				g.Expect(deps.InformerFactory.Pingcap().V1alpha1().Backups().Informer().GetIndexer().Add(backup)).To(Succeed())
+			},
+			expectFn: func(tcr *v1alpha1.TidbClusterReplication, deps *controller.Dependencies, err error) {
+				// the failed backup is deleted to be recreated
+				g.Expect(perrors.Find(err, controller.IsRequeueError)).NotTo(BeNil())
+				g.Expect(tcr.Status.Phase).To(Equal(v1alpha1.TidbClusterReplicationBootstrapping))
+				g.Expect(tcr.Status.RestoreName).To(BeEmpty())
+				_, err = deps.Clientset.PingcapV1alpha1().Backups("ns").Get(context.TODO(), "tcr-bootstrap", metav1.GetOptions{})
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			},
+		},
+		{
+			name: "password is not found",
+			modifyTcr: func(tcr *v1alpha1.TidbClusterReplication) {
+				tcr.Spec.Bootstrap = nil
+				tcr.Spec.Secondary.SecretRef.PasswordKey = "other"
+			},
+			expectFn: func(tcr *v1alpha1.TidbClusterReplication, deps *controller.Dependencies, err error) {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("key other is not found"))
+				g.Expect(tcr.Status.ChangefeedName).To(BeEmpty())
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Log(c.name)
+		control, _, deps := newTestControl(g)
+		tcr := newTidbClusterReplication()
+		c.modifyTcr(tcr)
+		if c.prepare != nil {
+			c.prepare(deps)
+		}
+		_, err := deps.Clientset.PingcapV1alpha1().TidbClusterReplications("ns").Create(context.TODO(), tcr, metav1.CreateOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+		tcr, err = reconcile(g, control, deps, tcr)
+		c.expectFn(tcr, deps, err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterreplication
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	perrors "github.com/pingcap/errors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// Controller syncs TidbClusterReplication
+type Controller struct {
+	deps    *controller.Dependencies
+	control ControlInterface
+	queue   workqueue.RateLimitingInterface
+}
+
+// NewController creates a TidbClusterReplication controller
+func NewController(deps *controller.Dependencies) *Controller {
+	c := &Controller{
+		deps:    deps,
+		control: NewDefaultTidbClusterReplicationControl(deps, NewDefaultTiDBSQLControl(), deps.Recorder),
+		queue: workqueue.NewNamedRateLimitingQueue(
+			controller.NewControllerRateLimiter(1*time.Second, 100*time.Second),
+			"tidb-cluster-replication",
+		),
+	}
+
+	tcrInformer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterReplications()
+	controller.WatchForObject(tcrInformer.Informer(), c.queue)
+	getTcr := func(ns, name string) (runtime.Object, error) {
+		return c.deps.TiDBClusterReplicationLister.TidbClusterReplications(ns).Get(name)
+	}
+	// the progress of the bootstrap and the checkpoint of the changefeed are observed from the owned objects
+	controller.WatchForController(deps.InformerFactory.Pingcap().V1alpha1().Backups().Informer(), c.queue, getTcr, nil)
+	controller.WatchForController(deps.InformerFactory.Pingcap().V1alpha1().Restores().Informer(), c.queue, getTcr, nil)
+	controller.WatchForController(deps.InformerFactory.Pingcap().V1alpha1().TiCDCChangefeeds().Informer(), c.queue, getTcr, nil)
+
+	return c
+}
+
+// Run runs the TidbClusterReplication controller.
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting tidb cluster replication controller")
+	defer klog.Info("Shutting down tidb cluster replication controller")
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	keyIface, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(keyIface)
+
+	key := keyIface.(string)
+	err := c.sync(key)
+	if err != nil {
+		if perrors.Find(err, controller.IsRequeueError) != nil {
+			klog.Infof("TidbClusterReplication %v still need sync: %v, requeuing", key, err)
+		} else {
+			utilruntime.HandleError(fmt.Errorf("TidbClusterReplication %v sync failed, err: %v", key, err))
+		}
+		c.queue.AddRateLimited(key)
+	} else {
+		c.queue.Forget(keyIface)
+	}
+
+	return true
+}
+
+func (c *Controller) sync(key string) (err error) {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).Infof("Finished syncing TidbClusterReplication %s (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("TidbClusterReplication", startTime, err)
+	}()
+
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	tcr, err := c.deps.TiDBClusterReplicationLister.TidbClusterReplications(ns).Get(name)
+	if errors.IsNotFound(err) {
+		klog.Infof("TidbClusterReplication %s has been deleted", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.control.Reconcile(tcr.DeepCopy())
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterreplication
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestControllerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		name        string
+		addToIndex  bool
+		reconcile   func(*v1alpha1.TidbClusterReplication) error
+		expectErrFn func(error)
+	}{
+		{
+			name:       "sync succeeded",
+			addToIndex: true,
+			expectErrFn: func(err error) {
+				g.Expect(err).Should(Succeed())
+			},
+		},
+		{
+			name: "tidbclusterreplication isn't found",
+			reconcile: func(*v1alpha1.TidbClusterReplication) error {
+				return fmt.Errorf("shouldn't arrive")
+			},
+			expectErrFn: func(err error) {
+				g.Expect(err).Should(Succeed())
+			},
+		},
+		{
+			name:       "reconcile tidbclusterreplication failed",
+			addToIndex: true,
+			reconcile: func(*v1alpha1.TidbClusterReplication) error {
+				return fmt.Errorf("reconcile failed")
+			},
+			expectErrFn: func(err error) {
+				g.Expect(err).Should(MatchError("reconcile failed"))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Log(c.name)
+
+		deps := controller.NewFakeDependencies()
+		indexer := deps.InformerFactory.Pingcap().V1alpha1().TidbClusterReplications().Informer().GetIndexer()
+		control := &FakeTidbClusterReplicationControl{}
+		ctrl := NewController(deps)
+		ctrl.control = control
+		if c.reconcile != nil {
+			control.MockReconcile(c.reconcile)
+		}
+
+		tcr := newTidbClusterReplication()
+		if c.addToIndex {
+			g.Expect(indexer.Add(tcr)).Should(Succeed())
+		}
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(tcr)
+		g.Expect(err).Should(Succeed())
+
+		c.expectErrFn(ctrl.sync(key))
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tidbclusterreplication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const sqlTimeout = 10 * time.Second
+
+// TiDBSQLControlInterface is the interface that knows how to stop and resume the writes to a TidbCluster by SQL
+type TiDBSQLControlInterface interface {
+	// SetReadOnly sets `tidb_restricted_read_only` of the TidbCluster serving at addr, the users with
+	// `RESTRICTED_REPLICA_WRITER_ADMIN` can still write to it
+	SetReadOnly(addr, user, password string, readOnly bool) error
+	// GrantReplicaWriter grants `RESTRICTED_REPLICA_WRITER_ADMIN` to the user connecting to the TidbCluster
+	// serving at addr, so that the changefeed can write to it with the user while it is read only
+	GrantReplicaWriter(addr, user, password string) error
+	// GetCurrentTSO returns the current TSO of the TidbCluster serving at addr
+	GetCurrentTSO(addr, user, password string) (uint64, error)
+}
+
+type defaultTiDBSQLControl struct{}
+
+// NewDefaultTiDBSQLControl returns a defaultTiDBSQLControl instance
+func NewDefaultTiDBSQLControl() TiDBSQLControlInterface {
+	return &defaultTiDBSQLControl{}
+}
+
+func (c *defaultTiDBSQLControl) SetReadOnly(addr, user, password string, readOnly bool) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), sqlTimeout)
+	defer cancel()
+	db, err := util.OpenDB(ctx, getDSN(addr, user, password))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	value := "OFF"
+	if readOnly {
+		value = "ON"
+	}
+	_, err = db.ExecContext(ctx, fmt.Sprintf("SET GLOBAL tidb_restricted_read_only = %s", value))
+	return err
+}
+
+func (c *defaultTiDBSQLControl) GrantReplicaWriter(addr, user, password string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), sqlTimeout)
+	defer cancel()
+	db, err := util.OpenDB(ctx, getDSN(addr, user, password))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// the account is in the form of `user@host`
+	var account string
+	if err := db.QueryRowContext(ctx, "SELECT CURRENT_USER()").Scan(&account); err != nil {
+		return err
+	}
+	idx := strings.LastIndexByte(account, '@')
+	if idx < 0 {
+		return fmt.Errorf("unexpected current user %q", account)
+	}
+	_, err = db.ExecContext(ctx, fmt.Sprintf("GRANT RESTRICTED_REPLICA_WRITER_ADMIN ON *.* TO %s@%s",
+		quoteString(account[:idx]), quoteString(account[idx+1:])))
+	return err
+}
+
+// quoteString quotes the string as a SQL string literal
+func quoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func (c *defaultTiDBSQLControl) GetCurrentTSO(addr, user, password string) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), sqlTimeout)
+	defer cancel()
+	db, err := util.OpenDB(ctx, getDSN(addr, user, password))
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	var tso uint64
+	err = db.QueryRowContext(ctx, "SELECT TIDB_CURRENT_TSO()").Scan(&tso)
+	return tso, err
+}
+
+// getDSN returns the DSN to connect to the TiDB serving at addr
+func getDSN(addr, user, password string) string {
+	cfg := mysql.NewConfig()
+	cfg.User = user
+	cfg.Passwd = password
+	cfg.Net = "tcp"
+	cfg.Addr = addr
+	return cfg.FormatDSN()
+}
+
+// FakeTiDBSQLControl is a fake implementation of TiDBSQLControlInterface
+type FakeTiDBSQLControl struct {
+	// ReadOnly is the `tidb_restricted_read_only` of the TidbClusters by address
+	ReadOnly map[string]bool
+	// ReplicaWriters are the users granted `RESTRICTED_REPLICA_WRITER_ADMIN` by address
+	ReplicaWriters map[string]string
+	TSO            uint64
+	Err            error
+}
+
+// NewFakeTiDBSQLControl returns a FakeTiDBSQLControl instance
+func NewFakeTiDBSQLControl() *FakeTiDBSQLControl {
+	return &FakeTiDBSQLControl{ReadOnly: map[string]bool{}, ReplicaWriters: map[string]string{}}
+}
+
+func (c *FakeTiDBSQLControl) SetReadOnly(addr, _, _ string, readOnly bool) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	c.ReadOnly[addr] = readOnly
+	return nil
+}
+
+func (c *FakeTiDBSQLControl) GrantReplicaWriter(addr, user, _ string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	c.ReplicaWriters[addr] = user
+	return nil
+}
+
+func (c *FakeTiDBSQLControl) GetCurrentTSO(_, _, _ string) (uint64, error) {
+	return c.TSO, c.Err
+}
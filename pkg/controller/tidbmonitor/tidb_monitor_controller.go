@@ -98,10 +98,11 @@ func (c *Controller) processNextWorkItem() bool {
 	return true
 }
 
-func (c *Controller) sync(key string) error {
+func (c *Controller) sync(key string) (err error) {
 	startTime := time.Now()
 	defer func() {
 		klog.V(4).Infof("Finished syncing TidbMonitor %q (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("TidbMonitor", startTime, err)
 	}()
 
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
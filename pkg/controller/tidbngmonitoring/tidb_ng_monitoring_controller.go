@@ -113,10 +113,11 @@ func (c *Controller) processNextWorkItem() bool {
 	return true
 }
 
-func (c *Controller) sync(key string) error {
+func (c *Controller) sync(key string) (err error) {
 	startTime := time.Now()
 	defer func() {
 		klog.V(4).Infof("Finished syncing TidbNGMonitoring %s (%v)", key, time.Since(startTime))
+		controller.ObserveReconcile("TidbNGMonitoring", startTime, err)
 	}()
 
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
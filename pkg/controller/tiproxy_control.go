@@ -0,0 +1,91 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
+)
+
+// TiProxyControlInterface is the interface that knows how to manage tiproxy servers
+type TiProxyControlInterface interface {
+	// IsHealthy returns whether the tiproxy server is healthy
+	IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)
+}
+
+// defaultTiProxyControl is default implementation of TiProxyControlInterface.
+type defaultTiProxyControl struct {
+	httpClient
+	// for unit test only
+	testURL string
+}
+
+// NewDefaultTiProxyControl returns a defaultTiProxyControl instance
+func NewDefaultTiProxyControl(secretLister corelisterv1.SecretLister) *defaultTiProxyControl {
+	return &defaultTiProxyControl{httpClient: httpClient{secretLister: secretLister}}
+}
+
+func (c *defaultTiProxyControl) IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	httpClient, err := c.getHTTPClient(tc)
+	if err != nil {
+		return false, err
+	}
+
+	url := fmt.Sprintf("%s/api/debug/health", c.getBaseURL(tc, ordinal))
+	res, err := httpClient.Get(url)
+	if err != nil {
+		return false, err
+	}
+	defer httputil.DeferClose(res.Body)
+	return res.StatusCode == http.StatusOK, nil
+}
+
+func (c *defaultTiProxyControl) getBaseURL(tc *v1alpha1.TidbCluster, ordinal int32) string {
+	if c.testURL != "" {
+		return c.testURL
+	}
+
+	tcName := tc.GetName()
+	ns := tc.GetNamespace()
+	scheme := tc.Scheme()
+	hostName := fmt.Sprintf("%s-%d", TiProxyMemberName(tcName), ordinal)
+
+	return fmt.Sprintf("%s://%s.%s.%s:3080", scheme, hostName, TiProxyPeerMemberName(tcName), ns)
+}
+
+// FakeTiProxyControl is a fake implementation of TiProxyControlInterface.
+type FakeTiProxyControl struct {
+	isHealthy func(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)
+}
+
+// NewFakeTiProxyControl returns a FakeTiProxyControl instance
+func NewFakeTiProxyControl() *FakeTiProxyControl {
+	return &FakeTiProxyControl{}
+}
+
+func (c *FakeTiProxyControl) MockIsHealthy(mockfunc func(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)) {
+	c.isHealthy = mockfunc
+}
+
+// IsHealthy returns healthy if it is not mocked
+func (c *FakeTiProxyControl) IsHealthy(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+	if c.isHealthy == nil {
+		return true, nil
+	}
+	return c.isHealthy(tc, ordinal)
+}
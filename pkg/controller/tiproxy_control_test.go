@@ -0,0 +1,61 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTiProxyIsHealthy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		caseName      string
+		status        int
+		expectHealthy bool
+	}{
+		{
+			caseName:      "tiproxy is healthy",
+			status:        http.StatusOK,
+			expectHealthy: true,
+		},
+		{
+			caseName:      "tiproxy is shutting down",
+			status:        http.StatusBadGateway,
+			expectHealthy: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Log(c.caseName)
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.URL.Path).To(Equal("/api/debug/health"))
+			w.WriteHeader(c.status)
+		})
+		defer svc.Close()
+
+		fakeClient := &fake.Clientset{}
+		informer := kubeinformers.NewSharedInformerFactory(fakeClient, 0)
+		control := NewDefaultTiProxyControl(informer.Core().V1().Secrets().Lister())
+		control.testURL = svc.URL
+		healthy, err := control.IsHealthy(getTidbCluster(), 0)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(healthy).To(Equal(c.expectHealthy))
+	}
+}
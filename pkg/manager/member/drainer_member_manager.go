@@ -0,0 +1,596 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultDrainerLogLevel = "info"
+	drainerPort            = 8249
+	// drainerCertPath is where the cert for inter-cluster communication stored (if any)
+	drainerCertPath = "/var/lib/drainer-tls"
+	// drainerSyncerCertPath is where the cert for connecting to the downstream stored (if any)
+	drainerSyncerCertPath = "/var/lib/drainer-syncer-tls"
+	// defaultDrainerFileSinkDir is where the file sink saves the binlog files, it is in the data storage
+	defaultDrainerFileSinkDir = "/data/pb"
+	// drainerDownstreamPasswordEnv is the env that drainer reads the password of the mysql and tidb downstream from
+	drainerDownstreamPasswordEnv = "MYSQL_PSWD"
+)
+
+type drainerMemberManager struct {
+	deps   *controller.Dependencies
+	scaler Scaler
+	// only use for test
+	binlogClient binlogClient
+}
+
+// NewDrainerMemberManager returns a controller to reconcile drainer
+func NewDrainerMemberManager(deps *controller.Dependencies, scaler Scaler) manager.Manager {
+	return &drainerMemberManager{
+		deps:   deps,
+		scaler: scaler,
+	}
+}
+
+func (m *drainerMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Drainer == nil {
+		return nil
+	}
+	if err := m.syncHeadlessService(tc); err != nil {
+		return err
+	}
+	return m.syncDrainerStatefulSet(tc)
+}
+
+func (m *drainerMemberManager) syncDrainerStatefulSet(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	oldSetTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.DrainerMemberName(tcName))
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncDrainerStatefulSet: failed to get sts %s for cluster %s/%s, error: %s", controller.DrainerMemberName(tcName), ns, tcName, err)
+	}
+	notFound := errors.IsNotFound(err)
+	oldSet := oldSetTmp.DeepCopy()
+
+	// failed to sync drainer status will not affect subsequent logic, just print the errors.
+	if err := m.syncDrainerStatus(tc, oldSet); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s drainer status, error: %v", ns, tcName, err)
+	}
+
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for drainer statefulset", ns, tcName)
+		return nil
+	}
+
+	cm, err := m.syncConfigMap(tc, oldSet)
+	if err != nil {
+		return err
+	}
+
+	newSet, err := getNewDrainerStatefulSet(tc, cm)
+	if err != nil {
+		return err
+	}
+	if notFound {
+		if !tc.PDIsAvailable() {
+			klog.Infof("TidbCluster: %s/%s, waiting for PD cluster running", ns, tcName)
+			return nil
+		}
+		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet)
+		if err != nil {
+			return err
+		}
+		return m.deps.StatefulSetControl.CreateStatefulSet(tc, newSet)
+	}
+
+	if err := m.scaler.Scale(tc, oldSet, newSet); err != nil {
+		return err
+	}
+
+	// drainer reads the binlog from all pumps, wait for PD, TiKV, TiFlash and Pump upgrading done
+	if tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase ||
+		tc.Status.PD.Phase == v1alpha1.UpgradePhase ||
+		tc.Status.TiKV.Phase == v1alpha1.UpgradePhase ||
+		tc.Status.Pump.Phase == v1alpha1.UpgradePhase {
+		klog.Infof("TidbCluster: [%s/%s]'s tiflash status is %s, "+
+			"pd status is %s, tikv status is %s, pump status is %s, can not upgrade drainer",
+			ns, tcName,
+			tc.Status.TiFlash.Phase, tc.Status.PD.Phase, tc.Status.TiKV.Phase, tc.Status.Pump.Phase)
+		_, podSpec, err := GetLastAppliedConfig(oldSet)
+		if err != nil {
+			return err
+		}
+		newSet.Spec.Template.Spec = *podSpec
+	}
+
+	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdateDrainerSTS", newSet, oldSet)
+}
+
+func (m *drainerMemberManager) buildBinlogClient(tc *v1alpha1.TidbCluster, control pdapi.PDControlInterface) (client binlogClient, err error) {
+	if m.binlogClient != nil {
+		return m.binlogClient, nil
+	}
+
+	return buildBinlogClient(tc, control)
+}
+
+func (m *drainerMemberManager) syncDrainerStatus(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) error {
+	if set == nil {
+		// skip if not created yet
+		return nil
+	}
+
+	tc.Status.Drainer.StatefulSet = &set.Status
+
+	upgrading, err := drainerStatefulSetIsUpgrading(m.deps, set, tc)
+	if err != nil {
+		return err
+	}
+	if upgrading {
+		tc.Status.Drainer.Phase = v1alpha1.UpgradePhase
+	} else {
+		tc.Status.Drainer.Phase = v1alpha1.NormalPhase
+	}
+
+	client, err := m.buildBinlogClient(tc, m.deps.PDControl)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	status, err := client.DrainerNodeStatus(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	// the drainers deployed by other means (e.g. helm chart) are registered in the same PD, skip them
+	members := []*v1alpha1.PumpNodeStatus{}
+	prefix := controller.DrainerMemberName(tc.Name) + "-"
+	for _, node := range status {
+		if strings.HasPrefix(node.Host, prefix) {
+			members = append(members, node)
+		}
+	}
+	tc.Status.Drainer.Members = members
+
+	return nil
+}
+
+func (m *drainerMemberManager) syncHeadlessService(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.Paused {
+		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for drainer headless service", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	newSvc := getNewDrainerHeadlessService(tc)
+	oldSvcTmp, err := m.deps.ServiceLister.Services(newSvc.Namespace).Get(newSvc.Name)
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.deps.ServiceControl.CreateService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncHeadlessService: failed to get svc %s/%s for cluster %s/%s, error %s", newSvc.Namespace, newSvc.Name, tc.GetNamespace(), tc.GetName(), err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+	equal, err := controller.ServiceEqual(newSvc, oldSvc)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		svc := *oldSvc
+		svc.Spec = newSvc.Spec
+		err = controller.SetServiceLastAppliedConfigAnnotation(&svc)
+		if err != nil {
+			return err
+		}
+		_, err = m.deps.ServiceControl.UpdateService(tc, &svc)
+		return err
+	}
+	return nil
+}
+
+func (m *drainerMemberManager) syncConfigMap(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
+	newCm, err := getNewDrainerConfigMap(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	var inUseName string
+	if set != nil {
+		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
+			return strings.HasPrefix(name, controller.DrainerMemberName(tc.Name))
+		})
+	}
+
+	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BaseDrainerSpec().ConfigUpdateStrategy(), inUseName, newCm)
+	if err != nil {
+		return nil, err
+	}
+	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+}
+
+func getNewDrainerHeadlessService(tc *v1alpha1.TidbCluster) *corev1.Service {
+	objMeta, drainerLabel := getDrainerMeta(tc)
+
+	return &corev1.Service{
+		ObjectMeta: objMeta,
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "None",
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "drainer",
+					Port:       drainerPort,
+					TargetPort: intstr.FromInt(drainerPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector:                 drainerLabel,
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
+// getNewDrainerConfigMap renders the configuration of drainer, the TLS settings and
+// the syncer settings in the spec take precedence over the ones in the config.
+func getNewDrainerConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+	spec := tc.Spec.Drainer
+	objMeta, _ := getDrainerMeta(tc)
+
+	cfg := config.New(map[string]interface{}{})
+	if spec.Config != nil {
+		cfg = spec.Config.DeepCopy()
+	}
+
+	if tc.IsTLSClusterEnabled() {
+		cfg.Set("security.ssl-ca", path.Join(drainerCertPath, corev1.ServiceAccountRootCAKey))
+		cfg.Set("security.ssl-cert", path.Join(drainerCertPath, corev1.TLSCertKey))
+		cfg.Set("security.ssl-key", path.Join(drainerCertPath, corev1.TLSPrivateKeyKey))
+	}
+
+	syncer := spec.Syncer
+	cfg.Set("syncer.db-type", string(syncer.DBType))
+	switch syncer.DBType {
+	case v1alpha1.DrainerSinkTypeMySQL, v1alpha1.DrainerSinkTypeTiDB:
+		if to := syncer.To; to != nil {
+			cfg.Set("syncer.to.host", to.Host)
+			if to.Port != 0 {
+				cfg.Set("syncer.to.port", int64(to.Port))
+			}
+			if to.User != "" {
+				cfg.Set("syncer.to.user", to.User)
+			}
+			if to.TLSClientSecretName != nil {
+				cfg.Set("syncer.to.security.ssl-ca", path.Join(drainerSyncerCertPath, corev1.ServiceAccountRootCAKey))
+				cfg.Set("syncer.to.security.ssl-cert", path.Join(drainerSyncerCertPath, corev1.TLSCertKey))
+				cfg.Set("syncer.to.security.ssl-key", path.Join(drainerSyncerCertPath, corev1.TLSPrivateKeyKey))
+			}
+		}
+		if cp := syncer.Checkpoint; cp != nil && cp.Schema != "" {
+			cfg.Set("syncer.to.checkpoint.schema", cp.Schema)
+		}
+	case v1alpha1.DrainerSinkTypeKafka:
+		if kafka := syncer.Kafka; kafka != nil {
+			cfg.Set("syncer.to.kafka-addrs", strings.Join(kafka.KafkaAddrs, ","))
+			if kafka.KafkaVersion != "" {
+				cfg.Set("syncer.to.kafka-version", kafka.KafkaVersion)
+			}
+			if kafka.TopicName != "" {
+				cfg.Set("syncer.to.topic-name", kafka.TopicName)
+			}
+		}
+	case v1alpha1.DrainerSinkTypeFile:
+		dir := defaultDrainerFileSinkDir
+		if syncer.File != nil && syncer.File.Dir != "" {
+			dir = syncer.File.Dir
+		}
+		cfg.Set("syncer.to.dir", dir)
+	}
+
+	confText, err := cfg.MarshalTOML()
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: objMeta,
+		Data: map[string]string{
+			"config-file": string(confText),
+		},
+	}, nil
+}
+
+func getNewDrainerStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
+	spec := tc.BaseDrainerSpec()
+	objMeta, stsLabels := getDrainerMeta(tc)
+	replicas := tc.Spec.Drainer.Replicas
+	podLabels := util.CombineStringMap(stsLabels.Labels(), spec.Labels())
+	podAnnos := util.CombineStringMap(controller.AnnProm(drainerPort), spec.Annotations())
+	storageRequest, err := controller.ParseStorageRequest(tc.Spec.Drainer.Requests)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse storage request for drainer, tidbcluster %s/%s, error: %v", tc.Namespace, tc.Name, err)
+	}
+	startScript, err := getDrainerStartScript(tc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render start-script for drainer, tidbcluster %s/%s, error: %v", tc.Namespace, tc.Name, err)
+	}
+
+	var envs []corev1.EnvVar
+	if spec.HostNetwork() {
+		// the advertise address in the start script is built from HOSTNAME
+		envs = append(envs, corev1.EnvVar{
+			Name: "HOSTNAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		})
+	}
+	to := tc.Spec.Drainer.Syncer.To
+	if to != nil && to.SecretName != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name: drainerDownstreamPasswordEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: to.SecretName},
+					Key:                  passwdKey,
+				},
+			},
+		})
+	}
+
+	dataVolumeName := string(v1alpha1.GetStorageVolumeName("", v1alpha1.DrainerMemberType))
+	volumeMounts := []corev1.VolumeMount{
+		{Name: dataVolumeName, MountPath: "/data"},
+		{Name: "config", ReadOnly: true, MountPath: "/etc/drainer"},
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cm.Name,
+					},
+					Items: []corev1.KeyToPath{{Key: "config-file", Path: "drainer.toml"}},
+				},
+			},
+		},
+	}
+	if tc.IsTLSClusterEnabled() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name: "drainer-tls", ReadOnly: true, MountPath: drainerCertPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "drainer-tls", VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.ClusterTLSSecretName(tc.Name, label.DrainerLabelVal),
+				},
+			},
+		})
+	}
+	if to != nil && to.TLSClientSecretName != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name: "drainer-syncer-tls", ReadOnly: true, MountPath: drainerSyncerCertPath,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "drainer-syncer-tls", VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: *to.TLSClientSecretName,
+				},
+			},
+		})
+	}
+	volumeMounts = append(volumeMounts, tc.Spec.Drainer.AdditionalVolumeMounts...)
+
+	container := corev1.Container{
+		Name:            v1alpha1.DrainerMemberType.String(),
+		Image:           *tc.DrainerImage(),
+		ImagePullPolicy: spec.ImagePullPolicy(),
+		Command:         []string{"/bin/sh", "-c", startScript},
+		Ports: []corev1.ContainerPort{{
+			Name:          "drainer",
+			ContainerPort: drainerPort,
+		}},
+		Resources:    controller.ContainerResource(tc.Spec.Drainer.ResourceRequirements),
+		Env:          util.AppendEnv(envs, spec.Env()),
+		EnvFrom:      spec.EnvFrom(),
+		VolumeMounts: volumeMounts,
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt(drainerPort),
+				},
+			},
+		},
+	}
+
+	serviceAccountName := tc.Spec.Drainer.ServiceAccount
+	if serviceAccountName == "" {
+		serviceAccountName = tc.Spec.ServiceAccount
+	}
+	podSpec := spec.BuildPodSpec()
+	podSpec.Containers = []corev1.Container{container}
+	podSpec.Volumes = append(volumes, spec.AdditionalVolumes()...)
+	podSpec.ServiceAccountName = serviceAccountName
+	podSpec.InitContainers = spec.InitContainers()
+	podSpec.DNSPolicy = spec.DnsPolicy()
+
+	return &apps.StatefulSet{
+		ObjectMeta: objMeta,
+		Spec: apps.StatefulSetSpec{
+			Selector:    stsLabels.LabelSelector(),
+			ServiceName: controller.DrainerMemberName(tc.Name),
+			Replicas:    &replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: podAnnos,
+					Labels:      podLabels,
+				},
+				Spec: podSpec,
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: dataVolumeName,
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{
+							corev1.ReadWriteOnce,
+						},
+						StorageClassName: tc.Spec.Drainer.StorageClassName,
+						Resources:        storageRequest,
+					},
+				},
+			},
+			PodManagementPolicy: spec.PodManagementPolicy(),
+			UpdateStrategy: apps.StatefulSetUpdateStrategy{
+				Type: spec.StatefulSetUpdateStrategy(),
+			},
+		},
+	}, nil
+}
+
+func getDrainerMeta(tc *v1alpha1.TidbCluster) (metav1.ObjectMeta, label.Label) {
+	drainerLabel := label.New().Instance(tc.GetInstanceName()).Drainer()
+
+	objMeta := metav1.ObjectMeta{
+		Name:            controller.DrainerMemberName(tc.Name),
+		Namespace:       tc.Namespace,
+		Labels:          drainerLabel,
+		OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+	}
+	return objMeta, drainerLabel
+}
+
+func getDrainerStartScript(tc *v1alpha1.TidbCluster) (string, error) {
+	scheme := "http"
+	if tc.IsTLSClusterEnabled() {
+		scheme = "https"
+	}
+
+	pdDomain := controller.PDMemberName(tc.Name)
+	if tc.Heterogeneous() && tc.WithoutLocalPD() {
+		pdDomain = controller.PDMemberName(tc.Spec.Cluster.Name) // use pd of reference cluster
+	}
+
+	initialCommitTs := int64(-1)
+	if tc.Spec.Drainer.InitialCommitTs != nil {
+		initialCommitTs = *tc.Spec.Drainer.InitialCommitTs
+	}
+
+	return RenderDrainerStartScript(&DrainerStartScriptModel{
+		CommonModel: CommonModel{
+			AcrossK8s:     tc.AcrossK8s(),
+			ClusterDomain: tc.Spec.ClusterDomain,
+		},
+		ClusterName:     tc.Name,
+		PDAddr:          fmt.Sprintf("%s://%s:2379", scheme, pdDomain),
+		LogLevel:        getDrainerLogLevel(tc),
+		Namespace:       tc.GetNamespace(),
+		InitialCommitTs: initialCommitTs,
+	})
+}
+
+func getDrainerLogLevel(tc *v1alpha1.TidbCluster) string {
+	cfg := tc.Spec.Drainer.Config
+	if cfg == nil {
+		return defaultDrainerLogLevel
+	}
+
+	v := cfg.Get("log-level")
+	if v == nil {
+		return defaultDrainerLogLevel
+	}
+
+	logLevel, err := v.AsString()
+	if err != nil {
+		klog.Warning("error log-level for drainer: ", err)
+		return defaultDrainerLogLevel
+	}
+
+	return logLevel
+}
+
+func drainerStatefulSetIsUpgrading(deps *controller.Dependencies, set *apps.StatefulSet, tc *v1alpha1.TidbCluster) (bool, error) {
+	if mngerutils.StatefulSetIsUpgrading(set) {
+		return true, nil
+	}
+	selector, err := label.New().
+		Instance(tc.GetInstanceName()).
+		Drainer().
+		Selector()
+	if err != nil {
+		return false, err
+	}
+	drainerPods, err := deps.PodLister.Pods(tc.GetNamespace()).List(selector)
+	if err != nil {
+		return false, fmt.Errorf("drainerStatefulSetIsUpgrading: failed to list pods for cluster %s/%s, selector %s, error: %s", tc.GetNamespace(), tc.GetName(), selector, err)
+	}
+	for _, pod := range drainerPods {
+		revisionHash, exist := pod.Labels[apps.ControllerRevisionHashLabelKey]
+		if !exist {
+			return false, nil
+		}
+		if revisionHash != tc.Status.Drainer.StatefulSet.UpdateRevision {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type FakeDrainerMemberManager struct {
+	err error
+}
+
+func NewFakeDrainerMemberManager() *FakeDrainerMemberManager {
+	return &FakeDrainerMemberManager{}
+}
+
+func (m *FakeDrainerMemberManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeDrainerMemberManager) Sync(*v1alpha1.TidbCluster) error {
+	if m.err != nil {
+		return m.err
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func newTidbClusterForDrainer() *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TidbCluster",
+			APIVersion: "pingcap.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD: &v1alpha1.PDSpec{},
+			Drainer: &v1alpha1.DrainerSpec{
+				BaseImage: "pingcap/tidb-binlog",
+				Replicas:  1,
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("10Gi"),
+					},
+				},
+				Syncer: v1alpha1.DrainerSyncer{
+					DBType: v1alpha1.DrainerSinkTypeMySQL,
+					To: &v1alpha1.TiDBAccessConfig{
+						Host:       "mysql",
+						Port:       3306,
+						User:       "root",
+						SecretName: "mysql-secret",
+					},
+				},
+			},
+			Version: "v6.5.0",
+		},
+	}
+}
+
+func TestGetNewDrainerConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name        string
+		changeTC    func(tc *v1alpha1.TidbCluster)
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "mysql sink",
+			changeTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Drainer.Config = config.New(map[string]interface{}{
+					"syncer": map[string]interface{}{
+						"worker-count": 16,
+						"db-type":      "file",
+					},
+				})
+				tc.Spec.Drainer.Syncer.Checkpoint = &v1alpha1.DrainerCheckpoint{Schema: "binlog_checkpoint"}
+			},
+			contains: []string{
+				`db-type = "mysql"`,
+				`worker-count = 16`,
+				`host = "mysql"`,
+				`port = 3306`,
+				`user = "root"`,
+				`schema = "binlog_checkpoint"`,
+			},
+			notContains: []string{
+				"password",
+				"ssl-ca",
+			},
+		},
+		{
+			name: "tidb sink with tls",
+			changeTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+				tc.Spec.Drainer.Syncer.DBType = v1alpha1.DrainerSinkTypeTiDB
+				tc.Spec.Drainer.Syncer.To.TLSClientSecretName = pointer.StringPtr("downstream-tls")
+			},
+			contains: []string{
+				`db-type = "tidb"`,
+				`ssl-ca = "/var/lib/drainer-tls/ca.crt"`,
+				`ssl-ca = "/var/lib/drainer-syncer-tls/ca.crt"`,
+				`ssl-key = "/var/lib/drainer-syncer-tls/tls.key"`,
+			},
+		},
+		{
+			name: "kafka sink",
+			changeTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Drainer.Syncer = v1alpha1.DrainerSyncer{
+					DBType: v1alpha1.DrainerSinkTypeKafka,
+					Kafka: &v1alpha1.DrainerKafkaSink{
+						KafkaAddrs:   []string{"kafka-0:9092", "kafka-1:9092"},
+						KafkaVersion: "2.4.0",
+						TopicName:    "binlog",
+					},
+				}
+			},
+			contains: []string{
+				`db-type = "kafka"`,
+				`kafka-addrs = "kafka-0:9092,kafka-1:9092"`,
+				`kafka-version = "2.4.0"`,
+				`topic-name = "binlog"`,
+			},
+			notContains: []string{
+				"host",
+			},
+		},
+		{
+			name: "file sink",
+			changeTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Drainer.Syncer = v1alpha1.DrainerSyncer{
+					DBType: v1alpha1.DrainerSinkTypeFile,
+				}
+			},
+			contains: []string{
+				`db-type = "file"`,
+				`dir = "/data/pb"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForDrainer()
+			tt.changeTC(tc)
+			cm, err := getNewDrainerConfigMap(tc)
+			g.Expect(err).To(Succeed())
+			g.Expect(cm.Name).To(Equal("foo-drainer"))
+			for _, s := range tt.contains {
+				g.Expect(cm.Data["config-file"]).To(ContainSubstring(s))
+			}
+			for _, s := range tt.notContains {
+				g.Expect(cm.Data["config-file"]).NotTo(ContainSubstring(s))
+			}
+		})
+	}
+}
+
+func TestGetNewDrainerStatefulSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForDrainer()
+	tc.Spec.Drainer.InitialCommitTs = pointer.Int64Ptr(400000000000000000)
+	tc.Spec.Drainer.Syncer.To.TLSClientSecretName = pointer.StringPtr("downstream-tls")
+	cm, err := getNewDrainerConfigMap(tc)
+	g.Expect(err).To(Succeed())
+
+	set, err := getNewDrainerStatefulSet(tc, cm)
+	g.Expect(err).To(Succeed())
+	g.Expect(set.Name).To(Equal("foo-drainer"))
+	g.Expect(set.Spec.ServiceName).To(Equal("foo-drainer"))
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(1)))
+	g.Expect(set.Spec.VolumeClaimTemplates).To(HaveLen(1))
+	g.Expect(set.Spec.VolumeClaimTemplates[0].Name).To(Equal("data"))
+
+	container := set.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Image).To(Equal("pingcap/tidb-binlog:v6.5.0"))
+	g.Expect(container.Command[2]).To(ContainSubstring("-initial-commit-ts=400000000000000000"))
+	g.Expect(container.Env).To(ContainElement(corev1.EnvVar{
+		Name: "MYSQL_PSWD",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "mysql-secret"},
+				Key:                  "password",
+			},
+		},
+	}))
+	g.Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+		Name: "drainer-syncer-tls", ReadOnly: true, MountPath: "/var/lib/drainer-syncer-tls",
+	}))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-drainer-0"},
+		Spec:       set.Spec.Template.Spec,
+	}
+	g.Expect(drainerAdvertiseAddr(pod)).To(Equal("foo-drainer-0.foo-drainer:8249"))
+}
+
+func TestDrainerMemberManagerSyncStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForDrainer()
+	fakeDeps := controller.NewFakeDependencies()
+	dmm := &drainerMemberManager{
+		deps:   fakeDeps,
+		scaler: NewFakePumpScaler(),
+		binlogClient: &fakeBinlogClient{
+			drainers: []*v1alpha1.PumpNodeStatus{
+				{NodeID: "foo-drainer-0:8249", Host: "foo-drainer-0.foo-drainer:8249", State: v1alpha1.DrainerStateOnline},
+				{NodeID: "helm-drainer-0:8249", Host: "helm-drainer-0.helm-drainer:8249", State: v1alpha1.DrainerStateOnline},
+			},
+		},
+	}
+	set := &appsv1.StatefulSet{
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        1,
+			CurrentRevision: "1",
+			UpdateRevision:  "1",
+		},
+	}
+
+	err := dmm.syncDrainerStatus(tc, set)
+	g.Expect(err).To(Succeed())
+	g.Expect(tc.Status.Drainer.Phase).To(Equal(v1alpha1.NormalPhase))
+	g.Expect(tc.Status.Drainer.StatefulSet).To(Equal(&set.Status))
+	g.Expect(tc.Status.Drainer.Members).To(HaveLen(1))
+	g.Expect(tc.Status.Drainer.Members[0].NodeID).To(Equal("foo-drainer-0:8249"))
+}
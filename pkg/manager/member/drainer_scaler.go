@@ -0,0 +1,144 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+type drainerScaler struct {
+	generalScaler
+}
+
+// NewDrainerScaler returns a drainer Scaler
+func NewDrainerScaler(deps *controller.Dependencies) *drainerScaler {
+	return &drainerScaler{generalScaler: generalScaler{deps: deps}}
+}
+
+func (s *drainerScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	scaling, _, _, _ := scaleOne(oldSet, newSet)
+	if scaling > 0 {
+		return s.ScaleOut(meta, oldSet, newSet)
+	} else if scaling < 0 {
+		return s.ScaleIn(meta, oldSet, newSet)
+	}
+
+	return nil
+}
+
+func (s *drainerScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	_, ordinal, replicas, deleteSlots := scaleOne(oldSet, newSet)
+	resetReplicas(newSet, oldSet)
+	obj, ok := meta.(runtime.Object)
+	if !ok {
+		return fmt.Errorf("cluster[%s/%s] can't convert to runtime.Object", meta.GetNamespace(), meta.GetName())
+	}
+
+	klog.Infof("scaling out drainer statefulset %s/%s, ordinal: %d (replicas: %d, delete slots: %v)", oldSet.Namespace, oldSet.Name, ordinal, replicas, deleteSlots.List())
+	// the PVCs are retained when scaling in, so they are only deleted here if they
+	// have been marked defer-deleting manually.
+	_, err := s.deleteDeferDeletingPVC(obj, v1alpha1.DrainerMemberType, ordinal)
+	if err != nil {
+		return err
+	}
+	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
+	return nil
+}
+
+// parse the advertise address from pod start command.
+// must respect to drainer start script in template.go
+func drainerAdvertiseAddr(pod *v1.Pod) string {
+	return binlogAdvertiseAddr(pod, "drainer")
+}
+
+// ScaleIn offlines the drainer through PD before reducing the replicas. Unlike pump, the
+// PVCs of the drainer are not marked defer-deleting: the data storage holds the savepoint
+// (and the checkpoint when syncing to kafka or file), so a drainer scaled out again later
+// can resume from where it stopped.
+func (s *drainerScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	ns := meta.GetNamespace()
+	tcName := meta.GetName()
+
+	_, ordinal, replicas, deleteSlots := scaleOne(oldSet, newSet)
+	resetReplicas(newSet, oldSet)
+
+	// after offline the drainer, the drainer will be not ready, so we only offline the next one after the successors pod are deleted.
+	if *oldSet.Spec.Replicas != oldSet.Status.Replicas {
+		return controller.RequeueErrorf("Wait for statefulset to be synced before scale in one more replica, desired: %d, running: %d", *oldSet.Spec.Replicas, oldSet.Status.Replicas)
+	}
+
+	klog.Infof("scaling in drainer statefulset %s/%s, ordinal: %d (replicas: %d, delete slots: %v)", oldSet.Namespace, oldSet.Name, ordinal, replicas, deleteSlots.List())
+	tc, ok := meta.(*v1alpha1.TidbCluster)
+	if !ok {
+		return fmt.Errorf("drainerScaler.ScaleIn: failed to convert cluster %s/%s", ns, tcName)
+	}
+	podName := ordinalPodName(v1alpha1.DrainerMemberType, tcName, ordinal)
+	pod, err := s.deps.PodLister.Pods(ns).Get(podName)
+	if err != nil {
+		return fmt.Errorf("drainerScaler.ScaleIn: failed to get pods %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
+	}
+
+	addr := drainerAdvertiseAddr(pod)
+	for _, node := range tc.Status.Drainer.Members {
+		if node.Host != addr {
+			continue
+		}
+
+		switch node.State {
+		case v1alpha1.DrainerStateOnline:
+			client, err := buildBinlogClient(tc, s.deps.PDControl)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			// the advertise address may not contain the namespace, see pumpScaler.ScaleIn
+			client.HookAddr = addrWithNamespace(ns)
+
+			if err := client.OfflineDrainer(context.TODO(), addr); err != nil {
+				return err
+			}
+			klog.Infof("drainerScaler.ScaleIn: send offline request to drainer %s/%s successfully", ns, podName)
+			return controller.RequeueErrorf("Drainer %s/%s is still in cluster, state: %s", ns, podName, node.State)
+		case v1alpha1.DrainerStateOffline:
+			klog.Infof("Drainer %s/%s becomes offline", ns, podName)
+			setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
+			return nil
+		default:
+			return controller.RequeueErrorf("Drainer %s/%s is still in cluster, state: %s", ns, podName, node.State)
+		}
+	}
+
+	// The same as pump, a drainer that is not found in the status and not ready
+	// may have never joined the cluster, wait for 5 resync periods to be sure.
+	if !podutil.IsPodReady(pod) {
+		safeTimeDeadline := pod.CreationTimestamp.Add(5 * s.deps.CLIConfig.ResyncDuration)
+		if time.Now().Before(safeTimeDeadline) {
+			return fmt.Errorf("Drainer %s/%s is not ready, wait for 5 resync periods to sync its status", ns, podName)
+		}
+		setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
+		return nil
+	}
+	return fmt.Errorf("Drainer %s/%s not found in cluster", ns, podName)
+}
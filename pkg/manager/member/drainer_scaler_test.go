@@ -0,0 +1,121 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDrainerScalerScaleIn(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name          string
+		state         string
+		notSynced     bool
+		errExpectFn   func(*GomegaWithT, error)
+		replicasAfter int32
+	}
+
+	testFn := func(test testcase) {
+		t.Log(test.name)
+		tc := newTidbClusterForDrainer()
+		fakeDeps := controller.NewFakeDependencies()
+		scaler := NewDrainerScaler(fakeDeps)
+
+		cm, err := getNewDrainerConfigMap(tc)
+		g.Expect(err).To(Succeed())
+		oldSet, err := getNewDrainerStatefulSet(tc, cm)
+		g.Expect(err).To(Succeed())
+		oldSet.Status.Replicas = 1
+		if test.notSynced {
+			oldSet.Status.Replicas = 2
+		}
+		newSet := oldSet.DeepCopy()
+		newSet.Spec.Replicas = new(int32)
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-drainer-0", Namespace: "ns"},
+			Spec:       oldSet.Spec.Template.Spec,
+		}
+		fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod)
+		tc.Status.Drainer.Members = []*v1alpha1.PumpNodeStatus{
+			{NodeID: "foo-drainer-0:8249", Host: "foo-drainer-0.foo-drainer:8249", State: test.state},
+		}
+
+		err = scaler.Scale(tc, oldSet, newSet)
+		test.errExpectFn(g, err)
+		g.Expect(*newSet.Spec.Replicas).To(Equal(test.replicasAfter))
+	}
+
+	tests := []testcase{
+		{
+			name:  "drainer is offline",
+			state: v1alpha1.DrainerStateOffline,
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+			replicasAfter: 0,
+		},
+		{
+			name:  "drainer is pausing",
+			state: "paused",
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+			},
+			replicasAfter: 1,
+		},
+		{
+			name:      "statefulset is not synced",
+			state:     v1alpha1.DrainerStateOffline,
+			notSynced: true,
+			errExpectFn: func(g *GomegaWithT, err error) {
+				g.Expect(controller.IsRequeueError(err)).To(BeTrue())
+			},
+			replicasAfter: 1,
+		},
+	}
+
+	for _, test := range tests {
+		testFn(test)
+	}
+}
+
+func TestDrainerScalerScaleOut(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForDrainer()
+	tc.Spec.Drainer.Replicas = 0
+	fakeDeps := controller.NewFakeDependencies()
+	scaler := NewDrainerScaler(fakeDeps)
+
+	oldSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-drainer", Namespace: "ns"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: new(int32)},
+	}
+	newSet := oldSet.DeepCopy()
+	newSet.Spec.Replicas = new(int32)
+	*newSet.Spec.Replicas = 1
+
+	g.Expect(scaler.Scale(tc, oldSet, newSet)).To(Succeed())
+	g.Expect(*newSet.Spec.Replicas).To(Equal(int32(1)))
+}
@@ -303,6 +303,7 @@ func (m *pdMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set *a
 	tcName := tc.GetName()
 
 	tc.Status.PD.StatefulSet = &set.Status
+	syncUpgradeStepStatus(&tc.Status.PD.UpgradeStep, tc.Status.PD.StatefulSet)
 
 	upgrading, err := m.pdStatefulSetIsUpgrading(set, tc)
 	if err != nil {
@@ -414,11 +415,6 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 	if tc.Spec.PD.Config == nil {
 		return nil, nil
 	}
-	newCm, err := getPDConfigMap(tc)
-	if err != nil {
-		return nil, err
-	}
-
 	var inUseName string
 	if set != nil {
 		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
@@ -426,6 +422,11 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 		})
 	}
 
+	newCm, err := getPDConfigMap(tc, m.pdAPIMode(tc, set, inUseName))
+	if err != nil {
+		return nil, err
+	}
+
 	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BasePDSpec().ConfigUpdateStrategy(), inUseName, newCm)
 	if err != nil {
 		return nil, err
@@ -433,6 +434,32 @@ func (m *pdMemberManager) syncPDConfigMap(tc *v1alpha1.TidbCluster, set *apps.St
 	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
 }
 
+// pdAPIMode returns whether PD should be started as the api service of the PD microservices. PD no longer
+// allocates timestamps in the api mode, so an existing PD is switched to the api mode only after the tso
+// microservice is ready, and it keeps in the api mode once switched. A new PD is started in the api mode
+// directly as no one requests timestamps before the cluster is bootstrapped.
+func (m *pdMemberManager) pdAPIMode(tc *v1alpha1.TidbCluster, set *apps.StatefulSet, inUseName string) bool {
+	if !tc.PDMSEnabled() {
+		return false
+	}
+	if set == nil {
+		return true
+	}
+	if inUseName != "" {
+		cm, err := m.deps.ConfigMapLister.ConfigMaps(tc.GetNamespace()).Get(inUseName)
+		if err == nil && strings.Contains(cm.Data["startup-script"], pdAPIModeArgs) {
+			return true
+		}
+	}
+	status := tc.Status.PDMS[v1alpha1.PDMSTSO]
+	if status == nil || len(status.Members) == 0 || status.StatefulSet == nil || status.StatefulSet.ReadyReplicas == 0 {
+		klog.Infof("TidbCluster: [%s/%s]'s tso microservice is not ready, keep pd out of the api mode",
+			tc.GetNamespace(), tc.GetName())
+		return false
+	}
+	return true
+}
+
 func (m *pdMemberManager) getNewPDServiceForTidbCluster(tc *v1alpha1.TidbCluster) *corev1.Service {
 	ns := tc.Namespace
 	tcName := tc.Name
@@ -811,7 +838,7 @@ func getNewPDSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (
 	return pdSet, nil
 }
 
-func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+func getPDConfigMap(tc *v1alpha1.TidbCluster, apiMode bool) (*corev1.ConfigMap, error) {
 	// For backward compatibility, only sync tidb configmap when .tidb.config is non-nil
 	if tc.Spec.PD.Config == nil {
 		return nil, nil
@@ -854,6 +881,7 @@ func getPDConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		},
 		Scheme:  tc.Scheme(),
 		DataDir: filepath.Join(pdDataVolumeMountPath, tc.Spec.PD.DataSubDir),
+		MSMode:  apiMode,
 	}
 	if tc.Spec.PD.StartUpScriptVersion == "v1" {
 		sm.CheckDomainScript = checkDNSV1
@@ -2162,7 +2162,7 @@ func TestGetPDConfigMap(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			cm, err := getPDConfigMap(&tt.tc)
+			cm, err := getPDConfigMap(&tt.tc, false)
 			g.Expect(err).To(Succeed())
 			if tt.expected == nil {
 				g.Expect(cm).To(BeNil())
@@ -3066,3 +3066,37 @@ func mustPDConfig(x interface{}) *v1alpha1.PDConfigWraper {
 
 	return c
 }
+
+func TestPDAPIMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pmm, _, _ := newFakePDMemberManager()
+	tc := newTidbClusterForPD()
+	set := &apps.StatefulSet{}
+	g.Expect(pmm.pdAPIMode(tc, set, "")).To(BeFalse())
+
+	tc.Spec.PD.Mode = v1alpha1.PDModeMS
+	// a new PD is started in the api mode directly
+	g.Expect(pmm.pdAPIMode(tc, nil, "")).To(BeTrue())
+	// an existing PD waits for the tso microservice
+	g.Expect(pmm.pdAPIMode(tc, set, "")).To(BeFalse())
+
+	tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{
+		v1alpha1.PDMSTSO: {
+			Name:        v1alpha1.PDMSTSO,
+			Members:     []string{"http://test-tso-0.test-tso-peer.default.svc:2379"},
+			StatefulSet: &apps.StatefulSetStatus{ReadyReplicas: 1},
+		},
+	}
+	g.Expect(pmm.pdAPIMode(tc, set, "")).To(BeTrue())
+
+	// PD keeps in the api mode even if the tso microservice is not ready
+	tc.Status.PDMS[v1alpha1.PDMSTSO].StatefulSet.ReadyReplicas = 0
+	g.Expect(pmm.pdAPIMode(tc, set, "")).To(BeFalse())
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pd-abc", Namespace: tc.GetNamespace()},
+		Data:       map[string]string{"startup-script": "ARGS=\"services api ${ARGS}\""},
+	}
+	g.Expect(pmm.deps.LabelFilterKubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer().Add(cm)).To(Succeed())
+	g.Expect(pmm.pdAPIMode(tc, set, "test-pd-abc")).To(BeTrue())
+}
@@ -0,0 +1,611 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// pdMSClusterCertPath is where the cert for inter-cluster communication stored (if any)
+	pdMSClusterCertPath = "/var/lib/pdms-tls"
+
+	pdMSPort = 2379
+)
+
+// pdMSMemberManager implements manager.Manager for the pd microservices, one statefulset
+// is managed for each of the microservices in `spec.pdms`.
+type pdMSMemberManager struct {
+	deps                     *controller.Dependencies
+	scaler                   Scaler
+	upgrader                 Upgrader
+	statefulSetIsUpgradingFn func(corelisters.PodLister, *apps.StatefulSet, *v1alpha1.TidbCluster, string) (bool, error)
+}
+
+// NewPDMSMemberManager returns a *pdMSMemberManager
+func NewPDMSMemberManager(deps *controller.Dependencies, scaler Scaler, upgrader Upgrader) manager.Manager {
+	m := &pdMSMemberManager{
+		deps:     deps,
+		scaler:   scaler,
+		upgrader: upgrader,
+	}
+	m.statefulSetIsUpgradingFn = pdMSStatefulSetIsUpgrading
+	return m
+}
+
+// Sync fulfills the manager.Manager interface
+func (m *pdMSMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if !tc.PDMSEnabled() || len(tc.Spec.PDMS) == 0 {
+		return nil
+	}
+
+	if tc.Status.PDMS == nil {
+		tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{}
+	}
+	// the status of the removed microservices is not needed any more
+	for name := range tc.Status.PDMS {
+		if pdMSSpecOf(tc, name) == nil {
+			delete(tc.Status.PDMS, name)
+		}
+	}
+
+	for _, spec := range tc.Spec.PDMS {
+		if tc.Status.PDMS[spec.Name] == nil {
+			tc.Status.PDMS[spec.Name] = &v1alpha1.PDMSStatus{Name: spec.Name}
+		}
+
+		if tc.Spec.Paused {
+			klog.Infof("TidbCluster %s/%s is paused, skip syncing %s service", tc.GetNamespace(), tc.GetName(), spec.Name)
+		} else {
+			if err := m.syncService(tc, getNewPDMSHeadlessService(tc, spec)); err != nil {
+				return err
+			}
+			if err := m.syncService(tc, getNewPDMSService(tc, spec)); err != nil {
+				return err
+			}
+		}
+
+		if err := m.syncStatefulSet(tc, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *pdMSMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	stsName := controller.PDMSMemberName(tcName, spec.Name)
+
+	oldStsTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(stsName)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncStatefulSet: failed to get sts %s for cluster %s/%s, error: %s", stsName, ns, tcName, err)
+	}
+
+	stsNotExist := errors.IsNotFound(err)
+	oldSts := oldStsTmp.DeepCopy()
+
+	// failed to sync the status will not affect subsequent logic, just print the errors.
+	if err := m.syncPDMSStatus(tc, spec, oldSts); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s %s status, error: %v",
+			ns, tcName, spec.Name, err)
+	}
+
+	if tc.Spec.Paused {
+		klog.Infof("TidbCluster %s/%s is paused, skip syncing %s statefulset", ns, tcName, spec.Name)
+		return nil
+	}
+
+	cm, err := m.syncPDMSConfigMap(tc, spec, oldSts)
+	if err != nil {
+		return err
+	}
+
+	newSts, err := getNewPDMSStatefulSet(tc, spec, cm)
+	if err != nil {
+		return err
+	}
+
+	if stsNotExist {
+		// the microservices register themselves to the pd api service
+		if !tc.PDIsAvailable() {
+			klog.Infof("TidbCluster: %s/%s, waiting for PD cluster running", ns, tcName)
+			return nil
+		}
+		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSts)
+		if err != nil {
+			return err
+		}
+		return m.deps.StatefulSetControl.CreateStatefulSet(tc, newSts)
+	}
+
+	// Scaling takes precedence over upgrading because:
+	// - if a pod fails in the upgrading, users may want to delete it or add
+	//   new replicas
+	// - it's ok to scale in the middle of upgrading (in statefulset controller
+	//   scaling takes precedence over upgrading too)
+	if err := m.scaler.Scale(tc, oldSts, newSts); err != nil {
+		return err
+	}
+
+	if !templateEqual(newSts, oldSts) || tc.Status.PDMS[spec.Name].Phase == v1alpha1.UpgradePhase {
+		if err := m.upgrader.Upgrade(tc, oldSts, newSts); err != nil {
+			return err
+		}
+	}
+
+	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdatePDMSSTS", newSts, oldSts)
+}
+
+func (m *pdMSMemberManager) syncPDMSStatus(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec, sts *apps.StatefulSet) error {
+	if sts == nil {
+		// skip if not created yet
+		return nil
+	}
+
+	status := tc.Status.PDMS[spec.Name]
+	status.StatefulSet = &sts.Status
+	upgrading, err := m.statefulSetIsUpgradingFn(m.deps.PodLister, sts, tc, spec.Name)
+	if err != nil {
+		status.Synced = false
+		return err
+	}
+	if upgrading {
+		status.Phase = v1alpha1.UpgradePhase
+	} else if *sts.Spec.Replicas != spec.Replicas {
+		status.Phase = v1alpha1.ScalePhase
+	} else {
+		status.Phase = v1alpha1.NormalPhase
+	}
+
+	if c := findContainerByName(sts, spec.Name); c != nil {
+		status.Image = c.Image
+	}
+
+	pdClient := controller.GetPDClient(m.deps.PDControl, tc)
+	members, err := pdClient.GetMSMembers(spec.Name)
+	if err != nil {
+		status.Synced = false
+		return err
+	}
+	primary, err := pdClient.GetMSPrimary(spec.Name)
+	if err != nil {
+		status.Synced = false
+		return err
+	}
+
+	status.Members = members
+	status.Primary = primary
+	status.Synced = len(members) == int(spec.Replicas)
+	return nil
+}
+
+func (m *pdMSMemberManager) syncPDMSConfigMap(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
+	newCm, err := getPDMSConfigMap(tc, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var inUseName string
+	if set != nil {
+		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
+			return strings.HasPrefix(name, controller.PDMSMemberName(tc.Name, spec.Name))
+		})
+	}
+
+	klog.V(3).Infof("get %s in use config map name: %s", spec.Name, inUseName)
+
+	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BasePDMSSpec(spec).ConfigUpdateStrategy(), inUseName, newCm)
+	if err != nil {
+		return nil, err
+	}
+	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+}
+
+func (m *pdMSMemberManager) syncService(tc *v1alpha1.TidbCluster, newSvc *corev1.Service) error {
+	ns := newSvc.Namespace
+	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(newSvc.Name)
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.deps.ServiceControl.CreateService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncService: failed to get svc %s for cluster %s/%s, error: %s", newSvc.Name, ns, tc.GetName(), err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+	util.RetainManagedFields(newSvc, oldSvc)
+
+	equal, err := controller.ServiceEqual(newSvc, oldSvc)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		svc := *oldSvc
+		svc.Spec = newSvc.Spec
+		err = controller.SetServiceLastAppliedConfigAnnotation(&svc)
+		if err != nil {
+			return err
+		}
+		svc.Spec.ClusterIP = oldSvc.Spec.ClusterIP
+		_, err = m.deps.ServiceControl.UpdateService(tc, &svc)
+		return err
+	}
+
+	return nil
+}
+
+// getPDMSConfigMap renders the configuration and the start script of the pd microservice
+func getPDMSConfigMap(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec) (*corev1.ConfigMap, error) {
+	cfg := config.New(map[string]interface{}{})
+	if spec.Config != nil {
+		cfg = spec.Config.DeepCopy()
+	}
+
+	if tc.IsTLSClusterEnabled() {
+		cfg.Set("security.cacert-path", path.Join(pdMSClusterCertPath, tlsSecretRootCAKey))
+		cfg.Set("security.cert-path", path.Join(pdMSClusterCertPath, corev1.TLSCertKey))
+		cfg.Set("security.key-path", path.Join(pdMSClusterCertPath, corev1.TLSPrivateKeyKey))
+	}
+
+	confText, err := cfg.MarshalTOML()
+	if err != nil {
+		return nil, err
+	}
+
+	startScript, err := RenderPDMSStartScript(&PDMSStartScriptModel{
+		CommonModel: CommonModel{
+			AcrossK8s:     tc.AcrossK8s(),
+			ClusterDomain: tc.Spec.ClusterDomain,
+		},
+		Name:        spec.Name,
+		Scheme:      tc.Scheme(),
+		ClusterName: tc.Name,
+		PDAddress:   fmt.Sprintf("%s://%s:%d", tc.Scheme(), controller.PDMemberName(tc.Name), pdMSPort),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.PDMSMemberName(tc.Name, spec.Name),
+			Namespace:       tc.Namespace,
+			Labels:          labelPDMS(tc, spec.Name).Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Data: map[string]string{
+			"config-file":    string(confText),
+			"startup-script": startScript,
+		},
+	}
+	return cm, nil
+}
+
+func getNewPDMSHeadlessService(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec) *corev1.Service {
+	svcLabel := labelPDMS(tc, spec.Name)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.PDMSPeerMemberName(tc.Name, spec.Name),
+			Namespace:       tc.Namespace,
+			Labels:          svcLabel.Copy().UsedByPeer().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "None",
+			Ports: []corev1.ServicePort{
+				{
+					Name:       fmt.Sprintf("tcp-peer-%d", pdMSPort),
+					Port:       pdMSPort,
+					TargetPort: intstr.FromInt(pdMSPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector:                 svcLabel.Labels(),
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
+func getNewPDMSService(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec) *corev1.Service {
+	selector := labelPDMS(tc, spec.Name)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.PDMSMemberName(tc.Name, spec.Name),
+			Namespace:       tc.Namespace,
+			Labels:          selector.Copy().UsedByEndUser().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "client",
+					Port:       pdMSPort,
+					TargetPort: intstr.FromInt(pdMSPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: selector.Labels(),
+		},
+	}
+
+	// override fields with user-defined ServiceSpec
+	svcSpec := spec.Service
+	if svcSpec != nil {
+		if svcSpec.Type != "" {
+			svc.Spec.Type = svcSpec.Type
+		}
+		svc.ObjectMeta.Annotations = util.CopyStringMap(svcSpec.Annotations)
+		svc.ObjectMeta.Labels = util.CombineStringMap(svc.ObjectMeta.Labels, svcSpec.Labels)
+		if svcSpec.LoadBalancerIP != nil {
+			svc.Spec.LoadBalancerIP = *svcSpec.LoadBalancerIP
+		}
+		if svcSpec.ClusterIP != nil {
+			svc.Spec.ClusterIP = *svcSpec.ClusterIP
+		}
+		if svcSpec.PortName != nil {
+			svc.Spec.Ports[0].Name = *svcSpec.PortName
+		}
+	}
+	return svc
+}
+
+// getNewPDMSStatefulSet returns the statefulset of the pd microservice, the microservices
+// keep no data locally, so there is no volume claim for them.
+func getNewPDMSStatefulSet(tc *v1alpha1.TidbCluster, spec *v1alpha1.PDMSSpec, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	baseSpec := tc.BasePDMSSpec(spec)
+	stsLabels := labelPDMS(tc, spec.Name)
+	stsName := controller.PDMSMemberName(tcName, spec.Name)
+	podLabels := util.CombineStringMap(stsLabels, baseSpec.Labels())
+	podAnnotations := util.CombineStringMap(map[string]string{
+		"prometheus.io/scrape": "true",
+		"prometheus.io/path":   "/metrics",
+		"prometheus.io/port":   fmt.Sprintf("%d", pdMSPort),
+	}, baseSpec.Annotations())
+	stsAnnotations := getStsAnnotations(tc.Annotations, spec.Name)
+	headlessSvcName := controller.PDMSPeerMemberName(tcName, spec.Name)
+
+	volMounts := []corev1.VolumeMount{
+		{Name: "config", ReadOnly: true, MountPath: "/etc/pd"},
+		{Name: "startup-script", ReadOnly: true, MountPath: "/usr/local/bin"},
+	}
+	vols := []corev1.Volume{
+		{Name: "config", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: cm.Name,
+				},
+				Items: []corev1.KeyToPath{{Key: "config-file", Path: "pd.toml"}},
+			}},
+		},
+		{Name: "startup-script", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: cm.Name,
+				},
+				Items: []corev1.KeyToPath{{Key: "startup-script", Path: "pdms_start_script.sh"}},
+			}},
+		},
+	}
+	if tc.IsTLSClusterEnabled() {
+		volMounts = append(volMounts, corev1.VolumeMount{
+			Name: "pdms-tls", ReadOnly: true, MountPath: pdMSClusterCertPath,
+		})
+		vols = append(vols, corev1.Volume{
+			Name: "pdms-tls", VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.ClusterTLSSecretName(tcName, spec.Name),
+				},
+			},
+		})
+	}
+	volMounts = append(volMounts, spec.AdditionalVolumeMounts...)
+
+	envs := []corev1.EnvVar{
+		{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		},
+		{
+			Name: "NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.namespace",
+				},
+			},
+		},
+		{
+			Name:  "PEER_SERVICE_NAME",
+			Value: headlessSvcName,
+		},
+		{
+			Name:  "TZ",
+			Value: tc.Spec.Timezone,
+		},
+	}
+
+	container := corev1.Container{
+		Name:            spec.Name,
+		Image:           tc.PDMSImage(spec),
+		ImagePullPolicy: baseSpec.ImagePullPolicy(),
+		Command:         []string{"/bin/sh", "/usr/local/bin/pdms_start_script.sh"},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "client",
+				ContainerPort: int32(pdMSPort),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: volMounts,
+		Resources:    controller.ContainerResource(spec.ResourceRequirements),
+		Env:          util.AppendEnv(envs, baseSpec.Env()),
+		EnvFrom:      baseSpec.EnvFrom(),
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt(pdMSPort),
+				},
+			},
+			InitialDelaySeconds: int32(10),
+		},
+	}
+
+	podSpec := baseSpec.BuildPodSpec()
+	podSpec.Containers = append([]corev1.Container{container}, baseSpec.AdditionalContainers()...)
+	podSpec.Volumes = append(vols, baseSpec.AdditionalVolumes()...)
+	podSpec.ServiceAccountName = spec.ServiceAccount
+	podSpec.InitContainers = append(podSpec.InitContainers, baseSpec.InitContainers()...)
+	if podSpec.ServiceAccountName == "" {
+		podSpec.ServiceAccountName = tc.Spec.ServiceAccount
+	}
+
+	updateStrategy := apps.StatefulSetUpdateStrategy{}
+	if baseSpec.StatefulSetUpdateStrategy() == apps.OnDeleteStatefulSetStrategyType {
+		updateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+	} else {
+		updateStrategy.Type = apps.RollingUpdateStatefulSetStrategyType
+		updateStrategy.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{
+			Partition: pointer.Int32Ptr(spec.Replicas),
+		}
+	}
+
+	return &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            stsName,
+			Namespace:       ns,
+			Labels:          stsLabels.Labels(),
+			Annotations:     stsAnnotations,
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(spec.Replicas),
+			Selector: stsLabels.LabelSelector(),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: podAnnotations,
+				},
+				Spec: podSpec,
+			},
+			ServiceName:         headlessSvcName,
+			PodManagementPolicy: baseSpec.PodManagementPolicy(),
+			UpdateStrategy:      updateStrategy,
+		},
+	}, nil
+}
+
+func labelPDMS(tc *v1alpha1.TidbCluster, name string) label.Label {
+	instanceName := tc.GetInstanceName()
+	return label.New().Instance(instanceName).PDMS(name)
+}
+
+// pdMSSpecOf returns the spec of the pd microservice with the given name
+func pdMSSpecOf(tc *v1alpha1.TidbCluster, name string) *v1alpha1.PDMSSpec {
+	for _, spec := range tc.Spec.PDMS {
+		if spec.Name == name {
+			return spec
+		}
+	}
+	return nil
+}
+
+// pdMSPodURL returns the url to access the pd microservice pod
+func pdMSPodURL(tc *v1alpha1.TidbCluster, name, podName string) string {
+	return fmt.Sprintf("%s://%s.%s.%s:%d", tc.Scheme(), podName, controller.PDMSPeerMemberName(tc.Name, name), tc.Namespace, pdMSPort)
+}
+
+// pdMSMemberPodName returns the pod name of the pd microservice member from its address, e.g.
+// http://basic-tso-0.basic-tso-peer.ns.svc:2379 -> basic-tso-0
+func pdMSMemberPodName(addr string) string {
+	if i := strings.Index(addr, "://"); i >= 0 {
+		addr = addr[i+3:]
+	}
+	return strings.SplitN(strings.SplitN(addr, ":", 2)[0], ".", 2)[0]
+}
+
+func pdMSStatefulSetIsUpgrading(podLister corelisters.PodLister, set *apps.StatefulSet, tc *v1alpha1.TidbCluster, name string) (bool, error) {
+	if mngerutils.StatefulSetIsUpgrading(set) {
+		return true, nil
+	}
+	selector, err := labelPDMS(tc, name).Selector()
+	if err != nil {
+		return false, err
+	}
+	pods, err := podLister.Pods(tc.GetNamespace()).List(selector)
+	if err != nil {
+		return false, fmt.Errorf("pdMSStatefulSetIsUpgrading: failed to list pods for cluster %s/%s, selector %s, error: %s", tc.GetNamespace(), tc.GetName(), selector, err)
+	}
+	for _, pod := range pods {
+		revisionHash, exist := pod.Labels[apps.ControllerRevisionHashLabelKey]
+		if !exist {
+			return false, nil
+		}
+		if revisionHash != tc.Status.PDMS[name].StatefulSet.UpdateRevision {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+type FakePDMSMemberManager struct {
+	err error
+}
+
+func NewFakePDMSMemberManager() *FakePDMSMemberManager {
+	return &FakePDMSMemberManager{}
+}
+
+func (m *FakePDMSMemberManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakePDMSMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if m.err != nil {
+		return m.err
+	}
+	return nil
+}
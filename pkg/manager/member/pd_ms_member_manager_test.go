@@ -0,0 +1,138 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+func newTidbClusterForPDMS() *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TidbCluster",
+			APIVersion: "pingcap.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "ns",
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			PD: &v1alpha1.PDSpec{
+				BaseImage: "pingcap/pd",
+				Replicas:  3,
+				Mode:      v1alpha1.PDModeMS,
+			},
+			PDMS: []*v1alpha1.PDMSSpec{
+				{Name: v1alpha1.PDMSTSO, BaseImage: "pingcap/pd", Replicas: 2},
+				{Name: v1alpha1.PDMSScheduling, BaseImage: "pingcap/pd", Replicas: 2},
+			},
+			Version: "v8.1.0",
+		},
+	}
+}
+
+func TestGetPDMSConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPDMS()
+	tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+	cm, err := getPDMSConfigMap(tc, tc.Spec.PDMS[0])
+	g.Expect(err).To(Succeed())
+	g.Expect(cm.Name).To(Equal("foo-tso"))
+	g.Expect(cm.Data["config-file"]).To(ContainSubstring(`cacert-path = "/var/lib/pdms-tls/ca.crt"`))
+	g.Expect(cm.Data["startup-script"]).To(ContainSubstring(`pd_url="https://foo-pd:2379"`))
+	g.Expect(cm.Data["startup-script"]).To(ContainSubstring("--listen-addr=https://0.0.0.0:2379"))
+	g.Expect(cm.Data["startup-script"]).To(ContainSubstring("exec /pd-server services tso ${ARGS}"))
+}
+
+func TestGetNewPDMSStatefulSet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPDMS()
+	spec := tc.Spec.PDMS[1]
+	cm, err := getPDMSConfigMap(tc, spec)
+	g.Expect(err).To(Succeed())
+
+	set, err := getNewPDMSStatefulSet(tc, spec, cm)
+	g.Expect(err).To(Succeed())
+	g.Expect(set.Name).To(Equal("foo-scheduling"))
+	g.Expect(set.Spec.ServiceName).To(Equal("foo-scheduling-peer"))
+	g.Expect(*set.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(set.Spec.VolumeClaimTemplates).To(BeEmpty())
+	g.Expect(set.Spec.Template.Labels).To(HaveKeyWithValue("app.kubernetes.io/component", "scheduling"))
+
+	container := set.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Name).To(Equal("scheduling"))
+	g.Expect(container.Image).To(Equal("pingcap/pd:v8.1.0"))
+	g.Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "PEER_SERVICE_NAME", Value: "foo-scheduling-peer"}))
+}
+
+func TestPDMSMemberManagerSyncStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForPDMS()
+	tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{
+		v1alpha1.PDMSTSO: {Name: v1alpha1.PDMSTSO},
+	}
+	fakeDeps := controller.NewFakeDependencies()
+	pdClient := controller.NewFakePDClient(fakeDeps.PDControl.(*pdapi.FakePDControl), tc)
+	members := []string{
+		"http://foo-tso-0.foo-tso-peer.ns.svc:2379",
+		"http://foo-tso-1.foo-tso-peer.ns.svc:2379",
+	}
+	pdClient.AddReaction(pdapi.GetMSMembersActionType, func(action *pdapi.Action) (interface{}, error) {
+		g.Expect(action.Name).To(Equal(v1alpha1.PDMSTSO))
+		return members, nil
+	})
+	pdClient.AddReaction(pdapi.GetMSPrimaryActionType, func(action *pdapi.Action) (interface{}, error) {
+		return members[1], nil
+	})
+
+	m := &pdMSMemberManager{
+		deps: fakeDeps,
+		statefulSetIsUpgradingFn: func(corelisters.PodLister, *apps.StatefulSet, *v1alpha1.TidbCluster, string) (bool, error) {
+			return true, nil
+		},
+	}
+	cm, err := getPDMSConfigMap(tc, tc.Spec.PDMS[0])
+	g.Expect(err).To(Succeed())
+	set, err := getNewPDMSStatefulSet(tc, tc.Spec.PDMS[0], cm)
+	g.Expect(err).To(Succeed())
+
+	g.Expect(m.syncPDMSStatus(tc, tc.Spec.PDMS[0], set)).To(Succeed())
+	status := tc.Status.PDMS[v1alpha1.PDMSTSO]
+	g.Expect(status.Phase).To(Equal(v1alpha1.UpgradePhase))
+	g.Expect(status.Image).To(Equal("pingcap/pd:v8.1.0"))
+	g.Expect(status.Members).To(Equal(members))
+	g.Expect(status.Primary).To(Equal(members[1]))
+	g.Expect(status.Synced).To(BeTrue())
+}
+
+func TestPDMSMemberPodName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(pdMSMemberPodName("http://foo-tso-0.foo-tso-peer.ns.svc:2379")).To(Equal("foo-tso-0"))
+	g.Expect(pdMSMemberPodName("foo-tso-1.foo-tso-peer:2379")).To(Equal("foo-tso-1"))
+	g.Expect(pdMSMemberPodName("foo-tso-2")).To(Equal("foo-tso-2"))
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+type pdMSScaler struct {
+	generalScaler
+}
+
+// NewPDMSScaler returns a pd microservice Scaler
+func NewPDMSScaler(deps *controller.Dependencies) *pdMSScaler {
+	return &pdMSScaler{generalScaler: generalScaler{deps: deps}}
+}
+
+// Scale scales in or out of the statefulset.
+func (s *pdMSScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	scaling, _, _, _ := scaleOne(oldSet, newSet)
+	if scaling > 0 {
+		return s.ScaleOut(meta, oldSet, newSet)
+	} else if scaling < 0 {
+		return s.ScaleIn(meta, oldSet, newSet)
+	}
+	return nil
+}
+
+// ScaleOut scales out of the statefulset, the pd microservices keep no data locally so
+// there is no PVC to handle.
+func (s *pdMSScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	_, ordinal, replicas, deleteSlots := scaleOne(oldSet, newSet)
+	resetReplicas(newSet, oldSet)
+
+	klog.Infof("scaling out %s statefulset %s/%s, ordinal: %d (replicas: %d, delete slots: %v)", oldSet.Labels[label.ComponentLabelKey], oldSet.Namespace, oldSet.Name, ordinal, replicas, deleteSlots.List())
+	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
+	return nil
+}
+
+// ScaleIn scales in of the statefulset, the primary is transferred to another member before
+// the primary pod is deleted.
+func (s *pdMSScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	ns := meta.GetNamespace()
+	tcName := meta.GetName()
+	name := oldSet.Labels[label.ComponentLabelKey]
+	_, ordinal, replicas, deleteSlots := scaleOne(oldSet, newSet)
+	resetReplicas(newSet, oldSet)
+
+	klog.Infof("scaling in %s statefulset %s/%s, ordinal: %d (replicas: %d, delete slots: %v)", name, oldSet.Namespace, oldSet.Name, ordinal, replicas, deleteSlots.List())
+	tc, ok := meta.(*v1alpha1.TidbCluster)
+	if !ok {
+		klog.Errorf("pdMSScaler.ScaleIn: failed to convert cluster %s/%s", ns, tcName)
+		return nil
+	}
+
+	podName := ordinalPodName(v1alpha1.PDMSMemberType(name), tcName, ordinal)
+	transferred, err := transferPDMSPrimaryIfNeeded(s.deps, tc, name, podName)
+	if err != nil {
+		return err
+	}
+	if transferred {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %s pod: [%s] is transferring the primary, wait for the next round", ns, tcName, name, podName)
+	}
+
+	setReplicasAndDeleteSlots(newSet, replicas, deleteSlots)
+	return nil
+}
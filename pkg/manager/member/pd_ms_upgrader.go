@@ -0,0 +1,159 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+type pdMSUpgrader struct {
+	deps *controller.Dependencies
+}
+
+// NewPDMSUpgrader returns a pd microservice Upgrader
+func NewPDMSUpgrader(deps *controller.Dependencies) Upgrader {
+	return &pdMSUpgrader{
+		deps: deps,
+	}
+}
+
+// Upgrade upgrades the pd microservice after the pd api service, and the scheduling service
+// is upgraded after the tso service.
+func (u *pdMSUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSet, newSet *apps.StatefulSet) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	name := oldSet.Labels[label.ComponentLabelKey]
+	spec := pdMSSpecOf(tc, name)
+	if spec == nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] has no spec of pd microservice %s", ns, tcName, name)
+	}
+	// return nil when scale replicas to 0
+	if spec.Replicas == int32(0) {
+		return nil
+	}
+	status := tc.Status.PDMS[name]
+
+	waitFor := ""
+	if tc.Status.PD.Phase == v1alpha1.UpgradePhase {
+		waitFor = v1alpha1.PDMemberType.String()
+	} else if tso := tc.Status.PDMS[v1alpha1.PDMSTSO]; name == v1alpha1.PDMSScheduling && tso != nil && tso.Phase == v1alpha1.UpgradePhase {
+		waitFor = v1alpha1.PDMSTSO
+	}
+	if waitFor != "" {
+		klog.Infof("TidbCluster: [%s/%s]'s %s is upgrading, can not upgrade %s", ns, tcName, waitFor, name)
+		_, podSpec, err := GetLastAppliedConfig(oldSet)
+		if err != nil {
+			return err
+		}
+		newSet.Spec.Template.Spec = *podSpec
+		return nil
+	}
+
+	status.Phase = v1alpha1.UpgradePhase
+	if !templateEqual(newSet, oldSet) {
+		return nil
+	}
+
+	if status.StatefulSet.UpdateRevision == status.StatefulSet.CurrentRevision {
+		return nil
+	}
+
+	if oldSet.Spec.UpdateStrategy.Type == apps.OnDeleteStatefulSetStrategyType || oldSet.Spec.UpdateStrategy.RollingUpdate == nil {
+		// the update strategy has been modified manually, let the native statefulset controller do the upgrade
+		newSet.Spec.UpdateStrategy = oldSet.Spec.UpdateStrategy
+		klog.Warningf("tidbcluster: [%s/%s] %s statefulset %s UpdateStrategy has been modified manually", ns, tcName, name, oldSet.GetName())
+		return nil
+	}
+
+	mngerutils.SetUpgradePartition(newSet, *oldSet.Spec.UpdateStrategy.RollingUpdate.Partition)
+	podOrdinals := helper.GetPodOrdinals(*oldSet.Spec.Replicas, oldSet).List()
+	for _i := len(podOrdinals) - 1; _i >= 0; _i-- {
+		i := podOrdinals[_i]
+		podName := ordinalPodName(v1alpha1.PDMSMemberType(name), tcName, i)
+		pod, err := u.deps.PodLister.Pods(ns).Get(podName)
+		if err != nil {
+			return fmt.Errorf("pdMSUpgrader.Upgrade: failed to get pod %s for cluster %s/%s, error: %s", podName, ns, tcName, err)
+		}
+		revision, exist := pod.Labels[apps.ControllerRevisionHashLabelKey]
+		if !exist {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %s pod: [%s] has no label: %s", ns, tcName, name, podName, apps.ControllerRevisionHashLabelKey)
+		}
+
+		if revision == status.StatefulSet.UpdateRevision {
+			if !podutil.IsPodReady(pod) {
+				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded %s pod: [%s] is not ready", ns, tcName, name, podName)
+			}
+			if err := u.podPDMSClient(tc, name, podName).GetHealth(); err != nil {
+				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded %s pod: [%s] is not healthy, error: %v", ns, tcName, name, podName, err)
+			}
+			continue
+		}
+
+		transferred, err := transferPDMSPrimaryIfNeeded(u.deps, tc, name, podName)
+		if err != nil {
+			return err
+		}
+		if transferred {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %s pod: [%s] is transferring the primary, wait for the next round", ns, tcName, name, podName)
+		}
+		mngerutils.SetUpgradePartition(newSet, i)
+		return nil
+	}
+
+	return nil
+}
+
+func (u *pdMSUpgrader) podPDMSClient(tc *v1alpha1.TidbCluster, name, podName string) pdapi.PDMSClient {
+	url := pdMSPodURL(tc, name, podName)
+	return u.deps.PDControl.GetPDMSClient(pdapi.Namespace(tc.GetNamespace()), tc.GetName(), name, tc.IsTLSClusterEnabled(), pdapi.SpecifyClient(url, url))
+}
+
+// transferPDMSPrimaryIfNeeded transfers the primary of the pd microservice to another member if the
+// pod is the primary, it returns true if the transfer request is sent.
+func transferPDMSPrimaryIfNeeded(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, name, podName string) (bool, error) {
+	status := tc.Status.PDMS[name]
+	if status == nil || status.Primary == "" || pdMSMemberPodName(status.Primary) != podName {
+		return false, nil
+	}
+
+	var target string
+	for _, member := range status.Members {
+		if memberPod := pdMSMemberPodName(member); memberPod != podName {
+			target = memberPod
+			break
+		}
+	}
+	if target == "" {
+		// the only member is going to be restarted, nothing to transfer to
+		return false, nil
+	}
+
+	if err := controller.GetPDMSClient(deps.PDControl, tc, name).TransferPrimary(target); err != nil {
+		return false, fmt.Errorf("failed to transfer the %s primary of tidbcluster [%s/%s] from %s to %s, error: %v",
+			name, tc.GetNamespace(), tc.GetName(), podName, target, err)
+	}
+	klog.Infof("tidbcluster: [%s/%s]'s %s primary is transferred from %s to %s", tc.GetNamespace(), tc.GetName(), name, podName, target)
+	return true, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPDMSUpgrader_Upgrade(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name        string
+		service     string
+		changeFn    func(*v1alpha1.TidbCluster)
+		healthErr   error
+		errorExpect bool
+		transferTo  string
+		partition   int32
+	}
+
+	testFn := func(test *testcase, t *testing.T) {
+		t.Log(test.name)
+		fakeDeps := controller.NewFakeDependencies()
+		pdControl := fakeDeps.PDControl.(*pdapi.FakePDControl)
+		upgrader := NewPDMSUpgrader(fakeDeps)
+
+		tc := newTidbClusterForPDMS()
+		spec := pdMSSpecOf(tc, test.service)
+		tc.Status.PDMS = map[string]*v1alpha1.PDMSStatus{}
+		for _, s := range tc.Spec.PDMS {
+			tc.Status.PDMS[s.Name] = &v1alpha1.PDMSStatus{
+				Name:        s.Name,
+				Phase:       v1alpha1.NormalPhase,
+				StatefulSet: &apps.StatefulSetStatus{CurrentRevision: "1", UpdateRevision: "2"},
+				Members: []string{
+					fmt.Sprintf("http://foo-%s-0.foo-%s-peer.ns.svc:2379", s.Name, s.Name),
+					fmt.Sprintf("http://foo-%s-1.foo-%s-peer.ns.svc:2379", s.Name, s.Name),
+				},
+				Primary: fmt.Sprintf("http://foo-%s-0.foo-%s-peer.ns.svc:2379", s.Name, s.Name),
+			}
+		}
+		if test.changeFn != nil {
+			test.changeFn(tc)
+		}
+
+		cm, err := getPDMSConfigMap(tc, spec)
+		g.Expect(err).To(Succeed())
+		oldSet, err := getNewPDMSStatefulSet(tc, spec, cm)
+		g.Expect(err).To(Succeed())
+		// the new template has been applied in the previous round, the pods are being upgraded
+		oldSet.Spec.Template.Spec.Containers[0].Image = "pingcap/pd:v8.2.0"
+		mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+		newSet := oldSet.DeepCopy()
+
+		podIndexer := fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+		for i := 0; i < 2; i++ {
+			revision := "1"
+			if i == 1 {
+				revision = "2"
+			}
+			podName := fmt.Sprintf("foo-%s-%d", test.service, i)
+			podIndexer.Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      podName,
+					Namespace: "ns",
+					Labels:    map[string]string{apps.ControllerRevisionHashLabelKey: revision},
+				},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			})
+			podClient := pdapi.NewFakePDMSClient()
+			healthErr := test.healthErr
+			podClient.AddReaction(pdapi.GetHealthActionType, func(action *pdapi.Action) (interface{}, error) {
+				return nil, healthErr
+			})
+			pdControl.SetPDMSClientWithAddress(pdMSPodURL(tc, test.service, podName), podClient)
+		}
+
+		var transferTo string
+		svcClient := controller.NewFakePDMSClient(pdControl, tc, test.service)
+		svcClient.AddReaction(pdapi.TransferPrimaryActionType, func(action *pdapi.Action) (interface{}, error) {
+			transferTo = action.Name
+			return nil, nil
+		})
+
+		err = upgrader.Upgrade(tc, oldSet, newSet)
+		if test.errorExpect {
+			g.Expect(err).To(HaveOccurred())
+		} else {
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		g.Expect(transferTo).To(Equal(test.transferTo))
+		g.Expect(*newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(test.partition))
+	}
+
+	tests := []*testcase{
+		{
+			name:    "upgrade the next pod after the primary is transferred",
+			service: v1alpha1.PDMSTSO,
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PDMS[v1alpha1.PDMSTSO].Primary = "http://foo-tso-1.foo-tso-peer.ns.svc:2379"
+			},
+			partition: 0,
+		},
+		{
+			name:        "transfer the primary before upgrading it",
+			service:     v1alpha1.PDMSTSO,
+			errorExpect: true,
+			transferTo:  "foo-tso-1",
+			partition:   2,
+		},
+		{
+			name:        "upgraded pod is not healthy",
+			service:     v1alpha1.PDMSTSO,
+			healthErr:   fmt.Errorf("unhealthy"),
+			errorExpect: true,
+			partition:   2,
+		},
+		{
+			name:    "scheduling waits for tso",
+			service: v1alpha1.PDMSScheduling,
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PDMS[v1alpha1.PDMSTSO].Phase = v1alpha1.UpgradePhase
+			},
+			partition: 2,
+		},
+		{
+			name:    "tso waits for pd",
+			service: v1alpha1.PDMSTSO,
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.UpgradePhase
+			},
+			partition: 2,
+		},
+	}
+
+	for _, test := range tests {
+		testFn(test, t)
+	}
+}
@@ -47,6 +47,9 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 	if !tc.Status.PD.Synced {
 		return fmt.Errorf("tidbcluster: [%s/%s]'s pd status sync failed, can not to be upgraded", ns, tcName)
 	}
+	if blocked, err := blockUpgradeByPreflight(tc, v1alpha1.PDMemberType, oldSet, newSet); err != nil || blocked {
+		return err
+	}
 	if tc.PDScaling() {
 		klog.Infof("TidbCluster: [%s/%s]'s pd status is %v, can not upgrade pd",
 			ns, tcName, tc.Status.PD.Phase)
@@ -102,6 +105,9 @@ func (u *pdUpgrader) gracefulUpgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Stat
 			continue
 		}
 
+		if err := checkUpgradeStep(u.deps, tc, v1alpha1.PDMemberType, int32(len(podOrdinals)), int32(len(podOrdinals)-1-_i)); err != nil {
+			return err
+		}
 		return u.upgradePDPod(tc, i, newSet)
 	}
 
@@ -48,6 +48,7 @@ const (
 
 type binlogClient interface {
 	PumpNodeStatus(ctx context.Context) (status []*v1alpha1.PumpNodeStatus, err error)
+	DrainerNodeStatus(ctx context.Context) (status []*v1alpha1.PumpNodeStatus, err error)
 	Close() error
 }
 
@@ -765,12 +765,17 @@ func TestSyncTiDBClusterStatus(t *testing.T) {
 }
 
 type fakeBinlogClient struct {
+	drainers []*v1alpha1.PumpNodeStatus
 }
 
 func (c *fakeBinlogClient) PumpNodeStatus(ctx context.Context) (status []*v1alpha1.PumpNodeStatus, err error) {
 	return nil, nil
 }
 
+func (c *fakeBinlogClient) DrainerNodeStatus(ctx context.Context) (status []*v1alpha1.PumpNodeStatus, err error) {
+	return c.drainers, nil
+}
+
 func (c *fakeBinlogClient) Close() error {
 	return nil
 }
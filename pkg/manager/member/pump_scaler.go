@@ -85,8 +85,13 @@ func (s *pumpScaler) ScaleOut(meta metav1.Object, oldSet *apps.StatefulSet, newS
 // parse the advertise address from pod start command.
 // must respect to pump start script in template.go
 func pumpAdvertiseAddr(pod *v1.Pod) string {
+	return binlogAdvertiseAddr(pod, "pump")
+}
+
+// binlogAdvertiseAddr parses the advertise address of pump or drainer from the start command of the container.
+func binlogAdvertiseAddr(pod *v1.Pod, containerName string) string {
 	for _, c := range pod.Spec.Containers {
-		if c.Name != "pump" {
+		if c.Name != containerName {
 			continue
 		}
 
@@ -165,21 +170,7 @@ func (s *pumpScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 	// and operator do not run in the same namespace with tidb-cluster,
 	// we can not use this advertise address to access pump.
 	// so will add the namespace part to the address if need.
-	client.HookAddr = func(addr string) string {
-		host, port, err := net.SplitHostPort(addr)
-		if err != nil {
-			return addr
-		}
-
-		suffix := "." + ns
-
-		if strings.Contains(addr, suffix) {
-			return addr
-		}
-
-		host += suffix
-		return host + ":" + port
-	}
+	client.HookAddr = addrWithNamespace(ns)
 
 	addr := pumpAdvertiseAddr(pod)
 
@@ -248,6 +239,25 @@ func (s *pumpScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 	return fmt.Errorf("Pump %s/%s not found in cluster", ns, podName)
 }
 
+// addrWithNamespace returns a function that adds the namespace to the host of the address if it is missing.
+func addrWithNamespace(ns string) func(addr string) string {
+	return func(addr string) string {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return addr
+		}
+
+		suffix := "." + ns
+
+		if strings.Contains(addr, suffix) {
+			return addr
+		}
+
+		host += suffix
+		return host + ":" + port
+	}
+}
+
 type fakePumpScaler struct{}
 
 // NewFakePumpScaler returns a fake pump Scaler
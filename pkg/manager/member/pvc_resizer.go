@@ -75,7 +75,9 @@ var (
 	tikvRequirement    = util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{label.TiKVLabelVal})
 	tiflashRequirement = util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{label.TiFlashLabelVal})
 	ticdcRequirement   = util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{label.TiCDCLabelVal})
+	tiproxyRequirement = util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{label.TiProxyLabelVal})
 	pumpRequirement    = util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{label.PumpLabelVal})
+	drainerRequirement = util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{label.DrainerLabelVal})
 
 	dmMasterRequirement = util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{label.DMMasterLabelVal})
 	dmWorkerRequirement = util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{label.DMWorkerLabelVal})
@@ -198,6 +200,13 @@ func (p *pvcResizer) buildContextForTC(tc *v1alpha1.TidbCluster, status v1alpha1
 			ctx.desiredVolumeQuantity[v1alpha1.GetStorageVolumeName("", v1alpha1.PDMemberType)] = quantity
 		}
 		storageVolumes = tc.Spec.PD.StorageVolumes
+	case v1alpha1.PDMSTSOMemberType, v1alpha1.PDMSSchedulingMemberType:
+		// the pd microservices have no data volume, there is nothing to resize
+		ctx.selector = selector.Add(*util.MustNewRequirement(label.ComponentLabelKey, selection.Equals, []string{comp.String()}))
+		pdmsStatus := status.(*v1alpha1.PDMSStatus)
+		if pdmsStatus.Volumes == nil {
+			pdmsStatus.Volumes = map[v1alpha1.StorageVolumeName]*v1alpha1.StorageVolumeStatus{}
+		}
 	case v1alpha1.TiDBMemberType:
 		ctx.selector = selector.Add(*tidbRequirement)
 		if tc.Status.TiDB.Volumes == nil {
@@ -229,6 +238,12 @@ func (p *pvcResizer) buildContextForTC(tc *v1alpha1.TidbCluster, status v1alpha1
 			tc.Status.TiCDC.Volumes = map[v1alpha1.StorageVolumeName]*v1alpha1.StorageVolumeStatus{}
 		}
 		storageVolumes = tc.Spec.TiCDC.StorageVolumes
+	case v1alpha1.TiProxyMemberType:
+		ctx.selector = selector.Add(*tiproxyRequirement)
+		if tc.Status.TiProxy.Volumes == nil {
+			tc.Status.TiProxy.Volumes = map[v1alpha1.StorageVolumeName]*v1alpha1.StorageVolumeStatus{}
+		}
+		storageVolumes = tc.Spec.TiProxy.StorageVolumes
 	case v1alpha1.PumpMemberType:
 		ctx.selector = selector.Add(*pumpRequirement)
 		if tc.Status.Pump.Volumes == nil {
@@ -237,6 +252,14 @@ func (p *pvcResizer) buildContextForTC(tc *v1alpha1.TidbCluster, status v1alpha1
 		if quantity, ok := tc.Spec.Pump.Requests[corev1.ResourceStorage]; ok {
 			ctx.desiredVolumeQuantity[v1alpha1.GetStorageVolumeName("", v1alpha1.PumpMemberType)] = quantity
 		}
+	case v1alpha1.DrainerMemberType:
+		ctx.selector = selector.Add(*drainerRequirement)
+		if tc.Status.Drainer.Volumes == nil {
+			tc.Status.Drainer.Volumes = map[v1alpha1.StorageVolumeName]*v1alpha1.StorageVolumeStatus{}
+		}
+		if quantity, ok := tc.Spec.Drainer.Requests[corev1.ResourceStorage]; ok {
+			ctx.desiredVolumeQuantity[v1alpha1.GetStorageVolumeName("", v1alpha1.DrainerMemberType)] = quantity
+		}
 	default:
 		return nil, fmt.Errorf("unsupported member type %s", comp)
 	}
@@ -202,6 +202,12 @@ sleep $((RANDOM % 5))
 done
 ARGS="${ARGS}${result}"
 fi
+{{- if .MSMode }}
+
+# In microservice mode, pd only serves as the api service, tso and scheduling are
+# provided by the pd microservices.
+ARGS="services api ${ARGS}"
+{{- end }}
 
 echo "starting pd-server ..."
 sleep $((RANDOM % 10))
@@ -233,12 +239,63 @@ type PDStartScriptModel struct {
 	Scheme            string
 	DataDir           string
 	CheckDomainScript string
+	// MSMode indicates whether pd is started in microservice mode
+	MSMode bool
 }
 
+// pdAPIModeArgs is the arguments of pd-server in the start script to start PD as the api service
+const pdAPIModeArgs = "services api"
+
 func RenderPDStartScript(model *PDStartScriptModel) (string, error) {
 	return renderTemplateFunc(pdStartScriptTpl, model)
 }
 
+// pdMSStartScriptTpl is the start script of the pd microservices, the endpoints of the
+// pd api service are got from the discovery service.
+// Note: changing this will cause a rolling-update of the pd microservice
+var pdMSStartScriptTpl = template.Must(template.New("pdms-start-script").Parse(`#!/bin/sh
+
+# This script is used to start pd microservice containers in kubernetes cluster
+
+set -uo pipefail
+
+# Use HOSTNAME if POD_NAME is unset for backward compatibility.
+POD_NAME=${POD_NAME:-$HOSTNAME}
+pd_url="{{ .PDAddress }}"
+encoded_domain_url=$(echo $pd_url | base64 | tr "\n" " " | sed "s/ //g")
+discovery_url="{{ .ClusterName }}-discovery.${NAMESPACE}:10261"
+
+until result=$(wget -qO- -T 3 http://${discovery_url}/verify/${encoded_domain_url} 2>/dev/null); do
+echo "waiting for the verification of PD endpoints ..."
+sleep $((RANDOM % 5))
+done
+
+ARGS="--name=${POD_NAME} \
+--backend-endpoints=${result} \
+--listen-addr={{ .Scheme }}://0.0.0.0:2379 \
+--advertise-listen-addr={{ .Scheme }}://${POD_NAME}.${PEER_SERVICE_NAME}.${NAMESPACE}.svc{{ .FormatClusterDomain }}:2379 \
+--config=/etc/pd/pd.toml \
+"
+
+echo "starting pd-server {{ .Name }} ..."
+echo "/pd-server services {{ .Name }} ${ARGS}"
+exec /pd-server services {{ .Name }} ${ARGS}
+`))
+
+type PDMSStartScriptModel struct {
+	CommonModel
+
+	// Name is the name of the pd microservice, tso or scheduling
+	Name        string
+	Scheme      string
+	ClusterName string
+	PDAddress   string
+}
+
+func RenderPDMSStartScript(model *PDMSStartScriptModel) (string, error) {
+	return renderTemplateFunc(pdMSStartScriptTpl, model)
+}
+
 var tikvStartScriptTpl = template.Must(template.New("tikv-start-script").Parse(`#!/bin/sh
 
 # This script is used to start tikv containers in kubernetes cluster
@@ -368,6 +425,63 @@ func RenderPumpStartScript(model *PumpStartScriptModel) (string, error) {
 	return renderTemplateFunc(pumpStartScriptTpl, model)
 }
 
+// drainerStartScriptTpl is the template string of drainer start script
+// Note: changing this will cause a rolling-update of drainer
+var drainerStartScriptTpl = template.Must(template.New("drainer-start-script").Parse(`{{ if .AcrossK8s }}
+pd_url="{{ .PDAddr }}"
+encoded_domain_url=$(echo $pd_url | base64 | tr "\n" " " | sed "s/ //g")
+discovery_url="{{ .ClusterName }}-discovery.{{ .Namespace }}:10261"
+until result=$(wget -qO- -T 3 http://${discovery_url}/verify/${encoded_domain_url} 2>/dev/null); do
+echo "waiting for the verification of PD endpoints ..."
+sleep $((RANDOM % 5))
+done
+
+pd_url=$result
+
+set -euo pipefail
+
+/drainer \
+-pd-urls=$pd_url \{{ else }}set -euo pipefail
+
+/drainer \
+-pd-urls={{ .PDAddr }} \{{ end }}
+-L={{ .LogLevel }} \
+-addr=0.0.0.0:8249 \
+-advertise-addr=` + "`" + `echo ${HOSTNAME}` + "`" + `.{{ .ClusterName }}-drainer{{ .FormatDrainerZone }}:8249 \
+-config=/etc/drainer/drainer.toml \
+-initial-commit-ts={{ .InitialCommitTs }} \
+-data-dir=/data \
+-log-file=
+
+if [ $? == 0 ]; then
+    echo $(date -u +"[%Y/%m/%d %H:%M:%S.%3N %:z]") "drainer offline, please delete my pod"
+    tail -f /dev/null
+fi`))
+
+type DrainerStartScriptModel struct {
+	CommonModel
+
+	ClusterName     string
+	PDAddr          string
+	LogLevel        string
+	Namespace       string
+	InitialCommitTs int64
+}
+
+func (dssm *DrainerStartScriptModel) FormatDrainerZone() string {
+	if dssm.ClusterDomain != "" {
+		return fmt.Sprintf(".%s.svc.%s", dssm.Namespace, dssm.ClusterDomain)
+	}
+	if dssm.ClusterDomain == "" && dssm.AcrossK8s {
+		return fmt.Sprintf(".%s.svc", dssm.Namespace)
+	}
+	return ""
+}
+
+func RenderDrainerStartScript(model *DrainerStartScriptModel) (string, error) {
+	return renderTemplateFunc(drainerStartScriptTpl, model)
+}
+
 // tidbInitStartScriptTpl is the template string of tidb initializer start script
 var tidbInitStartScriptTpl = template.Must(template.New("tidb-init-start-script").Parse(`import os, sys, time, MySQLdb
 host = '{{ .ClusterName }}-tidb'
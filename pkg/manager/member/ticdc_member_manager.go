@@ -212,6 +212,7 @@ func (m *ticdcMemberManager) syncTiCDCStatus(tc *v1alpha1.TidbCluster, sts *apps
 	tcName := tc.GetName()
 
 	tc.Status.TiCDC.StatefulSet = &sts.Status
+	syncUpgradeStepStatus(&tc.Status.TiCDC.UpgradeStep, tc.Status.TiCDC.StatefulSet)
 	upgrading, err := m.statefulSetIsUpgradingFn(m.deps.PodLister, m.deps.PDControl, sts, tc)
 	if err != nil {
 		tc.Status.TiCDC.Synced = false
@@ -152,6 +152,7 @@ func TestTiCDCScalerScaleIn(t *testing.T) {
 		isPodReady     bool
 		hasSynced      bool
 		pvcUpdateErr   bool
+		draining       bool
 		errExpectFn    func(*GomegaWithT, error)
 		changed        bool
 	}
@@ -222,6 +223,13 @@ func TestTiCDCScalerScaleIn(t *testing.T) {
 			pvcControl.SetUpdatePVCError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
 		}
 
+		if test.draining {
+			tc.Spec.TiCDC = &v1alpha1.TiCDCSpec{ComponentSpec: v1alpha1.ComponentSpec{Image: "ticdc-test-image:v6.3.0"}}
+			scaler.deps.CDCControl.(*controller.FakeTiCDCControl).MockDrainCapture(func(tc *v1alpha1.TidbCluster, ordinal int32) (int, bool, error) {
+				return 2, false, nil
+			})
+		}
+
 		err := scaler.ScaleIn(tc, oldSet, newSet)
 		test.errExpectFn(g, err)
 		if test.changed {
@@ -302,6 +310,17 @@ func TestTiCDCScalerScaleIn(t *testing.T) {
 			errExpectFn:    errExpectNotNil,
 			changed:        false,
 		},
+		{
+			name:           "ticdc capture is draining",
+			ticdcUpgrading: false,
+			hasPVC:         true,
+			isPodReady:     true,
+			hasSynced:      true,
+			pvcUpdateErr:   false,
+			draining:       true,
+			errExpectFn:    errExpectRequeue,
+			changed:        false,
+		},
 	}
 
 	for _, tt := range tests {
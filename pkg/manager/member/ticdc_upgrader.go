@@ -47,6 +47,10 @@ func (u *ticdcUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulS
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	if blocked, err := blockUpgradeByPreflight(tc, v1alpha1.TiCDCMemberType, oldSet, newSet); err != nil || blocked {
+		return err
+	}
+
 	if tc.Status.PD.Phase == v1alpha1.UpgradePhase ||
 		tc.Status.TiKV.Phase == v1alpha1.UpgradePhase ||
 		tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase ||
@@ -108,6 +112,12 @@ func (u *ticdcUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulS
 			}
 			continue
 		}
+		if err := checkUpgradeStep(u.deps, tc, v1alpha1.TiCDCMemberType, int32(len(podOrdinals)), int32(len(podOrdinals)-1-_i)); err != nil {
+			return err
+		}
+		if err := gracefulShutdownTiCDC(u.deps, tc, pod, i, "upgrade"); err != nil {
+			return err
+		}
 		mngerutils.SetUpgradePartition(newSet, i)
 		return nil
 	}
@@ -15,6 +15,7 @@ package member
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -41,12 +42,16 @@ func TestTiCDCUpgrader_Upgrade(t *testing.T) {
 		missPod      bool
 		errorExpect  bool
 		changeOldSet func(set *apps.StatefulSet)
+		changeCDC    func(cdcCtl *controller.FakeTiCDCControl)
 		expectFn     func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet)
 	}
 
 	testFn := func(test *testcase, t *testing.T) {
 		t.Log(test.name)
 		upgrader, podInformer := newTiCDCUpgrader()
+		if test.changeCDC != nil {
+			test.changeCDC(upgrader.(*ticdcUpgrader).deps.CDCControl.(*controller.FakeTiCDCControl))
+		}
 		tc := newTidbClusterForTiCDCUpgrader()
 		if test.changeFn != nil {
 			test.changeFn(tc)
@@ -102,6 +107,62 @@ func TestTiCDCUpgrader_Upgrade(t *testing.T) {
 			},
 			errorExpect: true,
 		},
+		{
+			name: "capture is draining",
+			changeCDC: func(cdcCtl *controller.FakeTiCDCControl) {
+				cdcCtl.MockDrainCapture(func(tc *v1alpha1.TidbCluster, ordinal int32) (int, bool, error) {
+					return 3, false, nil
+				})
+			},
+			errorExpect: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiCDC.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name: "capture is resigning owner",
+			changeCDC: func(cdcCtl *controller.FakeTiCDCControl) {
+				cdcCtl.MockResignOwner(func(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+					return false, nil
+				})
+			},
+			errorExpect: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiCDC.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name: "capture graceful shutdown timeout",
+			changeCDC: func(cdcCtl *controller.FakeTiCDCControl) {
+				cdcCtl.MockDrainCapture(func(tc *v1alpha1.TidbCluster, ordinal int32) (int, bool, error) {
+					return 3, false, nil
+				})
+			},
+			changePods: func(pods []*corev1.Pod) {
+				pods[0].Annotations = map[string]string{TiCDCGracefulShutdownBeginTime: time.Now().Add(-11 * time.Minute).Format(time.RFC3339)}
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiCDC.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+			},
+		},
+		{
+			name: "ticdc does not support draining capture",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiCDC.Image = "ticdc-test-image:v6.1.0"
+			},
+			changeCDC: func(cdcCtl *controller.FakeTiCDCControl) {
+				cdcCtl.MockDrainCapture(func(tc *v1alpha1.TidbCluster, ordinal int32) (int, bool, error) {
+					return 3, false, nil
+				})
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiCDC.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+			},
+		},
 		{
 			name: "modify oldSet update strategy to OnDelete",
 			changeOldSet: func(set *apps.StatefulSet) {
@@ -0,0 +1,120 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+const (
+	// TiCDCGracefulShutdownBeginTime is the key of the begin time of the graceful shutdown of a TiCDC capture
+	TiCDCGracefulShutdownBeginTime = "ticdcGracefulShutdownBeginTime"
+)
+
+var (
+	// the first version that ticdc supports the drain capture api
+	ticdcEqualOrGreaterThanV630, _ = cmpver.NewConstraint(cmpver.GreaterOrEqual, "v6.3.0")
+)
+
+// gracefulShutdownTiCDC resigns the ownership of the capture and moves its tables to the other captures
+// before the pod is restarted or deleted. It returns nil if the pod can be shutdown now, or a RequeueError
+// if the capture is still being drained, the pod is shutdown anyway after the graceful shutdown timeout.
+func gracefulShutdownTiCDC(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, pod *corev1.Pod, ordinal int32, action string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	podName := pod.GetName()
+
+	if supported, err := ticdcEqualOrGreaterThanV630.Check(tc.TiCDCVersion()); err != nil || !supported {
+		klog.Infof("tidbcluster: [%s/%s]'s ticdc version %s does not support draining capture, %s pod %s directly",
+			ns, tcName, tc.TiCDCVersion(), action, podName)
+		return nil
+	}
+	if !podutil.IsPodReady(pod) {
+		// the capture can not be drained if it is not serving
+		klog.Infof("tidbcluster: [%s/%s]'s ticdc pod %s is not ready, %s it directly", ns, tcName, podName, action)
+		return nil
+	}
+
+	beginTimeStr, shuttingDown := pod.Annotations[TiCDCGracefulShutdownBeginTime]
+	if !shuttingDown {
+		if err := beginGracefulShutdownTiCDC(deps, tc, pod); err != nil {
+			return err
+		}
+	} else {
+		beginTime, err := time.Parse(time.RFC3339, beginTimeStr)
+		if err != nil {
+			klog.Errorf("parse annotation:[%s] of pod %s/%s to time failed.", TiCDCGracefulShutdownBeginTime, ns, podName)
+		} else if timeout := tc.TiCDCGracefulShutdownTimeout(); time.Now().After(beginTime.Add(timeout)) {
+			klog.Infof("tidbcluster: [%s/%s]'s ticdc pod %s graceful shutdown timeout (threshold: %v), %s it directly",
+				ns, tcName, podName, timeout, action)
+			return nil
+		}
+	}
+
+	healthy, err := deps.CDCControl.IsHealthy(tc, ordinal)
+	if err != nil {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc pod %s get health failed, err: %v", ns, tcName, podName, err)
+	}
+	if !healthy {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc is not healthy, wait to drain pod %s", ns, tcName, podName)
+	}
+
+	resigned, err := deps.CDCControl.ResignOwner(tc, ordinal)
+	if err != nil {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc pod %s resign owner failed, err: %v", ns, tcName, podName, err)
+	}
+	if !resigned {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc pod %s is resigning owner", ns, tcName, podName)
+	}
+
+	tableCount, retry, err := deps.CDCControl.DrainCapture(tc, ordinal)
+	if err != nil {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc pod %s drain capture failed, err: %v", ns, tcName, podName, err)
+	}
+	if retry {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc is not ready to drain pod %s", ns, tcName, podName)
+	}
+	if tableCount > 0 {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s ticdc pod %s is draining, %d tables left", ns, tcName, podName, tableCount)
+	}
+
+	klog.Infof("tidbcluster: [%s/%s]'s ticdc pod %s has been drained, %s it", ns, tcName, podName, action)
+	return nil
+}
+
+func beginGracefulShutdownTiCDC(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, pod *corev1.Pod) error {
+	ns := tc.GetNamespace()
+	podName := pod.GetName()
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	now := time.Now().Format(time.RFC3339)
+	pod.Annotations[TiCDCGracefulShutdownBeginTime] = now
+	if _, err := deps.PodControl.UpdatePod(tc, pod); err != nil {
+		klog.Errorf("ticdc graceful shutdown: failed to set pod %s/%s annotation %s to %s, %v",
+			ns, podName, TiCDCGracefulShutdownBeginTime, now, err)
+		return err
+	}
+	klog.Infof("ticdc graceful shutdown: set pod %s/%s annotation %s to %s successfully",
+		ns, podName, TiCDCGracefulShutdownBeginTime, now)
+	return nil
+}
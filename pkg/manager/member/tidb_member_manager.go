@@ -59,6 +59,9 @@ const (
 	// When user use self-signed certificates, the root CA must be provided. We
 	// following the same convention used in Kubernetes service token.
 	tlsSecretRootCAKey = corev1.ServiceAccountRootCAKey
+	// defaultTiDBGracefulWaitBeforeShutdown is how long tidb waits before shutdown when tiproxy is deployed,
+	// it must be shorter than the termination grace period of the tidb pod
+	defaultTiDBGracefulWaitBeforeShutdown = 15
 )
 
 type tidbMemberManager struct {
@@ -89,6 +92,11 @@ func (m *tidbMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	if tc.Spec.RecoveryMode {
+		klog.V(4).Infof("TidbCluster: [%s/%s] is in recovery mode, skip syncing for tidb until the data is restored", ns, tcName)
+		return nil
+	}
+
 	if tc.Spec.TiKV != nil && !tc.TiKVIsAvailable() {
 		return controller.RequeueErrorf("TidbCluster: [%s/%s], waiting for TiKV cluster running", ns, tcName)
 	}
@@ -199,6 +207,11 @@ func (m *tidbMemberManager) syncTiDBStatefulSetForTidbCluster(tc *v1alpha1.TidbC
 		return err
 	}
 
+	// the serving condition reflects the readiness of the TiDB pods, so it is synced even if the cluster is paused
+	if err := m.syncTiDBServingCondition(tc, oldTiDBSet); err != nil {
+		return err
+	}
+
 	if tc.Spec.Paused {
 		klog.V(4).Infof("tidb cluster %s/%s is paused, skip syncing for tidb statefulset", tc.GetNamespace(), tc.GetName())
 		return nil
@@ -507,6 +520,18 @@ func getTiDBConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
 		config.Set("security.ssl-cert", path.Join(serverCertPath, corev1.TLSCertKey))
 		config.Set("security.ssl-key", path.Join(serverCertPath, corev1.TLSPrivateKeyKey))
 	}
+	if tc.Spec.TiProxy != nil {
+		// tiproxy migrates the sessions to other tidb servers only when tidb waits a while before closing
+		// the connections, and the sessions can be migrated only when all tidb servers sign the session
+		// tokens with the same certificate
+		if config.Get("graceful-wait-before-shutdown") == nil {
+			config.Set("graceful-wait-before-shutdown", defaultTiDBGracefulWaitBeforeShutdown)
+		}
+		if tc.IsTLSClusterEnabled() && config.Get("security.session-token-signing-cert") == nil {
+			config.Set("security.session-token-signing-cert", path.Join(clusterCertPath, corev1.TLSCertKey))
+			config.Set("security.session-token-signing-key", path.Join(clusterCertPath, corev1.TLSPrivateKeyKey))
+		}
+	}
 	confText, err := config.MarshalTOML()
 	if err != nil {
 		return nil, err
@@ -896,6 +921,9 @@ func getNewTiDBSetForTidbCluster(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap)
 	if podSpec.ServiceAccountName == "" {
 		podSpec.ServiceAccountName = tc.Spec.ServiceAccount
 	}
+	if tc.TiDBGracefulShutdownEnabled() {
+		podSpec.ReadinessGates = append(podSpec.ReadinessGates, corev1.PodReadinessGate{ConditionType: TiDBServingCondition})
+	}
 
 	stsLabels := label.New().Instance(instanceName).TiDB()
 	podLabels := util.CombineStringMap(stsLabels, baseTiDBSpec.Labels())
@@ -952,6 +980,8 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 	}
 
 	tc.Status.TiDB.StatefulSet = &set.Status
+	syncUpgradeStepStatus(&tc.Status.TiDB.UpgradeStep, tc.Status.TiDB.StatefulSet)
+	syncUpgradeRollbackCondition(&tc.Status.TiDB, set)
 
 	upgrading, err := m.tidbStatefulSetIsUpgradingFn(m.deps.PodLister, set, tc)
 	if err != nil {
@@ -961,7 +991,8 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 	if tc.TiDBStsDesiredReplicas() != *set.Spec.Replicas {
 		tc.Status.TiDB.Phase = v1alpha1.ScalePhase
 	} else if upgrading && tc.Status.TiKV.Phase != v1alpha1.UpgradePhase &&
-		tc.Status.PD.Phase != v1alpha1.UpgradePhase && tc.Status.Pump.Phase != v1alpha1.UpgradePhase {
+		tc.Status.PD.Phase != v1alpha1.UpgradePhase && tc.Status.Pump.Phase != v1alpha1.UpgradePhase &&
+		tc.Status.TiProxy.Phase != v1alpha1.UpgradePhase {
 		tc.Status.TiDB.Phase = v1alpha1.UpgradePhase
 	} else {
 		tc.Status.TiDB.Phase = v1alpha1.NormalPhase
@@ -1008,6 +1039,50 @@ func (m *tidbMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, set
 	return nil
 }
 
+// syncTiDBServingCondition sets the serving condition of the pods with the serving readiness gate,
+// the pods being drained are kept out of service until they are restarted or deleted.
+func (m *tidbMemberManager) syncTiDBServingCondition(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) error {
+	if set == nil {
+		return nil
+	}
+	ns := tc.GetNamespace()
+	desiredOrdinals := tc.TiDBStsDesiredOrdinals(false)
+	for ordinal := range helper.GetPodOrdinals(*set.Spec.Replicas, set) {
+		podName := tidbPodName(tc.GetName(), ordinal)
+		pod, err := m.deps.PodLister.Pods(ns).Get(podName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("syncTiDBServingCondition: failed to get pod %s for cluster %s/%s, error: %s", podName, ns, tc.GetName(), err)
+		}
+		if !hasTiDBServingGate(pod) {
+			continue
+		}
+
+		if _, draining := pod.Annotations[TiDBGracefulShutdownBeginTime]; draining {
+			// the pod is neither to be restarted nor deleted any more, e.g. the upgrade or scale-in is reverted
+			if desiredOrdinals.Has(ordinal) && tc.Status.TiDB.StatefulSet != nil &&
+				pod.Labels[apps.ControllerRevisionHashLabelKey] == tc.Status.TiDB.StatefulSet.UpdateRevision {
+				pod = pod.DeepCopy()
+				delete(pod.Annotations, TiDBGracefulShutdownBeginTime)
+				if pod, err = m.deps.PodControl.UpdatePod(tc, pod); err != nil {
+					return err
+				}
+			} else {
+				if err := setTiDBServingCondition(m.deps, tc, pod, corev1.ConditionFalse); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if err := setTiDBServingCondition(m.deps, tc, pod, corev1.ConditionTrue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func tidbStatefulSetIsUpgrading(podLister corelisters.PodLister, set *apps.StatefulSet, tc *v1alpha1.TidbCluster) (bool, error) {
 	if mngerutils.StatefulSetIsUpgrading(set) {
 		return true, nil
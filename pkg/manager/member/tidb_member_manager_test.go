@@ -38,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"k8s.io/utils/pointer"
 )
 
@@ -50,6 +51,7 @@ func TestTiDBMemberManagerSyncCreate(t *testing.T) {
 		err                      bool
 		setCreated               bool
 		tls                      bool
+		recoveryMode             bool
 	}
 
 	testFn := func(test *testcase, t *testing.T) {
@@ -59,6 +61,7 @@ func TestTiDBMemberManagerSyncCreate(t *testing.T) {
 		if test.tls {
 			tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
 		}
+		tc.Spec.RecoveryMode = test.recoveryMode
 		tc.Status.TiKV.Stores = map[string]v1alpha1.TiKVStore{
 			"tikv-0": {PodName: "tikv-0", State: v1alpha1.TiKVStateUp},
 		}
@@ -111,6 +114,14 @@ func TestTiDBMemberManagerSyncCreate(t *testing.T) {
 			setCreated:               true,
 			tls:                      true,
 		},
+		{
+			name:                     "recovery mode",
+			prepare:                  nil,
+			errWhenCreateStatefulSet: false,
+			err:                      false,
+			setCreated:               false,
+			recoveryMode:             true,
+		},
 		{
 			name: "tikv is not available",
 			prepare: func(tc *v1alpha1.TidbCluster) {
@@ -2065,6 +2076,65 @@ func TestGetTiDBConfigMap(t *testing.T) {
   cluster-ssl-key = "/var/lib/tidb-tls/tls.key"
   ssl-cert = "/var/lib/tidb-server-tls/tls.crt"
   ssl-key = "/var/lib/tidb-server-tls/tls.key"
+`,
+				},
+			},
+		},
+		{
+			name: "TiDB config with tiproxy deployed",
+			tc: v1alpha1.TidbCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "ns",
+				},
+				Spec: v1alpha1.TidbClusterSpec{
+					TLSCluster: &v1alpha1.TLSCluster{Enabled: true},
+					TiDB: &v1alpha1.TiDBSpec{
+						ComponentSpec: v1alpha1.ComponentSpec{
+							ConfigUpdateStrategy: &updateStrategy,
+						},
+						Config: v1alpha1.NewTiDBConfig(),
+					},
+					PD:      &v1alpha1.PDSpec{},
+					TiKV:    &v1alpha1.TiKVSpec{},
+					TiProxy: &v1alpha1.TiProxySpec{},
+				},
+			},
+			expected: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-tidb",
+					Namespace: "ns",
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       "tidb-cluster",
+						"app.kubernetes.io/managed-by": "tidb-operator",
+						"app.kubernetes.io/instance":   "foo",
+						"app.kubernetes.io/component":  "tidb",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "pingcap.com/v1alpha1",
+							Kind:       "TidbCluster",
+							Name:       "foo",
+							UID:        "",
+							Controller: func(b bool) *bool {
+								return &b
+							}(true),
+							BlockOwnerDeletion: func(b bool) *bool {
+								return &b
+							}(true),
+						},
+					},
+				},
+				Data: map[string]string{
+					"startup-script": "",
+					"config-file": `graceful-wait-before-shutdown = 15
+
+[security]
+  cluster-ssl-ca = "/var/lib/tidb-tls/ca.crt"
+  cluster-ssl-cert = "/var/lib/tidb-tls/tls.crt"
+  cluster-ssl-key = "/var/lib/tidb-tls/tls.key"
+  session-token-signing-cert = "/var/lib/tidb-tls/tls.crt"
+  session-token-signing-key = "/var/lib/tidb-tls/tls.key"
 `,
 				},
 			},
@@ -2467,6 +2537,94 @@ func TestBuildTiDBProbeHandler(t *testing.T) {
 	g.Expect(get).Should(Equal(defaultHandler))
 }
 
+func TestTiDBMemberManagerSyncTiDBServingCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name         string
+		ordinal      int32
+		revision     string
+		draining     bool
+		expectStatus corev1.ConditionStatus
+		expectDrain  bool
+	}
+
+	testFn := func(test *testcase) {
+		t.Log(test.name)
+		tmm, _, _, indexers := newFakeTiDBMemberManager()
+		tc := newTidbClusterForTiDB()
+		tc.Spec.TiDB.Replicas = 2
+		tc.Spec.TiDB.GracefulShutdown = &v1alpha1.TiDBGracefulShutdown{}
+		tc.Status.TiDB.StatefulSet = &apps.StatefulSetStatus{CurrentRevision: "1", UpdateRevision: "2"}
+
+		set, err := getNewTiDBSetForTidbCluster(tc, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(set.Spec.Template.Spec.ReadinessGates).To(ContainElement(corev1.PodReadinessGate{ConditionType: TiDBServingCondition}))
+		set.Spec.Replicas = pointer.Int32Ptr(3)
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tidbPodName(tc.GetName(), test.ordinal),
+				Namespace: tc.GetNamespace(),
+				Labels:    map[string]string{apps.ControllerRevisionHashLabelKey: test.revision},
+			},
+			Spec: corev1.PodSpec{
+				ReadinessGates: []corev1.PodReadinessGate{{ConditionType: TiDBServingCondition}},
+			},
+		}
+		if test.draining {
+			pod.Annotations = map[string]string{TiDBGracefulShutdownBeginTime: time.Now().Format(time.RFC3339)}
+		}
+		indexers.pod.Add(pod)
+
+		g.Expect(tmm.syncTiDBServingCondition(tc, set)).To(Succeed())
+
+		pod, err = tmm.deps.PodLister.Pods(tc.GetNamespace()).Get(pod.GetName())
+		g.Expect(err).NotTo(HaveOccurred())
+		_, cond := podutil.GetPodCondition(&pod.Status, TiDBServingCondition)
+		g.Expect(cond).NotTo(BeNil())
+		g.Expect(cond.Status).To(Equal(test.expectStatus))
+		_, draining := pod.Annotations[TiDBGracefulShutdownBeginTime]
+		g.Expect(draining).To(Equal(test.expectDrain))
+	}
+
+	tests := []*testcase{
+		{
+			name:         "new pod is serving",
+			ordinal:      0,
+			revision:     "2",
+			expectStatus: corev1.ConditionTrue,
+		},
+		{
+			name:         "pod is draining to upgrade",
+			ordinal:      0,
+			revision:     "1",
+			draining:     true,
+			expectStatus: corev1.ConditionFalse,
+			expectDrain:  true,
+		},
+		{
+			name:         "pod is draining to scale in",
+			ordinal:      2,
+			revision:     "2",
+			draining:     true,
+			expectStatus: corev1.ConditionFalse,
+			expectDrain:  true,
+		},
+		{
+			name:         "upgrade is reverted while pod is draining",
+			ordinal:      1,
+			revision:     "2",
+			draining:     true,
+			expectStatus: corev1.ConditionTrue,
+		},
+	}
+
+	for _, test := range tests {
+		testFn(test)
+	}
+}
+
 func newTidbClusterForTiDB() *v1alpha1.TidbCluster {
 	return &v1alpha1.TidbCluster{
 		TypeMeta: metav1.TypeMeta{
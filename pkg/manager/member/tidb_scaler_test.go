@@ -152,6 +152,7 @@ func TestTiDBScalerScaleIn(t *testing.T) {
 		isPodReady    bool
 		hasSynced     bool
 		pvcUpdateErr  bool
+		draining      bool
 		errExpectFn   func(*GomegaWithT, error)
 		changed       bool
 	}
@@ -164,6 +165,9 @@ func TestTiDBScalerScaleIn(t *testing.T) {
 		if test.tidbUpgrading {
 			tc.Status.TiDB.Phase = v1alpha1.UpgradePhase
 		}
+		if test.draining {
+			tc.Spec.TiDB.GracefulShutdown = &v1alpha1.TiDBGracefulShutdown{}
+		}
 
 		oldSet := newStatefulSetForPDScale()
 		newSet := oldSet.DeepCopy()
@@ -182,6 +186,9 @@ func TestTiDBScalerScaleIn(t *testing.T) {
 		if !test.isPodReady {
 			notReadyPodFunc(pod)
 		}
+		if test.draining {
+			pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: TiDBServingCondition}}
+		}
 
 		if !test.hasSynced {
 			pod.CreationTimestamp = metav1.Time{Time: time.Now().Add(1 * time.Hour)}
@@ -302,6 +309,17 @@ func TestTiDBScalerScaleIn(t *testing.T) {
 			errExpectFn:   errExpectNotNil,
 			changed:       false,
 		},
+		{
+			name:          "tidb pod begins to drain connections",
+			tidbUpgrading: false,
+			hasPVC:        true,
+			isPodReady:    true,
+			hasSynced:     true,
+			pvcUpdateErr:  false,
+			draining:      true,
+			errExpectFn:   errExpectRequeue,
+			changed:       false,
+		},
 	}
 
 	for _, tt := range tests {
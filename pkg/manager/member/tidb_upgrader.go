@@ -22,6 +22,8 @@ import (
 
 	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
 	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 )
@@ -47,17 +49,25 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	if paused, err := syncUpgradeRollback(tc, v1alpha1.TiDBMemberType, oldSet, newSet); err != nil || paused {
+		return err
+	}
+	if blocked, err := blockUpgradeByPreflight(tc, v1alpha1.TiDBMemberType, oldSet, newSet); err != nil || blocked {
+		return err
+	}
+
 	if tc.Status.PD.Phase == v1alpha1.UpgradePhase ||
 		tc.Status.TiKV.Phase == v1alpha1.UpgradePhase ||
 		tc.Status.TiFlash.Phase == v1alpha1.UpgradePhase ||
 		tc.Status.Pump.Phase == v1alpha1.UpgradePhase ||
+		tc.Status.TiProxy.Phase == v1alpha1.UpgradePhase ||
 		tc.TiDBScaling() {
 		klog.Infof("TidbCluster: [%s/%s]'s pd status is %s, "+
 			"tikv status is %s, tiflash status is %s, pump status is %s, "+
-			"tidb status is %s, can not upgrade tidb",
+			"tiproxy status is %s, tidb status is %s, can not upgrade tidb",
 			ns, tcName,
 			tc.Status.PD.Phase, tc.Status.TiKV.Phase, tc.Status.TiFlash.Phase,
-			tc.Status.Pump.Phase, tc.Status.TiDB.Phase)
+			tc.Status.Pump.Phase, tc.Status.TiProxy.Phase, tc.Status.TiDB.Phase)
 		_, podSpec, err := GetLastAppliedConfig(oldSet)
 		if err != nil {
 			return err
@@ -101,20 +111,32 @@ func (u *tidbUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.StatefulSe
 
 		if revision == tc.Status.TiDB.StatefulSet.UpdateRevision {
 			if !podutil.IsPodReady(pod) {
+				if upgradedPodUnhealthyTooLong(tc.Spec.TiDB.UpgradeStrategy, pod, metav1.Time{}) {
+					return rollbackUpgrade(u.deps, tc, v1alpha1.TiDBMemberType, newSet, podName)
+				}
 				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded tidb pod: [%s] is not ready", ns, tcName, podName)
 			}
 			if member, exist := tc.Status.TiDB.Members[podName]; !exist || !member.Health {
+				if upgradedPodUnhealthyTooLong(tc.Spec.TiDB.UpgradeStrategy, pod, member.LastTransitionTime) {
+					return rollbackUpgrade(u.deps, tc, v1alpha1.TiDBMemberType, newSet, podName)
+				}
 				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb upgraded pod: [%s] is not ready", ns, tcName, podName)
 			}
 			continue
 		}
-		return u.upgradeTiDBPod(tc, i, newSet)
+		if err := checkUpgradeStep(u.deps, tc, v1alpha1.TiDBMemberType, int32(len(podOrdinals)), int32(len(podOrdinals)-1-_i)); err != nil {
+			return err
+		}
+		return u.upgradeTiDBPod(tc, pod, i, newSet)
 	}
 
 	return nil
 }
 
-func (u *tidbUpgrader) upgradeTiDBPod(tc *v1alpha1.TidbCluster, ordinal int32, newSet *apps.StatefulSet) error {
+func (u *tidbUpgrader) upgradeTiDBPod(tc *v1alpha1.TidbCluster, pod *corev1.Pod, ordinal int32, newSet *apps.StatefulSet) error {
+	if err := gracefulShutdownTiDB(u.deps, tc, pod, ordinal, "upgrade"); err != nil {
+		return err
+	}
 	mngerutils.SetUpgradePartition(newSet, ordinal)
 	return nil
 }
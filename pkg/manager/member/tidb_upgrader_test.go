@@ -14,7 +14,10 @@
 package member
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb-operator/pkg/apis/label"
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
@@ -24,9 +27,13 @@ import (
 	. "github.com/onsi/gomega"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	podinformers "k8s.io/client-go/informers/core/v1"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"k8s.io/utils/pointer"
 )
 
@@ -40,16 +47,19 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 		getLastAppliedConfigErr bool
 		errorExpect             bool
 		changeOldSet            func(set *apps.StatefulSet)
+		connections             int
 		expectFn                func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet)
+		expectPodFn             func(g *GomegaWithT, pod *corev1.Pod)
 	}
 
 	testFn := func(test *testcase, t *testing.T) {
 		t.Log(test.name)
-		upgrader, _, podInformer := newTiDBUpgrader()
+		upgrader, tidbControl, podInformer := newTiDBUpgrader()
 		tc := newTidbClusterForTiDBUpgrader()
 		if test.changeFn != nil {
 			test.changeFn(tc)
 		}
+		tidbControl.SetConnections(map[string]int{tidbPodName(upgradeTcName, 0): test.connections})
 		pods := getTiDBPods()
 		if test.changePods != nil {
 			test.changePods(pods)
@@ -62,6 +72,14 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 		if test.changeOldSet != nil {
 			test.changeOldSet(oldSet)
 		}
+		// the controller revision of the stable pods to roll back to
+		stable := newStatefulSetForTiDBUpgrader().Spec.Template
+		stable.Spec.Containers[0].Image = "tidb-stable-image"
+		revisionData, _ := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"template": stable}})
+		upgrader.(*tidbUpgrader).deps.KubeClientset.AppsV1().ControllerRevisions(corev1.NamespaceDefault).Create(context.TODO(), &apps.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: tc.Status.TiDB.StatefulSet.CurrentRevision, Namespace: corev1.NamespaceDefault},
+			Data:       runtime.RawExtension{Raw: revisionData},
+		}, metav1.CreateOptions{})
 
 		newSet := oldSet.DeepCopy()
 		if test.getLastAppliedConfigErr {
@@ -76,6 +94,27 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred())
 		}
 		test.expectFn(g, tc, newSet)
+		if test.expectPodFn != nil {
+			pod, err := podInformer.Lister().Pods(corev1.NamespaceDefault).Get(tidbPodName(upgradeTcName, 0))
+			g.Expect(err).NotTo(HaveOccurred())
+			test.expectPodFn(g, pod)
+		}
+	}
+
+	enableGracefulShutdown := func(tc *v1alpha1.TidbCluster) {
+		tc.Status.PD.Phase = v1alpha1.NormalPhase
+		tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+		tc.Spec.TiDB.GracefulShutdown = &v1alpha1.TiDBGracefulShutdown{Timeout: pointer.StringPtr("10m")}
+	}
+	drainPod := func(beginTime time.Time) func(pods []*corev1.Pod) {
+		return func(pods []*corev1.Pod) {
+			for _, pod := range pods {
+				pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: TiDBServingCondition}}
+			}
+			if !beginTime.IsZero() {
+				pods[0].Annotations = map[string]string{TiDBGracefulShutdownBeginTime: beginTime.Format(time.RFC3339)}
+			}
+		}
 	}
 
 	tests := []*testcase{
@@ -227,6 +266,132 @@ func TestTiDBUpgrader_Upgrade(t *testing.T) {
 				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
 			},
 		},
+		{
+			name: "upgrade is paused at the end of a step",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+				tc.Spec.TiDB.UpgradeStrategy = &v1alpha1.UpgradeStrategy{Steps: []intstr.IntOrString{intstr.FromInt(1)}}
+			},
+			errorExpect: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+				g.Expect(tc.Status.TiDB.UpgradeStep).NotTo(BeNil())
+				g.Expect(tc.Status.TiDB.UpgradeStep.UpgradedReplicas).To(Equal(int32(1)))
+			},
+		},
+		{
+			name: "upgraded pod keeps unhealthy and the upgrade is rolled back",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+				tc.Spec.TiDB.UpgradeStrategy = &v1alpha1.UpgradeStrategy{
+					AutoRollback: &v1alpha1.AutoRollbackPolicy{UnhealthyTimeout: pointer.StringPtr("10m")},
+				}
+				tc.Status.TiDB.Members["upgrader-tidb-1"] = v1alpha1.TiDBMember{
+					Name:               "upgrader-tidb-1",
+					Health:             false,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				}
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-stable-image"))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+				g.Expect(newSet.Annotations).To(HaveKey(RolledBackConfigAnnotation))
+				g.Expect(meta.IsStatusConditionTrue(tc.Status.TiDB.Conditions, v1alpha1.ComponentUpgradeRolledBack)).To(BeTrue())
+			},
+		},
+		{
+			name: "upgrade is paused after the rollback",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+			},
+			changeOldSet: func(set *apps.StatefulSet) {
+				config, _ := json.Marshal(set.Spec)
+				set.Annotations = map[string]string{RolledBackConfigAnnotation: string(config)}
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiDB.Phase).NotTo(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+				g.Expect(newSet.Annotations).To(HaveKey(RolledBackConfigAnnotation))
+			},
+		},
+		{
+			name:        "tidb pod begins to drain connections",
+			changeFn:    enableGracefulShutdown,
+			changePods:  drainPod(time.Time{}),
+			errorExpect: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+			expectPodFn: func(g *GomegaWithT, pod *corev1.Pod) {
+				g.Expect(pod.Annotations).To(HaveKey(TiDBGracefulShutdownBeginTime))
+				_, cond := podutil.GetPodCondition(&pod.Status, TiDBServingCondition)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+			},
+		},
+		{
+			name:        "tidb pod is draining connections",
+			changeFn:    enableGracefulShutdown,
+			changePods:  drainPod(time.Now()),
+			connections: 5,
+			errorExpect: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name: "tidb pod connections drop to the threshold",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				enableGracefulShutdown(tc)
+				tc.Spec.TiDB.GracefulShutdown.MaxConnections = pointer.Int32Ptr(5)
+			},
+			changePods:  drainPod(time.Now()),
+			connections: 5,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+			},
+		},
+		{
+			name: "sessions on tidb pod are migrated by tiproxy",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				enableGracefulShutdown(tc)
+				tc.Spec.TiProxy = &v1alpha1.TiProxySpec{Replicas: 1}
+				tc.Status.TiProxy.Members = map[string]v1alpha1.TiProxyMember{
+					tiproxyPodName(upgradeTcName, 0): {Name: tiproxyPodName(upgradeTcName, 0), Health: true},
+				}
+			},
+			changePods: drainPod(time.Time{}),
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+			},
+			expectPodFn: func(g *GomegaWithT, pod *corev1.Pod) {
+				g.Expect(pod.Annotations).NotTo(HaveKey(TiDBGracefulShutdownBeginTime))
+			},
+		},
+		{
+			name: "tidb can not upgrade when tiproxy is upgrading",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.NormalPhase
+				tc.Status.TiKV.Phase = v1alpha1.NormalPhase
+				tc.Status.TiProxy.Phase = v1alpha1.UpgradePhase
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiDB.Phase).NotTo(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name:        "tidb pod draining connections timeout",
+			changeFn:    enableGracefulShutdown,
+			changePods:  drainPod(time.Now().Add(-time.Hour)),
+			connections: 5,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+			},
+		},
 	}
 
 	for _, test := range tests {
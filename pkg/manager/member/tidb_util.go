@@ -0,0 +1,139 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+const (
+	// TiDBServingCondition is the readiness gate of the TiDB pods, the pod is removed from the
+	// endpoints of the TiDB Service once it is set to False
+	TiDBServingCondition corev1.PodConditionType = "tidb.pingcap.com/serving"
+	// TiDBGracefulShutdownBeginTime is the key of the begin time of draining the connections of a TiDB pod
+	TiDBGracefulShutdownBeginTime = "tidbGracefulShutdownBeginTime"
+)
+
+// hasTiDBServingGate returns whether the pod is created with the serving readiness gate
+func hasTiDBServingGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == TiDBServingCondition {
+			return true
+		}
+	}
+	return false
+}
+
+// gracefulShutdownTiDB stops routing new connections to the TiDB pod and waits for its active connections
+// to drain before the pod is restarted or deleted. It returns nil if the pod can be shutdown now, or a
+// RequeueError if the connections are still being drained, the pod is shutdown anyway after the timeout.
+func gracefulShutdownTiDB(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, pod *corev1.Pod, ordinal int32, action string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+	podName := pod.GetName()
+
+	if !tc.TiDBGracefulShutdownEnabled() || !hasTiDBServingGate(pod) {
+		return nil
+	}
+	if tc.TiProxyDeployDesiredReplicas() > 0 && tc.TiProxyAllMembersReady() {
+		// tiproxy migrates the sessions away while the tidb server waits before shutdown, the connections
+		// to tiproxy are never closed so there is no need to drain them here
+		klog.Infof("tidbcluster: [%s/%s]'s sessions on tidb pod %s will be migrated by tiproxy, %s it directly", ns, tcName, podName, action)
+		return nil
+	}
+
+	beginTimeStr, draining := pod.Annotations[TiDBGracefulShutdownBeginTime]
+	if !draining {
+		if !podutil.IsPodReady(pod) {
+			// there are no connections to drain if the pod is not serving
+			klog.Infof("tidbcluster: [%s/%s]'s tidb pod %s is not ready, %s it directly", ns, tcName, podName, action)
+			return nil
+		}
+		if err := beginGracefulShutdownTiDB(deps, tc, pod); err != nil {
+			return err
+		}
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod %s begins to drain connections", ns, tcName, podName)
+	}
+
+	beginTime, err := time.Parse(time.RFC3339, beginTimeStr)
+	if err != nil {
+		klog.Errorf("parse annotation:[%s] of pod %s/%s to time failed.", TiDBGracefulShutdownBeginTime, ns, podName)
+	} else if timeout := tc.TiDBGracefulShutdownTimeout(); time.Now().After(beginTime.Add(timeout)) {
+		klog.Infof("tidbcluster: [%s/%s]'s tidb pod %s graceful shutdown timeout (threshold: %v), %s it directly",
+			ns, tcName, podName, timeout, action)
+		return nil
+	}
+
+	if err := setTiDBServingCondition(deps, tc, pod, corev1.ConditionFalse); err != nil {
+		return err
+	}
+
+	connections, err := deps.TiDBControl.GetConnections(tc, ordinal)
+	if err != nil {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod %s get connections failed, err: %v", ns, tcName, podName, err)
+	}
+	if maxConnections := tc.TiDBGracefulShutdownMaxConnections(); connections > int(maxConnections) {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s tidb pod %s is draining, %d connections left (threshold: %d)",
+			ns, tcName, podName, connections, maxConnections)
+	}
+
+	klog.Infof("tidbcluster: [%s/%s]'s tidb pod %s has been drained, %s it", ns, tcName, podName, action)
+	return nil
+}
+
+func beginGracefulShutdownTiDB(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, pod *corev1.Pod) error {
+	ns := tc.GetNamespace()
+	podName := pod.GetName()
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	now := time.Now().Format(time.RFC3339)
+	pod.Annotations[TiDBGracefulShutdownBeginTime] = now
+	updated, err := deps.PodControl.UpdatePod(tc, pod)
+	if err != nil {
+		klog.Errorf("tidb graceful shutdown: failed to set pod %s/%s annotation %s to %s, %v",
+			ns, podName, TiDBGracefulShutdownBeginTime, now, err)
+		return err
+	}
+	klog.Infof("tidb graceful shutdown: set pod %s/%s annotation %s to %s successfully",
+		ns, podName, TiDBGracefulShutdownBeginTime, now)
+	return setTiDBServingCondition(deps, tc, updated, corev1.ConditionFalse)
+}
+
+// setTiDBServingCondition sets the serving condition of the pod if it is changed
+func setTiDBServingCondition(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, pod *corev1.Pod, status corev1.ConditionStatus) error {
+	if _, cond := podutil.GetPodCondition(&pod.Status, TiDBServingCondition); cond != nil && cond.Status == status {
+		return nil
+	}
+	pod = pod.DeepCopy()
+	podutil.UpdatePodCondition(&pod.Status, &corev1.PodCondition{
+		Type:               TiDBServingCondition,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+	})
+	if _, err := deps.PodControl.UpdatePodStatus(tc, pod); err != nil {
+		klog.Errorf("tidb graceful shutdown: failed to set pod %s/%s condition %s to %s, %v",
+			pod.GetNamespace(), pod.GetName(), TiDBServingCondition, status, err)
+		return err
+	}
+	return nil
+}
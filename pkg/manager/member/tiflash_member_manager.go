@@ -650,6 +650,7 @@ func (m *tiflashMemberManager) syncTidbClusterStatus(tc *v1alpha1.TidbCluster, s
 		return nil
 	}
 	tc.Status.TiFlash.StatefulSet = &set.Status
+	syncUpgradeStepStatus(&tc.Status.TiFlash.UpgradeStep, tc.Status.TiFlash.StatefulSet)
 	upgrading, err := m.statefulSetIsUpgradingFn(m.deps.PodLister, m.deps.PDControl, set, tc)
 	if err != nil {
 		return err
@@ -49,7 +49,12 @@ func (u *tiflashUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 	ns := tc.GetNamespace()
 	tcName := tc.GetName()
 
+	if blocked, err := blockUpgradeByPreflight(tc, v1alpha1.TiFlashMemberType, oldSet, newSet); err != nil || blocked {
+		return err
+	}
+
 	if tc.Status.PD.Phase == v1alpha1.UpgradePhase ||
+		tc.PDMSUpgrading() ||
 		tc.TiFlashScaling() {
 		klog.Infof("TidbCluster: [%s/%s]'s pd status is %s, tiflash status is %s, can not upgrade tiflash",
 			ns, tcName,
@@ -126,6 +131,9 @@ func (u *tiflashUpgrader) Upgrade(tc *v1alpha1.TidbCluster, oldSet *apps.Statefu
 			continue
 		}
 
+		if err := checkUpgradeStep(u.deps, tc, v1alpha1.TiFlashMemberType, int32(len(podOrdinals)), int32(len(podOrdinals)-1-_i)); err != nil {
+			return err
+		}
 		mngerutils.SetUpgradePartition(newSet, i)
 		return nil
 	}
@@ -200,6 +200,13 @@ func (m *tikvMemberManager) syncStatefulSetForTidbCluster(tc *v1alpha1.TidbClust
 		return err
 	}
 	if setNotExist {
+		if tc.Spec.RecoveryMode {
+			// the TiKV stores restored from volume snapshots must take the cluster ID of PD
+			// and wait for BR to restore their data, so PD is marked before they start
+			if err := controller.GetPDClient(m.deps.PDControl, tc).MarkSnapshotRecovering(); err != nil {
+				return fmt.Errorf("syncStatefulSetForTidbCluster: failed to mark snapshot recovering for cluster %s/%s, error: %s", ns, tcName, err)
+			}
+		}
 		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSet)
 		if err != nil {
 			return err
@@ -754,6 +761,8 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 		return nil
 	}
 	tc.Status.TiKV.StatefulSet = &set.Status
+	syncUpgradeStepStatus(&tc.Status.TiKV.UpgradeStep, tc.Status.TiKV.StatefulSet)
+	syncUpgradeRollbackCondition(&tc.Status.TiKV, set)
 	upgrading, err := m.statefulSetIsUpgradingFn(m.deps.PodLister, m.deps.PDControl, set, tc)
 	if err != nil {
 		return err
@@ -774,7 +783,7 @@ func (m *tikvMemberManager) syncTiKVClusterStatus(tc *v1alpha1.TidbCluster, set
 	// Scaling takes precedence over upgrading.
 	if tc.TiKVStsDesiredReplicas() != *set.Spec.Replicas {
 		tc.Status.TiKV.Phase = v1alpha1.ScalePhase
-	} else if upgrading && tc.Status.PD.Phase != v1alpha1.UpgradePhase {
+	} else if upgrading && tc.Status.PD.Phase != v1alpha1.UpgradePhase && !tc.PDMSUpgrading() {
 		tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
 	} else {
 		tc.Status.TiKV.Phase = v1alpha1.NormalPhase
@@ -49,8 +49,11 @@ func TestTiKVMemberManagerSyncCreate(t *testing.T) {
 		errWhenGetStores             bool
 		err                          bool
 		tls                          bool
+		recoveryMode                 bool
+		errWhenMarkRecovering        bool
 		tikvPeerSvcCreated           bool
 		setCreated                   bool
+		recoveringMarked             bool
 		pdStores                     *pdapi.StoresInfo
 		tombstoneStores              *pdapi.StoresInfo
 	}
@@ -62,6 +65,7 @@ func TestTiKVMemberManagerSyncCreate(t *testing.T) {
 		if test.tls {
 			tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
 		}
+		tc.Spec.RecoveryMode = test.recoveryMode
 		tc.Status.PD.Members = map[string]v1alpha1.PDMember{
 			"pd-0": {Name: "pd-0", Health: true},
 			"pd-1": {Name: "pd-1", Health: true},
@@ -100,6 +104,15 @@ func TestTiKVMemberManagerSyncCreate(t *testing.T) {
 			})
 		}
 
+		recoveringMarked := false
+		pdClient.AddReaction(pdapi.MarkSnapshotRecoveringActionType, func(action *pdapi.Action) (interface{}, error) {
+			if test.errWhenMarkRecovering {
+				return nil, fmt.Errorf("failed to mark snapshot recovering")
+			}
+			recoveringMarked = true
+			return nil, nil
+		})
+
 		if test.errWhenCreateStatefulSet {
 			fakeSetControl.SetCreateStatefulSetError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
 		}
@@ -131,6 +144,7 @@ func TestTiKVMemberManagerSyncCreate(t *testing.T) {
 		} else {
 			expectErrIsNotFound(g, err)
 		}
+		g.Expect(recoveringMarked).To(Equal(test.recoveringMarked))
 	}
 
 	tests := []testcase{
@@ -157,6 +171,33 @@ func TestTiKVMemberManagerSyncCreate(t *testing.T) {
 			pdStores:                     &pdapi.StoresInfo{Count: 0, Stores: []*pdapi.StoreInfo{}},
 			tombstoneStores:              &pdapi.StoresInfo{Count: 0, Stores: []*pdapi.StoreInfo{}},
 		},
+		{
+			name:                         "recovery mode",
+			prepare:                      nil,
+			errWhenCreateStatefulSet:     false,
+			errWhenCreateTiKVPeerService: false,
+			err:                          false,
+			recoveryMode:                 true,
+			tikvPeerSvcCreated:           true,
+			setCreated:                   true,
+			recoveringMarked:             true,
+			pdStores:                     &pdapi.StoresInfo{Count: 0, Stores: []*pdapi.StoreInfo{}},
+			tombstoneStores:              &pdapi.StoresInfo{Count: 0, Stores: []*pdapi.StoreInfo{}},
+		},
+		{
+			name:                         "error when mark snapshot recovering",
+			prepare:                      nil,
+			errWhenCreateStatefulSet:     false,
+			errWhenCreateTiKVPeerService: false,
+			err:                          true,
+			recoveryMode:                 true,
+			errWhenMarkRecovering:        true,
+			tikvPeerSvcCreated:           true,
+			setCreated:                   false,
+			recoveringMarked:             false,
+			pdStores:                     &pdapi.StoresInfo{Count: 0, Stores: []*pdapi.StoreInfo{}},
+			tombstoneStores:              &pdapi.StoresInfo{Count: 0, Stores: []*pdapi.StoreInfo{}},
+		},
 		{
 			name: "pd is not available",
 			prepare: func(tc *v1alpha1.TidbCluster) {
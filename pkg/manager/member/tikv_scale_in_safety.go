@@ -0,0 +1,315 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// defaultLowSpaceRatio is the default low-space-ratio of PD, a store whose usage
+	// exceeds it is regarded as low space and no more regions are scheduled to it
+	defaultLowSpaceRatio = 0.8
+	// placementRuleGroupPD is the group of the placement rules of the TiKV replicas
+	placementRuleGroupPD = "pd"
+	// placementRoleLearner is the role of the replicas which don't count for the raft majority
+	placementRoleLearner = "learner"
+)
+
+// preCheckScaleInSafety analyzes whether the remaining Up TiKV stores can still hold the regions of the
+// store in the pod to be scaled in. It checks the capacity of the remaining stores against the used size,
+// the number of the stores matching each placement rule (or max-replicas if placement rules are disabled)
+// and the number of the isolated locations. The scale-in is blocked with the ScaleInBlocked condition
+// unless it is forced by the annotation.
+func (s *tikvScaler) preCheckScaleInSafety(tc *v1alpha1.TidbCluster, podName string) (bool, error) {
+	if !tc.TiKVBootStrapped() {
+		return true, nil
+	}
+	if tc.Annotations[label.AnnForceScaleInKey] == label.AnnForceScaleInVal {
+		klog.Infof("tikvScaler.ScaleIn: the safety analysis of tc[%s/%s] is skipped because of annotation %s", tc.Namespace, tc.Name, label.AnnForceScaleInKey)
+		resetScaleInBlockedCondition(tc)
+		return true, nil
+	}
+
+	var storeID uint64
+	for _, store := range tc.Status.TiKV.Stores {
+		if store.PodName != podName {
+			continue
+		}
+		// only a store in Up state has regions to be migrated
+		if store.State != v1alpha1.TiKVStateUp {
+			return true, nil
+		}
+		id, err := strconv.ParseUint(store.ID, 10, 64)
+		if err != nil {
+			return false, err
+		}
+		storeID = id
+	}
+	if storeID == 0 {
+		return true, nil
+	}
+
+	pdClient := controller.GetPDClient(s.deps.PDControl, tc)
+	storesInfo, err := pdClient.GetStores()
+	if err != nil {
+		return false, fmt.Errorf("failed to get stores info in TidbCluster %s/%s", tc.GetNamespace(), tc.GetName())
+	}
+	var target *pdapi.StoreInfo
+	var remaining []*pdapi.StoreInfo
+	for _, store := range storesInfo.Stores {
+		if store.Store == nil || store.Store.StateName != v1alpha1.TiKVStateUp ||
+			!util.MatchLabelFromStoreLabels(store.Store.Labels, label.TiKVLabelVal) {
+			continue
+		}
+		if store.Store.Id == storeID {
+			target = store
+		} else {
+			remaining = append(remaining, store)
+		}
+	}
+	if target == nil {
+		return true, nil
+	}
+
+	config, err := pdClient.GetConfig()
+	if err != nil {
+		return false, err
+	}
+	rules, err := replicaPlacementRules(pdClient, config)
+	if err != nil {
+		return false, err
+	}
+
+	reason, msg := checkRemainingCapacity(config, target, remaining)
+	if msg == "" {
+		reason, msg = checkRemainingTopology(rules, target, remaining)
+	}
+	if msg != "" {
+		errMsg := fmt.Sprintf("can't scale in TiKV of TidbCluster [%s/%s], podname %s: %s, set annotation %s to %q to force the scale-in",
+			tc.GetNamespace(), tc.GetName(), podName, msg, label.AnnForceScaleInKey, label.AnnForceScaleInVal)
+		klog.Error(errMsg)
+		if status := componentStatusOf(tc, v1alpha1.TiKVMemberType); status != nil {
+			if !meta.IsStatusConditionTrue(status.GetConditions(), v1alpha1.ComponentScaleInBlocked) {
+				s.deps.Recorder.Event(tc, corev1.EventTypeWarning, "FailedScaleIn", errMsg)
+			}
+			status.SetCondition(metav1.Condition{
+				Type:    v1alpha1.ComponentScaleInBlocked,
+				Status:  metav1.ConditionTrue,
+				Reason:  reason,
+				Message: errMsg,
+			})
+		}
+		return false, nil
+	}
+
+	resetScaleInBlockedCondition(tc)
+	return true, nil
+}
+
+// resetScaleInBlockedCondition resets the ScaleInBlocked condition once the scale-in is no longer blocked
+func resetScaleInBlockedCondition(tc *v1alpha1.TidbCluster) {
+	status := componentStatusOf(tc, v1alpha1.TiKVMemberType)
+	if status == nil || !meta.IsStatusConditionTrue(status.GetConditions(), v1alpha1.ComponentScaleInBlocked) {
+		return
+	}
+	status.SetCondition(metav1.Condition{
+		Type:    v1alpha1.ComponentScaleInBlocked,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ScaleInSafe",
+		Message: "The scale-in is not blocked",
+	})
+}
+
+// replicaPlacementRules returns the placement rules of the TiKV replicas, a default rule is built from
+// the replication config if placement rules are disabled
+func replicaPlacementRules(pdClient pdapi.PDClient, config *pdapi.PDConfigFromAPI) ([]pdapi.PlacementRule, error) {
+	replication := config.Replication
+	if replication == nil {
+		return nil, nil
+	}
+	if replication.EnablePlacementRules != nil && *replication.EnablePlacementRules {
+		rules, err := pdClient.GetPlacementRules(placementRuleGroupPD)
+		if err != nil {
+			return nil, err
+		}
+		var voters []pdapi.PlacementRule
+		for _, rule := range rules {
+			if rule.Role != placementRoleLearner {
+				voters = append(voters, rule)
+			}
+		}
+		return voters, nil
+	}
+
+	if replication.MaxReplicas == nil {
+		return nil, nil
+	}
+	rule := pdapi.PlacementRule{
+		GroupID:        placementRuleGroupPD,
+		ID:             "default",
+		Count:          int(*replication.MaxReplicas),
+		LocationLabels: replication.LocationLabels,
+	}
+	if replication.IsolationLevel != nil {
+		rule.IsolationLevel = *replication.IsolationLevel
+	}
+	return []pdapi.PlacementRule{rule}, nil
+}
+
+// checkRemainingCapacity checks whether the size used by the remaining stores and the store to be
+// scaled in fits in the capacity of the remaining stores under the low space ratio
+func checkRemainingCapacity(config *pdapi.PDConfigFromAPI, target *pdapi.StoreInfo, remaining []*pdapi.StoreInfo) (string, string) {
+	if target.Status == nil {
+		return "", ""
+	}
+	lowSpaceRatio := defaultLowSpaceRatio
+	if config.Schedule != nil && config.Schedule.LowSpaceRatio != nil {
+		lowSpaceRatio = *config.Schedule.LowSpaceRatio
+	}
+
+	used := usedSize(target.Status)
+	var capacity float64
+	for _, store := range remaining {
+		if store.Status == nil {
+			continue
+		}
+		capacity += float64(store.Status.Capacity)
+		used += usedSize(store.Status)
+	}
+	if used > capacity*lowSpaceRatio {
+		return "InsufficientCapacity", fmt.Sprintf("the used size %s exceeds %v of the capacity %s of the remaining stores",
+			byteSize(used), lowSpaceRatio, byteSize(capacity))
+	}
+	return "", ""
+}
+
+// checkRemainingTopology checks whether the remaining stores matching each placement rule are enough for
+// the count of the rule, and whether the isolated locations of them are reduced below the count
+func checkRemainingTopology(rules []pdapi.PlacementRule, target *pdapi.StoreInfo, remaining []*pdapi.StoreInfo) (string, string) {
+	for _, rule := range rules {
+		if !matchPlacementConstraints(target.Store.Labels, rule.LabelConstraints) {
+			continue
+		}
+		var matched []*pdapi.StoreInfo
+		for _, store := range remaining {
+			if matchPlacementConstraints(store.Store.Labels, rule.LabelConstraints) {
+				matched = append(matched, store)
+			}
+		}
+		if len(matched) < rule.Count {
+			return "InsufficientReplicas", fmt.Sprintf("only %d stores would remain for the %d replicas of placement rule %s/%s",
+				len(matched), rule.Count, rule.GroupID, rule.ID)
+		}
+
+		key := isolationLabel(rule)
+		if key == "" {
+			continue
+		}
+		before := locations(append(matched, target), key)
+		after := locations(matched, key)
+		if after < before && after < rule.Count {
+			return "InsufficientLocations", fmt.Sprintf("only %d locations of label %s would remain for the %d replicas of placement rule %s/%s",
+				after, key, rule.Count, rule.GroupID, rule.ID)
+		}
+	}
+	return "", ""
+}
+
+// isolationLabel returns the label key the replicas of the rule are isolated on
+func isolationLabel(rule pdapi.PlacementRule) string {
+	if rule.IsolationLevel != "" {
+		return rule.IsolationLevel
+	}
+	if len(rule.LocationLabels) > 0 {
+		return rule.LocationLabels[0]
+	}
+	return ""
+}
+
+// locations returns the number of the distinct values of the label of the stores
+func locations(stores []*pdapi.StoreInfo, key string) int {
+	values := map[string]struct{}{}
+	for _, store := range stores {
+		if value, ok := storeLabelValue(store.Store.Labels, key); ok {
+			values[value] = struct{}{}
+		}
+	}
+	return len(values)
+}
+
+// matchPlacementConstraints returns whether the store labels satisfy all the label constraints of a placement rule
+func matchPlacementConstraints(labels []*metapb.StoreLabel, constraints []pdapi.PlacementConstraint) bool {
+	for _, constraint := range constraints {
+		value, ok := storeLabelValue(labels, constraint.Key)
+		in := false
+		for _, v := range constraint.Values {
+			if ok && v == value {
+				in = true
+				break
+			}
+		}
+		switch constraint.Op {
+		case "in":
+			if !in {
+				return false
+			}
+		case "notIn":
+			if in {
+				return false
+			}
+		case "exists":
+			if !ok {
+				return false
+			}
+		case "notExists":
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func storeLabelValue(labels []*metapb.StoreLabel, key string) (string, bool) {
+	for _, l := range labels {
+		if l.Key == key {
+			return l.Value, true
+		}
+	}
+	return "", false
+}
+
+func usedSize(status *pdapi.StoreStatus) float64 {
+	if status.Available > status.Capacity {
+		return 0
+	}
+	return float64(status.Capacity - status.Available)
+}
+
+func byteSize(size float64) string {
+	return fmt.Sprintf("%.2fGiB", size/(1<<30))
+}
@@ -47,6 +47,9 @@ func (s *tikvScaler) Scale(meta metav1.Object, oldSet *apps.StatefulSet, newSet
 	} else if scaling < 0 {
 		return s.ScaleIn(meta, oldSet, newSet)
 	}
+	if tc, ok := meta.(*v1alpha1.TidbCluster); ok {
+		resetScaleInBlockedCondition(tc)
+	}
 	// we only sync auto scaler annotations when we are finishing syncing scaling
 	return nil
 }
@@ -107,6 +110,9 @@ func (s *tikvScaler) ScaleIn(meta metav1.Object, oldSet *apps.StatefulSet, newSe
 	if pass, err := s.preCheckUpStores(tc, podName); !pass {
 		return err
 	}
+	if pass, err := s.preCheckScaleInSafety(tc, podName); !pass {
+		return err
+	}
 
 	// call PD API to delete the store of the TiKV Pod to be scaled in
 	for _, store := range tc.Status.TiKV.Stores {
@@ -24,8 +24,10 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
 	"github.com/pingcap/tidb-operator/pkg/controller"
 	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/tikv/pd/pkg/typeutil"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
@@ -543,6 +545,131 @@ func TestTiKVScalerScaleIn(t *testing.T) {
 	}
 }
 
+func TestTiKVScalerPreCheckScaleInSafety(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const gib = 1 << 30
+	newStore := func(id uint64, zone string, capacity, available uint64) *pdapi.StoreInfo {
+		return &pdapi.StoreInfo{
+			Store: &pdapi.MetaStore{
+				StateName: v1alpha1.TiKVStateUp,
+				Store: &metapb.Store{
+					Id:     id,
+					Labels: []*metapb.StoreLabel{{Key: "zone", Value: zone}},
+				},
+			},
+			Status: &pdapi.StoreStatus{
+				Capacity:  typeutil.ByteSize(capacity * gib),
+				Available: typeutil.ByteSize(available * gib),
+			},
+		}
+	}
+	enabled := true
+	var maxReplicas uint64 = 3
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		stores      []*pdapi.StoreInfo
+		config      *pdapi.PDConfigFromAPI
+		rules       []pdapi.PlacementRule
+		pass        bool
+		reason      string
+	}{
+		{
+			name:   "enough capacity and zones",
+			stores: []*pdapi.StoreInfo{newStore(1, "a", 100, 90), newStore(10, "a", 100, 90), newStore(11, "b", 100, 90), newStore(12, "c", 100, 90)},
+			config: &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: &maxReplicas, LocationLabels: []string{"zone"}}},
+			pass:   true,
+		},
+		{
+			name:   "insufficient capacity",
+			stores: []*pdapi.StoreInfo{newStore(1, "a", 100, 10), newStore(10, "a", 100, 40), newStore(11, "b", 100, 40), newStore(12, "c", 100, 40)},
+			config: &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: &maxReplicas, LocationLabels: []string{"zone"}}},
+			pass:   false,
+			reason: "InsufficientCapacity",
+		},
+		{
+			name:   "the last store of a zone",
+			stores: []*pdapi.StoreInfo{newStore(1, "c", 100, 90), newStore(10, "a", 100, 90), newStore(11, "a", 100, 90), newStore(12, "b", 100, 90)},
+			config: &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: &maxReplicas, LocationLabels: []string{"zone"}}},
+			pass:   false,
+			reason: "InsufficientLocations",
+		},
+		{
+			name:   "insufficient stores for the placement rule",
+			stores: []*pdapi.StoreInfo{newStore(1, "a", 100, 90), newStore(10, "a", 100, 90), newStore(11, "b", 100, 90), newStore(12, "c", 100, 90)},
+			config: &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: &maxReplicas, EnablePlacementRules: &enabled}},
+			rules: []pdapi.PlacementRule{
+				{GroupID: "pd", ID: "default", Role: "voter", Count: 3},
+				{GroupID: "pd", ID: "zone-a", Role: "follower", Count: 2,
+					LabelConstraints: []pdapi.PlacementConstraint{{Key: "zone", Op: "in", Values: []string{"a"}}}},
+			},
+			pass:   false,
+			reason: "InsufficientReplicas",
+		},
+		{
+			name:   "learners are ignored",
+			stores: []*pdapi.StoreInfo{newStore(1, "a", 100, 90), newStore(10, "a", 100, 90), newStore(11, "b", 100, 90), newStore(12, "c", 100, 90)},
+			config: &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: &maxReplicas, EnablePlacementRules: &enabled}},
+			rules: []pdapi.PlacementRule{
+				{GroupID: "pd", ID: "default", Role: "voter", Count: 3},
+				{GroupID: "pd", ID: "learner", Role: "learner", Count: 4},
+			},
+			pass: true,
+		},
+		{
+			name:        "forced by annotation",
+			annotations: map[string]string{label.AnnForceScaleInKey: label.AnnForceScaleInVal},
+			stores:      []*pdapi.StoreInfo{newStore(1, "a", 100, 10), newStore(10, "a", 100, 40), newStore(11, "b", 100, 40), newStore(12, "c", 100, 40)},
+			config:      &pdapi.PDConfigFromAPI{Replication: &pdapi.PDReplicationConfig{MaxReplicas: &maxReplicas, LocationLabels: []string{"zone"}}},
+			pass:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tc := newTidbClusterForPD()
+			normalStoreFun(tc)
+			tc.Status.TiKV.BootStrapped = true
+			tc.Annotations = test.annotations
+			podName := ordinalPodName(v1alpha1.TiKVMemberType, tc.GetName(), 4)
+
+			scaler, pdControl, _, _, _ := newFakeTiKVScaler()
+			pdClient := controller.NewFakePDClient(pdControl, tc)
+			pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+				return &pdapi.StoresInfo{Count: len(test.stores), Stores: test.stores}, nil
+			})
+			pdClient.AddReaction(pdapi.GetConfigActionType, func(action *pdapi.Action) (interface{}, error) {
+				return test.config, nil
+			})
+			pdClient.AddReaction(pdapi.GetPlacementRulesActionType, func(action *pdapi.Action) (interface{}, error) {
+				return test.rules, nil
+			})
+
+			pass, err := scaler.preCheckScaleInSafety(tc, podName)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(pass).To(Equal(test.pass))
+
+			condition := meta.FindStatusCondition(tc.Status.TiKV.Conditions, v1alpha1.ComponentScaleInBlocked)
+			if test.pass {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(condition.Reason).To(Equal(test.reason))
+
+			// the condition is reset once the scale-in is forced
+			tc.Annotations = map[string]string{label.AnnForceScaleInKey: label.AnnForceScaleInVal}
+			pass, err = scaler.preCheckScaleInSafety(tc, podName)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(pass).To(BeTrue())
+			g.Expect(meta.IsStatusConditionFalse(tc.Status.TiKV.Conditions, v1alpha1.ComponentScaleInBlocked)).To(BeTrue())
+		})
+	}
+}
+
 func newFakeTiKVScaler(resyncDuration ...time.Duration) (*tikvScaler, *pdapi.FakePDControl, cache.Indexer, cache.Indexer, *controller.FakePVCControl) {
 	fakeDeps := controller.NewFakeDependencies()
 	if len(resyncDuration) > 0 {
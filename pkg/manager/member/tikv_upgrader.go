@@ -58,6 +58,12 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 	var status *v1alpha1.TiKVStatus
 	switch meta := meta.(type) {
 	case *v1alpha1.TidbCluster:
+		if paused, err := syncUpgradeRollback(meta, v1alpha1.TiKVMemberType, oldSet, newSet); err != nil || paused {
+			return err
+		}
+		if blocked, err := blockUpgradeByPreflight(meta, v1alpha1.TiKVMemberType, oldSet, newSet); err != nil || blocked {
+			return err
+		}
 		if ready, reason := isTiKVReadyToUpgrade(meta); !ready {
 			klog.Infof("TidbCluster: [%s/%s], can not upgrade tikv because: %s", ns, tcName, reason)
 			_, podSpec, err := GetLastAppliedConfig(oldSet)
@@ -128,9 +134,15 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 		if revision == status.StatefulSet.UpdateRevision {
 
 			if !podutil.IsPodReady(pod) {
+				if upgradedPodUnhealthyTooLong(tc.Spec.TiKV.UpgradeStrategy, pod, metav1.Time{}) {
+					return rollbackUpgrade(u.deps, tc, v1alpha1.TiKVMemberType, newSet, podName)
+				}
 				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded tikv pod: [%s] is not ready", ns, tcName, podName)
 			}
 			if store.State != v1alpha1.TiKVStateUp {
+				if upgradedPodUnhealthyTooLong(tc.Spec.TiKV.UpgradeStrategy, pod, store.LastTransitionTime) {
+					return rollbackUpgrade(u.deps, tc, v1alpha1.TiKVMemberType, newSet, podName)
+				}
 				return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded tikv pod: [%s] is not all ready", ns, tcName, podName)
 			}
 
@@ -145,6 +157,9 @@ func (u *tikvUpgrader) Upgrade(meta metav1.Object, oldSet *apps.StatefulSet, new
 			continue
 		}
 
+		if err := checkUpgradeStep(u.deps, tc, v1alpha1.TiKVMemberType, int32(len(podOrdinals)), int32(len(podOrdinals)-1-_i)); err != nil {
+			return err
+		}
 		return u.upgradeTiKVPod(tc, i, newSet)
 	}
 
@@ -327,6 +342,9 @@ func isTiKVReadyToUpgrade(tc *v1alpha1.TidbCluster) (bool, string) {
 	if tc.Status.PD.Phase == v1alpha1.UpgradePhase {
 		return false, fmt.Sprintf("pd status is %s", tc.Status.PD.Phase)
 	}
+	if tc.PDMSUpgrading() {
+		return false, "pd microservices are upgrading"
+	}
 	if tc.TiKVScaling() {
 		return false, fmt.Sprintf("tikv status is %s", tc.Status.TiKV.Phase)
 	}
@@ -0,0 +1,623 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	"github.com/pingcap/advanced-statefulset/client/apis/apps/v1/helper"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// tiproxyClusterCertPath is where the cert for inter-cluster communication stored (if any)
+	tiproxyClusterCertPath = "/var/lib/tiproxy-tls"
+	// tiproxyServerCertPath is where the cert for the SQL clients stored (if any)
+	tiproxyServerCertPath = "/var/lib/tiproxy-server-tls"
+	// tiproxySQLCertPath is where the cert for connecting to the tidb servers stored (if any)
+	tiproxySQLCertPath = "/var/lib/tiproxy-sql-tls"
+
+	tiproxyPort    = 6000
+	tiproxyAPIPort = 3080
+)
+
+// tiproxyMemberManager implements manager.Manager.
+type tiproxyMemberManager struct {
+	deps                     *controller.Dependencies
+	scaler                   Scaler
+	tiproxyUpgrader          Upgrader
+	statefulSetIsUpgradingFn func(corelisters.PodLister, *apps.StatefulSet, *v1alpha1.TidbCluster) (bool, error)
+}
+
+// NewTiProxyMemberManager returns a *tiproxyMemberManager
+func NewTiProxyMemberManager(deps *controller.Dependencies, scaler Scaler, tiproxyUpgrader Upgrader) manager.Manager {
+	m := &tiproxyMemberManager{
+		deps:            deps,
+		scaler:          scaler,
+		tiproxyUpgrader: tiproxyUpgrader,
+	}
+	m.statefulSetIsUpgradingFn = tiproxyStatefulSetIsUpgrading
+	return m
+}
+
+// Sync fulfills the manager.Manager interface
+func (m *tiproxyMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if tc.Spec.TiProxy == nil {
+		return nil
+	}
+
+	if tc.Spec.Paused {
+		klog.Infof("TidbCluster %s/%s is paused, skip syncing tiproxy service", tc.GetNamespace(), tc.GetName())
+	} else {
+		// Sync TiProxy Headless Service
+		if err := m.syncService(tc, getNewTiProxyHeadlessService(tc)); err != nil {
+			return err
+		}
+		// Sync TiProxy Service for the SQL clients
+		if err := m.syncService(tc, getNewTiProxyService(tc)); err != nil {
+			return err
+		}
+	}
+
+	return m.syncStatefulSet(tc)
+}
+
+func (m *tiproxyMemberManager) syncStatefulSet(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	oldStsTmp, err := m.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiProxyMemberName(tcName))
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("syncStatefulSet: failed to get sts %s for cluster %s/%s, error: %s", controller.TiProxyMemberName(tcName), ns, tcName, err)
+	}
+
+	stsNotExist := errors.IsNotFound(err)
+	oldSts := oldStsTmp.DeepCopy()
+
+	// failed to sync tiproxy status will not affect subsequent logic, just print the errors.
+	if err := m.syncTiProxyStatus(tc, oldSts); err != nil {
+		klog.Errorf("failed to sync TidbCluster: [%s/%s]'s tiproxy status, error: %v",
+			ns, tcName, err)
+	}
+
+	if tc.Spec.Paused {
+		klog.Infof("TidbCluster %s/%s is paused, skip syncing tiproxy statefulset", tc.GetNamespace(), tc.GetName())
+		return nil
+	}
+
+	cm, err := m.syncTiProxyConfigMap(tc, oldSts)
+	if err != nil {
+		return err
+	}
+
+	newSts, err := getNewTiProxyStatefulSet(tc, cm)
+	if err != nil {
+		return err
+	}
+
+	if stsNotExist {
+		if !tc.PDIsAvailable() {
+			klog.Infof("TidbCluster: %s/%s, waiting for PD cluster running", ns, tcName)
+			return nil
+		}
+		err = mngerutils.SetStatefulSetLastAppliedConfigAnnotation(newSts)
+		if err != nil {
+			return err
+		}
+		return m.deps.StatefulSetControl.CreateStatefulSet(tc, newSts)
+	}
+
+	// Scaling takes precedence over upgrading because:
+	// - if a pod fails in the upgrading, users may want to delete it or add
+	//   new replicas
+	// - it's ok to scale in the middle of upgrading (in statefulset controller
+	//   scaling takes precedence over upgrading too)
+	if err := m.scaler.Scale(tc, oldSts, newSts); err != nil {
+		return err
+	}
+
+	if !templateEqual(newSts, oldSts) || tc.Status.TiProxy.Phase == v1alpha1.UpgradePhase {
+		if err := m.tiproxyUpgrader.Upgrade(tc, oldSts, newSts); err != nil {
+			return err
+		}
+	}
+
+	return mngerutils.UpdateStatefulSetWithPrecheck(m.deps, tc, "FailedUpdateTiProxySTS", newSts, oldSts)
+}
+
+func (m *tiproxyMemberManager) syncTiProxyStatus(tc *v1alpha1.TidbCluster, sts *apps.StatefulSet) error {
+	if sts == nil {
+		// skip if not created yet
+		return nil
+	}
+
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	tc.Status.TiProxy.StatefulSet = &sts.Status
+	syncUpgradeStepStatus(&tc.Status.TiProxy.UpgradeStep, tc.Status.TiProxy.StatefulSet)
+	upgrading, err := m.statefulSetIsUpgradingFn(m.deps.PodLister, sts, tc)
+	if err != nil {
+		tc.Status.TiProxy.Synced = false
+		return err
+	}
+	if upgrading {
+		tc.Status.TiProxy.Phase = v1alpha1.UpgradePhase
+	} else {
+		tc.Status.TiProxy.Phase = v1alpha1.NormalPhase
+	}
+
+	members := map[string]v1alpha1.TiProxyMember{}
+	allMembersHealthy := true
+	for id := range helper.GetPodOrdinals(tc.Status.TiProxy.StatefulSet.Replicas, sts) {
+		podName := tiproxyPodName(tcName, id)
+
+		_, err := m.deps.PodLister.Pods(ns).Get(podName)
+		if err != nil {
+			klog.Warningf("Failed to get Pod %s of [%s/%s], error: %v", podName, ns, tcName, err)
+			continue
+		}
+
+		health, err := m.deps.TiProxyControl.IsHealthy(tc, id)
+		if err != nil {
+			klog.Warningf("Failed to get health for Pod %s of [%s/%s], error: %v", podName, ns, tcName, err)
+		}
+		if !health {
+			allMembersHealthy = false
+		}
+
+		member := v1alpha1.TiProxyMember{
+			Name:               podName,
+			Health:             health,
+			LastTransitionTime: metav1.Now(),
+		}
+		if old, exist := tc.Status.TiProxy.Members[podName]; exist && old.Health == health {
+			member.LastTransitionTime = old.LastTransitionTime
+		}
+		members[podName] = member
+	}
+
+	tc.Status.TiProxy.Synced = len(members) == int(tc.TiProxyDeployDesiredReplicas()) && allMembersHealthy
+	tc.Status.TiProxy.Members = members
+
+	return nil
+}
+
+func (m *tiproxyMemberManager) syncTiProxyConfigMap(tc *v1alpha1.TidbCluster, set *apps.StatefulSet) (*corev1.ConfigMap, error) {
+	newCm, err := getTiProxyConfigMap(tc)
+	if err != nil {
+		return nil, err
+	}
+
+	var inUseName string
+	if set != nil {
+		inUseName = mngerutils.FindConfigMapVolume(&set.Spec.Template.Spec, func(name string) bool {
+			return strings.HasPrefix(name, controller.TiProxyMemberName(tc.Name))
+		})
+	}
+
+	klog.V(3).Info("get tiproxy in use config map name: ", inUseName)
+
+	err = mngerutils.UpdateConfigMapIfNeed(m.deps.ConfigMapLister, tc.BaseTiProxySpec().ConfigUpdateStrategy(), inUseName, newCm)
+	if err != nil {
+		return nil, err
+	}
+	return m.deps.TypedControl.CreateOrUpdateConfigMap(tc, newCm)
+}
+
+func (m *tiproxyMemberManager) syncService(tc *v1alpha1.TidbCluster, newSvc *corev1.Service) error {
+	ns := newSvc.Namespace
+	oldSvcTmp, err := m.deps.ServiceLister.Services(ns).Get(newSvc.Name)
+	if errors.IsNotFound(err) {
+		err = controller.SetServiceLastAppliedConfigAnnotation(newSvc)
+		if err != nil {
+			return err
+		}
+		return m.deps.ServiceControl.CreateService(tc, newSvc)
+	}
+	if err != nil {
+		return fmt.Errorf("syncService: failed to get svc %s for cluster %s/%s, error: %s", newSvc.Name, ns, tc.GetName(), err)
+	}
+
+	oldSvc := oldSvcTmp.DeepCopy()
+	util.RetainManagedFields(newSvc, oldSvc)
+
+	equal, err := controller.ServiceEqual(newSvc, oldSvc)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		svc := *oldSvc
+		svc.Spec = newSvc.Spec
+		err = controller.SetServiceLastAppliedConfigAnnotation(&svc)
+		if err != nil {
+			return err
+		}
+		svc.Spec.ClusterIP = oldSvc.Spec.ClusterIP
+		_, err = m.deps.ServiceControl.UpdateService(tc, &svc)
+		return err
+	}
+
+	return nil
+}
+
+// getTiProxyConfigMap renders the configuration of tiproxy, the addresses and the TLS settings
+// are managed by the operator.
+func getTiProxyConfigMap(tc *v1alpha1.TidbCluster) (*corev1.ConfigMap, error) {
+	cfg := config.New(map[string]interface{}{})
+	if tc.Spec.TiProxy.Config != nil {
+		cfg = tc.Spec.TiProxy.Config.DeepCopy()
+	}
+
+	cfg.Set("proxy.addr", fmt.Sprintf("0.0.0.0:%d", tiproxyPort))
+	cfg.Set("api.addr", fmt.Sprintf("0.0.0.0:%d", tiproxyAPIPort))
+	if cfg.Get("proxy.pd-addrs") == nil {
+		pdAddr := fmt.Sprintf("%s:2379", controller.PDMemberName(tc.Name))
+		if tc.Heterogeneous() && tc.WithoutLocalPD() {
+			pdAddr = fmt.Sprintf("%s:2379", controller.PDMemberName(tc.Spec.Cluster.Name)) // use pd of reference cluster
+		}
+		cfg.Set("proxy.pd-addrs", pdAddr)
+	}
+
+	if tc.IsTLSClusterEnabled() {
+		for _, key := range []string{"security.cluster-tls", "security.server-http-tls"} {
+			cfg.Set(key+".ca", path.Join(tiproxyClusterCertPath, tlsSecretRootCAKey))
+			cfg.Set(key+".cert", path.Join(tiproxyClusterCertPath, corev1.TLSCertKey))
+			cfg.Set(key+".key", path.Join(tiproxyClusterCertPath, corev1.TLSPrivateKeyKey))
+		}
+	}
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() {
+		// the SQL clients connect to tiproxy with the same TLS settings as connecting to tidb
+		if !tc.Spec.TiDB.TLSClient.DisableClientAuthn {
+			cfg.Set("security.server-tls.ca", path.Join(tiproxyServerCertPath, tlsSecretRootCAKey))
+			cfg.Set("security.sql-tls.cert", path.Join(tiproxySQLCertPath, corev1.TLSCertKey))
+			cfg.Set("security.sql-tls.key", path.Join(tiproxySQLCertPath, corev1.TLSPrivateKeyKey))
+		}
+		cfg.Set("security.server-tls.cert", path.Join(tiproxyServerCertPath, corev1.TLSCertKey))
+		cfg.Set("security.server-tls.key", path.Join(tiproxyServerCertPath, corev1.TLSPrivateKeyKey))
+		cfg.Set("security.sql-tls.ca", path.Join(tiproxySQLCertPath, tlsSecretRootCAKey))
+	}
+
+	confText, err := cfg.MarshalTOML()
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.TiProxyMemberName(tc.Name),
+			Namespace:       tc.Namespace,
+			Labels:          labelTiProxy(tc).Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Data: map[string]string{
+			"config-file": string(confText),
+		},
+	}
+	return cm, nil
+}
+
+func getNewTiProxyHeadlessService(tc *v1alpha1.TidbCluster) *corev1.Service {
+	svcLabel := labelTiProxy(tc)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.TiProxyPeerMemberName(tc.Name),
+			Namespace:       tc.Namespace,
+			Labels:          svcLabel.Copy().UsedByPeer().Labels(),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "None",
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "api",
+					Port:       tiproxyAPIPort,
+					TargetPort: intstr.FromInt(tiproxyAPIPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector:                 svcLabel.Labels(),
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
+func getNewTiProxyService(tc *v1alpha1.TidbCluster) *corev1.Service {
+	svcSpec := tc.Spec.TiProxy.Service
+	if svcSpec == nil {
+		svcSpec = &v1alpha1.ServiceSpec{}
+	}
+	selector := labelTiProxy(tc)
+	portName := "mysql-client"
+	if svcSpec.PortName != nil {
+		portName = *svcSpec.PortName
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            controller.TiProxyMemberName(tc.Name),
+			Namespace:       tc.Namespace,
+			Labels:          util.CombineStringMap(selector.Copy().UsedByEndUser().Labels(), svcSpec.Labels),
+			Annotations:     util.CopyStringMap(svcSpec.Annotations),
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: svcSpec.Type,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       portName,
+					Port:       tc.Spec.TiProxy.GetServicePort(),
+					TargetPort: intstr.FromInt(tiproxyPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: selector.Labels(),
+		},
+	}
+	if svc.Spec.Type == "" {
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+	}
+	if svcSpec.Type == corev1.ServiceTypeLoadBalancer {
+		if svcSpec.LoadBalancerIP != nil {
+			svc.Spec.LoadBalancerIP = *svcSpec.LoadBalancerIP
+		}
+		if svcSpec.LoadBalancerSourceRanges != nil {
+			svc.Spec.LoadBalancerSourceRanges = svcSpec.LoadBalancerSourceRanges
+		}
+	}
+	if svcSpec.ClusterIP != nil {
+		svc.Spec.ClusterIP = *svcSpec.ClusterIP
+	}
+	return svc
+}
+
+func getNewTiProxyStatefulSet(tc *v1alpha1.TidbCluster, cm *corev1.ConfigMap) (*apps.StatefulSet, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	baseTiProxySpec := tc.BaseTiProxySpec()
+	stsLabels := labelTiProxy(tc)
+	stsName := controller.TiProxyMemberName(tcName)
+	podLabels := util.CombineStringMap(stsLabels, baseTiProxySpec.Labels())
+	podAnnotations := util.CombineStringMap(map[string]string{
+		"prometheus.io/scrape": "true",
+		"prometheus.io/path":   "/api/metrics",
+		"prometheus.io/port":   fmt.Sprintf("%d", tiproxyAPIPort),
+	}, baseTiProxySpec.Annotations())
+	stsAnnotations := getStsAnnotations(tc.Annotations, label.TiProxyLabelVal)
+	headlessSvcName := controller.TiProxyPeerMemberName(tcName)
+
+	cmdArgs := []string{
+		"/bin/tiproxy",
+		"--config=/etc/proxy/proxy.toml",
+		fmt.Sprintf("--advertise-addr=${POD_NAME}.${HEADLESS_SERVICE_NAME}.${NAMESPACE}.svc%s", controller.FormatClusterDomain(tc.Spec.ClusterDomain)),
+	}
+
+	volMounts := []corev1.VolumeMount{
+		{Name: "config", ReadOnly: true, MountPath: "/etc/proxy"},
+	}
+	vols := []corev1.Volume{
+		{Name: "config", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: cm.Name,
+				},
+				Items: []corev1.KeyToPath{{Key: "config-file", Path: "proxy.toml"}},
+			}},
+		},
+	}
+	if tc.IsTLSClusterEnabled() {
+		volMounts = append(volMounts, corev1.VolumeMount{
+			Name: "tiproxy-tls", ReadOnly: true, MountPath: tiproxyClusterCertPath,
+		})
+		vols = append(vols, corev1.Volume{
+			Name: "tiproxy-tls", VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.ClusterTLSSecretName(tcName, label.TiProxyLabelVal),
+				},
+			},
+		})
+	}
+	if tc.Spec.TiDB != nil && tc.Spec.TiDB.IsTLSClientEnabled() {
+		volMounts = append(volMounts, corev1.VolumeMount{
+			Name: "tiproxy-server-tls", ReadOnly: true, MountPath: tiproxyServerCertPath,
+		}, corev1.VolumeMount{
+			Name: "tiproxy-sql-tls", ReadOnly: true, MountPath: tiproxySQLCertPath,
+		})
+		vols = append(vols, corev1.Volume{
+			Name: "tiproxy-server-tls", VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.TiProxyServerTLSSecretName(tcName),
+				},
+			},
+		}, corev1.Volume{
+			Name: "tiproxy-sql-tls", VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: util.TiDBClientTLSSecretName(tcName),
+				},
+			},
+		})
+	}
+
+	// handle StorageVolumes and AdditionalVolumeMounts in ComponentSpec
+	storageVolMounts, additionalPVCs := util.BuildStorageVolumeAndVolumeMount(tc.Spec.TiProxy.StorageVolumes, tc.Spec.TiProxy.StorageClassName, v1alpha1.TiProxyMemberType)
+	volMounts = append(volMounts, storageVolMounts...)
+	volMounts = append(volMounts, tc.Spec.TiProxy.AdditionalVolumeMounts...)
+
+	envs := []corev1.EnvVar{
+		{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		},
+		{
+			Name: "NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.namespace",
+				},
+			},
+		},
+		{
+			Name:  "HEADLESS_SERVICE_NAME",
+			Value: headlessSvcName,
+		},
+	}
+
+	tiproxyContainer := corev1.Container{
+		Name:            v1alpha1.TiProxyMemberType.String(),
+		Image:           tc.TiProxyImage(),
+		ImagePullPolicy: baseTiProxySpec.ImagePullPolicy(),
+		Command:         []string{"/bin/sh", "-c", strings.Join(cmdArgs, " ")},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "tiproxy",
+				ContainerPort: int32(tiproxyPort),
+				Protocol:      corev1.ProtocolTCP,
+			},
+			{
+				Name:          "api",
+				ContainerPort: int32(tiproxyAPIPort),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: volMounts,
+		Resources:    controller.ContainerResource(tc.Spec.TiProxy.ResourceRequirements),
+		Env:          util.AppendEnv(envs, baseTiProxySpec.Env()),
+		EnvFrom:      baseTiProxySpec.EnvFrom(),
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt(tiproxyPort),
+				},
+			},
+			InitialDelaySeconds: int32(10),
+		},
+	}
+
+	podSpec := baseTiProxySpec.BuildPodSpec()
+	podSpec.Containers = []corev1.Container{tiproxyContainer}
+	podSpec.Volumes = append(vols, baseTiProxySpec.AdditionalVolumes()...)
+	podSpec.ServiceAccountName = tc.Spec.TiProxy.ServiceAccount
+	podSpec.InitContainers = append(podSpec.InitContainers, baseTiProxySpec.InitContainers()...)
+	if podSpec.ServiceAccountName == "" {
+		podSpec.ServiceAccountName = tc.Spec.ServiceAccount
+	}
+
+	updateStrategy := apps.StatefulSetUpdateStrategy{}
+	if baseTiProxySpec.StatefulSetUpdateStrategy() == apps.OnDeleteStatefulSetStrategyType {
+		updateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+	} else {
+		updateStrategy.Type = apps.RollingUpdateStatefulSetStrategyType
+		updateStrategy.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{
+			Partition: pointer.Int32Ptr(tc.TiProxyDeployDesiredReplicas()),
+		}
+	}
+
+	tiproxySts := &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            stsName,
+			Namespace:       ns,
+			Labels:          stsLabels.Labels(),
+			Annotations:     stsAnnotations,
+			OwnerReferences: []metav1.OwnerReference{controller.GetOwnerRef(tc)},
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(tc.TiProxyDeployDesiredReplicas()),
+			Selector: stsLabels.LabelSelector(),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: podAnnotations,
+				},
+				Spec: podSpec,
+			},
+			ServiceName:         headlessSvcName,
+			PodManagementPolicy: baseTiProxySpec.PodManagementPolicy(),
+			UpdateStrategy:      updateStrategy,
+		},
+	}
+	tiproxySts.Spec.VolumeClaimTemplates = append(tiproxySts.Spec.VolumeClaimTemplates, additionalPVCs...)
+	return tiproxySts, nil
+}
+
+func labelTiProxy(tc *v1alpha1.TidbCluster) label.Label {
+	instanceName := tc.GetInstanceName()
+	return label.New().Instance(instanceName).TiProxy()
+}
+
+func tiproxyStatefulSetIsUpgrading(podLister corelisters.PodLister, set *apps.StatefulSet, tc *v1alpha1.TidbCluster) (bool, error) {
+	if mngerutils.StatefulSetIsUpgrading(set) {
+		return true, nil
+	}
+	selector, err := labelTiProxy(tc).Selector()
+	if err != nil {
+		return false, err
+	}
+	tiproxyPods, err := podLister.Pods(tc.GetNamespace()).List(selector)
+	if err != nil {
+		return false, fmt.Errorf("tiproxyStatefulSetIsUpgrading: failed to list pods for cluster %s/%s, selector %s, error: %s", tc.GetNamespace(), tc.GetName(), selector, err)
+	}
+	for _, pod := range tiproxyPods {
+		revisionHash, exist := pod.Labels[apps.ControllerRevisionHashLabelKey]
+		if !exist {
+			return false, nil
+		}
+		if revisionHash != tc.Status.TiProxy.StatefulSet.UpdateRevision {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+type FakeTiProxyMemberManager struct {
+	err error
+}
+
+func NewFakeTiProxyMemberManager() *FakeTiProxyMemberManager {
+	return &FakeTiProxyMemberManager{}
+}
+
+func (m *FakeTiProxyMemberManager) SetSyncError(err error) {
+	m.err = err
+}
+
+func (m *FakeTiProxyMemberManager) Sync(tc *v1alpha1.TidbCluster) error {
+	if m.err != nil {
+		return m.err
+	}
+	return nil
+}
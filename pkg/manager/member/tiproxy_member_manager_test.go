@@ -0,0 +1,390 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/apis/util/config"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestTiProxyMemberManagerSyncCreate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	type testcase struct {
+		name    string
+		errSync bool
+		err     bool
+		tls     bool
+	}
+
+	testFn := func(test *testcase, t *testing.T) {
+		t.Log(test.name)
+
+		tc := newTidbClusterForTiProxy()
+		if test.tls {
+			tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+		}
+		oldSpec := tc.Spec
+
+		tmm, fakeSetControl := newFakeTiProxyMemberManager()
+		if test.errSync {
+			fakeSetControl.SetCreateStatefulSetError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
+		}
+
+		err := tmm.Sync(tc)
+		if test.err {
+			g.Expect(err).To(HaveOccurred())
+		} else {
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		g.Expect(tc.Spec).To(Equal(oldSpec))
+
+		ns := tc.GetNamespace()
+		_, err = tmm.deps.ServiceLister.Services(ns).Get(controller.TiProxyPeerMemberName(tc.Name))
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = tmm.deps.ServiceLister.Services(ns).Get(controller.TiProxyMemberName(tc.Name))
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = tmm.deps.StatefulSetLister.StatefulSets(ns).Get(controller.TiProxyMemberName(tc.Name))
+		if test.errSync {
+			g.Expect(errors.IsNotFound(err)).To(BeTrue())
+		} else {
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	tests := []testcase{
+		{
+			name: "normal",
+		},
+		{
+			name: "normal with tls",
+			tls:  true,
+		},
+		{
+			name:    "error when sync",
+			errSync: true,
+			err:     true,
+		},
+	}
+
+	for i := range tests {
+		testFn(&tests[i], t)
+	}
+}
+
+func TestTiProxyMemberManagerSyncTiProxyStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name     string
+		healthy  func(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error)
+		expectFn func(g *GomegaWithT, status *v1alpha1.TiProxyStatus)
+	}
+
+	testFn := func(test *testcase, t *testing.T) {
+		t.Log(test.name)
+
+		tc := newTidbClusterForTiProxy()
+		tc.Spec.TiProxy.Replicas = 2
+		tmm, _ := newFakeTiProxyMemberManager()
+		if test.healthy != nil {
+			tmm.deps.TiProxyControl.(*controller.FakeTiProxyControl).MockIsHealthy(test.healthy)
+		}
+		tmm.statefulSetIsUpgradingFn = func(corelisters.PodLister, *apps.StatefulSet, *v1alpha1.TidbCluster) (bool, error) {
+			return false, nil
+		}
+		podIndexer := tmm.deps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+		for i := int32(0); i < 2; i++ {
+			podIndexer.Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tiproxyPodName(tc.Name, i),
+					Namespace: tc.Namespace,
+				},
+			})
+		}
+		sts := &apps.StatefulSet{
+			Spec:   apps.StatefulSetSpec{Replicas: pointer.Int32Ptr(2)},
+			Status: apps.StatefulSetStatus{Replicas: 2},
+		}
+
+		err := tmm.syncTiProxyStatus(tc, sts)
+		g.Expect(err).NotTo(HaveOccurred())
+		test.expectFn(g, &tc.Status.TiProxy)
+	}
+
+	tests := []testcase{
+		{
+			name: "all members are healthy",
+			expectFn: func(g *GomegaWithT, status *v1alpha1.TiProxyStatus) {
+				g.Expect(status.Synced).To(BeTrue())
+				g.Expect(status.Phase).To(Equal(v1alpha1.NormalPhase))
+				g.Expect(status.Members).To(HaveLen(2))
+				g.Expect(status.Members["test-tiproxy-0"].Health).To(BeTrue())
+			},
+		},
+		{
+			name: "one member is unreachable",
+			healthy: func(tc *v1alpha1.TidbCluster, ordinal int32) (bool, error) {
+				if ordinal == 1 {
+					return false, fmt.Errorf("connection refused")
+				}
+				return true, nil
+			},
+			expectFn: func(g *GomegaWithT, status *v1alpha1.TiProxyStatus) {
+				g.Expect(status.Synced).To(BeFalse())
+				g.Expect(status.Members).To(HaveLen(2))
+				g.Expect(status.Members["test-tiproxy-0"].Health).To(BeTrue())
+				g.Expect(status.Members["test-tiproxy-1"].Health).To(BeFalse())
+			},
+		},
+	}
+
+	for i := range tests {
+		testFn(&tests[i], t)
+	}
+}
+
+func TestGetTiProxyConfigMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tests := []struct {
+		name     string
+		setTC    func(tc *v1alpha1.TidbCluster)
+		expected []string
+		missing  []string
+	}{
+		{
+			name: "basic",
+			expected: []string{
+				`addr = "0.0.0.0:6000"`,
+				`addr = "0.0.0.0:3080"`,
+				`pd-addrs = "test-pd:2379"`,
+			},
+			missing: []string{"[security"},
+		},
+		{
+			name: "user config is kept",
+			setTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiProxy.Config = config.New(map[string]interface{}{
+					"proxy": map[string]interface{}{
+						"pd-addrs":        "my-pd:2379",
+						"max-connections": 100,
+					},
+				})
+			},
+			expected: []string{
+				`pd-addrs = "my-pd:2379"`,
+				`max-connections = 100`,
+			},
+		},
+		{
+			name: "heterogeneous cluster without local pd",
+			setTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.PD = nil
+				tc.Spec.Cluster = &v1alpha1.TidbClusterRef{Name: "ref"}
+			},
+			expected: []string{`pd-addrs = "ref-pd:2379"`},
+		},
+		{
+			name: "tls enabled",
+			setTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+				tc.Spec.TiDB = &v1alpha1.TiDBSpec{
+					TLSClient: &v1alpha1.TiDBTLSClient{Enabled: true},
+				}
+			},
+			expected: []string{
+				"[security.cluster-tls]",
+				"[security.server-http-tls]",
+				`ca = "/var/lib/tiproxy-tls/ca.crt"`,
+				"[security.server-tls]",
+				`cert = "/var/lib/tiproxy-server-tls/tls.crt"`,
+				`ca = "/var/lib/tiproxy-server-tls/ca.crt"`,
+				"[security.sql-tls]",
+				`cert = "/var/lib/tiproxy-sql-tls/tls.crt"`,
+				`ca = "/var/lib/tiproxy-sql-tls/ca.crt"`,
+			},
+		},
+		{
+			name: "client authn disabled",
+			setTC: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiDB = &v1alpha1.TiDBSpec{
+					TLSClient: &v1alpha1.TiDBTLSClient{Enabled: true, DisableClientAuthn: true},
+				}
+			},
+			expected: []string{
+				`cert = "/var/lib/tiproxy-server-tls/tls.crt"`,
+				`ca = "/var/lib/tiproxy-sql-tls/ca.crt"`,
+			},
+			missing: []string{
+				`ca = "/var/lib/tiproxy-server-tls/ca.crt"`,
+				`cert = "/var/lib/tiproxy-sql-tls/tls.crt"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTidbClusterForTiProxy()
+			if tt.setTC != nil {
+				tt.setTC(tc)
+			}
+			cm, err := getTiProxyConfigMap(tc)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cm.Name).To(Equal("test-tiproxy"))
+			for _, s := range tt.expected {
+				g.Expect(cm.Data["config-file"]).To(ContainSubstring(s))
+			}
+			for _, s := range tt.missing {
+				g.Expect(cm.Data["config-file"]).NotTo(ContainSubstring(s))
+			}
+		})
+	}
+}
+
+func TestGetNewTiProxyService(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiProxy()
+	svc := getNewTiProxyService(tc)
+	g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+	g.Expect(svc.Spec.Ports).To(HaveLen(1))
+	g.Expect(svc.Spec.Ports[0].Name).To(Equal("mysql-client"))
+	g.Expect(svc.Spec.Ports[0].Port).To(Equal(v1alpha1.DefaultTiProxyServicePort))
+	g.Expect(svc.Spec.Ports[0].TargetPort.IntValue()).To(Equal(tiproxyPort))
+	g.Expect(svc.Spec.Selector).To(Equal(labelTiProxy(tc).Labels()))
+
+	tc.Spec.TiProxy.Service = &v1alpha1.ServiceSpec{
+		Type:           corev1.ServiceTypeLoadBalancer,
+		Port:           pointer.Int32Ptr(4000),
+		LoadBalancerIP: pointer.StringPtr("1.2.3.4"),
+	}
+	svc = getNewTiProxyService(tc)
+	g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+	g.Expect(svc.Spec.Ports[0].Port).To(Equal(int32(4000)))
+	g.Expect(svc.Spec.LoadBalancerIP).To(Equal("1.2.3.4"))
+}
+
+func TestGetNewTiProxyStatefulSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		tc      func() *v1alpha1.TidbCluster
+		testSts func(sts *apps.StatefulSet)
+	}{
+		{
+			name: "basic",
+			tc:   newTidbClusterForTiProxy,
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				g.Expect(sts.Name).To(Equal("test-tiproxy"))
+				g.Expect(sts.Spec.ServiceName).To(Equal("test-tiproxy-peer"))
+				g.Expect(*sts.Spec.Replicas).To(Equal(int32(1)))
+				container := sts.Spec.Template.Spec.Containers[0]
+				g.Expect(container.Image).To(Equal("pingcap/tiproxy:v0.1.1"))
+				g.Expect(strings.Join(container.Command, " ")).To(ContainSubstring("--config=/etc/proxy/proxy.toml"))
+				g.Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "config", ReadOnly: true, MountPath: "/etc/proxy"}))
+				g.Expect(sts.Spec.Template.Annotations["prometheus.io/port"]).To(Equal("3080"))
+				g.Expect(sts.Spec.Template.Labels[label.ComponentLabelKey]).To(Equal(label.TiProxyLabelVal))
+			},
+		},
+		{
+			name: "tls enabled",
+			tc: func() *v1alpha1.TidbCluster {
+				tc := newTidbClusterForTiProxy()
+				tc.Spec.TLSCluster = &v1alpha1.TLSCluster{Enabled: true}
+				tc.Spec.TiDB = &v1alpha1.TiDBSpec{
+					TLSClient: &v1alpha1.TiDBTLSClient{Enabled: true},
+				}
+				return tc
+			},
+			testSts: func(sts *apps.StatefulSet) {
+				g := NewGomegaWithT(t)
+				secrets := map[string]string{}
+				for _, vol := range sts.Spec.Template.Spec.Volumes {
+					if vol.Secret != nil {
+						secrets[vol.Name] = vol.Secret.SecretName
+					}
+				}
+				g.Expect(secrets).To(Equal(map[string]string{
+					"tiproxy-tls":        "test-tiproxy-cluster-secret",
+					"tiproxy-server-tls": "test-tiproxy-server-secret",
+					"tiproxy-sql-tls":    "test-tidb-client-secret",
+				}))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := tt.tc()
+			cm, err := getTiProxyConfigMap(tc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sts, err := getNewTiProxyStatefulSet(tc, cm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.testSts(sts)
+		})
+	}
+}
+
+func newFakeTiProxyMemberManager() (*tiproxyMemberManager, *controller.FakeStatefulSetControl) {
+	fakeDeps := controller.NewFakeDependencies()
+	tmm := &tiproxyMemberManager{
+		deps:            fakeDeps,
+		scaler:          NewTiProxyScaler(fakeDeps),
+		tiproxyUpgrader: NewTiProxyUpgrader(fakeDeps),
+	}
+	tmm.statefulSetIsUpgradingFn = tiproxyStatefulSetIsUpgrading
+	setControl := fakeDeps.StatefulSetControl.(*controller.FakeStatefulSetControl)
+	return tmm, setControl
+}
+
+func newTidbClusterForTiProxy() *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TidbCluster",
+			APIVersion: "pingcap.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: corev1.NamespaceDefault,
+			UID:       types.UID("test"),
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiProxy: &v1alpha1.TiProxySpec{
+				ComponentSpec: v1alpha1.ComponentSpec{
+					Version: pointer.StringPtr("v0.1.1"),
+				},
+				BaseImage: "pingcap/tiproxy",
+				Replicas:  1,
+			},
+		},
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+)
+
+func TestTiProxyScalerScaleOut(t *testing.T) {
+	g := NewGomegaWithT(t)
+	type testcase struct {
+		name         string
+		hasPVC       bool
+		hasDeferAnn  bool
+		pvcDeleteErr bool
+		annoIsNil    bool
+		errExpectFn  func(*GomegaWithT, error)
+		changed      bool
+		pvcDeleted   bool
+	}
+
+	testFn := func(test testcase, t *testing.T) {
+		tc := newTidbClusterForPD()
+
+		oldSet := newStatefulSetForPDScale()
+		oldSet.Name = fmt.Sprintf("%s-tiproxy", tc.Name)
+		newSet := oldSet.DeepCopy()
+		newSet.Spec.Replicas = pointer.Int32Ptr(7)
+
+		scaler, pvcIndexer, _, pvcControl := newFakeTiProxyScaler()
+
+		pvc := newPVCForStatefulSet(oldSet, v1alpha1.TiProxyMemberType, tc.Name)
+		if !test.annoIsNil {
+			pvc.Annotations = map[string]string{}
+		}
+		if test.hasDeferAnn {
+			pvc.Annotations = map[string]string{}
+			pvc.Annotations[label.AnnPVCDeferDeleting] = time.Now().Format(time.RFC3339)
+		}
+		if test.hasPVC {
+			pvcIndexer.Add(pvc)
+		}
+
+		if test.pvcDeleteErr {
+			pvcControl.SetDeletePVCError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
+		}
+
+		err := scaler.ScaleOut(tc, oldSet, newSet)
+		test.errExpectFn(g, err)
+		if test.changed {
+			g.Expect(int(*newSet.Spec.Replicas)).To(Equal(6))
+		} else {
+			g.Expect(int(*newSet.Spec.Replicas)).To(Equal(5))
+		}
+		_, exist, err := pvcIndexer.Get(pvc)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(exist).To(Equal(test.hasPVC && !test.pvcDeleted))
+	}
+
+	tests := []testcase{
+		{
+			name:         "pvc without annotations",
+			hasPVC:       true,
+			hasDeferAnn:  false,
+			annoIsNil:    true,
+			pvcDeleteErr: false,
+			errExpectFn:  errExpectNotNil,
+			changed:      false,
+		},
+		{
+			name:         "cache don't have pvc",
+			hasPVC:       false,
+			hasDeferAnn:  false,
+			annoIsNil:    true,
+			pvcDeleteErr: false,
+			errExpectFn:  errExpectNil,
+			changed:      true,
+		},
+		{
+			name:         "pvc annotation is not nil but doesn't contain defer deletion annotation",
+			hasPVC:       true,
+			hasDeferAnn:  false,
+			annoIsNil:    false,
+			pvcDeleteErr: false,
+			errExpectFn:  errExpectNotNil,
+			changed:      false,
+		},
+		{
+			name:         "pvc annotations defer deletion is not nil, pvc is deleted",
+			hasPVC:       true,
+			hasDeferAnn:  true,
+			pvcDeleteErr: false,
+			errExpectFn:  errExpectRequeue,
+			changed:      false,
+			pvcDeleted:   true,
+		},
+		{
+			name:         "pvc annotations defer deletion is not nil, pvc delete failed",
+			hasPVC:       true,
+			hasDeferAnn:  true,
+			pvcDeleteErr: true,
+			errExpectFn:  errExpectNotNil,
+			changed:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFn(tt, t)
+		})
+	}
+}
+
+func TestTiProxyScalerScaleIn(t *testing.T) {
+	g := NewGomegaWithT(t)
+	type testcase struct {
+		name         string
+		hasPod       bool
+		hasPVC       bool
+		pvcUpdateErr bool
+		errExpectFn  func(*GomegaWithT, error)
+		changed      bool
+	}
+
+	testFn := func(test testcase, t *testing.T) {
+		tc := newTidbClusterForPD()
+
+		oldSet := newStatefulSetForPDScale()
+		oldSet.Name = fmt.Sprintf("%s-tiproxy", tc.Name)
+		newSet := oldSet.DeepCopy()
+		newSet.Spec.Replicas = pointer.Int32Ptr(3)
+
+		pod := &corev1.Pod{
+			TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tiproxyPodName(tc.GetName(), 4),
+				Namespace: corev1.NamespaceDefault,
+			},
+		}
+
+		scaler, pvcIndexer, podIndexer, pvcControl := newFakeTiProxyScaler()
+
+		var pvc *corev1.PersistentVolumeClaim
+		if test.hasPVC {
+			pvc = newScaleInPVCForStatefulSet(oldSet, v1alpha1.TiProxyMemberType, tc.Name)
+			pvcIndexer.Add(pvc)
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvc.Name,
+					},
+				},
+			})
+		}
+		if test.hasPod {
+			podIndexer.Add(pod)
+		}
+
+		if test.pvcUpdateErr {
+			pvcControl.SetUpdatePVCError(errors.NewInternalError(fmt.Errorf("API server failed")), 0)
+		}
+
+		err := scaler.ScaleIn(tc, oldSet, newSet)
+		test.errExpectFn(g, err)
+		if test.changed {
+			g.Expect(int(*newSet.Spec.Replicas)).To(Equal(4))
+		} else {
+			g.Expect(int(*newSet.Spec.Replicas)).To(Equal(5))
+		}
+		if test.hasPVC && test.changed {
+			// the pvc is marked to be deleted when the tiproxy is scaled out again
+			obj, exist, err := pvcIndexer.Get(pvc)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(exist).To(BeTrue())
+			g.Expect(obj.(*corev1.PersistentVolumeClaim).Annotations).To(HaveKey(label.AnnPVCDeferDeleting))
+		}
+	}
+
+	tests := []testcase{
+		{
+			name:         "able to scale in",
+			hasPod:       true,
+			hasPVC:       true,
+			pvcUpdateErr: false,
+			errExpectFn:  errExpectNil,
+			changed:      true,
+		},
+		{
+			name:         "don't have pvc",
+			hasPod:       true,
+			hasPVC:       false,
+			pvcUpdateErr: false,
+			errExpectFn:  errExpectNil,
+			changed:      true,
+		},
+		{
+			name:         "pod not found",
+			hasPod:       false,
+			hasPVC:       true,
+			pvcUpdateErr: false,
+			errExpectFn:  errExpectNotNil,
+			changed:      false,
+		},
+		{
+			name:         "update PVC failed",
+			hasPod:       true,
+			hasPVC:       true,
+			pvcUpdateErr: true,
+			errExpectFn:  errExpectNotNil,
+			changed:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFn(tt, t)
+		})
+	}
+}
+
+func newFakeTiProxyScaler() (*tiproxyScaler, cache.Indexer, cache.Indexer, *controller.FakePVCControl) {
+	fakeDeps := controller.NewFakeDependencies()
+	pvcIndexer := fakeDeps.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer()
+	podIndexer := fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	pvcControl := fakeDeps.PVCControl.(*controller.FakePVCControl)
+	return &tiproxyScaler{generalScaler{deps: fakeDeps}}, pvcIndexer, podIndexer, pvcControl
+}
@@ -0,0 +1,224 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+func TestTiProxyUpgrader_Upgrade(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name           string
+		changeFn       func(*v1alpha1.TidbCluster)
+		changePods     func(pods []*corev1.Pod)
+		changeTemplate bool
+		errorExpect    bool
+		expectFn       func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet)
+	}
+
+	testFn := func(test *testcase, t *testing.T) {
+		t.Log(test.name)
+		fakeDeps := controller.NewFakeDependencies()
+		upgrader := NewTiProxyUpgrader(fakeDeps)
+		tc := newTidbClusterForTiProxyUpgrader()
+		if test.changeFn != nil {
+			test.changeFn(tc)
+		}
+		pods := getTiProxyPods()
+		if test.changePods != nil {
+			test.changePods(pods)
+		}
+		podIndexer := fakeDeps.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+		for _, pod := range pods {
+			podIndexer.Add(pod)
+		}
+
+		oldSet := newStatefulSetForTiProxyUpgrader()
+		newSet := oldSet.DeepCopy()
+		if test.changeTemplate {
+			newSet.Spec.Template.Spec.Containers[0].Image = "tiproxy-test-image:new"
+		}
+		mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+
+		err := upgrader.Upgrade(tc, oldSet, newSet)
+		if test.errorExpect {
+			g.Expect(err).To(HaveOccurred())
+		} else {
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		test.expectFn(g, tc, newSet)
+	}
+
+	tests := []*testcase{
+		{
+			name: "normal",
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiProxy.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(0)))
+			},
+		},
+		{
+			name: "upgraded pod is not ready",
+			changePods: func(pods []*corev1.Pod) {
+				pods[1].Status = corev1.PodStatus{}
+			},
+			errorExpect: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiProxy.Phase).To(Equal(v1alpha1.UpgradePhase))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name: "upgraded member is not healthy",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.TiProxy.Members[tiproxyPodName(upgradeTcName, 1)] = v1alpha1.TiProxyMember{
+					Name:   tiproxyPodName(upgradeTcName, 1),
+					Health: false,
+				}
+			},
+			errorExpect: true,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name:           "tiproxy can not upgrade when tidb is upgrading",
+			changeTemplate: true,
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.TiDB.Phase = v1alpha1.UpgradePhase
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiProxy.Phase).To(Equal(v1alpha1.NormalPhase))
+				g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tiproxy-test-image"))
+				g.Expect(newSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(pointer.Int32Ptr(1)))
+			},
+		},
+		{
+			name:           "tiproxy can not upgrade when tikv is upgrading",
+			changeTemplate: true,
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.TiKV.Phase = v1alpha1.UpgradePhase
+			},
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster, newSet *apps.StatefulSet) {
+				g.Expect(tc.Status.TiProxy.Phase).To(Equal(v1alpha1.NormalPhase))
+				g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tiproxy-test-image"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testFn(test, t)
+	}
+}
+
+func newStatefulSetForTiProxyUpgrader() *apps.StatefulSet {
+	return &apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      controller.TiProxyMemberName(upgradeTcName),
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(2),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "tiproxy",
+							Image: "tiproxy-test-image",
+						},
+					},
+				},
+			},
+			UpdateStrategy: apps.StatefulSetUpdateStrategy{Type: apps.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{
+					Partition: pointer.Int32Ptr(1),
+				},
+			},
+		},
+	}
+}
+
+func newTidbClusterForTiProxyUpgrader() *v1alpha1.TidbCluster {
+	return &v1alpha1.TidbCluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "TidbCluster",
+			APIVersion: "pingcap.com/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      upgradeTcName,
+			Namespace: corev1.NamespaceDefault,
+			UID:       types.UID(upgradeTcName),
+		},
+		Spec: v1alpha1.TidbClusterSpec{
+			TiProxy: &v1alpha1.TiProxySpec{
+				ComponentSpec: v1alpha1.ComponentSpec{
+					Image: "tiproxy-test-image",
+				},
+				Replicas: 2,
+			},
+		},
+		Status: v1alpha1.TidbClusterStatus{
+			TiProxy: v1alpha1.TiProxyStatus{
+				Phase: v1alpha1.NormalPhase,
+				StatefulSet: &apps.StatefulSetStatus{
+					CurrentReplicas: 1,
+					UpdatedReplicas: 1,
+					CurrentRevision: "1",
+					UpdateRevision:  "2",
+					Replicas:        2,
+				},
+				Members: map[string]v1alpha1.TiProxyMember{
+					tiproxyPodName(upgradeTcName, 0): {Name: tiproxyPodName(upgradeTcName, 0), Health: true},
+					tiproxyPodName(upgradeTcName, 1): {Name: tiproxyPodName(upgradeTcName, 1), Health: true},
+				},
+			},
+		},
+	}
+}
+
+func getTiProxyPods() []*corev1.Pod {
+	var pods []*corev1.Pod
+	for i, revision := range []string{"1", "2"} {
+		l := label.New().Instance(upgradeInstanceName).TiProxy().Labels()
+		l[apps.ControllerRevisionHashLabelKey] = revision
+		pods = append(pods, &corev1.Pod{
+			TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tiproxyPodName(upgradeTcName, int32(i)),
+				Namespace: corev1.NamespaceDefault,
+				Labels:    l,
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				},
+			},
+		})
+	}
+	return pods
+}
@@ -0,0 +1,360 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/manager"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	"github.com/pingcap/tidb-operator/pkg/util/cmpver"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// the versions before v4.0.0 have to be upgraded to v4.0 first before upgrading to v5.0.0 or later
+	versionLessThanV400, _ = cmpver.NewConstraint(cmpver.Less, "v4.0.0")
+	versionLessThanV500, _ = cmpver.NewConstraint(cmpver.Less, "v5.0.0")
+)
+
+// versionChange is the version change of a component
+type versionChange struct {
+	memberType v1alpha1.MemberType
+	current    string
+	target     string
+}
+
+type upgradePreflightManager struct {
+	deps *controller.Dependencies
+}
+
+// NewUpgradePreflightManager returns a manager that runs the pre-flight checks before changing the version
+// of the components and records the result in the UpgradePreflightPassed condition of TidbCluster
+func NewUpgradePreflightManager(deps *controller.Dependencies) manager.Manager {
+	return &upgradePreflightManager{
+		deps: deps,
+	}
+}
+
+func (m *upgradePreflightManager) Sync(tc *v1alpha1.TidbCluster) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if tc.Spec.UpgradePreflight == nil {
+		return nil
+	}
+	changes := pendingVersionChanges(tc, m.ticdcImage(tc))
+	if len(changes) == 0 {
+		// the version change blocked by the failed checks is reverted, reset the condition so that
+		// blockUpgradeByPreflight does not act on a stale result
+		if cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterUpgradePreflightPassed); cond != nil &&
+			cond.Status == corev1.ConditionFalse {
+			klog.Infof("tidbcluster: [%s/%s] has no pending version change, reset the failed upgrade pre-flight checks", ns, tcName)
+			setUpgradePreflightCondition(tc, corev1.ConditionTrue, utiltidbcluster.UpgradePreflightPassed, "No version change is pending")
+		}
+		return nil
+	}
+	for _, status := range v1alpha1.ComponentStatusFromTC(tc) {
+		if status.GetPhase() == v1alpha1.UpgradePhase {
+			// the checks are run before the upgrade begins, the result is kept during the upgrade
+			return nil
+		}
+	}
+
+	if tc.Annotations[label.AnnSkipUpgradePreflightKey] == label.AnnSkipUpgradePreflightVal {
+		klog.Infof("tidbcluster: [%s/%s]'s upgrade pre-flight checks are skipped", ns, tcName)
+		setUpgradePreflightCondition(tc, corev1.ConditionTrue, utiltidbcluster.UpgradePreflightSkipped, "Pre-flight checks are skipped by annotation")
+		return nil
+	}
+
+	if err := m.check(tc, changes); err != nil {
+		klog.Warningf("tidbcluster: [%s/%s]'s upgrade pre-flight checks failed: %v", ns, tcName, err)
+		if cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterUpgradePreflightPassed); cond == nil ||
+			cond.Status != corev1.ConditionFalse {
+			m.deps.Recorder.Event(tc, corev1.EventTypeWarning, utiltidbcluster.UpgradePreflightFailed, err.Error())
+		}
+		setUpgradePreflightCondition(tc, corev1.ConditionFalse, utiltidbcluster.UpgradePreflightFailed, err.Error())
+		return nil
+	}
+	setUpgradePreflightCondition(tc, corev1.ConditionTrue, utiltidbcluster.UpgradePreflightPassed, "Pre-flight checks are passed")
+	return nil
+}
+
+func (m *upgradePreflightManager) check(tc *v1alpha1.TidbCluster, changes []versionChange) error {
+	for _, change := range changes {
+		if err := checkVersionJump(change); err != nil {
+			return err
+		}
+	}
+	if err := checkUpgradeOrder(tc); err != nil {
+		return err
+	}
+	if err := m.checkPD(tc); err != nil {
+		return err
+	}
+	return m.checkBackup(tc)
+}
+
+// checkPD checks that PD has no down stores, no pending region merges and the unhealthy regions
+// are no more than the threshold
+func (m *upgradePreflightManager) checkPD(tc *v1alpha1.TidbCluster) error {
+	pdCli := controller.GetPDClient(m.deps.PDControl, tc)
+
+	storesInfo, err := pdCli.GetStores()
+	if err != nil {
+		return fmt.Errorf("failed to get stores from pd: %v", err)
+	}
+	for _, store := range storesInfo.Stores {
+		if store.Store != nil && store.Store.StateName == v1alpha1.TiKVStateDown {
+			return fmt.Errorf("store %d (%s) is down", store.Store.GetId(), store.Store.GetAddress())
+		}
+	}
+
+	operators, err := pdCli.GetOperators()
+	if err != nil {
+		return fmt.Errorf("failed to get operators from pd: %v", err)
+	}
+	var merges int
+	for _, op := range operators {
+		if strings.Contains(op, "merge-region") {
+			merges++
+		}
+	}
+	if merges > 0 {
+		return fmt.Errorf("%d region merges are pending", merges)
+	}
+
+	var unhealthy int
+	for _, state := range []pdapi.RegionCheckState{
+		pdapi.RegionCheckDownPeer,
+		pdapi.RegionCheckPendingPeer,
+		pdapi.RegionCheckMissPeer,
+		pdapi.RegionCheckExtraPeer,
+	} {
+		regionsInfo, err := pdCli.CheckRegions(state)
+		if err != nil {
+			return fmt.Errorf("failed to check %s regions from pd: %v", state, err)
+		}
+		unhealthy += regionsInfo.Count
+	}
+	var maxUnhealthy int32
+	if tc.Spec.UpgradePreflight.MaxUnhealthyRegions != nil {
+		maxUnhealthy = *tc.Spec.UpgradePreflight.MaxUnhealthyRegions
+	}
+	if unhealthy > int(maxUnhealthy) {
+		return fmt.Errorf("%d regions are unhealthy (threshold: %d)", unhealthy, maxUnhealthy)
+	}
+	return nil
+}
+
+// checkBackup checks that the cluster is not being backed up, the log backups are ignored as they keep running
+func (m *upgradePreflightManager) checkBackup(tc *v1alpha1.TidbCluster) error {
+	backups, err := m.deps.BackupLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %v", err)
+	}
+	for _, backup := range backups {
+		if backup.Spec.BR == nil || backup.Spec.Mode == v1alpha1.BackupModeLog {
+			continue
+		}
+		clusterNamespace := backup.Spec.BR.ClusterNamespace
+		if clusterNamespace == "" {
+			clusterNamespace = backup.GetNamespace()
+		}
+		if backup.Spec.BR.Cluster != tc.GetName() || clusterNamespace != tc.GetNamespace() {
+			continue
+		}
+		if v1alpha1.IsBackupComplete(backup) || v1alpha1.IsBackupFailed(backup) || v1alpha1.IsBackupInvalid(backup) {
+			continue
+		}
+		if v1alpha1.IsBackupScheduled(backup) || v1alpha1.IsBackupPrepared(backup) || v1alpha1.IsBackupRunning(backup) {
+			return fmt.Errorf("backup %s/%s is running", backup.GetNamespace(), backup.GetName())
+		}
+	}
+	return nil
+}
+
+// checkVersionJump checks that the component is not downgraded and the versions before v4.0.0 are not
+// upgraded to v5.0.0 or later directly, the versions that are not semantic versions are not checked
+func checkVersionJump(change versionChange) error {
+	downgrade, err := cmpver.Compare(change.target, cmpver.Less, change.current)
+	if err != nil {
+		klog.V(4).Infof("skip checking the version jump of %s from %s to %s: %v", change.memberType, change.current, change.target, err)
+		return nil
+	}
+	if downgrade {
+		return fmt.Errorf("downgrading %s from %s to %s is not supported", change.memberType, change.current, change.target)
+	}
+	fromV3, err := versionLessThanV400.Check(change.current)
+	if err != nil {
+		return nil
+	}
+	toV5, err := versionLessThanV500.Check(change.target)
+	if err != nil {
+		return nil
+	}
+	if fromV3 && !toV5 {
+		return fmt.Errorf("upgrading %s from %s to %s is not supported, upgrade to v4.0 first", change.memberType, change.current, change.target)
+	}
+	return nil
+}
+
+// checkUpgradeOrder checks that the target versions respect the upgrade order of the components, the components
+// that depend on PD and TiKV must not be newer than them
+func checkUpgradeOrder(tc *v1alpha1.TidbCluster) error {
+	type component struct {
+		memberType v1alpha1.MemberType
+		version    string
+	}
+	pd := component{v1alpha1.PDMemberType, imageVersion(tc.PDImage())}
+	tikv := component{v1alpha1.TiKVMemberType, imageVersion(tc.TiKVImage())}
+	tiflash := component{v1alpha1.TiFlashMemberType, imageVersion(tc.TiFlashImage())}
+	tidb := component{v1alpha1.TiDBMemberType, imageVersion(tc.TiDBImage())}
+	ticdc := component{v1alpha1.TiCDCMemberType, imageVersion(tc.TiCDCImage())}
+
+	for _, order := range [][2]component{
+		{pd, tikv}, {pd, tiflash}, {pd, tidb}, {pd, ticdc},
+		{tikv, tiflash}, {tikv, tidb}, {tikv, ticdc},
+	} {
+		lower, upper := order[0], order[1]
+		if lower.version == "" || upper.version == "" {
+			continue
+		}
+		newer, err := cmpver.Compare(upper.version, cmpver.Greater, lower.version)
+		if err != nil {
+			continue
+		}
+		if newer {
+			return fmt.Errorf("%s version %s is newer than %s version %s, %s should be upgraded first",
+				upper.memberType, upper.version, lower.memberType, lower.version, lower.memberType)
+		}
+	}
+	return nil
+}
+
+// pendingVersionChanges returns the components whose versions in spec differ from the versions in use,
+// ticdcImage is the image in use by TiCDC as it is not recorded in the status
+func pendingVersionChanges(tc *v1alpha1.TidbCluster, ticdcImage string) []versionChange {
+	var changes []versionChange
+	add := func(memberType v1alpha1.MemberType, current, target string) {
+		if current == "" || target == "" {
+			return
+		}
+		currentVersion, targetVersion := imageVersion(current), imageVersion(target)
+		if currentVersion != targetVersion {
+			changes = append(changes, versionChange{memberType: memberType, current: currentVersion, target: targetVersion})
+		}
+	}
+	add(v1alpha1.PDMemberType, tc.Status.PD.Image, tc.PDImage())
+	add(v1alpha1.TiKVMemberType, tc.Status.TiKV.Image, tc.TiKVImage())
+	add(v1alpha1.TiFlashMemberType, tc.Status.TiFlash.Image, tc.TiFlashImage())
+	add(v1alpha1.TiDBMemberType, tc.Status.TiDB.Image, tc.TiDBImage())
+	add(v1alpha1.TiCDCMemberType, ticdcImage, tc.TiCDCImage())
+	return changes
+}
+
+// ticdcImage returns the image of the TiCDC statefulset in use, or empty string if it does not exist
+func (m *upgradePreflightManager) ticdcImage(tc *v1alpha1.TidbCluster) string {
+	if tc.Spec.TiCDC == nil {
+		return ""
+	}
+	set, err := m.deps.StatefulSetLister.StatefulSets(tc.GetNamespace()).Get(controller.TiCDCMemberName(tc.GetName()))
+	if err != nil {
+		return ""
+	}
+	if container := findContainerByName(set, v1alpha1.TiCDCMemberType.String()); container != nil {
+		return container.Image
+	}
+	return ""
+}
+
+// imageVersion returns the version of the image, which is the tag of the image reference or the digest
+// if the image is referenced by digest only. A colon in the registry host (e.g. registry:5000/pingcap/tidb)
+// is not regarded as the tag separator.
+func imageVersion(image string) string {
+	if image == "" {
+		return ""
+	}
+	name := image
+	digest := ""
+	if idx := strings.IndexByte(image, '@'); idx >= 0 {
+		name, digest = image[:idx], image[idx+1:]
+	}
+	if idx := strings.LastIndexByte(name, ':'); idx > strings.LastIndexByte(name, '/') {
+		return name[idx+1:]
+	}
+	if digest != "" {
+		return digest
+	}
+	return "latest"
+}
+
+func setUpgradePreflightCondition(tc *v1alpha1.TidbCluster, status corev1.ConditionStatus, reason, message string) {
+	cond := utiltidbcluster.NewTidbClusterCondition(v1alpha1.TidbClusterUpgradePreflightPassed, status, reason, message)
+	utiltidbcluster.SetTidbClusterCondition(&tc.Status, *cond)
+}
+
+// blockUpgradeByPreflight reverts the image of the component in newSet to the one in use if the pre-flight
+// checks fail and the image is changing, the other changes of the template are kept. It returns true if
+// nothing is left to upgrade after the revert, so that the component is not regarded as upgrading.
+func blockUpgradeByPreflight(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, oldSet, newSet *apps.StatefulSet) (bool, error) {
+	if tc.Spec.UpgradePreflight == nil {
+		return false, nil
+	}
+	cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterUpgradePreflightPassed)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		return false, nil
+	}
+	_, podSpec, err := GetLastAppliedConfig(oldSet)
+	if err != nil {
+		return false, err
+	}
+	var oldImage string
+	for _, container := range podSpec.Containers {
+		if container.Name == memberType.String() {
+			oldImage = container.Image
+		}
+	}
+	for i := range newSet.Spec.Template.Spec.Containers {
+		container := &newSet.Spec.Template.Spec.Containers[i]
+		if container.Name != memberType.String() || oldImage == "" || imageVersion(oldImage) == imageVersion(container.Image) {
+			continue
+		}
+		klog.Infof("tidbcluster: [%s/%s]'s %s upgrade from %s to %s is blocked by the failed pre-flight checks: %s",
+			tc.GetNamespace(), tc.GetName(), memberType, oldImage, container.Image, cond.Message)
+		container.Image = oldImage
+		return templateEqual(newSet, oldSet) && oldSet.Status.CurrentRevision == oldSet.Status.UpdateRevision, nil
+	}
+	return false, nil
+}
+
+type fakeUpgradePreflightManager struct{}
+
+// NewFakeUpgradePreflightManager returns a fake upgrade pre-flight manager
+func NewFakeUpgradePreflightManager() manager.Manager {
+	return &fakeUpgradePreflightManager{}
+}
+
+func (m *fakeUpgradePreflightManager) Sync(_ *v1alpha1.TidbCluster) error {
+	return nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+	"github.com/pingcap/tidb-operator/pkg/pdapi"
+	utiltidbcluster "github.com/pingcap/tidb-operator/pkg/util/tidbcluster"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestUpgradePreflightManagerSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name         string
+		changeFn     func(tc *v1alpha1.TidbCluster)
+		stores       []*pdapi.StoreInfo
+		operators    []string
+		regions      int
+		backup       *v1alpha1.Backup
+		ticdcImage   string
+		expectStatus corev1.ConditionStatus
+		expectReason string
+	}
+
+	testFn := func(test *testcase) {
+		t.Log(test.name)
+		deps := controller.NewFakeDependencies()
+		tc := newTidbClusterForPD()
+		tc.Spec.PD.BaseImage = "pingcap/pd"
+		tc.Spec.TiKV.BaseImage = "pingcap/tikv"
+		tc.Spec.TiDB.BaseImage = "pingcap/tidb"
+		tc.Spec.Version = "v6.5.0"
+		tc.Spec.UpgradePreflight = &v1alpha1.UpgradePreflight{}
+		tc.Status.PD.Image = "pingcap/pd:v6.1.0"
+		tc.Status.TiKV.Image = "pingcap/tikv:v6.1.0"
+		tc.Status.TiDB.Image = "pingcap/tidb:v6.1.0"
+		if test.changeFn != nil {
+			test.changeFn(tc)
+		}
+
+		pdClient := controller.NewFakePDClient(deps.PDControl.(*pdapi.FakePDControl), tc)
+		pdClient.AddReaction(pdapi.GetStoresActionType, func(action *pdapi.Action) (interface{}, error) {
+			return &pdapi.StoresInfo{Count: len(test.stores), Stores: test.stores}, nil
+		})
+		pdClient.AddReaction(pdapi.GetOperatorsActionType, func(action *pdapi.Action) (interface{}, error) {
+			return test.operators, nil
+		})
+		pdClient.AddReaction(pdapi.CheckRegionsActionType, func(action *pdapi.Action) (interface{}, error) {
+			if action.RegionState == pdapi.RegionCheckMissPeer {
+				return &pdapi.RegionsInfo{Count: test.regions}, nil
+			}
+			return &pdapi.RegionsInfo{}, nil
+		})
+		if test.backup != nil {
+			deps.InformerFactory.Pingcap().V1alpha1().Backups().Informer().GetIndexer().Add(test.backup)
+		}
+
+		if test.ticdcImage != "" {
+			deps.KubeInformerFactory.Apps().V1().StatefulSets().Informer().GetIndexer().Add(&apps.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: controller.TiCDCMemberName(tc.GetName()), Namespace: tc.GetNamespace()},
+				Spec: apps.StatefulSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: v1alpha1.TiCDCMemberType.String(), Image: test.ticdcImage}},
+				}}},
+			})
+		}
+
+		m := NewUpgradePreflightManager(deps)
+		g.Expect(m.Sync(tc)).To(Succeed())
+		cond := utiltidbcluster.GetTidbClusterCondition(tc.Status, v1alpha1.TidbClusterUpgradePreflightPassed)
+		if test.expectStatus == "" {
+			g.Expect(cond).To(BeNil())
+			return
+		}
+		g.Expect(cond).NotTo(BeNil())
+		g.Expect(cond.Status).To(Equal(test.expectStatus))
+		g.Expect(cond.Reason).To(Equal(test.expectReason))
+	}
+
+	runningBackup := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: metav1.NamespaceDefault},
+		Spec:       v1alpha1.BackupSpec{BR: &v1alpha1.BRConfig{Cluster: "test"}},
+	}
+	v1alpha1.UpdateBackupCondition(&runningBackup.Status, &v1alpha1.BackupCondition{
+		Type:   v1alpha1.BackupRunning,
+		Status: corev1.ConditionTrue,
+	})
+
+	tests := []*testcase{
+		{
+			name:         "pre-flight checks are passed",
+			expectStatus: corev1.ConditionTrue,
+			expectReason: utiltidbcluster.UpgradePreflightPassed,
+		},
+		{
+			name: "pre-flight checks are disabled",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.UpgradePreflight = nil
+			},
+		},
+		{
+			name: "no version change",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Version = "v6.1.0"
+			},
+		},
+		{
+			name: "no version change after the failed checks",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Version = "v6.1.0"
+				setUpgradePreflightCondition(tc, corev1.ConditionFalse, utiltidbcluster.UpgradePreflightFailed, "store 1 is down")
+			},
+			expectStatus: corev1.ConditionTrue,
+			expectReason: utiltidbcluster.UpgradePreflightPassed,
+		},
+		{
+			name: "the upgrade has begun",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Status.PD.Phase = v1alpha1.UpgradePhase
+			},
+			backup: runningBackup,
+		},
+		{
+			name: "downgrade",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.Version = "v5.4.0"
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectReason: utiltidbcluster.UpgradePreflightFailed,
+		},
+		{
+			name: "tidb is newer than tikv",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiDB.Version = pointer.StringPtr("v7.1.0")
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectReason: utiltidbcluster.UpgradePreflightFailed,
+		},
+		{
+			name: "store is down",
+			stores: []*pdapi.StoreInfo{
+				{Store: &pdapi.MetaStore{Store: &metapb.Store{Id: 1}, StateName: v1alpha1.TiKVStateDown}},
+			},
+			expectStatus: corev1.ConditionFalse,
+			expectReason: utiltidbcluster.UpgradePreflightFailed,
+		},
+		{
+			name:         "region merges are pending",
+			operators:    []string{"merge-region {merge: region 2 to 3}"},
+			expectStatus: corev1.ConditionFalse,
+			expectReason: utiltidbcluster.UpgradePreflightFailed,
+		},
+		{
+			name:         "unhealthy regions exceed the threshold",
+			regions:      3,
+			expectStatus: corev1.ConditionFalse,
+			expectReason: utiltidbcluster.UpgradePreflightFailed,
+		},
+		{
+			name: "unhealthy regions do not exceed the threshold",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.UpgradePreflight.MaxUnhealthyRegions = pointer.Int32Ptr(3)
+			},
+			regions:      3,
+			expectStatus: corev1.ConditionTrue,
+			expectReason: utiltidbcluster.UpgradePreflightPassed,
+		},
+		{
+			name:         "backup is running",
+			backup:       runningBackup,
+			expectStatus: corev1.ConditionFalse,
+			expectReason: utiltidbcluster.UpgradePreflightFailed,
+		},
+		{
+			name: "ticdc is downgraded",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiCDC = &v1alpha1.TiCDCSpec{ComponentSpec: v1alpha1.ComponentSpec{Image: "registry:5000/pingcap/ticdc:v6.1.0"}}
+			},
+			ticdcImage:   "registry:5000/pingcap/ticdc:v6.5.0",
+			expectStatus: corev1.ConditionFalse,
+			expectReason: utiltidbcluster.UpgradePreflightFailed,
+		},
+		{
+			name: "pre-flight checks are skipped",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Annotations = map[string]string{label.AnnSkipUpgradePreflightKey: label.AnnSkipUpgradePreflightVal}
+			},
+			backup:       runningBackup,
+			expectStatus: corev1.ConditionTrue,
+			expectReason: utiltidbcluster.UpgradePreflightSkipped,
+		},
+	}
+
+	for _, test := range tests {
+		testFn(test)
+	}
+}
+
+func TestCheckVersionJump(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(checkVersionJump(versionChange{v1alpha1.TiKVMemberType, "v6.1.0", "v6.5.0"})).To(Succeed())
+	g.Expect(checkVersionJump(versionChange{v1alpha1.TiKVMemberType, "v3.0.20", "v4.0.16"})).To(Succeed())
+	g.Expect(checkVersionJump(versionChange{v1alpha1.TiKVMemberType, "v6.1.0", "nightly"})).To(Succeed())
+	g.Expect(checkVersionJump(versionChange{v1alpha1.TiKVMemberType, "v6.5.0", "v6.1.0"})).NotTo(Succeed())
+	g.Expect(checkVersionJump(versionChange{v1alpha1.TiKVMemberType, "v3.0.20", "v5.4.0"})).NotTo(Succeed())
+}
+
+func TestBlockUpgradeByPreflight(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := newTidbClusterForTiDBUpgrader()
+	tc.Spec.TiDB.Image = "tidb-test-image:v6.5.0"
+	tc.Status.TiDB.Image = "tidb-test-image:v6.1.0"
+	oldSet := newStatefulSetForTiDBUpgrader()
+	oldSet.Status.UpdateRevision = oldSet.Status.CurrentRevision
+	mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+	newSet := newStatefulSetForTiDBUpgrader()
+	newSet.Spec.Template.Spec.Containers[0].Image = "tidb-test-image:v6.5.0"
+
+	blocked, err := blockUpgradeByPreflight(tc, v1alpha1.TiDBMemberType, oldSet, newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(blocked).To(BeFalse())
+
+	tc.Spec.UpgradePreflight = &v1alpha1.UpgradePreflight{}
+	setUpgradePreflightCondition(tc, corev1.ConditionFalse, utiltidbcluster.UpgradePreflightFailed, "store 1 is down")
+	blocked, err = blockUpgradeByPreflight(tc, v1alpha1.TiKVMemberType, oldSet, newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(blocked).To(BeFalse())
+
+	blocked, err = blockUpgradeByPreflight(tc, v1alpha1.TiDBMemberType, oldSet, newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(blocked).To(BeTrue())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-test-image"))
+
+	// the changes other than the image are kept
+	newSet = newStatefulSetForTiDBUpgrader()
+	newSet.Spec.Template.Spec.Containers[0].Image = "tidb-test-image:v6.5.0"
+	newSet.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "TZ", Value: "UTC"}}
+	blocked, err = blockUpgradeByPreflight(tc, v1alpha1.TiDBMemberType, oldSet, newSet)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(blocked).To(BeFalse())
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-test-image"))
+	g.Expect(newSet.Spec.Template.Spec.Containers[0].Env).To(HaveLen(1))
+}
+
+func TestImageVersion(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(imageVersion("pingcap/tidb:v6.5.0")).To(Equal("v6.5.0"))
+	g.Expect(imageVersion("pingcap/tidb")).To(Equal("latest"))
+	g.Expect(imageVersion("registry:5000/pingcap/tidb")).To(Equal("latest"))
+	g.Expect(imageVersion("registry:5000/pingcap/tidb:v6.5.0")).To(Equal("v6.5.0"))
+	g.Expect(imageVersion("pingcap/tidb:v6.5.0@sha256:abc")).To(Equal("v6.5.0"))
+	g.Expect(imageVersion("registry:5000/pingcap/tidb@sha256:abc")).To(Equal("sha256:abc"))
+}
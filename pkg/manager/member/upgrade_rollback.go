@@ -0,0 +1,173 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+	"github.com/pingcap/tidb-operator/pkg/util"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+)
+
+const (
+	// RolledBackConfigAnnotation is annotation key of the statefulset spec that has been rolled back,
+	// the upgrade is paused until the spec of the component is changed
+	RolledBackConfigAnnotation = "pingcap.com/rolled-back-configuration"
+)
+
+// syncUpgradeRollback returns true if the desired spec has been rolled back and is not changed, newSet is
+// reverted to the last applied spec, which is the rolled back one, and the upgrade should be paused.
+func syncUpgradeRollback(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, oldSet, newSet *apps.StatefulSet) (bool, error) {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	if rolledBackConfig, ok := oldSet.Annotations[RolledBackConfigAnnotation]; ok {
+		rolledBack := &apps.StatefulSetSpec{}
+		if err := json.Unmarshal([]byte(rolledBackConfig), rolledBack); err != nil {
+			return false, err
+		}
+		if apiequality.Semantic.DeepEqual(rolledBack.Template.Spec, newSet.Spec.Template.Spec) {
+			_, podSpec, err := GetLastAppliedConfig(oldSet)
+			if err != nil {
+				return false, err
+			}
+			newSet.Spec.Template.Spec = *podSpec
+			setStatefulSetAnnotation(newSet, RolledBackConfigAnnotation, rolledBackConfig)
+			klog.Infof("tidbcluster: [%s/%s]'s %s upgrade has been rolled back, skip upgrading until the spec is changed", ns, tcName, memberType)
+			return true, nil
+		}
+		klog.Infof("tidbcluster: [%s/%s]'s %s spec is changed after the rollback, resume upgrading", ns, tcName, memberType)
+	}
+	return false, nil
+}
+
+// upgradedPodUnhealthyTooLong returns whether the upgraded pod has been unhealthy for longer than the
+// unhealthy timeout of the auto rollback policy. unhealthySince is the time the member of the pod became
+// unhealthy, it is zero if only the pod is not ready.
+func upgradedPodUnhealthyTooLong(strategy *v1alpha1.UpgradeStrategy, pod *corev1.Pod, unhealthySince metav1.Time) bool {
+	if !strategy.AutoRollbackEnabled() {
+		return false
+	}
+	since := pod.CreationTimestamp.Time
+	if _, cond := podutil.GetPodCondition(&pod.Status, corev1.PodReady); cond != nil && cond.Status != corev1.ConditionTrue &&
+		cond.LastTransitionTime.After(since) {
+		since = cond.LastTransitionTime.Time
+	}
+	if unhealthySince.After(since) {
+		since = unhealthySince.Time
+	}
+	return time.Now().After(since.Add(strategy.UpgradeUnhealthyTimeout()))
+}
+
+// rollbackUpgrade reverts the statefulset template of newSet to the spec of the current revision, which is in
+// use before the upgrade, and records the rolled back spec. The upgraded pods are recreated by the statefulset
+// controller with the stable spec, which is kept by syncUpgradeRollback as the last applied spec while the
+// upgrade is paused.
+func rollbackUpgrade(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType,
+	newSet *apps.StatefulSet, podName string) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	_, _, stsStatus, _ := upgradeStepOf(tc, memberType)
+	if stsStatus == nil || stsStatus.CurrentRevision == "" {
+		return controller.RequeueErrorf("tidbcluster: [%s/%s]'s upgraded %s pod: [%s] keeps unhealthy, but no stable revision is found to roll back to",
+			ns, tcName, memberType, podName)
+	}
+	stable, err := getRevisionPodSpec(deps, ns, stsStatus.CurrentRevision)
+	if err != nil {
+		return err
+	}
+	rolledBackConfig, err := util.Encode(newSet.Spec)
+	if err != nil {
+		return err
+	}
+
+	newSet.Spec.Template.Spec = *stable
+	setStatefulSetAnnotation(newSet, RolledBackConfigAnnotation, rolledBackConfig)
+
+	message := "upgraded pod " + podName + " keeps unhealthy, the upgrade is rolled back and paused until the spec is changed"
+	if status := componentStatusOf(tc, memberType); status != nil {
+		status.SetCondition(metav1.Condition{
+			Type:    v1alpha1.ComponentUpgradeRolledBack,
+			Status:  metav1.ConditionTrue,
+			Reason:  "UnhealthyPod",
+			Message: message,
+		})
+	}
+	deps.Recorder.Eventf(tc, corev1.EventTypeWarning, "UpgradeRolledBack", "%s %s", memberType, message)
+	klog.Warningf("tidbcluster: [%s/%s]'s %s %s", ns, tcName, memberType, message)
+	return nil
+}
+
+// syncUpgradeRollbackCondition resets the rolled back condition once the rolled back spec is dropped
+// from the statefulset
+func syncUpgradeRollbackCondition(status v1alpha1.ComponentStatus, set *apps.StatefulSet) {
+	if _, ok := set.Annotations[RolledBackConfigAnnotation]; ok {
+		return
+	}
+	if meta.IsStatusConditionTrue(status.GetConditions(), v1alpha1.ComponentUpgradeRolledBack) {
+		status.SetCondition(metav1.Condition{
+			Type:    v1alpha1.ComponentUpgradeRolledBack,
+			Status:  metav1.ConditionFalse,
+			Reason:  "SpecChanged",
+			Message: "The spec is changed after the rollback, the upgrade is resumed",
+		})
+	}
+}
+
+func componentStatusOf(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) v1alpha1.ComponentStatus {
+	for _, status := range v1alpha1.ComponentStatusFromTC(tc) {
+		if status.GetMemberType() == memberType {
+			return status
+		}
+	}
+	return nil
+}
+
+// getRevisionPodSpec returns the pod spec in the controller revision of the statefulset with the name
+func getRevisionPodSpec(deps *controller.Dependencies, ns, name string) (*corev1.PodSpec, error) {
+	revision, err := deps.KubeClientset.AppsV1().ControllerRevisions(ns).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controller revision %s/%s, error: %v", ns, name, err)
+	}
+	// the revision data is the patch of the statefulset which replaces the template
+	patch := struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(revision.Data.Raw, &patch); err != nil {
+		return nil, fmt.Errorf("failed to decode controller revision %s/%s, error: %v", ns, name, err)
+	}
+	return &patch.Spec.Template.Spec, nil
+}
+
+func setStatefulSetAnnotation(set *apps.StatefulSet, key, value string) {
+	if set.Annotations == nil {
+		set.Annotations = map[string]string{}
+	}
+	set.Annotations[key] = value
+}
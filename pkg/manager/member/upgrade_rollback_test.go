@@ -0,0 +1,122 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	mngerutils "github.com/pingcap/tidb-operator/pkg/manager/utils"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestSyncUpgradeRollback(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name         string
+		changeOldSet func(set *apps.StatefulSet)
+		newImage     string
+		expectPaused bool
+		expectFn     func(g *GomegaWithT, newSet *apps.StatefulSet)
+	}
+
+	testFn := func(test *testcase) {
+		t.Log(test.name)
+		tc := newTidbClusterForTiDBUpgrader()
+		tc.Spec.TiDB.UpgradeStrategy = &v1alpha1.UpgradeStrategy{AutoRollback: &v1alpha1.AutoRollbackPolicy{}}
+		oldSet := newStatefulSetForTiDBUpgrader()
+		mngerutils.SetStatefulSetLastAppliedConfigAnnotation(oldSet)
+		if test.changeOldSet != nil {
+			test.changeOldSet(oldSet)
+		}
+		newSet := newStatefulSetForTiDBUpgrader()
+		newSet.Spec.Template.Spec.Containers[0].Image = test.newImage
+
+		paused, err := syncUpgradeRollback(tc, v1alpha1.TiDBMemberType, oldSet, newSet)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(paused).To(Equal(test.expectPaused))
+		test.expectFn(g, newSet)
+	}
+
+	tests := []*testcase{
+		{
+			name:     "the rolled back spec is not changed",
+			newImage: "tidb-new-image",
+			changeOldSet: func(set *apps.StatefulSet) {
+				set.Annotations[RolledBackConfigAnnotation] = `{"template":{"spec":{"containers":[{"name":"tidb","image":"tidb-new-image"}]}}}`
+			},
+			expectPaused: true,
+			expectFn: func(g *GomegaWithT, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-test-image"))
+				g.Expect(newSet.Annotations).To(HaveKey(RolledBackConfigAnnotation))
+			},
+		},
+		{
+			name:     "the spec is changed after the rollback",
+			newImage: "tidb-fixed-image",
+			changeOldSet: func(set *apps.StatefulSet) {
+				set.Annotations[RolledBackConfigAnnotation] = `{"template":{"spec":{"containers":[{"name":"tidb","image":"tidb-new-image"}]}}}`
+			},
+			expectFn: func(g *GomegaWithT, newSet *apps.StatefulSet) {
+				g.Expect(newSet.Spec.Template.Spec.Containers[0].Image).To(Equal("tidb-fixed-image"))
+				g.Expect(newSet.Annotations).NotTo(HaveKey(RolledBackConfigAnnotation))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testFn(test)
+	}
+}
+
+func TestUpgradedPodUnhealthyTooLong(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	strategy := &v1alpha1.UpgradeStrategy{AutoRollback: &v1alpha1.AutoRollbackPolicy{UnhealthyTimeout: pointer.StringPtr("10m")}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+	}
+	g.Expect(upgradedPodUnhealthyTooLong(nil, pod, metav1.Time{})).To(BeFalse())
+	g.Expect(upgradedPodUnhealthyTooLong(strategy, pod, metav1.Time{})).To(BeTrue())
+	g.Expect(upgradedPodUnhealthyTooLong(strategy, pod, metav1.Now())).To(BeFalse())
+
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionFalse, LastTransitionTime: metav1.Now()},
+	}
+	g.Expect(upgradedPodUnhealthyTooLong(strategy, pod, metav1.Time{})).To(BeFalse())
+}
+
+func TestSyncUpgradeRollbackCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	status := &v1alpha1.TiDBStatus{}
+	status.SetCondition(metav1.Condition{Type: v1alpha1.ComponentUpgradeRolledBack, Status: metav1.ConditionTrue, Reason: "UnhealthyPod"})
+	set := newStatefulSetForTiDBUpgrader()
+	set.Annotations = map[string]string{RolledBackConfigAnnotation: "{}"}
+
+	syncUpgradeRollbackCondition(status, set)
+	g.Expect(meta.IsStatusConditionTrue(status.GetConditions(), v1alpha1.ComponentUpgradeRolledBack)).To(BeTrue())
+
+	delete(set.Annotations, RolledBackConfigAnnotation)
+	syncUpgradeRollbackCondition(status, set)
+	g.Expect(meta.IsStatusConditionFalse(status.GetConditions(), v1alpha1.ComponentUpgradeRolledBack)).To(BeTrue())
+}
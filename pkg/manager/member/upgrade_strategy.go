@@ -0,0 +1,167 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+)
+
+// upgradeStepOf returns the upgrade strategy, the staged upgrade status, the statefulset status and
+// the approval annotation key of the component
+func upgradeStepOf(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) (*v1alpha1.UpgradeStrategy, **v1alpha1.UpgradeStepStatus, *apps.StatefulSetStatus, string) {
+	switch memberType {
+	case v1alpha1.PDMemberType:
+		return tc.Spec.PD.UpgradeStrategy, &tc.Status.PD.UpgradeStep, tc.Status.PD.StatefulSet, label.AnnPDUpgradeApprovedStep
+	case v1alpha1.TiKVMemberType:
+		return tc.Spec.TiKV.UpgradeStrategy, &tc.Status.TiKV.UpgradeStep, tc.Status.TiKV.StatefulSet, label.AnnTiKVUpgradeApprovedStep
+	case v1alpha1.TiFlashMemberType:
+		return tc.Spec.TiFlash.UpgradeStrategy, &tc.Status.TiFlash.UpgradeStep, tc.Status.TiFlash.StatefulSet, label.AnnTiFlashUpgradeApprovedStep
+	case v1alpha1.TiDBMemberType:
+		return tc.Spec.TiDB.UpgradeStrategy, &tc.Status.TiDB.UpgradeStep, tc.Status.TiDB.StatefulSet, label.AnnTiDBUpgradeApprovedStep
+	case v1alpha1.TiCDCMemberType:
+		return tc.Spec.TiCDC.UpgradeStrategy, &tc.Status.TiCDC.UpgradeStep, tc.Status.TiCDC.StatefulSet, label.AnnTiCDCUpgradeApprovedStep
+	case v1alpha1.TiProxyMemberType:
+		return tc.Spec.TiProxy.UpgradeStrategy, &tc.Status.TiProxy.UpgradeStep, tc.Status.TiProxy.StatefulSet, label.AnnTiProxyUpgradeApprovedStep
+	}
+	return nil, nil, nil, ""
+}
+
+// checkUpgradeStep decides whether the upgrader can go on to upgrade the next pod of the component.
+// replicas is the count of the pods and upgraded is the count of the pods that have been upgraded.
+// It returns nil if the next pod can be upgraded, or a RequeueError if the upgrade is paused at the
+// end of a step until the cluster is healthy and the step is approved or the bake time passes.
+func checkUpgradeStep(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType, replicas, upgraded int32) error {
+	ns := tc.GetNamespace()
+	tcName := tc.GetName()
+
+	strategy, stepStatus, stsStatus, annKey := upgradeStepOf(tc, memberType)
+	if strategy == nil || len(strategy.Steps) == 0 || stsStatus == nil {
+		return nil
+	}
+
+	status := *stepStatus
+	if status == nil || status.Revision != stsStatus.UpdateRevision {
+		status = &v1alpha1.UpgradeStepStatus{Revision: stsStatus.UpdateRevision}
+		*stepStatus = status
+	}
+	status.UpgradedReplicas = upgraded
+
+	for int(status.Step) < len(strategy.Steps) {
+		target, err := intstr.GetValueFromIntOrPercent(&strategy.Steps[status.Step], int(replicas), true)
+		if err != nil {
+			return fmt.Errorf("tidbcluster: [%s/%s]'s %s upgrade step %d is invalid, error: %v", ns, tcName, memberType, status.Step, err)
+		}
+		status.Replicas = int32(target)
+		if upgraded < status.Replicas {
+			return nil
+		}
+
+		if err := checkUpgradeStepHealth(tc, memberType); err != nil {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %s upgrade step %d is waiting for the cluster to be healthy: %v",
+				ns, tcName, memberType, status.Step, err)
+		}
+		if status.PausedTime == nil {
+			now := metav1.Now()
+			status.PausedTime = &now
+			klog.Infof("tidbcluster: [%s/%s]'s %s upgrade is paused at step %d, %d/%d pods are upgraded",
+				ns, tcName, memberType, status.Step, upgraded, replicas)
+		}
+
+		approved := tc.Annotations[annKey] == strconv.Itoa(int(status.Step))
+		baked := strategy.BakeTime != nil && upgradeStepBaked(status, *strategy.BakeTime)
+		if !approved && !baked {
+			return controller.RequeueErrorf("tidbcluster: [%s/%s]'s %s upgrade is paused at step %d, waiting for approval",
+				ns, tcName, memberType, status.Step)
+		}
+		if approved {
+			if err := consumeUpgradeApproval(deps, tc, annKey); err != nil {
+				return err
+			}
+		}
+		klog.Infof("tidbcluster: [%s/%s]'s %s upgrade step %d is finished (approved: %t), continue upgrading",
+			ns, tcName, memberType, status.Step, approved)
+		status.Step++
+		status.PausedTime = nil
+	}
+	status.Replicas = replicas
+	return nil
+}
+
+func upgradeStepBaked(status *v1alpha1.UpgradeStepStatus, bakeTime string) bool {
+	d, err := time.ParseDuration(bakeTime)
+	if err != nil {
+		klog.Errorf("parse upgrade bake time %s failed, err: %v", bakeTime, err)
+		return false
+	}
+	return time.Now().After(status.PausedTime.Add(d))
+}
+
+// consumeUpgradeApproval removes the approval annotation so that it is not reused by the later steps
+func consumeUpgradeApproval(deps *controller.Dependencies, tc *v1alpha1.TidbCluster, annKey string) error {
+	data := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, annKey))
+	if _, err := deps.TiDBClusterControl.Patch(tc, data); err != nil {
+		return fmt.Errorf("tidbcluster: [%s/%s] failed to remove annotation %s, error: %v", tc.GetNamespace(), tc.GetName(), annKey, err)
+	}
+	delete(tc.Annotations, annKey)
+	return nil
+}
+
+// checkUpgradeStepHealth checks the health of PD, TiKV, TiDB and the component being upgraded
+func checkUpgradeStepHealth(tc *v1alpha1.TidbCluster, memberType v1alpha1.MemberType) error {
+	if tc.Spec.PD != nil && !tc.PDAllMembersReady() {
+		return fmt.Errorf("not all pd members are healthy")
+	}
+	if tc.Spec.TiKV != nil && !tc.TiKVAllStoresReady() {
+		return fmt.Errorf("not all tikv stores are up")
+	}
+	if tc.Spec.TiDB != nil && !tc.TiDBAllMembersReady() {
+		return fmt.Errorf("not all tidb members are healthy")
+	}
+	switch memberType {
+	case v1alpha1.TiFlashMemberType:
+		if !tc.TiFlashAllStoresReady() {
+			return fmt.Errorf("not all tiflash stores are up")
+		}
+	case v1alpha1.TiCDCMemberType:
+		if !tc.TiCDCAllCapturesReady() {
+			return fmt.Errorf("not all ticdc captures are ready")
+		}
+	case v1alpha1.TiProxyMemberType:
+		if !tc.TiProxyAllMembersReady() {
+			return fmt.Errorf("not all tiproxy members are healthy")
+		}
+	}
+	return nil
+}
+
+// syncUpgradeStepStatus clears the staged upgrade status once the upgrade is finished
+func syncUpgradeStepStatus(stepStatus **v1alpha1.UpgradeStepStatus, stsStatus *apps.StatefulSetStatus) {
+	if *stepStatus == nil || stsStatus == nil {
+		return
+	}
+	if stsStatus.UpdateRevision == stsStatus.CurrentRevision {
+		*stepStatus = nil
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package member
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb-operator/pkg/apis/label"
+	"github.com/pingcap/tidb-operator/pkg/apis/pingcap/v1alpha1"
+	"github.com/pingcap/tidb-operator/pkg/controller"
+
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+func TestCheckUpgradeStep(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type testcase struct {
+		name        string
+		changeFn    func(tc *v1alpha1.TidbCluster)
+		upgraded    int32
+		errExpectFn func(*GomegaWithT, error)
+		expectFn    func(g *GomegaWithT, tc *v1alpha1.TidbCluster)
+	}
+
+	pausedAt := func(step int32, pausedTime time.Time) func(tc *v1alpha1.TidbCluster) {
+		return func(tc *v1alpha1.TidbCluster) {
+			tc.Status.TiDB.UpgradeStep = &v1alpha1.UpgradeStepStatus{
+				Revision:   "2",
+				Step:       step,
+				PausedTime: &metav1.Time{Time: pausedTime},
+			}
+		}
+	}
+
+	testFn := func(test *testcase) {
+		t.Log(test.name)
+		deps := controller.NewFakeDependencies()
+		tc := newTidbClusterForTiDB()
+		tc.Spec.TiDB.Replicas = 4
+		tc.Spec.TiDB.UpgradeStrategy = &v1alpha1.UpgradeStrategy{
+			Steps: []intstr.IntOrString{intstr.FromInt(1), intstr.FromString("50%")},
+		}
+		tc.Status.TiDB.StatefulSet = &apps.StatefulSetStatus{CurrentRevision: "1", UpdateRevision: "2"}
+		tc.Status.TiDB.Members = map[string]v1alpha1.TiDBMember{}
+		for i := int32(0); i < 4; i++ {
+			name := tidbPodName(tc.GetName(), i)
+			tc.Status.TiDB.Members[name] = v1alpha1.TiDBMember{Name: name, Health: true}
+		}
+		tc.Spec.PD = nil
+		tc.Spec.TiKV = nil
+		if test.changeFn != nil {
+			test.changeFn(tc)
+		}
+
+		err := checkUpgradeStep(deps, tc, v1alpha1.TiDBMemberType, 4, test.upgraded)
+		test.errExpectFn(g, err)
+		if test.expectFn != nil {
+			test.expectFn(g, tc)
+		}
+	}
+
+	tests := []*testcase{
+		{
+			name: "no upgrade strategy",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				tc.Spec.TiDB.UpgradeStrategy = nil
+			},
+			upgraded:    1,
+			errExpectFn: errExpectNil,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiDB.UpgradeStep).To(BeNil())
+			},
+		},
+		{
+			name:        "upgrade the first pod",
+			upgraded:    0,
+			errExpectFn: errExpectNil,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiDB.UpgradeStep.Revision).To(Equal("2"))
+				g.Expect(tc.Status.TiDB.UpgradeStep.Step).To(Equal(int32(0)))
+				g.Expect(tc.Status.TiDB.UpgradeStep.Replicas).To(Equal(int32(1)))
+				g.Expect(tc.Status.TiDB.UpgradeStep.PausedTime).To(BeNil())
+			},
+		},
+		{
+			name:        "pause at the end of the first step",
+			upgraded:    1,
+			errExpectFn: errExpectRequeue,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiDB.UpgradeStep.Step).To(Equal(int32(0)))
+				g.Expect(tc.Status.TiDB.UpgradeStep.PausedTime).NotTo(BeNil())
+			},
+		},
+		{
+			name: "wait for the cluster to be healthy",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				name := tidbPodName(tc.GetName(), 3)
+				tc.Status.TiDB.Members[name] = v1alpha1.TiDBMember{Name: name, Health: false}
+			},
+			upgraded:    1,
+			errExpectFn: errExpectRequeue,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiDB.UpgradeStep.PausedTime).To(BeNil())
+			},
+		},
+		{
+			name: "the step is approved",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				pausedAt(0, time.Now())(tc)
+				tc.Annotations = map[string]string{label.AnnTiDBUpgradeApprovedStep: "0"}
+			},
+			upgraded:    1,
+			errExpectFn: errExpectNil,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiDB.UpgradeStep.Step).To(Equal(int32(1)))
+				g.Expect(tc.Status.TiDB.UpgradeStep.Replicas).To(Equal(int32(2)))
+				g.Expect(tc.Status.TiDB.UpgradeStep.PausedTime).To(BeNil())
+				g.Expect(tc.Annotations).NotTo(HaveKey(label.AnnTiDBUpgradeApprovedStep))
+			},
+		},
+		{
+			name: "another step is approved",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				pausedAt(0, time.Now())(tc)
+				tc.Annotations = map[string]string{label.AnnTiDBUpgradeApprovedStep: "1"}
+			},
+			upgraded:    1,
+			errExpectFn: errExpectRequeue,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiDB.UpgradeStep.Step).To(Equal(int32(0)))
+			},
+		},
+		{
+			name: "bake time passes",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				pausedAt(1, time.Now().Add(-time.Hour))(tc)
+				tc.Spec.TiDB.UpgradeStrategy.BakeTime = pointer.StringPtr("30m")
+			},
+			upgraded:    2,
+			errExpectFn: errExpectNil,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiDB.UpgradeStep.Step).To(Equal(int32(2)))
+				g.Expect(tc.Status.TiDB.UpgradeStep.Replicas).To(Equal(int32(4)))
+			},
+		},
+		{
+			name: "bake time does not pass",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				pausedAt(1, time.Now())(tc)
+				tc.Spec.TiDB.UpgradeStrategy.BakeTime = pointer.StringPtr("30m")
+			},
+			upgraded:    2,
+			errExpectFn: errExpectRequeue,
+		},
+		{
+			name: "a new revision restarts the steps",
+			changeFn: func(tc *v1alpha1.TidbCluster) {
+				pausedAt(2, time.Now())(tc)
+				tc.Status.TiDB.UpgradeStep.Revision = "0"
+			},
+			upgraded:    0,
+			errExpectFn: errExpectNil,
+			expectFn: func(g *GomegaWithT, tc *v1alpha1.TidbCluster) {
+				g.Expect(tc.Status.TiDB.UpgradeStep.Revision).To(Equal("2"))
+				g.Expect(tc.Status.TiDB.UpgradeStep.Step).To(Equal(int32(0)))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testFn(test)
+	}
+}
+
+func TestSyncUpgradeStepStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	step := &v1alpha1.UpgradeStepStatus{Revision: "2", Step: 1}
+	syncUpgradeStepStatus(&step, &apps.StatefulSetStatus{CurrentRevision: "1", UpdateRevision: "2"})
+	g.Expect(step).NotTo(BeNil())
+
+	syncUpgradeStepStatus(&step, &apps.StatefulSetStatus{CurrentRevision: "2", UpdateRevision: "2"})
+	g.Expect(step).To(BeNil())
+}
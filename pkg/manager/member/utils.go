@@ -133,6 +133,10 @@ func ticdcPodName(tcName string, ordinal int32) string {
 	return fmt.Sprintf("%s-%d", controller.TiCDCMemberName(tcName), ordinal)
 }
 
+func tiproxyPodName(tcName string, ordinal int32) string {
+	return fmt.Sprintf("%s-%d", controller.TiProxyMemberName(tcName), ordinal)
+}
+
 func DMMasterPodName(dcName string, ordinal int32) string {
 	return fmt.Sprintf("%s-%d", controller.DMMasterMemberName(dcName), ordinal)
 }
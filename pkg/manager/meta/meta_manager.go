@@ -61,12 +61,14 @@ func (m *metaManager) Sync(tc *v1alpha1.TidbCluster) error {
 		case label.PDLabelVal,
 			label.TiKVLabelVal,
 			label.TiFlashLabelVal,
-			label.PumpLabelVal:
-			// Currently PD/TiKV/TiFlash/Pump must uses PV
+			label.PumpLabelVal,
+			label.DrainerLabelVal:
+			// Currently PD/TiKV/TiFlash/Pump/Drainer must uses PV
 			mustUsePV = true
 		case label.TiDBLabelVal,
-			label.TiCDCLabelVal:
-			// Currently TiDB/TiCDC maybe uses PV
+			label.TiCDCLabelVal,
+			label.TiProxyLabelVal:
+			// Currently TiDB/TiCDC/TiProxy maybe uses PV
 			mustUsePV = false
 		default:
 			// Skip syncing meta info for pod that doesn't use PV
@@ -0,0 +1,138 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	BackupProgress = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup",
+			Name:      "progress_percentage",
+			Help:      "Percentage of the current step of the running Backup reported by BR",
+		}, []string{LabelNamespace, LabelName})
+
+	BackupProgressRemainingSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup",
+			Name:      "progress_remaining_seconds",
+			Help:      "Estimated remaining seconds of the current step of the running Backup reported by BR",
+		}, []string{LabelNamespace, LabelName})
+
+	BackupProcessedBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup",
+			Name:      "processed_bytes",
+			Help:      "Size in bytes of the data processed by BR for the Backup, reported in the success summary of BR",
+		}, []string{LabelNamespace, LabelName})
+
+	RestoreProgress = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "restore",
+			Name:      "progress_percentage",
+			Help:      "Percentage of the current step of the running Restore reported by BR",
+		}, []string{LabelNamespace, LabelName})
+
+	RestoreProgressRemainingSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "restore",
+			Name:      "progress_remaining_seconds",
+			Help:      "Estimated remaining seconds of the current step of the running Restore reported by BR",
+		}, []string{LabelNamespace, LabelName})
+
+	RestoreProcessedBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "restore",
+			Name:      "processed_bytes",
+			Help:      "Size in bytes of the data processed by BR for the Restore, reported in the success summary of BR",
+		}, []string{LabelNamespace, LabelName})
+
+	BackupPhaseTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup",
+			Name:      "phase_transitions_total",
+			Help:      "Counter of Backups entering each phase",
+		}, []string{LabelNamespace, LabelType, LabelPhase})
+
+	BackupDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup",
+			Name:      "duration_seconds",
+			Help:      "Bucketed histogram of the duration of the completed Backups",
+			// 1m ~ 2.8d
+			Buckets: prometheus.ExponentialBuckets(60, 2, 13),
+		}, []string{LabelNamespace, LabelType})
+
+	BackupSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup",
+			Name:      "size_bytes",
+			Help:      "Bucketed histogram of the data size of the completed Backups",
+			// 1MiB ~ 16TiB
+			Buckets: prometheus.ExponentialBuckets(1<<20, 4, 13),
+		}, []string{LabelNamespace, LabelType})
+
+	BackupCleanResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup",
+			Name:      "clean_total",
+			Help:      "Counter of the results of cleaning the data of the deleted Backups",
+		}, []string{LabelNamespace, LabelResult})
+
+	RestorePhaseTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "restore",
+			Name:      "phase_transitions_total",
+			Help:      "Counter of Restores entering each phase",
+		}, []string{LabelNamespace, LabelPhase})
+
+	RestoreDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "restore",
+			Name:      "duration_seconds",
+			Help:      "Bucketed histogram of the duration of the completed Restores",
+			// 1m ~ 2.8d
+			Buckets: prometheus.ExponentialBuckets(60, 2, 13),
+		}, []string{LabelNamespace})
+
+	BackupScheduleLastSuccessTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup_schedule",
+			Name:      "last_success_timestamp_seconds",
+			Help:      "Unix timestamp at which the last successful Backup of the BackupSchedule was completed",
+		}, []string{LabelNamespace, LabelName})
+
+	BackupScheduleLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "backup_schedule",
+			Name:      "lag_seconds",
+			Help:      "Seconds since the next Backup of the BackupSchedule should have been created, 0 if it is not overdue",
+		}, []string{LabelNamespace, LabelName})
+)
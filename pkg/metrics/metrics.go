@@ -19,12 +19,39 @@ import (
 
 // RegisterMetrics registers all metrics of tidb-operator.
 func RegisterMetrics() {
+	prometheus.MustRegister(ReconcileDuration)
+	prometheus.MustRegister(ReconcileErrors)
 	prometheus.MustRegister(ClusterSpecReplicas)
+	prometheus.MustRegister(ClusterReconcileDuration)
+	prometheus.MustRegister(ClusterReconcileErrors)
+	prometheus.MustRegister(ClusterComponentPhase)
+	prometheus.MustRegister(ClusterFailureMembers)
+	prometheus.MustRegister(ClusterEvictLeaderStores)
+	prometheus.MustRegister(ClusterVolumeResizing)
+	prometheus.MustRegister(ClusterPDQuorumHealthy)
+	prometheus.MustRegister(BackupProgress)
+	prometheus.MustRegister(BackupProgressRemainingSeconds)
+	prometheus.MustRegister(BackupProcessedBytes)
+	prometheus.MustRegister(RestoreProgress)
+	prometheus.MustRegister(RestoreProgressRemainingSeconds)
+	prometheus.MustRegister(RestoreProcessedBytes)
+	prometheus.MustRegister(BackupPhaseTransitions)
+	prometheus.MustRegister(BackupDuration)
+	prometheus.MustRegister(BackupSize)
+	prometheus.MustRegister(BackupCleanResults)
+	prometheus.MustRegister(RestorePhaseTransitions)
+	prometheus.MustRegister(RestoreDuration)
+	prometheus.MustRegister(BackupScheduleLastSuccessTimestamp)
+	prometheus.MustRegister(BackupScheduleLag)
 }
 
 // Label constants.
 const (
-	LabelNamespace = "namespace"
-	LabelName      = "name"
-	LabelComponent = "component"
+	LabelNamespace  = "namespace"
+	LabelName       = "name"
+	LabelComponent  = "component"
+	LabelPhase      = "phase"
+	LabelType       = "type"
+	LabelResult     = "result"
+	LabelController = "controller"
 )
@@ -25,4 +25,61 @@ var (
 			Name:      "spec_replicas",
 			Help:      "Desired replicas of each component in TidbCluster",
 		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	ClusterReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of the reconcile of each TidbCluster",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 15),
+		}, []string{LabelNamespace, LabelName})
+
+	ClusterReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "reconcile_errors_total",
+			Help:      "Total number of the reconcile errors of each TidbCluster, the type is one of requeue, ignore and error",
+		}, []string{LabelNamespace, LabelName, LabelType})
+
+	ClusterComponentPhase = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "component_phase",
+			Help:      "Phase of each component in TidbCluster, it is 1 for the current phase and 0 for the others",
+		}, []string{LabelNamespace, LabelName, LabelComponent, LabelPhase})
+
+	ClusterFailureMembers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "failure_members",
+			Help:      "Number of the failure members or stores of each component in TidbCluster",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	ClusterEvictLeaderStores = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "evict_leader_stores",
+			Help:      "Number of the TiKV stores evicting leaders in TidbCluster",
+		}, []string{LabelNamespace, LabelName})
+
+	ClusterVolumeResizing = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "volume_resizing",
+			Help:      "Whether the volumes of each component in TidbCluster are resizing",
+		}, []string{LabelNamespace, LabelName, LabelComponent})
+
+	ClusterPDQuorumHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "tidb_operator",
+			Subsystem: "cluster",
+			Name:      "pd_quorum_healthy",
+			Help:      "Whether the majority of the PD members in TidbCluster are healthy",
+		}, []string{LabelNamespace, LabelName})
 )
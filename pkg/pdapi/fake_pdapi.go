@@ -15,6 +15,7 @@ package pdapi
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/kvproto/pkg/pdpb"
@@ -43,6 +44,18 @@ const (
 	GetPDLeaderActionType                       ActionType = "GetPDLeader"
 	TransferPDLeaderActionType                  ActionType = "TransferPDLeader"
 	GetAutoscalingPlansActionType               ActionType = "GetAutoscalingPlans"
+	GetMinResolvedTSActionType                  ActionType = "GetMinResolvedTS"
+	GetActiveSchedulersActionType               ActionType = "GetActiveSchedulers"
+	PauseSchedulersActionType                   ActionType = "PauseSchedulers"
+	ResumeSchedulersActionType                  ActionType = "ResumeSchedulers"
+	UpdateServiceGCSafePointActionType          ActionType = "UpdateServiceGCSafePoint"
+	CheckRegionsActionType                      ActionType = "CheckRegions"
+	GetOperatorsActionType                      ActionType = "GetOperators"
+	GetMSMembersActionType                      ActionType = "GetMSMembers"
+	GetMSPrimaryActionType                      ActionType = "GetMSPrimary"
+	TransferPrimaryActionType                   ActionType = "TransferPrimary"
+	GetPlacementRulesActionType                 ActionType = "GetPlacementRules"
+	MarkSnapshotRecoveringActionType            ActionType = "MarkSnapshotRecovering"
 )
 
 type NotFoundReaction struct {
@@ -58,6 +71,11 @@ type Action struct {
 	Name        string
 	Labels      map[string]string
 	Replication PDReplicationConfig
+	Delay       time.Duration
+	TTL         int64
+	SafePoint   uint64
+	RegionState RegionCheckState
+	Schedulers  []string
 }
 
 type Reaction func(action *Action) (interface{}, error)
@@ -270,3 +288,142 @@ func (c *FakePDClient) GetAutoscalingPlans(strategy Strategy) ([]Plan, error) {
 	}
 	return nil, nil
 }
+
+func (c *FakePDClient) GetMinResolvedTS() (uint64, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetMinResolvedTSActionType, action)
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), nil
+}
+
+func (c *FakePDClient) GetActiveSchedulers() ([]string, error) {
+	if reaction, ok := c.reactions[GetActiveSchedulersActionType]; ok {
+		action := &Action{}
+		result, err := reaction(action)
+		if err != nil {
+			return nil, err
+		}
+		return result.([]string), nil
+	}
+	return nil, nil
+}
+
+func (c *FakePDClient) PauseSchedulers(delay time.Duration, schedulers []string) error {
+	if reaction, ok := c.reactions[PauseSchedulersActionType]; ok {
+		action := &Action{Delay: delay, Schedulers: schedulers}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) ResumeSchedulers(schedulers []string) error {
+	if reaction, ok := c.reactions[ResumeSchedulersActionType]; ok {
+		action := &Action{Schedulers: schedulers}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) UpdateServiceGCSafePoint(serviceID string, ttl int64, safePoint uint64) error {
+	if reaction, ok := c.reactions[UpdateServiceGCSafePointActionType]; ok {
+		action := &Action{Name: serviceID, TTL: ttl, SafePoint: safePoint}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) MarkSnapshotRecovering() error {
+	if reaction, ok := c.reactions[MarkSnapshotRecoveringActionType]; ok {
+		action := &Action{}
+		_, err := reaction(action)
+		return err
+	}
+	return nil
+}
+
+func (c *FakePDClient) CheckRegions(state RegionCheckState) (*RegionsInfo, error) {
+	action := &Action{RegionState: state}
+	result, err := c.fakeAPI(CheckRegionsActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*RegionsInfo), nil
+}
+
+func (c *FakePDClient) GetOperators() ([]string, error) {
+	action := &Action{}
+	result, err := c.fakeAPI(GetOperatorsActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
+func (c *FakePDClient) GetMSMembers(service string) ([]string, error) {
+	action := &Action{Name: service}
+	result, err := c.fakeAPI(GetMSMembersActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
+func (c *FakePDClient) GetMSPrimary(service string) (string, error) {
+	action := &Action{Name: service}
+	result, err := c.fakeAPI(GetMSPrimaryActionType, action)
+	if err != nil {
+		return "", err
+	}
+	return result.(string), nil
+}
+
+func (c *FakePDClient) GetPlacementRules(group string) ([]PlacementRule, error) {
+	action := &Action{Name: group}
+	result, err := c.fakeAPI(GetPlacementRulesActionType, action)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]PlacementRule), nil
+}
+
+// FakePDMSClient implements a fake version of PDMSClient.
+type FakePDMSClient struct {
+	reactions map[ActionType]Reaction
+}
+
+func NewFakePDMSClient() *FakePDMSClient {
+	return &FakePDMSClient{reactions: map[ActionType]Reaction{}}
+}
+
+func (c *FakePDMSClient) AddReaction(actionType ActionType, reaction Reaction) {
+	c.reactions[actionType] = reaction
+}
+
+// fakeAPI is a small helper for fake API calls
+func (c *FakePDMSClient) fakeAPI(actionType ActionType, action *Action) (interface{}, error) {
+	if reaction, ok := c.reactions[actionType]; ok {
+		result, err := reaction(action)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return nil, &NotFoundReaction{actionType}
+}
+
+func (c *FakePDMSClient) GetHealth() error {
+	action := &Action{}
+	_, err := c.fakeAPI(GetHealthActionType, action)
+	return err
+}
+
+func (c *FakePDMSClient) TransferPrimary(newPrimary string) error {
+	action := &Action{Name: newPrimary}
+	_, err := c.fakeAPI(TransferPrimaryActionType, action)
+	return err
+}
@@ -77,6 +77,8 @@ type PDControlInterface interface {
 	GetPDEtcdClient(namespace Namespace, tcName string, tlsEnabled bool, opts ...Option) (PDEtcdClient, error)
 	// GetEndpoints return the endpoints and client tls.Config to connection pd/etcd.
 	GetEndpoints(namespace Namespace, tcName string, tlsEnabled bool, opts ...Option) (endpoints []string, tlsConfig *tls.Config, err error)
+	// GetPDMSClient provides PDMSClient of the pd microservice of the tidb cluster.
+	GetPDMSClient(namespace Namespace, tcName, serviceName string, tlsEnabled bool, opts ...Option) PDMSClient
 }
 
 type clientConfig struct {
@@ -118,6 +120,25 @@ func (c *clientConfig) completeForPDClient(namespace Namespace, tcName string) {
 	}
 }
 
+// completeForPDMSClient populate and correct config for pd microservice client
+func (c *clientConfig) completeForPDMSClient(namespace Namespace, tcName, serviceName string) {
+	scheme := "http"
+	if c.tlsEnable {
+		scheme = "https"
+		if c.tlsSecretName == "" {
+			c.tlsSecretNamespace = namespace
+			c.tlsSecretName = util.ClusterClientTLSSecretName(tcName)
+		}
+	}
+
+	if c.clientURL == "" {
+		c.clientURL = genPDMSClientUrl(namespace, tcName, serviceName, scheme, c.clusterDomain)
+	}
+	if c.clientKey == "" {
+		c.clientKey = genPDMSClientKey(scheme, namespace, tcName, serviceName, c.clusterDomain)
+	}
+}
+
 // completeForEtcdClient populate and correct config for pd etcd client
 func (c *clientConfig) completeForEtcdClient(namespace Namespace, tcName string) {
 	if c.tlsEnable {
@@ -144,6 +165,9 @@ type defaultPDControl struct {
 
 	etcdmutex     sync.Mutex
 	pdEtcdClients map[string]PDEtcdClient
+
+	pdMSMutex   sync.Mutex
+	pdMSClients map[string]PDMSClient
 }
 
 type noOpClose struct {
@@ -156,12 +180,12 @@ func (c *noOpClose) Close() error {
 
 // NewDefaultPDControl returns a defaultPDControl instance
 func NewDefaultPDControl(secretLister corelisterv1.SecretLister) PDControlInterface {
-	return &defaultPDControl{secretLister: secretLister, pdClients: map[string]PDClient{}, pdEtcdClients: map[string]PDEtcdClient{}}
+	return &defaultPDControl{secretLister: secretLister, pdClients: map[string]PDClient{}, pdEtcdClients: map[string]PDEtcdClient{}, pdMSClients: map[string]PDMSClient{}}
 }
 
 // NewDefaultPDControl returns a defaultPDControl instance
 func NewDefaultPDControlByCli(kubeCli kubernetes.Interface) PDControlInterface {
-	return &defaultPDControl{pdClients: map[string]PDClient{}, pdEtcdClients: map[string]PDEtcdClient{}}
+	return &defaultPDControl{pdClients: map[string]PDClient{}, pdEtcdClients: map[string]PDEtcdClient{}, pdMSClients: map[string]PDMSClient{}}
 }
 
 func (pdc *defaultPDControl) GetEndpoints(namespace Namespace, tcName string, tlsEnabled bool, opts ...Option) (endpoints []string, tlsConfig *tls.Config, err error) {
@@ -241,6 +265,33 @@ func (pdc *defaultPDControl) GetPDClient(namespace Namespace, tcName string, tls
 	return pdc.pdClients[config.clientKey]
 }
 
+// GetPDMSClient provides a PDMSClient of the pd microservice, if the PDMSClient not existing, it will create new one.
+func (pdc *defaultPDControl) GetPDMSClient(namespace Namespace, tcName, serviceName string, tlsEnabled bool, opts ...Option) PDMSClient {
+	config := &clientConfig{}
+
+	config.tlsEnable = tlsEnabled
+	config.applyOptions(opts...)
+
+	config.completeForPDMSClient(namespace, tcName, serviceName)
+
+	pdc.pdMSMutex.Lock()
+	defer pdc.pdMSMutex.Unlock()
+
+	if config.tlsEnable {
+		tlsConfig, err := GetTLSConfig(pdc.secretLister, config.tlsSecretNamespace, config.tlsSecretName)
+		if err != nil {
+			klog.Errorf("Unable to get tls config for tidb cluster %q in %s, pd microservice client may not work: %v", tcName, namespace, err)
+			return &pdMSClient{serviceName: serviceName, url: config.clientURL, httpClient: &http.Client{Timeout: DefaultTimeout}}
+		}
+
+		return NewPDMSClient(serviceName, config.clientURL, DefaultTimeout, tlsConfig)
+	}
+	if _, ok := pdc.pdMSClients[config.clientKey]; !ok {
+		pdc.pdMSClients[config.clientKey] = NewPDMSClient(serviceName, config.clientURL, DefaultTimeout, nil)
+	}
+	return pdc.pdMSClients[config.clientKey]
+}
+
 func genClientKey(scheme string, namespace Namespace, clusterName string, clusterDomain string) string {
 	if len(clusterDomain) == 0 {
 		return fmt.Sprintf("%s.%s.%s", scheme, clusterName, string(namespace))
@@ -255,6 +306,21 @@ func genEtcdClientKey(namespace Namespace, clusterName string, clusterDomain str
 	return fmt.Sprintf("%s.%s.%s.%v", clusterName, string(namespace), clusterDomain, tlsEnabled)
 }
 
+func genPDMSClientKey(scheme string, namespace Namespace, clusterName, serviceName, clusterDomain string) string {
+	return fmt.Sprintf("%s.%s", genClientKey(scheme, namespace, clusterName, clusterDomain), serviceName)
+}
+
+// genPDMSClientUrl builds the url of the pd microservice client
+func genPDMSClientUrl(namespace Namespace, clusterName, serviceName, scheme, clusterDomain string) string {
+	if len(namespace) == 0 {
+		return fmt.Sprintf("%s://%s-%s:2379", scheme, clusterName, serviceName)
+	}
+	if len(clusterDomain) == 0 {
+		return fmt.Sprintf("%s://%s-%s.%s:2379", scheme, clusterName, serviceName, string(namespace))
+	}
+	return fmt.Sprintf("%s://%s-%s.%s.svc.%s:2379", scheme, clusterName, serviceName, string(namespace), clusterDomain)
+}
+
 // genClientUrl builds the url of cluster pd client
 func genClientUrl(namespace Namespace, clusterName string, scheme string, clusterDomain string, headlessSvc bool) string {
 	svc := "pd"
@@ -289,7 +355,7 @@ type FakePDControl struct {
 
 func NewFakePDControl(secretLister corelisterv1.SecretLister) *FakePDControl {
 	return &FakePDControl{
-		defaultPDControl{secretLister: secretLister, pdClients: map[string]PDClient{}},
+		defaultPDControl{secretLister: secretLister, pdClients: map[string]PDClient{}, pdMSClients: map[string]PDMSClient{}},
 	}
 }
 
@@ -304,3 +370,11 @@ func (fpc *FakePDControl) SetPDClientWithClusterDomain(namespace Namespace, tcNa
 func (fpc *FakePDControl) SetPDClientWithAddress(peerURL string, pdclient PDClient) {
 	fpc.defaultPDControl.pdClients[peerURL] = pdclient
 }
+
+func (fpc *FakePDControl) SetPDMSClient(namespace Namespace, tcName, serviceName string, pdMSClient PDMSClient) {
+	fpc.defaultPDControl.pdMSClients[genPDMSClientKey("http", namespace, tcName, serviceName, "")] = pdMSClient
+}
+
+func (fpc *FakePDControl) SetPDMSClientWithAddress(peerURL string, pdMSClient PDMSClient) {
+	fpc.defaultPDControl.pdMSClients[peerURL] = pdMSClient
+}
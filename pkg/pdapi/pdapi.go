@@ -15,11 +15,13 @@ package pdapi
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -28,6 +30,9 @@ import (
 	"github.com/pingcap/tidb-operator/pkg/util/crypto"
 	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
 	"github.com/tikv/pd/pkg/typeutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 )
@@ -92,6 +97,30 @@ type PDClient interface {
 	TransferPDLeader(name string) error
 	// GetAutoscalingPlans returns the scaling plan for the cluster
 	GetAutoscalingPlans(strategy Strategy) ([]Plan, error)
+	// GetMinResolvedTS returns the minimum resolved ts of all the TiKV stores
+	GetMinResolvedTS() (uint64, error)
+	// GetActiveSchedulers gets the schedulers which are not paused, evict leader schedulers are excluded
+	GetActiveSchedulers() ([]string, error)
+	// PauseSchedulers pauses the schedulers for the duration, PD resumes them automatically after that
+	PauseSchedulers(delay time.Duration, schedulers []string) error
+	// ResumeSchedulers resumes the schedulers
+	ResumeSchedulers(schedulers []string) error
+	// UpdateServiceGCSafePoint keeps GC from advancing beyond the safe point during the ttl (in seconds)
+	// for the service, the service safe point is removed if ttl is not positive
+	UpdateServiceGCSafePoint(serviceID string, ttl int64, safePoint uint64) error
+	// CheckRegions returns the regions in the abnormal state, such as the regions with down peers
+	CheckRegions(state RegionCheckState) (*RegionsInfo, error)
+	// GetOperators returns the descriptions of the running operators
+	GetOperators() ([]string, error)
+	// GetMSMembers returns the addresses of the members of the PD microservice
+	GetMSMembers(service string) ([]string, error)
+	// GetMSPrimary returns the address of the primary member of the PD microservice
+	GetMSPrimary(service string) (string, error)
+	// GetPlacementRules returns the placement rules in the rule group
+	GetPlacementRules(group string) ([]PlacementRule, error)
+	// MarkSnapshotRecovering marks the cluster as recovering from volume snapshots, the TiKV stores
+	// started then take the cluster ID of PD and wait for the data to be restored by BR
+	MarkSnapshotRecovering() error
 }
 
 var (
@@ -109,12 +138,24 @@ var (
 	// config API, available since PD v3.1.0.
 	evictLeaderSchedulerConfigPrefix = "pd/api/v1/scheduler-config/evict-leader-scheduler/list"
 	autoscalingPrefix                = "autoscaling"
+	// minResolvedTSPrefix is the prefix of min resolved ts API, available since PD v6.2.0.
+	minResolvedTSPrefix = "pd/api/v1/min-resolved-ts"
+	regionsCheckPrefix  = "pd/api/v1/regions/check"
+	operatorsPrefix     = "pd/api/v1/operators"
+	// msMembersPrefix and msPrimaryPrefix are the prefixes of the PD microservice APIs, available since PD v8.0.0.
+	msMembersPrefix = "pd/api/v2/ms/members"
+	msPrimaryPrefix = "pd/api/v2/ms/primary"
+	// placementRulesPrefix is the prefix of placement rules API, available since PD v4.0.0.
+	placementRulesPrefix = "pd/api/v1/config/rules/group"
+
+	snapshotRecoveringMarkPrefix = "pd/api/v1/admin/cluster/markers/snapshot-recovering"
 )
 
 // pdClient is default implementation of PDClient
 type pdClient struct {
 	url        string
 	httpClient *http.Client
+	tlsConfig  *tls.Config
 }
 
 // NewPDClient returns a new PDClient
@@ -129,6 +170,7 @@ func NewPDClient(url string, timeout time.Duration, tlsConfig *tls.Config) PDCli
 			Timeout:   timeout,
 			Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: disableKeepalive},
 		},
+		tlsConfig: tlsConfig,
 	}
 }
 
@@ -240,6 +282,66 @@ type Plan struct {
 	Labels       map[string]string `json:"labels"`
 }
 
+// MinResolvedTS is the min resolved ts info returned from PD RESTful interface
+type MinResolvedTS struct {
+	IsRealTime      bool   `json:"is_real_time,omitempty"`
+	MinResolvedTS   uint64 `json:"min_resolved_ts"`
+	PersistInterval string `json:"persist_interval,omitempty"`
+}
+
+// RegionCheckState is the abnormal state of the regions checked by PD
+type RegionCheckState string
+
+const (
+	// RegionCheckDownPeer is the state of the regions with down peers
+	RegionCheckDownPeer RegionCheckState = "down-peer"
+	// RegionCheckPendingPeer is the state of the regions with pending peers
+	RegionCheckPendingPeer RegionCheckState = "pending-peer"
+	// RegionCheckMissPeer is the state of the regions that miss peers
+	RegionCheckMissPeer RegionCheckState = "miss-peer"
+	// RegionCheckExtraPeer is the state of the regions with extra peers
+	RegionCheckExtraPeer RegionCheckState = "extra-peer"
+)
+
+// RegionsInfo is the regions info returned from PD RESTful interface, the details of the regions are omitted
+type RegionsInfo struct {
+	Count int `json:"count"`
+}
+
+// ServiceRegistryEntry is the registry entry of a PD microservice member returned from PD RESTful interface
+type ServiceRegistryEntry struct {
+	ServiceAddr    string `json:"service-addr"`
+	Version        string `json:"version,omitempty"`
+	StartTimestamp int64  `json:"start-timestamp,omitempty"`
+}
+
+// PlacementRule is the placement rule returned from PD RESTful interface
+type PlacementRule struct {
+	GroupID          string                `json:"group_id"`
+	ID               string                `json:"id"`
+	Index            int                   `json:"index,omitempty"`
+	Override         bool                  `json:"override,omitempty"`
+	StartKeyHex      string                `json:"start_key"`
+	EndKeyHex        string                `json:"end_key"`
+	Role             string                `json:"role"`
+	Count            int                   `json:"count"`
+	LabelConstraints []PlacementConstraint `json:"label_constraints,omitempty"`
+	LocationLabels   []string              `json:"location_labels,omitempty"`
+	IsolationLevel   string                `json:"isolation_level,omitempty"`
+}
+
+// PlacementConstraint is the label constraint of a placement rule
+type PlacementConstraint struct {
+	Key    string   `json:"key"`
+	Op     string   `json:"op"`
+	Values []string `json:"values"`
+}
+
+type schedulerPauseInfo struct {
+	// Delay is the seconds to pause the scheduler, 0 means resuming the scheduler
+	Delay int64 `json:"delay"`
+}
+
 type schedulerInfo struct {
 	Name    string `json:"name"`
 	StoreID uint64 `json:"store_id"`
@@ -726,6 +828,211 @@ func (c *pdClient) GetAutoscalingPlans(strategy Strategy) ([]Plan, error) {
 	return plans, nil
 }
 
+func (c *pdClient) GetMinResolvedTS() (uint64, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, minResolvedTSPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return 0, err
+	}
+	info := &MinResolvedTS{}
+	err = json.Unmarshal(body, info)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsRealTime {
+		return 0, fmt.Errorf("min resolved ts is not enabled, persist interval: %s", info.PersistInterval)
+	}
+	return info.MinResolvedTS, nil
+}
+
+func (c *pdClient) GetActiveSchedulers() ([]string, error) {
+	schedulers, err := c.getSchedulers("")
+	if err != nil {
+		return nil, err
+	}
+	paused, err := c.getSchedulers("paused")
+	if err != nil {
+		return nil, err
+	}
+	pausedSet := sets.NewString(paused...)
+
+	var active []string
+	for _, scheduler := range schedulers {
+		if strings.HasPrefix(scheduler, evictSchedulerLeader) {
+			// evict leader schedulers are managed by the upgrade of TiKV
+			continue
+		}
+		if !pausedSet.Has(scheduler) {
+			active = append(active, scheduler)
+		}
+	}
+	return active, nil
+}
+
+func (c *pdClient) getSchedulers(status string) ([]string, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, schedulersPrefix)
+	if status != "" {
+		apiURL = fmt.Sprintf("%s?status=%s", apiURL, status)
+	}
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	var schedulers []string
+	err = json.Unmarshal(body, &schedulers)
+	if err != nil {
+		return nil, err
+	}
+	return schedulers, nil
+}
+
+func (c *pdClient) PauseSchedulers(delay time.Duration, schedulers []string) error {
+	return c.pauseSchedulers(int64(delay/time.Second), schedulers)
+}
+
+func (c *pdClient) ResumeSchedulers(schedulers []string) error {
+	return c.pauseSchedulers(0, schedulers)
+}
+
+func (c *pdClient) pauseSchedulers(delay int64, schedulers []string) error {
+	data, err := json.Marshal(schedulerPauseInfo{Delay: delay})
+	if err != nil {
+		return err
+	}
+	for _, scheduler := range schedulers {
+		apiURL := fmt.Sprintf("%s/%s/%s", c.url, schedulersPrefix, scheduler)
+		if _, err := httputil.PostBodyOK(c.httpClient, apiURL, bytes.NewBuffer(data)); err != nil {
+			return fmt.Errorf("failed to pause scheduler %s for %d seconds: %v", scheduler, delay, err)
+		}
+	}
+	return nil
+}
+
+func (c *pdClient) MarkSnapshotRecovering() error {
+	apiURL := fmt.Sprintf("%s/%s", c.url, snapshotRecoveringMarkPrefix)
+	_, err := httputil.PostBodyOK(c.httpClient, apiURL, nil)
+	return err
+}
+
+func (c *pdClient) UpdateServiceGCSafePoint(serviceID string, ttl int64, safePoint uint64) error {
+	cluster, err := c.GetCluster()
+	if err != nil {
+		return err
+	}
+	// the service safe point can only be updated by the pd leader
+	leader, err := c.GetPDLeader()
+	if err != nil {
+		return err
+	}
+	if len(leader.GetClientUrls()) == 0 {
+		return fmt.Errorf("no client url of pd leader %s", leader.GetName())
+	}
+	leaderURL, err := url.Parse(leader.GetClientUrls()[0])
+	if err != nil {
+		return err
+	}
+
+	dialOpt := grpc.WithInsecure()
+	if leaderURL.Scheme == "https" {
+		dialOpt = grpc.WithTransportCredentials(credentials.NewTLS(c.tlsConfig))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.httpClient.Timeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, leaderURL.Host, dialOpt, grpc.WithBlock())
+	if err != nil {
+		return fmt.Errorf("failed to connect to pd leader %s: %v", leaderURL.Host, err)
+	}
+	defer conn.Close()
+
+	resp, err := pdpb.NewPDClient(conn).UpdateServiceGCSafePoint(ctx, &pdpb.UpdateServiceGCSafePointRequest{
+		Header:    &pdpb.RequestHeader{ClusterId: cluster.GetId()},
+		ServiceId: []byte(serviceID),
+		TTL:       ttl,
+		SafePoint: safePoint,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.GetHeader().GetError() != nil {
+		return fmt.Errorf("failed to update service gc safe point of %s: %s", serviceID, resp.GetHeader().GetError().GetMessage())
+	}
+	return nil
+}
+
+func (c *pdClient) CheckRegions(state RegionCheckState) (*RegionsInfo, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, regionsCheckPrefix, state)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	regionsInfo := &RegionsInfo{}
+	err = json.Unmarshal(body, regionsInfo)
+	if err != nil {
+		return nil, err
+	}
+	return regionsInfo, nil
+}
+
+func (c *pdClient) GetOperators() ([]string, error) {
+	apiURL := fmt.Sprintf("%s/%s", c.url, operatorsPrefix)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	operators := []string{}
+	err = json.Unmarshal(body, &operators)
+	if err != nil {
+		return nil, err
+	}
+	return operators, nil
+}
+
+func (c *pdClient) GetMSMembers(service string) ([]string, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, msMembersPrefix, service)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	entries := []ServiceRegistryEntry{}
+	err = json.Unmarshal(body, &entries)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		members = append(members, entry.ServiceAddr)
+	}
+	return members, nil
+}
+
+func (c *pdClient) GetMSPrimary(service string) (string, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, msPrimaryPrefix, service)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return "", err
+	}
+	var primary string
+	err = json.Unmarshal(body, &primary)
+	if err != nil {
+		return "", err
+	}
+	return primary, nil
+}
+
+func (c *pdClient) GetPlacementRules(group string) ([]PlacementRule, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, placementRulesPrefix, group)
+	body, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	rules := []PlacementRule{}
+	err = json.Unmarshal(body, &rules)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
 func getLeaderEvictSchedulerInfo(storeID uint64) *schedulerInfo {
 	return &schedulerInfo{"evict-leader-scheduler", storeID}
 }
@@ -22,7 +22,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pingcap/kvproto/pkg/metapb"
@@ -727,6 +729,119 @@ func TestGetEvictLeaderSchedulersForStores(t *testing.T) {
 	}
 }
 
+func TestGetMinResolvedTS(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		name   string
+		resp   []byte
+		expect func(ts uint64, err error)
+	}{
+		{
+			name: "min resolved ts is reported",
+			resp: []byte(`{"is_real_time":true,"min_resolved_ts":434763491567992834,"persist_interval":"1s"}`),
+			expect: func(ts uint64, err error) {
+				g.Expect(err).To(Succeed())
+				g.Expect(ts).To(Equal(uint64(434763491567992834)))
+			},
+		},
+		{
+			name: "min resolved ts is not enabled",
+			resp: []byte(`{"is_real_time":false,"min_resolved_ts":0,"persist_interval":"0s"}`),
+			expect: func(ts uint64, err error) {
+				g.Expect(err).To(HaveOccurred())
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("test case: %s", tc.name)
+
+		svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+			g.Expect(request.Method).To(Equal("GET"), "check method")
+			g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s", minResolvedTSPrefix)), "check url")
+
+			w.Header().Set("Content-Type", ContentTypeJSON)
+			w.Write(tc.resp)
+		})
+		defer svc.Close()
+
+		pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+		ts, err := pdClient.GetMinResolvedTS()
+		tc.expect(ts, err)
+	}
+}
+
+func TestCheckRegionsAndGetOperators(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		g.Expect(request.Method).To(Equal("GET"), "check method")
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		switch request.URL.Path {
+		case fmt.Sprintf("/%s/%s", regionsCheckPrefix, RegionCheckDownPeer):
+			w.Write([]byte(`{"count":2,"regions":[{"id":2},{"id":3}]}`))
+		case fmt.Sprintf("/%s", operatorsPrefix):
+			w.Write([]byte(`["merge-region {merge: region 2 to 3} (kind:leader,region,merge, region:2(5,1))"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer svc.Close()
+
+	pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+	regions, err := pdClient.CheckRegions(RegionCheckDownPeer)
+	g.Expect(err).To(Succeed())
+	g.Expect(regions.Count).To(Equal(2))
+
+	operators, err := pdClient.GetOperators()
+	g.Expect(err).To(Succeed())
+	g.Expect(operators).To(HaveLen(1))
+	g.Expect(operators[0]).To(HavePrefix("merge-region"))
+}
+
+func TestPauseSchedulers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	paused := map[string]int64{}
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		if request.Method == "GET" {
+			g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s", schedulersPrefix)), "check url")
+			w.Header().Set("Content-Type", ContentTypeJSON)
+			if request.URL.Query().Get("status") == "paused" {
+				w.Write([]byte(`["balance-hot-region-scheduler"]`))
+				return
+			}
+			w.Write([]byte(`["balance-leader-scheduler","balance-region-scheduler","balance-hot-region-scheduler","evict-leader-scheduler-1"]`))
+			return
+		}
+		g.Expect(request.Method).To(Equal("POST"), "check method")
+		info := &schedulerPauseInfo{}
+		g.Expect(readJSON(request.Body, info)).To(Succeed())
+		paused[strings.TrimPrefix(request.URL.Path, fmt.Sprintf("/%s/", schedulersPrefix))] = info.Delay
+		w.WriteHeader(http.StatusOK)
+	})
+	defer svc.Close()
+
+	pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+	// the schedulers paused by others and the evict leader schedulers are not active
+	schedulers, err := pdClient.GetActiveSchedulers()
+	g.Expect(err).To(Succeed())
+	g.Expect(schedulers).To(Equal([]string{"balance-leader-scheduler", "balance-region-scheduler"}))
+
+	g.Expect(pdClient.PauseSchedulers(5*time.Minute, schedulers)).To(Succeed())
+	g.Expect(paused).To(Equal(map[string]int64{
+		"balance-leader-scheduler": 300,
+		"balance-region-scheduler": 300,
+	}))
+
+	g.Expect(pdClient.ResumeSchedulers(schedulers)).To(Succeed())
+	g.Expect(paused).To(Equal(map[string]int64{
+		"balance-leader-scheduler": 0,
+		"balance-region-scheduler": 0,
+	}))
+}
+
 func readJSON(r io.ReadCloser, data interface{}) error {
 	defer r.Close()
 
@@ -897,3 +1012,88 @@ func TestGeneric(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMSMembersAndPrimary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		g.Expect(request.Method).To(Equal("GET"), "check method")
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		switch request.URL.Path {
+		case fmt.Sprintf("/%s/tso", msMembersPrefix):
+			w.Write([]byte(`[{"service-addr":"http://basic-tso-0.basic-tso-peer.ns.svc:2379","version":"v8.1.0"},{"service-addr":"http://basic-tso-1.basic-tso-peer.ns.svc:2379"}]`))
+		case fmt.Sprintf("/%s/tso", msPrimaryPrefix):
+			w.Write([]byte(`"http://basic-tso-1.basic-tso-peer.ns.svc:2379"`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer svc.Close()
+
+	pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+	members, err := pdClient.GetMSMembers("tso")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(members).To(Equal([]string{
+		"http://basic-tso-0.basic-tso-peer.ns.svc:2379",
+		"http://basic-tso-1.basic-tso-peer.ns.svc:2379",
+	}))
+	primary, err := pdClient.GetMSPrimary("tso")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(primary).To(Equal("http://basic-tso-1.basic-tso-peer.ns.svc:2379"))
+
+	_, err = pdClient.GetMSMembers("scheduling")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGetPlacementRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		g.Expect(request.Method).To(Equal("GET"), "check method")
+		g.Expect(request.URL.Path).To(Equal(fmt.Sprintf("/%s/tiflash", placementRulesPrefix)), "check url")
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		w.Write([]byte(`[{"group_id":"tiflash","id":"table-45-r","index":120,"start_key":"7480000000000000ff2d5f720000000000fa","end_key":"7480000000000000ff2e00000000000000f8","role":"learner","count":2,"label_constraints":[{"key":"engine","op":"in","values":["tiflash"]}]}]`))
+	})
+	defer svc.Close()
+
+	pdClient := NewPDClient(svc.URL, DefaultTimeout, &tls.Config{})
+	rules, err := pdClient.GetPlacementRules("tiflash")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rules).To(Equal([]PlacementRule{
+		{
+			GroupID:     "tiflash",
+			ID:          "table-45-r",
+			Index:       120,
+			StartKeyHex: "7480000000000000ff2d5f720000000000fa",
+			EndKeyHex:   "7480000000000000ff2e00000000000000f8",
+			Role:        "learner",
+			Count:       2,
+			LabelConstraints: []PlacementConstraint{
+				{Key: "engine", Op: "in", Values: []string{"tiflash"}},
+			},
+		},
+	}))
+}
+
+func TestPDMSClient(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := getClientServer(func(w http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/tso/" + pdMSHealthPrefix:
+			g.Expect(request.Method).To(Equal("GET"), "check method")
+		case "/tso/" + pdMSPrimaryTransferPrefix:
+			g.Expect(request.Method).To(Equal("POST"), "check method")
+			data, err := ioutil.ReadAll(request.Body)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(data)).To(Equal(`{"new_primary":"basic-tso-0"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	defer svc.Close()
+
+	g.Expect(NewPDMSClient("tso", svc.URL, DefaultTimeout, &tls.Config{}).GetHealth()).To(Succeed())
+	g.Expect(NewPDMSClient("tso", svc.URL, DefaultTimeout, &tls.Config{}).TransferPrimary("basic-tso-0")).To(Succeed())
+	g.Expect(NewPDMSClient("scheduling", svc.URL, DefaultTimeout, &tls.Config{}).GetHealth()).NotTo(Succeed())
+}
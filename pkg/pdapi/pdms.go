@@ -0,0 +1,85 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdapi
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	httputil "github.com/pingcap/tidb-operator/pkg/util/http"
+)
+
+// PDMSClient provides pd microservice server's api
+type PDMSClient interface {
+	// GetHealth returns nil if the pd microservice member is healthy
+	GetHealth() error
+	// TransferPrimary transfers the primary of the pd microservice to the specified member
+	TransferPrimary(newPrimary string) error
+}
+
+var (
+	pdMSHealthPrefix          = "api/v1/health"
+	pdMSPrimaryTransferPrefix = "api/v1/primary/transfer"
+)
+
+// pdMSClient is default implementation of PDMSClient
+type pdMSClient struct {
+	serviceName string
+	url         string
+	httpClient  *http.Client
+}
+
+// NewPDMSClient returns a new PDMSClient
+func NewPDMSClient(serviceName, url string, timeout time.Duration, tlsConfig *tls.Config) PDMSClient {
+	var disableKeepalive bool
+	if tlsConfig != nil {
+		disableKeepalive = true
+	}
+	return &pdMSClient{
+		serviceName: serviceName,
+		url:         url,
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: disableKeepalive},
+		},
+	}
+}
+
+func (c *pdMSClient) GetHealth() error {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, c.serviceName, pdMSHealthPrefix)
+	_, err := httputil.GetBodyOK(c.httpClient, apiURL)
+	return err
+}
+
+func (c *pdMSClient) TransferPrimary(newPrimary string) error {
+	apiURL := fmt.Sprintf("%s/%s/%s", c.url, c.serviceName, pdMSPrimaryTransferPrefix)
+	data, err := json.Marshal(map[string]string{"new_primary": newPrimary})
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Post(apiURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	defer httputil.DeferClose(res.Body)
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	err2 := httputil.ReadErrorBody(res.Body)
+	return fmt.Errorf("failed %v to transfer %s primary to %s, error: %v", res.StatusCode, c.serviceName, newPrimary, err2)
+}
@@ -34,6 +34,13 @@ const (
 	TiDBUnhealthy = "TiDBUnhealthy"
 	// TiFlashStoreNotUp is added when one of tiflash stores is not up.
 	TiFlashStoreNotUp = "TiFlashStoreNotUp"
+
+	// UpgradePreflightPassed is added when the upgrade pre-flight checks are passed.
+	UpgradePreflightPassed = "UpgradePreflightPassed"
+	// UpgradePreflightSkipped is added when the upgrade pre-flight checks are skipped by annotation.
+	UpgradePreflightSkipped = "UpgradePreflightSkipped"
+	// UpgradePreflightFailed is added when the upgrade pre-flight checks fail.
+	UpgradePreflightFailed = "UpgradePreflightFailed"
 )
 
 // NewTidbClusterCondition creates a new tidbcluster condition.
@@ -60,10 +67,11 @@ func GetTidbClusterCondition(status v1alpha1.TidbClusterStatus, condType v1alpha
 }
 
 // SetTidbClusterCondition updates the tidb cluster to include the provided condition. If the condition that
-// we are about to add already exists and has the same status and reason then we are not going to update.
+// we are about to add already exists and has the same status, reason and message then we are not going to update.
 func SetTidbClusterCondition(status *v1alpha1.TidbClusterStatus, condition v1alpha1.TidbClusterCondition) {
 	currentCond := GetTidbClusterCondition(*status, condition.Type)
-	if currentCond != nil && currentCond.Status == condition.Status && currentCond.Reason == condition.Reason {
+	if currentCond != nil && currentCond.Status == condition.Status && currentCond.Reason == condition.Reason &&
+		currentCond.Message == condition.Message {
 		return
 	}
 	// Do not update lastTransitionTime if the status of the condition doesn't change.